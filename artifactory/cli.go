@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	ioutils "github.com/jfrog/gofrog/io"
+	clibuildinfo "github.com/jfrog/jfrog-cli/artifactory/commands/buildinfo"
+	containercmd "github.com/jfrog/jfrog-cli/artifactory/commands/container"
 	"github.com/jfrog/jfrog-cli/utils/accesstoken"
 	"os"
 	"strconv"
@@ -44,6 +46,7 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokencreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildadddependencies"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildaddgit"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildaggregate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildappend"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildclean"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildcollectenv"
@@ -52,6 +55,7 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpromote"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpublish"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildscan"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildverify"
 	copydocs "github.com/jfrog/jfrog-cli/docs/artifactory/copy"
 	curldocs "github.com/jfrog/jfrog-cli/docs/artifactory/curl"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/delete"
@@ -249,7 +253,7 @@ func GetCommands() []cli.Command {
 			Usage:        buildcollectenv.GetDescription(),
 			HelpName:     corecommon.CreateUsage("rt build-collect-env", buildcollectenv.GetDescription(), buildcollectenv.Usage),
 			UsageText:    buildcollectenv.GetArguments(),
-			ArgsUsage:    common.CreateEnvVars(),
+			ArgsUsage:    common.CreateEnvVars(common.JfrogCliEnvPolicy),
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 			Action:       buildCollectEnvCmd,
 			Category:     buildCategory,
@@ -266,6 +270,18 @@ func GetCommands() []cli.Command {
 			Action:       buildAppendCmd,
 			Category:     buildCategory,
 		},
+		{
+			Name:         "build-aggregate",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildAggregate),
+			Aliases:      []string{"bagg"},
+			Usage:        buildaggregate.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt build-aggregate", buildaggregate.GetDescription(), buildaggregate.Usage),
+			UsageText:    buildaggregate.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       buildAggregateCmd,
+			Category:     buildCategory,
+		},
 		{
 			Name:         "build-add-dependencies",
 			Flags:        cliutils.GetCommandFlags(cliutils.BuildAddDependencies),
@@ -327,6 +343,18 @@ func GetCommands() []cli.Command {
 			Action:       buildPromoteCmd,
 			Category:     buildCategory,
 		},
+		{
+			Name:         "build-verify",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildVerify),
+			Aliases:      []string{"bv"},
+			Usage:        buildverify.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt build-verify", buildverify.GetDescription(), buildverify.Usage),
+			UsageText:    buildverify.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       buildVerifyCmd,
+			Category:     buildCategory,
+		},
 		{
 			Name:         "build-discard",
 			Flags:        cliutils.GetCommandFlags(cliutils.BuildDiscard),
@@ -1052,24 +1080,45 @@ func getRetryWaitTimeVerificationError() error {
 }
 
 func dockerPromoteCmd(c *cli.Context) error {
-	if c.NArg() != 3 {
+	// When promoting the images of a build, the source docker image argument is optional.
+	if c.NArg() != 3 && (c.NArg() != 2 || !c.IsSet("build")) {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
 	}
 	artDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
 	if err != nil {
 		return err
 	}
-	params := services.NewDockerPromoteParams(c.Args().Get(0), c.Args().Get(1), c.Args().Get(2))
+	var params services.DockerPromoteParams
+	if c.NArg() == 3 {
+		params = services.NewDockerPromoteParams(c.Args().Get(0), c.Args().Get(1), c.Args().Get(2))
+	} else {
+		params = services.NewDockerPromoteParams("", c.Args().Get(0), c.Args().Get(1))
+	}
 	params.TargetDockerImage = c.String("target-docker-image")
 	params.SourceTag = c.String("source-tag")
 	params.TargetTag = c.String("target-tag")
 	params.Copy = c.Bool("copy")
+	if c.IsSet("tags-regex") || c.IsSet("created-after") || c.IsSet("build") || c.Bool("dry-run") {
+		return dockerBulkPromote(c, params, artDetails)
+	}
 	dockerPromoteCommand := container.NewDockerPromoteCommand()
 	dockerPromoteCommand.SetParams(params).SetServerDetails(artDetails)
 
 	return commands.Exec(dockerPromoteCommand)
 }
 
+func dockerBulkPromote(c *cli.Context, params services.DockerPromoteParams, artDetails *coreConfig.ServerDetails) error {
+	bulkPromoteCommand := containercmd.NewDockerBulkPromoteCommand()
+	bulkPromoteCommand.SetParams(params).SetServerDetails(artDetails).SetBuild(c.String("build")).SetProject(cliutils.GetProject(c)).SetDryRun(c.Bool("dry-run"))
+	if _, err := bulkPromoteCommand.SetTagsRegex(c.String("tags-regex")); err != nil {
+		return err
+	}
+	if _, err := bulkPromoteCommand.SetCreatedAfter(c.String("created-after")); err != nil {
+		return err
+	}
+	return commands.Exec(bulkPromoteCommand)
+}
+
 func containerPushCmd(c *cli.Context, containerManagerType containerutils.ContainerManagerType) (err error) {
 	if c.NArg() != 2 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
@@ -1666,7 +1715,8 @@ func buildPublishCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).SetDetailedSummary(cliutils.GetDetailedSummary(c))
+	buildPublishCmd := clibuildinfo.NewBuildPublishCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).
+		SetDetailedSummary(cliutils.GetDetailedSummary(c)).SetSignKeyPath(c.String("sign-key"))
 
 	err = commands.Exec(buildPublishCmd)
 	if buildPublishCmd.IsDetailedSummary() {
@@ -1694,6 +1744,30 @@ func buildAppendCmd(c *cli.Context) error {
 	return commands.Exec(buildAppendCmd)
 }
 
+func buildAggregateCmd(c *cli.Context) error {
+	if c.NArg() != 0 && c.NArg() != 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	if !c.IsSet("children") {
+		return cliutils.PrintHelpAndReturnError("The '--children' option is mandatory.", c)
+	}
+	children, err := clibuildinfo.ParseChildBuilds(c.String("children"))
+	if err != nil {
+		return err
+	}
+	buildConfiguration := cliutils.CreateBuildConfiguration(c)
+	if err = buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildAggregateCmd := clibuildinfo.NewBuildAggregateCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetChildren(children).
+		SetFlattenArtifacts(c.Bool("flatten-artifacts")).SetFlattenDependencies(c.Bool("flatten-dependencies"))
+	return commands.Exec(buildAggregateCmd)
+}
+
 func buildAddDependenciesCmd(c *cli.Context) error {
 	if c.NArg() > 2 && c.IsSet("spec") {
 		return cliutils.PrintHelpAndReturnError("Only path or spec is allowed, not both.", c)
@@ -1744,7 +1818,8 @@ func buildCollectEnvCmd(c *cli.Context) error {
 	if err := buildConfiguration.ValidateBuildParams(); err != nil {
 		return err
 	}
-	buildCollectEnvCmd := buildinfo.NewBuildCollectEnvCommand().SetBuildConfiguration(buildConfiguration)
+	buildCollectEnvCmd := clibuildinfo.NewBuildCollectEnvCommand().SetBuildConfiguration(buildConfiguration).
+		SetPolicyPath(cliutils.GetEnvPolicy(c.String("env-policy"))).SetPrintReport(c.Bool("report"))
 
 	return commands.Exec(buildCollectEnvCmd)
 }
@@ -1758,7 +1833,8 @@ func buildAddGitCmd(c *cli.Context) error {
 		return err
 	}
 
-	buildAddGitConfigurationCmd := buildinfo.NewBuildAddGitCommand().SetBuildConfiguration(buildConfiguration).SetConfigFilePath(c.String("config")).SetServerId(c.String("server-id"))
+	buildAddGitConfigurationCmd := clibuildinfo.NewBuildAddGitCommand().SetBuildConfiguration(buildConfiguration).SetConfigFilePath(c.String("config")).
+		SetServerId(c.String("server-id")).SetMainBranch(c.String("main-branch"))
 	if c.NArg() == 3 {
 		buildAddGitConfigurationCmd.SetDotGitPath(c.Args().Get(2))
 	} else if c.NArg() == 1 {
@@ -1822,10 +1898,35 @@ func buildPromoteCmd(c *cli.Context) error {
 	if err := buildConfiguration.ValidateBuildParams(); err != nil {
 		return err
 	}
+	if c.String("public-key") != "" {
+		buildVerifyCmd := clibuildinfo.NewBuildVerifyCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetPublicKeyPath(c.String("public-key"))
+		if err = commands.Exec(buildVerifyCmd); err != nil {
+			return errorutils.CheckErrorf("build promotion aborted: %s", err.Error())
+		}
+	}
 	buildPromotionCmd := buildinfo.NewBuildPromotionCommand().SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetPromotionParams(configuration).SetBuildConfiguration(buildConfiguration)
 	return commands.Exec(buildPromotionCmd)
 }
 
+func buildVerifyCmd(c *cli.Context) error {
+	if c.NArg() > 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	if c.String("public-key") == "" {
+		return cliutils.PrintHelpAndReturnError("The '--public-key' option is mandatory.", c)
+	}
+	buildConfiguration := cliutils.CreateBuildConfiguration(c)
+	if err := buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildVerifyCmd := clibuildinfo.NewBuildVerifyCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetPublicKeyPath(c.String("public-key"))
+	return commands.Exec(buildVerifyCmd)
+}
+
 func buildDiscardCmd(c *cli.Context) error {
 	if c.NArg() > 1 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
@@ -1834,12 +1935,16 @@ func buildDiscardCmd(c *cli.Context) error {
 	if configuration.BuildName == "" {
 		return cliutils.PrintHelpAndReturnError("Build name is expected as a command argument or environment variable.", c)
 	}
-	buildDiscardCmd := buildinfo.NewBuildDiscardCommand()
+	retentionRules, err := createBuildDiscardRetentionRules(c)
+	if err != nil {
+		return err
+	}
+	buildDiscardCmd := clibuildinfo.NewBuildDiscardCommand()
 	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
 	if err != nil {
 		return err
 	}
-	buildDiscardCmd.SetServerDetails(rtDetails).SetDiscardBuildsParams(configuration)
+	buildDiscardCmd.SetServerDetails(rtDetails).SetDiscardBuildsParams(configuration).SetRetentionRules(retentionRules).SetDryRun(c.Bool("dry-run"))
 
 	return commands.Exec(buildDiscardCmd)
 }
@@ -2596,6 +2701,26 @@ func createBuildDiscardConfiguration(c *cli.Context) services.DiscardBuildsParam
 	return discardParamsImpl
 }
 
+func createBuildDiscardRetentionRules(c *cli.Context) (retentionRules clibuildinfo.RetentionRules, err error) {
+	retentionRules.KeepLatestPerBranch, err = cliutils.GetIntFlagValue(c, "keep-latest-per-branch", 0)
+	if err != nil {
+		return
+	}
+	if retentionRules.KeepLatestPerBranch < 0 {
+		err = cliutils.PrintHelpAndReturnError("The '--keep-latest-per-branch' option should have a positive numeric value.", c)
+		return
+	}
+	if c.IsSet("branch-prop") && retentionRules.KeepLatestPerBranch == 0 {
+		err = cliutils.PrintHelpAndReturnError("The '--branch-prop' option can be sent only with the '--keep-latest-per-branch' option.", c)
+		return
+	}
+	retentionRules.KeepStatuses = cliutils.GetCommaSeparatedFlagValue(c, "keep-statuses")
+	retentionRules.KeepReleaseBundles = cliutils.GetCommaSeparatedFlagValue(c, "keep-release-bundles")
+	retentionRules.BranchProperty = c.String("branch-prop")
+	retentionRules.KeepProperties = cliutils.GetStringsArrFlagValue(c, "keep-props")
+	return
+}
+
 func createGitLfsCleanConfiguration(c *cli.Context) (gitLfsCleanConfiguration *generic.GitLfsCleanConfiguration) {
 	gitLfsCleanConfiguration = new(generic.GitLfsCleanConfiguration)
 
@@ -153,3 +153,16 @@ func TestCreateUploadConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateBuildDiscardRetentionRules(t *testing.T) {
+	context, _ := tests.CreateContext(t, []string{"keep-statuses= Released, Staged ,,", "keep-release-bundles=bundle/1.0.0 ,"}, []string{})
+	retentionRules, err := createBuildDiscardRetentionRules(context)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Released", "Staged"}, retentionRules.KeepStatuses)
+	assert.Equal(t, []string{"bundle/1.0.0"}, retentionRules.KeepReleaseBundles)
+
+	context, _ = tests.CreateContext(t, []string{}, []string{})
+	retentionRules, err = createBuildDiscardRetentionRules(context)
+	assert.NoError(t, err)
+	assert.True(t, retentionRules.IsEmpty())
+}
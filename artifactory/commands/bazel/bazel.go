@@ -0,0 +1,381 @@
+package bazel
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	buildEventsFileFlag = "--build_event_json_file"
+	bazelrcFlag         = "--bazelrc"
+
+	bazelModuleType buildinfo.ModuleType = "bazel"
+)
+
+// The commands which build targets, so the downloads and the remote cache are configured for them, and their build events are collected.
+// The other commands are run as is.
+var buildCommands = map[string]bool{"build": true, "test": true, "run": true, "coverage": true}
+
+// Runs any Bazel command. The build commands download the external dependencies through the resolution repository,
+// and use the deployment repository, which should be a generic repository, as an HTTP remote cache.
+// The configuration is passed to Bazel through a temporary bazelrc file, so that the credentials don't appear in the command line.
+// If build-info collection was requested, the build event protocol (BEP) output of the build is read:
+// the outputs of each top-level target are recorded as the artifacts of a module which is named after the target,
+// and the downloaded external dependencies are recorded as the dependencies of the workspace module.
+type BazelCommand struct {
+	cmdName          string
+	startupOptions   []string
+	args             []string
+	configFilePath   string
+	executablePath   string
+	workingDirectory string
+	repo             string
+	// The repository of the remote cache.
+	deployRepo            string
+	serverDetails         *config.ServerDetails
+	deployerServerDetails *config.ServerDetails
+	buildConfiguration    *build.BuildConfiguration
+}
+
+func NewBazelCommand(cmdName string) *BazelCommand {
+	return &BazelCommand{cmdName: cmdName}
+}
+
+func (bc *BazelCommand) SetConfigFilePath(configFilePath string) *BazelCommand {
+	bc.configFilePath = configFilePath
+	return bc
+}
+
+// The startup options are the options before the command, such as --output_base.
+func (bc *BazelCommand) SetStartupOptions(startupOptions []string) *BazelCommand {
+	bc.startupOptions = startupOptions
+	return bc
+}
+
+func (bc *BazelCommand) SetArgs(args []string) *BazelCommand {
+	bc.args = args
+	return bc
+}
+
+func (bc *BazelCommand) ServerDetails() (*config.ServerDetails, error) {
+	if bc.serverDetails != nil {
+		return bc.serverDetails, nil
+	}
+	return bc.deployerServerDetails, nil
+}
+
+func (bc *BazelCommand) CommandName() string {
+	return "rt_bazel_" + bc.cmdName
+}
+
+// Splits the arguments of the bazel command into the startup options, the command name and the command arguments.
+func SplitArgs(args []string) (startupOptions []string, cmdName string, cmdArgs []string) {
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			return args[:i], arg, args[i+1:]
+		}
+	}
+	return args, "", nil
+}
+
+// Both the resolution and the deployment repositories are optional, but at least one of them is required.
+func (bc *BazelCommand) Init() (err error) {
+	log.Debug("Preparing to read the config file", bc.configFilePath)
+	vConfig, err := project.ReadConfigFile(bc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	if !vConfig.IsSet(project.ProjectConfigResolverPrefix) && !vConfig.IsSet(project.ProjectConfigDeployerPrefix) {
+		return errorutils.CheckErrorf("neither a resolution nor a deployment repository is set in %s. Please run 'jf bazel-config'", bc.configFilePath)
+	}
+	if vConfig.IsSet(project.ProjectConfigResolverPrefix) {
+		resolverParams, err := project.GetRepoConfigByPrefix(bc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		bc.repo = resolverParams.TargetRepo()
+		if bc.serverDetails, err = resolverParams.ServerDetails(); err != nil {
+			return err
+		}
+	}
+	if vConfig.IsSet(project.ProjectConfigDeployerPrefix) {
+		deployerParams, err := project.GetRepoConfigByPrefix(bc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		bc.deployRepo = deployerParams.TargetRepo()
+		if bc.deployerServerDetails, err = deployerParams.ServerDetails(); err != nil {
+			return err
+		}
+	}
+	bc.args, bc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(bc.args)
+	return err
+}
+
+func (bc *BazelCommand) Run() (err error) {
+	log.Info("Running bazel " + bc.cmdName + "...")
+	if bc.executablePath, err = getExecutablePath(); err != nil {
+		return err
+	}
+	if bc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return err
+	}
+	collectBuildInfo, err := bc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if !buildCommands[bc.cmdName] {
+		if err = bc.runBazel(bc.startupOptions, bc.args); err != nil {
+			return err
+		}
+		log.Info("bazel " + bc.cmdName + " finished successfully.")
+		return nil
+	}
+
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	bazelrcPath, err := bc.writeBazelrc(tempDir)
+	if err != nil {
+		return err
+	}
+	startupOptions := append(append([]string(nil), bc.startupOptions...), bazelrcFlag+"="+bazelrcPath)
+	args := append([]string(nil), bc.args...)
+	_, _, buildEventsPath, err := coreutils.FindFlag(buildEventsFileFlag, args)
+	if err != nil {
+		return err
+	}
+	if collectBuildInfo && buildEventsPath == "" {
+		buildEventsPath = filepath.Join(tempDir, "build_events.json")
+		args = append([]string{buildEventsFileFlag + "=" + buildEventsPath}, args...)
+	}
+	if err = bc.runBazel(startupOptions, args); err != nil {
+		return err
+	}
+	if collectBuildInfo {
+		if err = bc.collectBuildInfo(buildEventsPath); err != nil {
+			return err
+		}
+	}
+	log.Info("bazel " + bc.cmdName + " finished successfully.")
+	return nil
+}
+
+// Bazelisk is used if Bazel isn't installed, since it's the recommended way of installing Bazel.
+func getExecutablePath() (string, error) {
+	executablePath, err := exec.LookPath("bazel")
+	if err == nil {
+		return executablePath, nil
+	}
+	if executablePath, bazeliskErr := exec.LookPath("bazelisk"); bazeliskErr == nil {
+		return executablePath, nil
+	}
+	return "", errorutils.CheckError(err)
+}
+
+// Writes the bazelrc file with the downloader configuration and the remote cache.
+// The bazelrc file which is passed by --bazelrc replaces the user's ~/.bazelrc, so it's imported first, unless the user passed a bazelrc file of their own.
+func (bc *BazelCommand) writeBazelrc(tempDir string) (string, error) {
+	var lines []string
+	if !hasBazelrcOption(bc.startupOptions) {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			lines = append(lines, "try-import "+filepath.ToSlash(filepath.Join(homeDir, ".bazelrc")))
+		}
+	}
+	if bc.repo != "" {
+		downloaderConfigPath := filepath.Join(tempDir, "downloader.cfg")
+		content, err := createDownloaderConfig(bc.serverDetails, bc.repo)
+		if err != nil {
+			return "", err
+		}
+		if err = os.WriteFile(downloaderConfigPath, []byte(content), 0600); err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		lines = append(lines, "build --downloader_config="+filepath.ToSlash(downloaderConfigPath))
+	}
+	if bc.deployRepo != "" {
+		remoteCacheUrl, err := getRepositoryUrl(bc.deployerServerDetails, bc.deployRepo)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, "build --remote_cache="+remoteCacheUrl)
+	}
+	bazelrcPath := filepath.Join(tempDir, "jfrog.bazelrc")
+	return bazelrcPath, errorutils.CheckError(os.WriteFile(bazelrcPath, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+}
+
+func hasBazelrcOption(startupOptions []string) bool {
+	for _, option := range startupOptions {
+		if option == bazelrcFlag || strings.HasPrefix(option, bazelrcFlag+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the downloader configuration, which rewrites the URLs of all the downloads to the resolution repository: <host>/<path> is downloaded from <repo>/<host>/<path>.
+// Bazel authenticates by the credentials in the rewritten URL.
+func createDownloaderConfig(serverDetails *config.ServerDetails, repo string) (string, error) {
+	repositoryUrl, err := getRepositoryUrl(serverDetails, repo)
+	if err != nil {
+		return "", err
+	}
+	return "rewrite (.*) " + repositoryUrl + "/$1\n", nil
+}
+
+// Returns the URL of the repository, with the basic authentication credentials of the server.
+func getRepositoryUrl(serverDetails *config.ServerDetails, repo string) (string, error) {
+	repositoryUrl, err := url.Parse(strings.TrimSuffix(serverDetails.GetArtifactoryUrl(), "/") + "/" + repo)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if username, password := projectconfig.GetBasicAuthCredentials(serverDetails); password != "" {
+		repositoryUrl.User = url.UserPassword(username, password)
+	}
+	return repositoryUrl.String(), nil
+}
+
+func (bc *BazelCommand) runBazel(startupOptions, args []string) error {
+	command := exec.Command(bc.executablePath, append(append(append([]string(nil), startupOptions...), bc.cmdName), args...)...)
+	command.Dir = bc.workingDirectory
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	if err := command.Run(); err != nil {
+		return errorutils.CheckErrorf("bazel %s failed: %s", bc.cmdName, err.Error())
+	}
+	return nil
+}
+
+func (bc *BazelCommand) collectBuildInfo(buildEventsPath string) error {
+	log.Info("Collecting build-info from the build events of " + buildEventsPath + "...")
+	events, err := parseBuildEventsFile(buildEventsPath)
+	if err != nil {
+		return err
+	}
+	dependencies, err := bc.getDependencies(events.FetchedUrls)
+	if err != nil {
+		return err
+	}
+	partials := createPartials(events, dependencies, bc.getWorkspaceModuleId(events), bc.buildConfiguration.GetModule())
+	if len(partials) == 0 {
+		log.Warn("No outputs or external dependencies were found in the build events, so no modules are added to the build-info.")
+		return nil
+	}
+	buildName, err := bc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := bc.buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	for _, partial := range partials {
+		if err = build.SavePartialBuildInfo(buildName, buildNumber, projectKey, func(p *buildinfo.Partial) {
+			p.ModuleType = partial.ModuleType
+			p.ModuleId = partial.ModuleId
+			p.Artifacts = partial.Artifacts
+			p.Dependencies = partial.Dependencies
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The workspace module is named after the workspace directory.
+func (bc *BazelCommand) getWorkspaceModuleId(events *buildEvents) string {
+	if events.WorkspaceDirectory != "" {
+		return filepath.Base(events.WorkspaceDirectory)
+	}
+	return filepath.Base(bc.workingDirectory)
+}
+
+// Returns a module for each top-level target with outputs, and the workspace module with the external dependencies.
+// If a module was set by the --module option, all the artifacts and dependencies are recorded in that module.
+func createPartials(events *buildEvents, dependencies []buildinfo.Dependency, workspaceModuleId, moduleId string) []*buildinfo.Partial {
+	var partials []*buildinfo.Partial
+	if moduleId != "" {
+		partial := &buildinfo.Partial{ModuleType: bazelModuleType, ModuleId: moduleId, Dependencies: dependencies}
+		for _, target := range events.Targets {
+			partial.Artifacts = append(partial.Artifacts, target.toArtifacts()...)
+		}
+		if len(partial.Artifacts) > 0 || len(partial.Dependencies) > 0 {
+			partials = append(partials, partial)
+		}
+		return partials
+	}
+	if len(dependencies) > 0 {
+		partials = append(partials, &buildinfo.Partial{ModuleType: bazelModuleType, ModuleId: workspaceModuleId, Dependencies: dependencies})
+	}
+	for _, target := range events.Targets {
+		if artifacts := target.toArtifacts(); len(artifacts) > 0 {
+			partials = append(partials, &buildinfo.Partial{ModuleType: bazelModuleType, ModuleId: normalizeLabel(target.Label), Artifacts: artifacts})
+		}
+	}
+	return partials
+}
+
+// The checksums of the dependencies which were downloaded through the resolution repository are read from Artifactory, by their rewritten path.
+func (bc *BazelCommand) getDependencies(fetchedUrls []string) ([]buildinfo.Dependency, error) {
+	dependencies := make([]buildinfo.Dependency, 0, len(fetchedUrls))
+	for _, fetchedUrl := range fetchedUrls {
+		dependencies = append(dependencies, toDependency(fetchedUrl))
+	}
+	if bc.repo == "" || len(dependencies) == 0 {
+		return dependencies, nil
+	}
+	servicesManager, err := utils.CreateServiceManager(bc.serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	var missingDependencies []string
+	for i, fetchedUrl := range fetchedUrls {
+		relativePath := getRewrittenPath(fetchedUrl)
+		if relativePath == "" {
+			missingDependencies = append(missingDependencies, dependencies[i].Id)
+			continue
+		}
+		fileInfo, err := servicesManager.FileInfo(bc.repo + "/" + relativePath)
+		if err != nil {
+			log.Debug("Failed reading the checksums of " + fetchedUrl + ": " + err.Error())
+			missingDependencies = append(missingDependencies, dependencies[i].Id)
+			continue
+		}
+		dependencies[i].Checksum = buildinfo.Checksum{Sha1: fileInfo.Checksums.Sha1, Md5: fileInfo.Checksums.Md5, Sha256: fileInfo.Checksums.Sha256}
+	}
+	commandUtils.PrintMissingDependencies(missingDependencies)
+	return dependencies, nil
+}
+
+// Returns the path of the URL in the resolution repository, as rewritten by the downloader configuration: <host>/<path>.
+func getRewrittenPath(fetchedUrl string) string {
+	parsedUrl, err := url.Parse(fetchedUrl)
+	if err != nil || parsedUrl.Host == "" {
+		return ""
+	}
+	return parsedUrl.Host + parsedUrl.EscapedPath()
+}
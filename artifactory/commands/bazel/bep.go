@@ -0,0 +1,226 @@
+package bazel
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The output group of the files which are built by default for a target.
+const defaultOutputGroup = "default"
+
+// The archive extensions which consist of two parts, so that the type of the archive is tar.gz rather than gz.
+var compoundExtensions = []string{".tar.gz", ".tar.bz2", ".tar.xz", ".tar.zst"}
+
+// A build event of the build event protocol, as written by --build_event_json_file, one event per line.
+// Only the events which are relevant for the build-info are parsed.
+type buildEvent struct {
+	Id struct {
+		Started         *struct{}               `json:"started"`
+		Fetch           *struct{ Url string }   `json:"fetch"`
+		NamedSet        *struct{ Id string }    `json:"namedSet"`
+		TargetCompleted *struct{ Label string } `json:"targetCompleted"`
+	} `json:"id"`
+	Started *struct {
+		Command            string `json:"command"`
+		WorkspaceDirectory string `json:"workspaceDirectory"`
+	} `json:"started"`
+	Fetch *struct {
+		Success bool `json:"success"`
+	} `json:"fetch"`
+	NamedSetOfFiles *namedSetOfFiles `json:"namedSetOfFiles"`
+	Completed       *struct {
+		Success     bool `json:"success"`
+		OutputGroup []struct {
+			Name     string           `json:"name"`
+			FileSets []namedSetOfRefs `json:"fileSets"`
+		} `json:"outputGroup"`
+	} `json:"completed"`
+}
+
+type namedSetOfRefs struct {
+	Id string `json:"id"`
+}
+
+type namedSetOfFiles struct {
+	Files    []outputFile     `json:"files"`
+	FileSets []namedSetOfRefs `json:"fileSets"`
+}
+
+// A file which was built by Bazel. The name is relative to the path prefix, which is the output directory of the configuration, such as bazel-out/k8-fastbuild/bin.
+type outputFile struct {
+	Name       string   `json:"name"`
+	Uri        string   `json:"uri"`
+	PathPrefix []string `json:"pathPrefix"`
+	// The SHA-256 digest of the file, with the default digest function of Bazel.
+	Digest string `json:"digest"`
+}
+
+// The outputs of a top-level target which was built successfully.
+type targetOutputs struct {
+	Label string
+	Files []outputFile
+}
+
+// The information which is collected into the build-info from the build events.
+type buildEvents struct {
+	Command            string
+	WorkspaceDirectory string
+	// The URLs of the external dependencies which were downloaded successfully, such as the archives of http_archive rules.
+	FetchedUrls []string
+	Targets     []targetOutputs
+}
+
+func parseBuildEventsFile(buildEventsPath string) (*buildEvents, error) {
+	file, err := os.Open(buildEventsPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	return parseBuildEvents(file)
+}
+
+// The named sets of files may reference each other, and they're written before the events which reference them,
+// so the outputs of the targets are resolved after all the events are read.
+func parseBuildEvents(reader io.Reader) (*buildEvents, error) {
+	events := new(buildEvents)
+	namedSets := make(map[string]*namedSetOfFiles)
+	fetchedUrls := make(map[string]bool)
+	type completedTarget struct {
+		label    string
+		fileSets []namedSetOfRefs
+	}
+	var completedTargets []completedTarget
+
+	scanner := bufio.NewScanner(reader)
+	// The named sets of large targets may be longer than the default limit of a line.
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var event buildEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			return nil, errorutils.CheckErrorf("failed parsing the build event: %s", err.Error())
+		}
+		switch {
+		case event.Id.Started != nil && event.Started != nil:
+			events.Command = event.Started.Command
+			events.WorkspaceDirectory = event.Started.WorkspaceDirectory
+		case event.Id.Fetch != nil && event.Fetch != nil && event.Fetch.Success:
+			if !fetchedUrls[event.Id.Fetch.Url] {
+				fetchedUrls[event.Id.Fetch.Url] = true
+				events.FetchedUrls = append(events.FetchedUrls, event.Id.Fetch.Url)
+			}
+		case event.Id.NamedSet != nil && event.NamedSetOfFiles != nil:
+			namedSets[event.Id.NamedSet.Id] = event.NamedSetOfFiles
+		case event.Id.TargetCompleted != nil && event.Completed != nil && event.Completed.Success:
+			target := completedTarget{label: event.Id.TargetCompleted.Label}
+			for _, outputGroup := range event.Completed.OutputGroup {
+				if outputGroup.Name == defaultOutputGroup {
+					target.fileSets = append(target.fileSets, outputGroup.FileSets...)
+				}
+			}
+			completedTargets = append(completedTargets, target)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+
+	for _, target := range completedTargets {
+		visited := make(map[string]bool)
+		var files []outputFile
+		for _, fileSet := range target.fileSets {
+			files = collectNamedSetFiles(fileSet.Id, namedSets, visited, files)
+		}
+		events.Targets = append(events.Targets, targetOutputs{Label: target.label, Files: files})
+	}
+	return events, nil
+}
+
+func collectNamedSetFiles(id string, namedSets map[string]*namedSetOfFiles, visited map[string]bool, files []outputFile) []outputFile {
+	namedSet, exists := namedSets[id]
+	if !exists || visited[id] {
+		return files
+	}
+	visited[id] = true
+	files = append(files, namedSet.Files...)
+	for _, fileSet := range namedSet.FileSets {
+		files = collectNamedSetFiles(fileSet.Id, namedSets, visited, files)
+	}
+	return files
+}
+
+// Returns the label of the target without the name of the main repository, which Bazel 7 adds, so that //app:server and @@//app:server are the same module.
+func normalizeLabel(label string) string {
+	if strings.HasPrefix(label, "@@//") || strings.HasPrefix(label, "@//") {
+		return label[strings.Index(label, "//"):]
+	}
+	return label
+}
+
+// Returns the outputs of the target as build-info artifacts. The path of each artifact is its path in the output directory.
+// Bazel reports the SHA-256 digest of each file. The other checksums are calculated if the file is available locally.
+func (to *targetOutputs) toArtifacts() []buildinfo.Artifact {
+	artifacts := make([]buildinfo.Artifact, 0, len(to.Files))
+	for _, file := range to.Files {
+		artifact := buildinfo.Artifact{
+			Name:     path.Base(file.Name),
+			Type:     getFileType(file.Name),
+			Path:     file.Name,
+			Checksum: buildinfo.Checksum{Sha256: file.Digest},
+		}
+		if localPath := getLocalPath(file.Uri); localPath != "" {
+			if fileDetails, err := fileutils.GetFileDetails(localPath, true); err == nil {
+				artifact.Checksum = buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256}
+			} else {
+				log.Debug("Failed calculating the checksums of " + localPath + ": " + err.Error())
+			}
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts
+}
+
+// Returns the local path of a file:// URI. The files of builds with remote execution have bytestream:// URIs, so they're not available locally.
+func getLocalPath(uri string) string {
+	parsedUri, err := url.Parse(uri)
+	if err != nil || parsedUri.Scheme != "file" {
+		return ""
+	}
+	if _, err = os.Stat(parsedUri.Path); err != nil {
+		return ""
+	}
+	return parsedUri.Path
+}
+
+// Returns the external dependency of a downloaded URL. The dependency ID is the name of the downloaded file.
+func toDependency(fetchedUrl string) buildinfo.Dependency {
+	name := fetchedUrl
+	if parsedUrl, err := url.Parse(fetchedUrl); err == nil {
+		name = path.Base(parsedUrl.Path)
+	}
+	return buildinfo.Dependency{Id: name, Type: getFileType(name)}
+}
+
+func getFileType(fileName string) string {
+	for _, extension := range compoundExtensions {
+		if strings.HasSuffix(fileName, extension) {
+			return strings.TrimPrefix(extension, ".")
+		}
+	}
+	return strings.TrimPrefix(path.Ext(fileName), ".")
+}
@@ -0,0 +1,66 @@
+package bazel
+
+import (
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBuildEventsFile(t *testing.T) {
+	events, err := parseBuildEventsFile(filepath.Join("testdata", "build_events.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "build", events.Command)
+	assert.Equal(t, "/home/dev/shop", events.WorkspaceDirectory)
+	assert.Equal(t, []string{
+		"https://github.com/bazelbuild/rules_go/releases/download/v0.48.0/rules_go-v0.48.0.zip",
+		"https://github.com/protocolbuffers/protobuf/archive/v27.1.tar.gz",
+	}, events.FetchedUrls)
+
+	require.Len(t, events.Targets, 2)
+	assert.Equal(t, "@@//app:server", events.Targets[0].Label)
+	require.Len(t, events.Targets[0].Files, 2)
+	assert.Equal(t, "app/server_/server", events.Targets[0].Files[0].Name)
+	assert.Equal(t, "lib/libutils.a", events.Targets[0].Files[1].Name)
+	assert.Equal(t, "@@//lib:utils", events.Targets[1].Label)
+	assert.Len(t, events.Targets[1].Files, 1)
+}
+
+func TestCreatePartials(t *testing.T) {
+	events, err := parseBuildEventsFile(filepath.Join("testdata", "build_events.json"))
+	require.NoError(t, err)
+	var dependencies []buildinfo.Dependency
+	for _, fetchedUrl := range events.FetchedUrls {
+		dependencies = append(dependencies, toDependency(fetchedUrl))
+	}
+	assert.Equal(t, []buildinfo.Dependency{{Id: "rules_go-v0.48.0.zip", Type: "zip"}, {Id: "v27.1.tar.gz", Type: "tar.gz"}}, dependencies)
+
+	partials := createPartials(events, dependencies, "shop", "")
+	require.Len(t, partials, 3)
+	assert.Equal(t, "shop", partials[0].ModuleId)
+	assert.Equal(t, dependencies, partials[0].Dependencies)
+	assert.Empty(t, partials[0].Artifacts)
+
+	assert.Equal(t, "//app:server", partials[1].ModuleId)
+	assert.Equal(t, bazelModuleType, partials[1].ModuleType)
+	assert.Equal(t, []buildinfo.Artifact{
+		{Name: "server", Path: "app/server_/server", Checksum: buildinfo.Checksum{Sha256: "9a8b7c6d5e4f30219a8b7c6d5e4f30219a8b7c6d5e4f30219a8b7c6d5e4f3021"}},
+		{Name: "libutils.a", Type: "a", Path: "lib/libutils.a", Checksum: buildinfo.Checksum{Sha256: "1f2d3e4c5b6a79881f2d3e4c5b6a79881f2d3e4c5b6a79881f2d3e4c5b6a7988"}},
+	}, partials[1].Artifacts)
+	assert.Equal(t, "//lib:utils", partials[2].ModuleId)
+
+	partials = createPartials(events, dependencies, "shop", "shop-all")
+	require.Len(t, partials, 1)
+	assert.Equal(t, "shop-all", partials[0].ModuleId)
+	assert.Len(t, partials[0].Artifacts, 3)
+	assert.Equal(t, dependencies, partials[0].Dependencies)
+}
+
+func TestNormalizeLabel(t *testing.T) {
+	assert.Equal(t, "//app:server", normalizeLabel("@@//app:server"))
+	assert.Equal(t, "//app:server", normalizeLabel("@//app:server"))
+	assert.Equal(t, "//app:server", normalizeLabel("//app:server"))
+	assert.Equal(t, "@@rules_go~//go:tools", normalizeLabel("@@rules_go~//go:tools"))
+}
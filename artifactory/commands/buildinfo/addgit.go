@@ -0,0 +1,325 @@
+package buildinfo
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	corebuildinfo "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	gitPropertiesPrefix = "vcs.git."
+	// The separator between the fields of a single commit in the git log output.
+	gitLogFieldsSeparator = "\x1f"
+)
+
+// BuildAddGitCommand collects the same VCS details as the build-add-git command of jfrog-cli-core,
+// and enriches the build-info with additional git metadata, read from the local repository using the git binary:
+// the tags pointing at HEAD, the merge-base with the main branch, and the commits added since the previous published build,
+// including their authors and signature status.
+type BuildAddGitCommand struct {
+	*corebuildinfo.BuildAddGitCommand
+	buildConfiguration *build.BuildConfiguration
+	dotGitPath         string
+	mainBranch         string
+}
+
+func NewBuildAddGitCommand() *BuildAddGitCommand {
+	return &BuildAddGitCommand{BuildAddGitCommand: corebuildinfo.NewBuildAddGitCommand()}
+}
+
+func (bag *BuildAddGitCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildAddGitCommand {
+	bag.buildConfiguration = buildConfiguration
+	bag.BuildAddGitCommand.SetBuildConfiguration(buildConfiguration)
+	return bag
+}
+
+func (bag *BuildAddGitCommand) SetDotGitPath(dotGitPath string) *BuildAddGitCommand {
+	bag.dotGitPath = dotGitPath
+	bag.BuildAddGitCommand.SetDotGitPath(dotGitPath)
+	return bag
+}
+
+func (bag *BuildAddGitCommand) SetConfigFilePath(configFilePath string) *BuildAddGitCommand {
+	bag.BuildAddGitCommand.SetConfigFilePath(configFilePath)
+	return bag
+}
+
+func (bag *BuildAddGitCommand) SetServerId(serverId string) *BuildAddGitCommand {
+	bag.BuildAddGitCommand.SetServerId(serverId)
+	return bag
+}
+
+// The branch used for calculating the merge-base. If empty, 'main' or 'master' are used, in this order.
+func (bag *BuildAddGitCommand) SetMainBranch(mainBranch string) *BuildAddGitCommand {
+	bag.mainBranch = mainBranch
+	return bag
+}
+
+func (bag *BuildAddGitCommand) Run() error {
+	if err := bag.BuildAddGitCommand.Run(); err != nil {
+		return err
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		log.Warn("Skipping the collection of additional git details, since git was not found in PATH.")
+		return nil
+	}
+	if bag.dotGitPath == "" {
+		// Same lookup as the one done by the base command.
+		var exists bool
+		var err error
+		bag.dotGitPath, exists, err = fileutils.FindUpstream(".git", fileutils.Any)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return errorutils.CheckErrorf("Could not find .git")
+		}
+	}
+	gitManager := clientutils.NewGitManager(bag.dotGitPath)
+	if err := gitManager.ReadConfig(); err != nil {
+		return err
+	}
+
+	buildName, err := bag.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bag.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	previousBuild, previousRevision := bag.getPreviousBuildRevision(buildName, buildNumber, gitManager.GetUrl())
+	gitDetails, err := CollectGitDetails(bag.dotGitPath, bag.mainBranch, previousRevision)
+	if err != nil {
+		return err
+	}
+	gitDetails.PreviousBuild = previousBuild
+
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.Env = gitDetails.ToProperties()
+	}
+	if err = build.SavePartialBuildInfo(buildName, buildNumber, bag.buildConfiguration.GetProject(), populateFunc); err != nil {
+		return err
+	}
+	log.Debug("Collected additional git details for", buildName+"/"+buildNumber+".")
+	return nil
+}
+
+// Returns the number and the VCS revision of the latest published build with the same name, which was built from the provided VCS URL.
+// Failing to reach Artifactory doesn't fail the command, since the rest of the git details are collected offline.
+func (bag *BuildAddGitCommand) getPreviousBuildRevision(buildName, buildNumber, vcsUrl string) (previousBuild, previousRevision string) {
+	serverDetails, err := bag.ServerDetails()
+	if err != nil || serverDetails == nil || serverDetails.ArtifactoryUrl == "" {
+		log.Debug("No Artifactory server is configured. Skipping the commit range collection.")
+		return
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		log.Warn("Failed to find the previous build of", buildName+":", err.Error())
+		return
+	}
+	runs, err := GetBuildRuns(servicesManager, buildName, bag.buildConfiguration.GetProject())
+	if err != nil {
+		log.Warn("Failed to find the previous build of", buildName+":", err.Error())
+		return
+	}
+	for _, run := range runs {
+		if run.Number == buildNumber {
+			continue
+		}
+		publishedBuild, found, err := GetPublishedBuild(servicesManager, buildName, run.Number, bag.buildConfiguration.GetProject())
+		if err != nil {
+			log.Warn("Failed to read build", buildName+"/"+run.Number+":", err.Error())
+			return
+		}
+		if !found {
+			continue
+		}
+		for _, vcs := range publishedBuild.BuildInfo.VcsList {
+			if vcs.Url == vcsUrl && vcs.Revision != "" {
+				return run.Number, vcs.Revision
+			}
+		}
+		// The latest build wasn't built from this repository.
+		return
+	}
+	log.Debug("No previous build of", buildName, "was found. Skipping the commit range collection.")
+	return
+}
+
+// Git details which aren't part of the build-info VCS entity.
+type GitDetails struct {
+	Tags             []string
+	MainBranch       string
+	MergeBase        string
+	PreviousBuild    string
+	PreviousRevision string
+	Revision         string
+	// The commits between the previous revision and HEAD, up to the git log limit of the build-info.
+	Commits []CommitDetails
+	// The number of the commits between the previous revision and HEAD, which may exceed the number of the collected commits.
+	CommitsCount int
+}
+
+type CommitDetails struct {
+	Revision string
+	Author   string
+	// Whether the commit carries a signature, whether or not it's verified.
+	Signed bool
+	// Whether the commit carries a good signature, which couldn't be fully verified, such as by a missing, expired or revoked key.
+	UnverifiedSignature bool
+	// Whether the commit carries a signature, which failed verification.
+	BadSignature bool
+}
+
+// Returns the distinct commit authors, in the order of their first appearance in the commit list.
+func (gd *GitDetails) Authors() (authors []string) {
+	for _, commit := range gd.Commits {
+		if !isInSlice(commit.Author, authors) {
+			authors = append(authors, commit.Author)
+		}
+	}
+	return
+}
+
+// Returns the git details as build properties.
+func (gd *GitDetails) ToProperties() buildinfo.Env {
+	properties := buildinfo.Env{}
+	addProperty := func(key, value string) {
+		if value != "" {
+			properties[gitPropertiesPrefix+key] = value
+		}
+	}
+	addProperty("tags", strings.Join(gd.Tags, ","))
+	addProperty("mainBranch", gd.MainBranch)
+	addProperty("mergeBase", gd.MergeBase)
+	if gd.PreviousRevision == "" {
+		return properties
+	}
+	addProperty("previousBuild", gd.PreviousBuild)
+	addProperty("commitRange", gd.PreviousRevision+".."+gd.Revision)
+	addProperty("commitsCount", strconv.Itoa(gd.CommitsCount))
+	// Build properties containing 'auth' are filtered out by default on build-publish, hence the 'contributors' key.
+	addProperty("contributors", strings.Join(gd.Authors(), ","))
+	var signed, unsigned, unverifiedSignature, badSignature []string
+	for _, commit := range gd.Commits {
+		switch {
+		case !commit.Signed:
+			unsigned = append(unsigned, commit.Revision)
+		case commit.BadSignature:
+			badSignature = append(badSignature, commit.Revision)
+		case commit.UnverifiedSignature:
+			unverifiedSignature = append(unverifiedSignature, commit.Revision)
+		default:
+			signed = append(signed, commit.Revision)
+		}
+	}
+	addProperty("signedCommits", strings.Join(signed, ","))
+	addProperty("unsignedCommits", strings.Join(unsigned, ","))
+	addProperty("unverifiedSignatureCommits", strings.Join(unverifiedSignature, ","))
+	addProperty("badSignatureCommits", strings.Join(badSignature, ","))
+	return properties
+}
+
+// Reads the git details of the repository in the provided directory.
+// If previousRevision isn't empty, the commits between it and HEAD are collected as well.
+func CollectGitDetails(repoPath, mainBranch, previousRevision string) (*GitDetails, error) {
+	gitDetails := &GitDetails{PreviousRevision: previousRevision}
+	var err error
+	if gitDetails.Revision, err = runGit(repoPath, "rev-parse", "HEAD"); err != nil {
+		return nil, err
+	}
+	tags, err := runGit(repoPath, "tag", "--points-at", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	gitDetails.Tags = splitAndTrim(tags, "\n")
+
+	gitDetails.MainBranch = resolveMainBranch(repoPath, mainBranch)
+	if gitDetails.MainBranch == "" {
+		log.Debug("The main branch was not found in the git repository. Skipping the merge-base collection.")
+	} else if gitDetails.MergeBase, err = runGit(repoPath, "merge-base", "HEAD", gitDetails.MainBranch); err != nil {
+		// Happens when the histories are unrelated.
+		log.Debug("Failed calculating the merge-base with", gitDetails.MainBranch+":", err.Error())
+	}
+
+	if previousRevision == "" {
+		return gitDetails, nil
+	}
+	commitsLog, err := runGit(repoPath, "log", "--format=%H"+gitLogFieldsSeparator+"%G?"+gitLogFieldsSeparator+"%an <%ae>",
+		"-"+strconv.Itoa(corebuildinfo.GitLogLimit), previousRevision+"..HEAD")
+	if err != nil {
+		// The revision of the previous build may not exist in the local history, probably due to a shallow clone, squash or revert.
+		log.Info("Revision", previousRevision, "of the previous build does not exist in the git revision range. Skipping the commit range collection.")
+		gitDetails.PreviousRevision = ""
+		return gitDetails, nil
+	}
+	gitDetails.Commits = parseCommitsLog(commitsLog)
+	gitDetails.CommitsCount = len(gitDetails.Commits)
+	if gitDetails.CommitsCount == corebuildinfo.GitLogLimit {
+		commitsCount, err := runGit(repoPath, "rev-list", "--count", previousRevision+"..HEAD")
+		if err != nil {
+			return nil, err
+		}
+		if gitDetails.CommitsCount, err = strconv.Atoi(commitsCount); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if gitDetails.CommitsCount > corebuildinfo.GitLogLimit {
+			log.Warn("The commit range", previousRevision+"..HEAD", "has", gitDetails.CommitsCount, "commits, of which only the latest",
+				corebuildinfo.GitLogLimit, "are collected and reported by their signatures.")
+		}
+	}
+	return gitDetails, nil
+}
+
+// Parses the output of 'git log' formatted as "<revision> <signature status> <author>", separated by gitLogFieldsSeparator.
+// Every commit which carries a signature is considered signed, that is every status but no signature (N).
+// A good signature (G) is verified. A bad signature (B) failed verification. The rest of the statuses are good signatures with an unknown validity of the key (U),
+// by an expired key (Y) or a revoked key (R), expired signatures (X), and signatures which can't be checked, such as by a missing key (E).
+func parseCommitsLog(commitsLog string) (commits []CommitDetails) {
+	for _, line := range splitAndTrim(commitsLog, "\n") {
+		fields := strings.SplitN(line, gitLogFieldsSeparator, 3)
+		if len(fields) != 3 {
+			log.Debug("Unexpected git log line:", line)
+			continue
+		}
+		status := fields[1]
+		commits = append(commits, CommitDetails{Revision: fields[0], Author: fields[2], Signed: status != "N",
+			UnverifiedSignature: status != "N" && status != "G" && status != "B", BadSignature: status == "B"})
+	}
+	return
+}
+
+// Returns the provided main branch, or its remote tracking branch, if exists in the repository.
+// If no main branch is provided, 'main' and 'master' are checked in this order.
+func resolveMainBranch(repoPath, mainBranch string) string {
+	branches := []string{mainBranch}
+	if mainBranch == "" {
+		branches = []string{"main", "master"}
+	}
+	for _, branch := range branches {
+		for _, ref := range []string{branch, "origin/" + branch} {
+			if _, err := runGit(repoPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err == nil {
+				return ref
+			}
+		}
+	}
+	return ""
+}
+
+func runGit(repoPath string, args ...string) (string, error) {
+	stdout, stderr, err := clientutils.NewGitManager(repoPath).ExecGit(append([]string{"-C", repoPath}, args...)...)
+	if err != nil {
+		return "", errorutils.CheckErrorf("'git %s' failed: %s %s", strings.Join(args, " "), err.Error(), stderr)
+	}
+	return stdout, nil
+}
@@ -0,0 +1,111 @@
+package buildinfo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Creates a local git repository with two commits on main, and two more commits on a feature branch.
+// Returns the repository path and the revision of the first commit on the feature branch.
+func createTestGitRepo(t *testing.T) (repoPath, firstFeatureRevision string) {
+	repoPath = t.TempDir()
+	git := func(args ...string) string {
+		output, err := runGit(repoPath, append([]string{"-c", "user.name=Dev", "-c", "user.email=dev@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		require.NoError(t, err)
+		return output
+	}
+	commit := func(fileName, author string) {
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, fileName), []byte(fileName), 0644))
+		git("add", fileName)
+		git("commit", "-m", "Add "+fileName, "--author", author)
+	}
+	git("init", "-b", "main")
+	commit("a.txt", "Alice <alice@example.com>")
+	commit("b.txt", "Bob <bob@example.com>")
+	git("checkout", "-b", "feature")
+	commit("c.txt", "Alice <alice@example.com>")
+	firstFeatureRevision = git("rev-parse", "HEAD")
+	commit("d.txt", "Carol <carol@example.com>")
+	git("tag", "v1.0.0")
+	return
+}
+
+func TestCollectGitDetails(t *testing.T) {
+	repoPath, firstFeatureRevision := createTestGitRepo(t)
+	mainRevision, err := runGit(repoPath, "rev-parse", "main")
+	require.NoError(t, err)
+
+	gitDetails, err := CollectGitDetails(repoPath, "", mainRevision)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0"}, gitDetails.Tags)
+	assert.Equal(t, "main", gitDetails.MainBranch)
+	assert.Equal(t, mainRevision, gitDetails.MergeBase)
+	assert.Equal(t, 2, gitDetails.CommitsCount)
+	if assert.Len(t, gitDetails.Commits, 2) {
+		assert.Equal(t, gitDetails.Revision, gitDetails.Commits[0].Revision)
+		assert.Equal(t, firstFeatureRevision, gitDetails.Commits[1].Revision)
+		assert.False(t, gitDetails.Commits[0].Signed)
+	}
+	assert.Equal(t, []string{"Carol <carol@example.com>", "Alice <alice@example.com>"}, gitDetails.Authors())
+
+	// Unknown main branch and unknown previous revision.
+	gitDetails, err = CollectGitDetails(repoPath, "develop", "0123456789abcdef0123456789abcdef01234567")
+	require.NoError(t, err)
+	assert.Empty(t, gitDetails.MainBranch)
+	assert.Empty(t, gitDetails.MergeBase)
+	assert.Empty(t, gitDetails.PreviousRevision)
+	assert.Empty(t, gitDetails.Commits)
+}
+
+func TestParseCommitsLog(t *testing.T) {
+	commitsLog := "aaa\x1fG\x1fAlice <alice@example.com>\nbbb\x1fN\x1fBob <bob@example.com>\nccc\x1fE\x1fAlice <alice@example.com>\n" +
+		"ddd\x1fB\x1fBob <bob@example.com>\neee\x1fU\x1fBob <bob@example.com>\nfff\x1fR\x1fBob <bob@example.com>\ninvalid"
+	assert.Equal(t, []CommitDetails{
+		{Revision: "aaa", Author: "Alice <alice@example.com>", Signed: true},
+		{Revision: "bbb", Author: "Bob <bob@example.com>", Signed: false},
+		{Revision: "ccc", Author: "Alice <alice@example.com>", Signed: true, UnverifiedSignature: true},
+		{Revision: "ddd", Author: "Bob <bob@example.com>", Signed: true, BadSignature: true},
+		{Revision: "eee", Author: "Bob <bob@example.com>", Signed: true, UnverifiedSignature: true},
+		{Revision: "fff", Author: "Bob <bob@example.com>", Signed: true, UnverifiedSignature: true},
+	}, parseCommitsLog(commitsLog))
+}
+
+func TestGitDetailsToProperties(t *testing.T) {
+	gitDetails := &GitDetails{
+		Tags:             []string{"v1.0.0", "latest"},
+		MainBranch:       "origin/main",
+		MergeBase:        "base",
+		PreviousBuild:    "41",
+		PreviousRevision: "prev",
+		Revision:         "head",
+		Commits: []CommitDetails{
+			{Revision: "head", Author: "Alice <alice@example.com>", Signed: true},
+			{Revision: "mid", Author: "Bob <bob@example.com>"},
+			{Revision: "expired", Author: "Alice <alice@example.com>", Signed: true, UnverifiedSignature: true},
+			{Revision: "first", Author: "Bob <bob@example.com>", Signed: true, BadSignature: true},
+		},
+		// More commits than the collected ones.
+		CommitsCount: 150,
+	}
+	assert.Equal(t, map[string]string{
+		"vcs.git.tags":                       "v1.0.0,latest",
+		"vcs.git.mainBranch":                 "origin/main",
+		"vcs.git.mergeBase":                  "base",
+		"vcs.git.previousBuild":              "41",
+		"vcs.git.commitRange":                "prev..head",
+		"vcs.git.commitsCount":               "150",
+		"vcs.git.contributors":               "Alice <alice@example.com>,Bob <bob@example.com>",
+		"vcs.git.signedCommits":              "head",
+		"vcs.git.unsignedCommits":            "mid",
+		"vcs.git.unverifiedSignatureCommits": "expired",
+		"vcs.git.badSignatureCommits":        "first",
+	}, map[string]string(gitDetails.ToProperties()))
+
+	// Without a previous build, only the HEAD details are recorded.
+	gitDetails.PreviousRevision = ""
+	assert.Len(t, gitDetails.ToProperties(), 3)
+}
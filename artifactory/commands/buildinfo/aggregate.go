@@ -0,0 +1,166 @@
+package buildinfo
+
+import (
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	corebuildinfo "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A published build, referenced by an aggregated build.
+type ChildBuild struct {
+	Name   string
+	Number string
+}
+
+func (cb ChildBuild) String() string {
+	return cb.Name + "/" + cb.Number
+}
+
+// Parses a list of comma-separated builds in the form of "name1/number1,name2/number2,...".
+// Since build names may include slashes, the build number is taken from the last slash.
+func ParseChildBuilds(childBuilds string) (children []ChildBuild, err error) {
+	for _, child := range splitAndTrim(childBuilds, ",") {
+		separatorIndex := strings.LastIndex(child, "/")
+		if separatorIndex <= 0 || separatorIndex == len(child)-1 {
+			return nil, errorutils.CheckErrorf("invalid child build '%s'. Expected the form of 'build-name/build-number'", child)
+		}
+		children = append(children, ChildBuild{Name: child[:separatorIndex], Number: child[separatorIndex+1:]})
+	}
+	if len(children) == 0 {
+		return nil, errorutils.CheckErrorf("at least one child build is expected")
+	}
+	return
+}
+
+// BuildAggregateCommand creates an umbrella build-info, which references a list of published child builds.
+// Each child is referenced the same way the build-append command does. In addition, the modules' artifacts and dependencies
+// of the children can be flattened into the umbrella build-info, to allow acting on the whole product.
+type BuildAggregateCommand struct {
+	buildConfiguration  *build.BuildConfiguration
+	serverDetails       *config.ServerDetails
+	children            []ChildBuild
+	flattenArtifacts    bool
+	flattenDependencies bool
+}
+
+func NewBuildAggregateCommand() *BuildAggregateCommand {
+	return &BuildAggregateCommand{}
+}
+
+func (bag *BuildAggregateCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildAggregateCommand {
+	bag.serverDetails = serverDetails
+	return bag
+}
+
+func (bag *BuildAggregateCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildAggregateCommand {
+	bag.buildConfiguration = buildConfiguration
+	return bag
+}
+
+func (bag *BuildAggregateCommand) SetChildren(children []ChildBuild) *BuildAggregateCommand {
+	bag.children = children
+	return bag
+}
+
+func (bag *BuildAggregateCommand) SetFlattenArtifacts(flattenArtifacts bool) *BuildAggregateCommand {
+	bag.flattenArtifacts = flattenArtifacts
+	return bag
+}
+
+func (bag *BuildAggregateCommand) SetFlattenDependencies(flattenDependencies bool) *BuildAggregateCommand {
+	bag.flattenDependencies = flattenDependencies
+	return bag
+}
+
+func (bag *BuildAggregateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bag.serverDetails, nil
+}
+
+func (bag *BuildAggregateCommand) CommandName() string {
+	return "rt_build_aggregate"
+}
+
+func (bag *BuildAggregateCommand) Run() error {
+	buildName, err := bag.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bag.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(bag.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	for _, child := range bag.children {
+		log.Info("Aggregating build", child.String(), "into", buildName+"/"+buildNumber+"...")
+		appendCmd := corebuildinfo.NewBuildAppendCommand().SetServerDetails(bag.serverDetails).SetBuildConfiguration(bag.buildConfiguration).
+			SetBuildNameToAppend(child.Name).SetBuildNumberToAppend(child.Number)
+		if err = commands.Exec(appendCmd); err != nil {
+			return err
+		}
+		if !bag.flattenArtifacts && !bag.flattenDependencies {
+			continue
+		}
+		if err = bag.flattenChild(servicesManager, buildName, buildNumber, child); err != nil {
+			return err
+		}
+	}
+	log.Info("Successfully aggregated", len(bag.children), "builds into", buildName+"/"+buildNumber)
+	return nil
+}
+
+// Copy the modules of the child build into the aggregated build-info.
+func (bag *BuildAggregateCommand) flattenChild(servicesManager artifactory.ArtifactoryServicesManager, buildName, buildNumber string, child ChildBuild) error {
+	publishedBuild, found, err := GetPublishedBuild(servicesManager, child.Name, child.Number, bag.buildConfiguration.GetProject())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errorutils.CheckErrorf("build %s was not found in Artifactory", child.String())
+	}
+	for _, module := range publishedBuild.BuildInfo.Modules {
+		partial := bag.createFlattenedPartial(child, module)
+		if partial == nil {
+			continue
+		}
+		log.Debug("Flattening module", module.Id, "of build", child.String())
+		populateFunc := func(p *buildinfo.Partial) {
+			p.ModuleType, p.ModuleId, p.Checksum = partial.ModuleType, partial.ModuleId, partial.Checksum
+			p.Artifacts, p.Dependencies = partial.Artifacts, partial.Dependencies
+		}
+		if err = build.SavePartialBuildInfo(buildName, buildNumber, bag.buildConfiguration.GetProject(), populateFunc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the partial build-info holding the flattened content of the child's module, or nil if there's nothing to flatten.
+// Modules referencing other builds are kept as references. The IDs of the flattened modules are prefixed with the child build,
+// since children often have modules with the same ID, which would otherwise be merged into one module of the aggregated build.
+func (bag *BuildAggregateCommand) createFlattenedPartial(child ChildBuild, module buildinfo.Module) *buildinfo.Partial {
+	if module.Type == buildinfo.Build {
+		return &buildinfo.Partial{ModuleType: module.Type, ModuleId: module.Id, Checksum: module.Checksum}
+	}
+	partial := &buildinfo.Partial{ModuleType: module.Type, ModuleId: child.String() + "/" + module.Id}
+	if bag.flattenArtifacts {
+		partial.Artifacts = module.Artifacts
+	}
+	if bag.flattenDependencies {
+		partial.Dependencies = module.Dependencies
+	}
+	if len(partial.Artifacts) == 0 && len(partial.Dependencies) == 0 {
+		return nil
+	}
+	return partial
+}
@@ -0,0 +1,48 @@
+package buildinfo
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChildBuilds(t *testing.T) {
+	children, err := ParseChildBuilds("svcA/12, team/svcB/40,")
+	assert.NoError(t, err)
+	assert.Equal(t, []ChildBuild{{Name: "svcA", Number: "12"}, {Name: "team/svcB", Number: "40"}}, children)
+
+	for _, invalid := range []string{"", " , ", "svcA", "/12", "svcA/"} {
+		_, err = ParseChildBuilds(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCreateFlattenedPartial(t *testing.T) {
+	module := buildinfo.Module{
+		Type:         buildinfo.Npm,
+		Id:           "svcA:1.0.0",
+		Artifacts:    []buildinfo.Artifact{{Name: "svcA-1.0.0.tgz"}},
+		Dependencies: []buildinfo.Dependency{{Id: "lodash:4.17.21"}},
+	}
+
+	child := ChildBuild{Name: "svcA", Number: "12"}
+	partial := NewBuildAggregateCommand().SetFlattenArtifacts(true).createFlattenedPartial(child, module)
+	assert.Equal(t, "svcA/12/svcA:1.0.0", partial.ModuleId)
+	assert.Equal(t, module.Artifacts, partial.Artifacts)
+	assert.Empty(t, partial.Dependencies)
+
+	partial = NewBuildAggregateCommand().SetFlattenDependencies(true).createFlattenedPartial(child, module)
+	assert.Empty(t, partial.Artifacts)
+	assert.Equal(t, module.Dependencies, partial.Dependencies)
+
+	// Nothing to flatten.
+	assert.Nil(t, NewBuildAggregateCommand().SetFlattenDependencies(true).createFlattenedPartial(child, buildinfo.Module{Type: buildinfo.Npm, Id: "empty"}))
+
+	// Modules referencing other builds are kept as references.
+	reference := buildinfo.Module{Type: buildinfo.Build, Id: "svcC/3", Checksum: buildinfo.Checksum{Sha1: "abc"}}
+	partial = NewBuildAggregateCommand().SetFlattenArtifacts(true).createFlattenedPartial(child, reference)
+	assert.Equal(t, reference.Id, partial.ModuleId)
+	assert.Equal(t, reference.Checksum, partial.Checksum)
+	assert.Equal(t, buildinfo.Build, partial.ModuleType)
+}
@@ -0,0 +1,111 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	corebuildinfo "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// BuildCollectEnvCommand collects the environment variables into the build-info.
+// If an environment policy is provided, only the variables allowed by the policy are collected, and values that look like secrets are masked.
+// Otherwise, all the variables are collected using the build-collect-env command of jfrog-cli-core.
+type BuildCollectEnvCommand struct {
+	*corebuildinfo.BuildCollectEnvCommand
+	buildConfiguration *build.BuildConfiguration
+	policyPath         string
+	printReport        bool
+	report             *EnvCaptureReport
+}
+
+func NewBuildCollectEnvCommand() *BuildCollectEnvCommand {
+	return &BuildCollectEnvCommand{BuildCollectEnvCommand: corebuildinfo.NewBuildCollectEnvCommand()}
+}
+
+func (bcec *BuildCollectEnvCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildCollectEnvCommand {
+	bcec.buildConfiguration = buildConfiguration
+	bcec.BuildCollectEnvCommand.SetBuildConfiguration(buildConfiguration)
+	return bcec
+}
+
+func (bcec *BuildCollectEnvCommand) SetPolicyPath(policyPath string) *BuildCollectEnvCommand {
+	bcec.policyPath = policyPath
+	return bcec
+}
+
+// If true, the report of the captured and scrubbed variables is printed to the standard output as JSON.
+func (bcec *BuildCollectEnvCommand) SetPrintReport(printReport bool) *BuildCollectEnvCommand {
+	bcec.printReport = printReport
+	return bcec
+}
+
+func (bcec *BuildCollectEnvCommand) Report() *EnvCaptureReport {
+	return bcec.report
+}
+
+func (bcec *BuildCollectEnvCommand) Run() error {
+	if bcec.policyPath == "" {
+		if bcec.printReport {
+			log.Warn("A capture report is available only when an environment policy is provided.")
+		}
+		return bcec.BuildCollectEnvCommand.Run()
+	}
+	policy, err := LoadEnvPolicy(bcec.policyPath)
+	if err != nil {
+		return err
+	}
+	log.Info("Collecting environment variables using the policy in", bcec.policyPath+"...")
+	buildName, err := bcec.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bcec.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, bcec.buildConfiguration.GetProject()); err != nil {
+		return err
+	}
+	captured, report, err := policy.Apply(readEnv())
+	if err != nil {
+		return err
+	}
+	bcec.report = report
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.Env = make(buildinfo.Env, len(captured))
+		for name, value := range captured {
+			partial.Env[buildinfo.BuildInfoEnvPrefix+name] = value
+		}
+	}
+	if err = build.SavePartialBuildInfo(buildName, buildNumber, bcec.buildConfiguration.GetProject(), populateFunc); err != nil {
+		return err
+	}
+	for _, scrubbed := range report.Scrubbed {
+		log.Warn("The value of", scrubbed.Name, "was masked, since it looks like a secret ("+scrubbed.Reason+").")
+	}
+	log.Info("Collected", len(report.Captured), "environment variables for", buildName+"/"+buildNumber+".", len(report.Excluded), "were excluded and",
+		len(report.Scrubbed), "were masked.")
+	if bcec.printReport {
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+	}
+	return nil
+}
+
+func readEnv() map[string]string {
+	env := make(map[string]string)
+	for _, pair := range os.Environ() {
+		if name, value, found := strings.Cut(pair, "="); found && name != "" {
+			env[name] = value
+		}
+	}
+	return env
+}
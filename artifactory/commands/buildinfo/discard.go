@@ -0,0 +1,362 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/gofrog/stringutils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	artifactoryutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	lifecycleServices "github.com/jfrog/jfrog-client-go/lifecycle/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	buildNameProperty   = "build.name"
+	buildNumberProperty = "build.number"
+)
+
+// Retention rules, which protect builds from being discarded by the max-days and max-builds rules.
+type RetentionRules struct {
+	// Keep builds which were promoted to one of these statuses.
+	KeepStatuses []string
+	// Keep builds which are referenced by one of these release bundles, in the form of "name/version".
+	KeepReleaseBundles []string
+	// Keep the latest N builds of every branch. 0 disables this rule.
+	KeepLatestPerBranch int
+	// The build property holding the branch name. If empty, the branch is taken from the build's VCS details.
+	BranchProperty string
+	// Keep builds with at least one of these properties, in the form of "key=value". The value may include wildcards.
+	KeepProperties []string
+}
+
+func (rr *RetentionRules) IsEmpty() bool {
+	return len(rr.KeepStatuses) == 0 && len(rr.KeepReleaseBundles) == 0 && rr.KeepLatestPerBranch == 0 && len(rr.KeepProperties) == 0
+}
+
+// BuildDiscardCommand discards builds according to the max-days and max-builds parameters,
+// while keeping the builds protected by the retention rules.
+// When no retention rules are set and dry-run is off, discarding is delegated to Artifactory's build retention API.
+type BuildDiscardCommand struct {
+	serverDetails  *config.ServerDetails
+	retentionRules RetentionRules
+	dryRun         bool
+	// The build numbers which were (or would have been, on dry-run) discarded.
+	discardedBuilds []string
+	services.DiscardBuildsParams
+}
+
+func NewBuildDiscardCommand() *BuildDiscardCommand {
+	return &BuildDiscardCommand{}
+}
+
+func (bdc *BuildDiscardCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildDiscardCommand {
+	bdc.serverDetails = serverDetails
+	return bdc
+}
+
+func (bdc *BuildDiscardCommand) SetDiscardBuildsParams(params services.DiscardBuildsParams) *BuildDiscardCommand {
+	bdc.DiscardBuildsParams = params
+	return bdc
+}
+
+func (bdc *BuildDiscardCommand) SetRetentionRules(retentionRules RetentionRules) *BuildDiscardCommand {
+	bdc.retentionRules = retentionRules
+	return bdc
+}
+
+func (bdc *BuildDiscardCommand) SetDryRun(dryRun bool) *BuildDiscardCommand {
+	bdc.dryRun = dryRun
+	return bdc
+}
+
+func (bdc *BuildDiscardCommand) DiscardedBuilds() []string {
+	return bdc.discardedBuilds
+}
+
+func (bdc *BuildDiscardCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bdc.serverDetails, nil
+}
+
+func (bdc *BuildDiscardCommand) CommandName() string {
+	return "rt_build_discard"
+}
+
+func (bdc *BuildDiscardCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(bdc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	if bdc.retentionRules.IsEmpty() && !bdc.dryRun {
+		return servicesManager.DiscardBuilds(bdc.DiscardBuildsParams)
+	}
+	if bdc.Async {
+		log.Warn("The --async option is ignored when retention rules or --dry-run are used.")
+	}
+
+	log.Info("Calculating builds to discard...")
+	runs, err := GetBuildRuns(servicesManager, bdc.BuildName, bdc.ProjectKey)
+	if err != nil {
+		return err
+	}
+	candidates, err := bdc.getDiscardCandidates(runs, time.Now())
+	if err != nil {
+		return err
+	}
+	// The latest builds of a branch can only be determined by inspecting all the builds.
+	inspectedRuns := candidates
+	if bdc.retentionRules.KeepLatestPerBranch > 0 {
+		inspectedRuns = runs
+	}
+	records, err := bdc.getBuildRecords(servicesManager, inspectedRuns)
+	if err != nil {
+		return err
+	}
+	releaseBundleBuilds, err := bdc.getReleaseBundleBuilds()
+	if err != nil {
+		return err
+	}
+	bdc.discardedBuilds = bdc.applyRetentionRules(candidates, records, releaseBundleBuilds)
+
+	if bdc.dryRun {
+		log.Info(fmt.Sprintf("[Dry run] %d builds of '%s' would be discarded:", len(bdc.discardedBuilds), bdc.BuildName))
+		for _, buildNumber := range bdc.discardedBuilds {
+			log.Output(buildNumber)
+		}
+		return nil
+	}
+	if len(bdc.discardedBuilds) == 0 {
+		log.Info("No builds to discard.")
+		return nil
+	}
+	return bdc.deleteBuilds(servicesManager)
+}
+
+// Returns the build runs matching the max-days and max-builds parameters.
+// The runs are expected to be sorted from the newest to the oldest.
+func (bdc *BuildDiscardCommand) getDiscardCandidates(runs []BuildRun, now time.Time) ([]BuildRun, error) {
+	maxBuilds, minimumBuildDate := -1, time.Time{}
+	if bdc.MaxBuilds != "" {
+		var err error
+		if maxBuilds, err = strconv.Atoi(bdc.MaxBuilds); err != nil {
+			return nil, errorutils.CheckErrorf("the --max-builds option should have a numeric value")
+		}
+	}
+	if bdc.MaxDays != "" {
+		maxDays, err := strconv.Atoi(bdc.MaxDays)
+		if err != nil {
+			return nil, errorutils.CheckErrorf("the --max-days option should have a numeric value")
+		}
+		minimumBuildDate = now.Add(-24 * time.Hour * time.Duration(maxDays))
+	}
+	excludedBuilds := splitAndTrim(bdc.ExcludeBuilds, ",")
+
+	var candidates []BuildRun
+	for i, run := range runs {
+		exceedsMaxBuilds := maxBuilds >= 0 && i >= maxBuilds
+		exceedsMaxDays := !minimumBuildDate.IsZero() && run.Started.Before(minimumBuildDate)
+		if !exceedsMaxBuilds && !exceedsMaxDays {
+			continue
+		}
+		if isInSlice(run.Number, excludedBuilds) {
+			log.Debug("Build", run.Number, "is excluded by the --exclude-builds option.")
+			continue
+		}
+		candidates = append(candidates, run)
+	}
+	return candidates, nil
+}
+
+// The details of a build run, required for applying the retention rules.
+type buildRecord struct {
+	BuildRun
+	Statuses   []string
+	Branch     string
+	Properties map[string]string
+}
+
+// Fetch the build-info of each run, only if the retention rules require its content.
+func (bdc *BuildDiscardCommand) getBuildRecords(servicesManager artifactory.ArtifactoryServicesManager, runs []BuildRun) ([]*buildRecord, error) {
+	records := make([]*buildRecord, 0, len(runs))
+	requiresBuildInfo := len(bdc.retentionRules.KeepStatuses) > 0 || bdc.retentionRules.KeepLatestPerBranch > 0 || len(bdc.retentionRules.KeepProperties) > 0
+	for _, run := range runs {
+		record := &buildRecord{BuildRun: run}
+		records = append(records, record)
+		if !requiresBuildInfo {
+			continue
+		}
+		publishedBuild, found, err := GetPublishedBuild(servicesManager, bdc.BuildName, run.Number, bdc.ProjectKey)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			log.Debug("Build", bdc.BuildName+"/"+run.Number, "was not found. Skipping its retention rules.")
+			continue
+		}
+		for _, status := range publishedBuild.BuildInfo.Statuses {
+			record.Statuses = append(record.Statuses, status.Status)
+		}
+		record.Properties = publishedBuild.BuildInfo.Properties
+		record.Branch = getBuildBranch(publishedBuild.BuildInfo.VcsList, record.Properties, bdc.retentionRules.BranchProperty)
+	}
+	return records, nil
+}
+
+// Returns the build numbers of this build which are referenced by the release bundles in the retention rules.
+func (bdc *BuildDiscardCommand) getReleaseBundleBuilds() (map[string]bool, error) {
+	releaseBundleBuilds := make(map[string]bool)
+	if len(bdc.retentionRules.KeepReleaseBundles) == 0 {
+		return releaseBundleBuilds, nil
+	}
+	lcServicesManager, err := utils.CreateLifecycleServiceManager(bdc.serverDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, releaseBundle := range bdc.retentionRules.KeepReleaseBundles {
+		name, version, found := strings.Cut(releaseBundle, "/")
+		if !found || name == "" || version == "" {
+			return nil, errorutils.CheckErrorf("invalid release bundle '%s'. Expected the form of 'name/version'", releaseBundle)
+		}
+		spec, err := lcServicesManager.GetReleaseBundleSpecification(lifecycleServices.ReleaseBundleDetails{ReleaseBundleName: name, ReleaseBundleVersion: version})
+		if err != nil {
+			return nil, err
+		}
+		for _, artifact := range spec.Artifacts {
+			var buildNames, buildNumbers []string
+			for _, property := range artifact.Properties {
+				switch property.Key {
+				case buildNameProperty:
+					buildNames = property.Values
+				case buildNumberProperty:
+					buildNumbers = property.Values
+				}
+			}
+			if !isInSlice(bdc.BuildName, buildNames) {
+				continue
+			}
+			for _, buildNumber := range buildNumbers {
+				releaseBundleBuilds[buildNumber] = true
+			}
+		}
+	}
+	return releaseBundleBuilds, nil
+}
+
+// Returns the numbers of the candidates which aren't protected by any of the retention rules.
+func (bdc *BuildDiscardCommand) applyRetentionRules(candidates []BuildRun, records []*buildRecord, releaseBundleBuilds map[string]bool) (toDiscard []string) {
+	latestPerBranch := bdc.getLatestPerBranch(records)
+	recordsByNumber := make(map[string]*buildRecord, len(records))
+	for _, record := range records {
+		recordsByNumber[record.Number] = record
+	}
+	for _, candidate := range candidates {
+		record, exists := recordsByNumber[candidate.Number]
+		if !exists {
+			record = &buildRecord{BuildRun: candidate}
+		}
+		if reason := bdc.getKeepReason(record, releaseBundleBuilds, latestPerBranch); reason != "" {
+			log.Info(fmt.Sprintf("Keeping build %s/%s: %s.", bdc.BuildName, record.Number, reason))
+			continue
+		}
+		toDiscard = append(toDiscard, record.Number)
+	}
+	return
+}
+
+func (bdc *BuildDiscardCommand) getKeepReason(record *buildRecord, releaseBundleBuilds map[string]bool, latestPerBranch map[string]bool) string {
+	for _, status := range record.Statuses {
+		for _, keepStatus := range bdc.retentionRules.KeepStatuses {
+			if strings.EqualFold(status, keepStatus) {
+				return fmt.Sprintf("promoted to status '%s'", status)
+			}
+		}
+	}
+	if releaseBundleBuilds[record.Number] {
+		return "referenced by a release bundle"
+	}
+	if latestPerBranch[record.Number] {
+		return fmt.Sprintf("one of the latest %d builds of branch '%s'", bdc.retentionRules.KeepLatestPerBranch, record.Branch)
+	}
+	for _, keepProperty := range bdc.retentionRules.KeepProperties {
+		key, valuePattern, _ := strings.Cut(keepProperty, "=")
+		value, exists := record.Properties[key]
+		if !exists {
+			continue
+		}
+		if valuePattern == "" {
+			return fmt.Sprintf("tagged with the property '%s'", key)
+		}
+		if match, err := stringutils.MatchWildcardPattern(valuePattern, value); err == nil && match {
+			return fmt.Sprintf("tagged with the property '%s=%s'", key, value)
+		}
+	}
+	return ""
+}
+
+// Returns the build numbers which are among the latest N builds of their branch.
+// Builds without a branch are not protected by this rule.
+func (bdc *BuildDiscardCommand) getLatestPerBranch(records []*buildRecord) map[string]bool {
+	latest := make(map[string]bool)
+	if bdc.retentionRules.KeepLatestPerBranch <= 0 {
+		return latest
+	}
+	byBranch := make(map[string][]*buildRecord)
+	for _, record := range records {
+		if record.Branch != "" {
+			byBranch[record.Branch] = append(byBranch[record.Branch], record)
+		}
+	}
+	for _, branchRecords := range byBranch {
+		sort.SliceStable(branchRecords, func(i, j int) bool {
+			return branchRecords[i].Started.After(branchRecords[j].Started)
+		})
+		for i := 0; i < len(branchRecords) && i < bdc.retentionRules.KeepLatestPerBranch; i++ {
+			latest[branchRecords[i].Number] = true
+		}
+	}
+	return latest
+}
+
+type deleteBuildsBody struct {
+	BuildName       string   `json:"buildName"`
+	BuildNumbers    []string `json:"buildNumbers"`
+	Project         string   `json:"project,omitempty"`
+	DeleteArtifacts bool     `json:"deleteArtifacts"`
+	DeleteAll       bool     `json:"deleteAll"`
+}
+
+func (bdc *BuildDiscardCommand) deleteBuilds(servicesManager artifactory.ArtifactoryServicesManager) error {
+	log.Info(fmt.Sprintf("Discarding %d builds of '%s'...", len(bdc.discardedBuilds), bdc.BuildName))
+	content, err := json.Marshal(deleteBuildsBody{
+		BuildName:       bdc.BuildName,
+		BuildNumbers:    bdc.discardedBuilds,
+		Project:         bdc.ProjectKey,
+		DeleteArtifacts: bdc.DeleteArtifacts,
+	})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	artifactoryutils.SetContentType("application/json", &httpClientDetails.Headers)
+	resp, body, err := servicesManager.Client().SendPost(serviceDetails.GetUrl()+"api/build/delete", content, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent); err != nil {
+		return err
+	}
+	log.Debug("Artifactory response:", resp.Status, clientutils.IndentJson(body))
+	log.Info("Builds discarded.")
+	return nil
+}
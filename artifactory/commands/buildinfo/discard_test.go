@@ -0,0 +1,97 @@
+package buildinfo
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2024, 5, 20, 12, 0, 0, 0, time.UTC)
+
+// Returns build runs 10 to 1, sorted from the newest to the oldest. Build N started 11-N days before testNow.
+func createTestBuildRuns() (runs []BuildRun) {
+	for i := 10; i >= 1; i-- {
+		runs = append(runs, BuildRun{Number: strconv.Itoa(i), Started: testNow.Add(-24 * time.Hour * time.Duration(11-i))})
+	}
+	return
+}
+
+func TestGetDiscardCandidates(t *testing.T) {
+	testCases := []struct {
+		name     string
+		params   services.DiscardBuildsParams
+		expected []string
+	}{
+		{"noRules", services.DiscardBuildsParams{}, nil},
+		{"maxBuilds", services.DiscardBuildsParams{MaxBuilds: "7"}, []string{"3", "2", "1"}},
+		{"maxDays", services.DiscardBuildsParams{MaxDays: "8"}, []string{"2", "1"}},
+		{"maxBuildsAndMaxDays", services.DiscardBuildsParams{MaxBuilds: "9", MaxDays: "8"}, []string{"2", "1"}},
+		{"excludeBuilds", services.DiscardBuildsParams{MaxBuilds: "7", ExcludeBuilds: "2, 3"}, []string{"1"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			command := NewBuildDiscardCommand().SetDiscardBuildsParams(testCase.params)
+			candidates, err := command.getDiscardCandidates(createTestBuildRuns(), testNow)
+			assert.NoError(t, err)
+			var actual []string
+			for _, candidate := range candidates {
+				actual = append(actual, candidate.Number)
+			}
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+}
+
+func TestGetDiscardCandidatesInvalidValues(t *testing.T) {
+	_, err := NewBuildDiscardCommand().SetDiscardBuildsParams(services.DiscardBuildsParams{MaxBuilds: "a"}).getDiscardCandidates(createTestBuildRuns(), testNow)
+	assert.Error(t, err)
+	_, err = NewBuildDiscardCommand().SetDiscardBuildsParams(services.DiscardBuildsParams{MaxDays: "a"}).getDiscardCandidates(createTestBuildRuns(), testNow)
+	assert.Error(t, err)
+}
+
+func TestApplyRetentionRules(t *testing.T) {
+	runs := createTestBuildRuns()
+	records := []*buildRecord{
+		{BuildRun: runs[0], Branch: "main"},
+		{BuildRun: runs[1], Branch: "release"},
+		{BuildRun: runs[2], Branch: "main"},
+		{BuildRun: runs[3], Branch: "main", Statuses: []string{"Released"}},
+		{BuildRun: runs[4], Branch: "feature"},
+		{BuildRun: runs[5], Branch: "release"},
+		{BuildRun: runs[6], Properties: map[string]string{"keep": "forever"}},
+		{BuildRun: runs[7], Branch: "feature", Properties: map[string]string{"audit": "2024"}},
+		{BuildRun: runs[8], Branch: "feature"},
+		{BuildRun: runs[9]},
+	}
+	// All builds but the newest one are candidates.
+	candidates := runs[1:]
+
+	testCases := []struct {
+		name                string
+		rules               RetentionRules
+		releaseBundleBuilds map[string]bool
+		expected            []string
+	}{
+		{"noRules", RetentionRules{}, nil, []string{"9", "8", "7", "6", "5", "4", "3", "2", "1"}},
+		{"keepStatuses", RetentionRules{KeepStatuses: []string{"released"}}, nil, []string{"9", "8", "6", "5", "4", "3", "2", "1"}},
+		{"keepReleaseBundles", RetentionRules{}, map[string]bool{"2": true, "1": true}, []string{"9", "8", "7", "6", "5", "4", "3"}},
+		{"keepLatestPerBranch", RetentionRules{KeepLatestPerBranch: 1}, nil, []string{"8", "7", "5", "4", "3", "2", "1"}},
+		{"keepLatestTwoPerBranch", RetentionRules{KeepLatestPerBranch: 2}, nil, []string{"7", "4", "2", "1"}},
+		{"keepProperties", RetentionRules{KeepProperties: []string{"keep=for*", "audit"}}, nil, []string{"9", "8", "7", "6", "5", "2", "1"}},
+		{"keepPropertiesNoMatch", RetentionRules{KeepProperties: []string{"keep=never"}}, nil, []string{"9", "8", "7", "6", "5", "4", "3", "2", "1"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			command := NewBuildDiscardCommand().SetRetentionRules(testCase.rules)
+			assert.Equal(t, testCase.expected, command.applyRetentionRules(candidates, records, testCase.releaseBundleBuilds))
+		})
+	}
+}
+
+func TestGetBuildBranch(t *testing.T) {
+	assert.Equal(t, "main", getBuildBranch(nil, map[string]string{"branch": "main"}, "branch"))
+	assert.Equal(t, "", getBuildBranch(nil, map[string]string{}, "branch"))
+}
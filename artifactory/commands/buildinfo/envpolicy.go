@@ -0,0 +1,243 @@
+package buildinfo
+
+import (
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/gofrog/stringutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultMaskValue        = "******"
+	defaultEntropyThreshold = 3.5
+	// Hex words draw from 16 characters rather than 64, so their entropy is lower.
+	defaultHexEntropyThreshold = 3.0
+	defaultEntropyMinLength    = 20
+)
+
+// Reasons for scrubbing an environment variable value.
+const (
+	ScrubReasonHighEntropy = "high-entropy"
+	ScrubReasonJwt         = "jwt"
+	ScrubReasonAwsKey      = "aws-access-key"
+	ScrubReasonJfrogToken  = "jfrog-token"
+)
+
+var (
+	jwtRegExp    = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	awsKeyRegExp = regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA|A3T[A-Z0-9])[A-Z0-9]{16}\b`)
+	// JFrog reference tokens start with the base64 encoding of 'reftkn', and API keys start with 'AKCp'.
+	jfrogTokenRegExp = regexp.MustCompile(`\b(?:cmVmdGtu[A-Za-z0-9+/=]{20,}|AKCp[A-Za-z0-9]{60,})`)
+	// Values are split into words before measuring their entropy, to avoid flagging lists such as PATH.
+	valueWordsRegExp = regexp.MustCompile(`[\s:;,]+`)
+	hexWordRegExp    = regexp.MustCompile(`^[0-9A-Fa-f]+$`)
+	// Tokens consist of base64 (including its URL-safe alphabet) characters.
+	tokenWordRegExp = regexp.MustCompile(`^[A-Za-z0-9+/=_-]+$`)
+)
+
+// EnvPolicy determines which environment variables are captured into the build-info by the build-collect-env command,
+// and which captured values are scrubbed since they look like secrets.
+//
+// Example:
+//
+//	include: ["*"]
+//	exclude: ["*password*", "*secret*"]
+//	secretDetection:
+//	  allow: ["GIT_COMMIT"]
+//	  entropyThreshold: 3.8
+type EnvPolicy struct {
+	// Wildcard patterns of variable names to capture. If empty, all variables are captured.
+	Include []string `yaml:"include,omitempty"`
+	// Wildcard patterns of variable names to skip.
+	Exclude []string `yaml:"exclude,omitempty"`
+	// The value replacing scrubbed values. Defaults to "******".
+	Mask            string                `yaml:"mask,omitempty"`
+	SecretDetection SecretDetectionPolicy `yaml:"secretDetection,omitempty"`
+}
+
+type SecretDetectionPolicy struct {
+	// Value-based detection is enabled unless explicitly disabled.
+	Disabled bool `yaml:"disabled,omitempty"`
+	// Wildcard patterns of variable names whose values are never scrubbed.
+	Allow []string `yaml:"allow,omitempty"`
+	// Base64 and alphanumeric words with at least this Shannon entropy (bits per character) are considered secrets. Defaults to 3.5.
+	EntropyThreshold float64 `yaml:"entropyThreshold,omitempty"`
+	// Hex words with at least this Shannon entropy are considered secrets. Defaults to 3.0.
+	HexEntropyThreshold float64 `yaml:"hexEntropyThreshold,omitempty"`
+	// Words shorter than this length are not checked for entropy. Defaults to 20.
+	EntropyMinLength int `yaml:"entropyMinLength,omitempty"`
+}
+
+// Lists which environment variables were captured and which were scrubbed. Values are never included.
+type EnvCaptureReport struct {
+	Captured []string      `json:"captured"`
+	Excluded []string      `json:"excluded"`
+	Scrubbed []ScrubbedEnv `json:"scrubbed"`
+}
+
+type ScrubbedEnv struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+func LoadEnvPolicy(policyPath string) (*EnvPolicy, error) {
+	content, err := os.ReadFile(policyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	policy := new(EnvPolicy)
+	if err = yaml.UnmarshalStrict(content, policy); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the environment policy file %s: %s", policyPath, err.Error())
+	}
+	policy.setDefaults()
+	return policy, policy.validate()
+}
+
+func (ep *EnvPolicy) setDefaults() {
+	if len(ep.Include) == 0 {
+		ep.Include = []string{"*"}
+	}
+	if ep.Mask == "" {
+		ep.Mask = defaultMaskValue
+	}
+	if ep.SecretDetection.EntropyThreshold == 0 {
+		ep.SecretDetection.EntropyThreshold = defaultEntropyThreshold
+	}
+	if ep.SecretDetection.HexEntropyThreshold == 0 {
+		ep.SecretDetection.HexEntropyThreshold = defaultHexEntropyThreshold
+	}
+	if ep.SecretDetection.EntropyMinLength == 0 {
+		ep.SecretDetection.EntropyMinLength = defaultEntropyMinLength
+	}
+}
+
+func (ep *EnvPolicy) validate() error {
+	for _, patterns := range [][]string{ep.Include, ep.Exclude, ep.SecretDetection.Allow} {
+		if _, err := matchAnyWildcardPattern(patterns, ""); err != nil {
+			return err
+		}
+	}
+	if ep.SecretDetection.EntropyThreshold < 0 || ep.SecretDetection.HexEntropyThreshold < 0 || ep.SecretDetection.EntropyMinLength < 0 {
+		return errorutils.CheckErrorf("the secret detection entropy thresholds and minimum length must not be negative")
+	}
+	return nil
+}
+
+// Applies the policy on the provided environment variables, and returns the variables to capture with a report.
+func (ep *EnvPolicy) Apply(env map[string]string) (captured map[string]string, report *EnvCaptureReport, err error) {
+	captured = make(map[string]string)
+	report = &EnvCaptureReport{Captured: []string{}, Excluded: []string{}, Scrubbed: []ScrubbedEnv{}}
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		include, err := ep.shouldCapture(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !include {
+			report.Excluded = append(report.Excluded, name)
+			continue
+		}
+		value := env[name]
+		reason, err := ep.detectSecret(name, value)
+		if err != nil {
+			return nil, nil, err
+		}
+		if reason != "" {
+			value = ep.Mask
+			report.Scrubbed = append(report.Scrubbed, ScrubbedEnv{Name: name, Reason: reason})
+		}
+		captured[name] = value
+		report.Captured = append(report.Captured, name)
+	}
+	return captured, report, nil
+}
+
+func (ep *EnvPolicy) shouldCapture(name string) (bool, error) {
+	included, err := matchAnyWildcardPattern(ep.Include, name)
+	if err != nil || !included {
+		return false, err
+	}
+	excluded, err := matchAnyWildcardPattern(ep.Exclude, name)
+	return !excluded, err
+}
+
+// Returns the reason for considering the value a secret, or an empty string if it doesn't look like one.
+func (ep *EnvPolicy) detectSecret(name, value string) (string, error) {
+	if ep.SecretDetection.Disabled || value == "" {
+		return "", nil
+	}
+	if allowed, err := matchAnyWildcardPattern(ep.SecretDetection.Allow, name); err != nil || allowed {
+		return "", err
+	}
+	switch {
+	case jwtRegExp.MatchString(value):
+		return ScrubReasonJwt, nil
+	case jfrogTokenRegExp.MatchString(value):
+		return ScrubReasonJfrogToken, nil
+	case awsKeyRegExp.MatchString(value):
+		return ScrubReasonAwsKey, nil
+	}
+	for _, word := range valueWordsRegExp.Split(value, -1) {
+		if len(word) < ep.SecretDetection.EntropyMinLength {
+			continue
+		}
+		if threshold, isToken := ep.entropyThreshold(word); isToken && shannonEntropy(word) >= threshold {
+			return ScrubReasonHighEntropy, nil
+		}
+	}
+	return "", nil
+}
+
+// Returns the entropy threshold by the character set of the word. Words with characters outside the base64 alphabet, such as URLs and file names, aren't tokens.
+func (ep *EnvPolicy) entropyThreshold(word string) (threshold float64, isToken bool) {
+	switch {
+	case hexWordRegExp.MatchString(word):
+		return ep.SecretDetection.HexEntropyThreshold, true
+	case tokenWordRegExp.MatchString(word):
+		return ep.SecretDetection.EntropyThreshold, true
+	default:
+		return 0, false
+	}
+}
+
+// Returns the Shannon entropy of the string, in bits per character.
+func shannonEntropy(value string) float64 {
+	if value == "" {
+		return 0
+	}
+	frequencies := make(map[rune]int)
+	length := 0
+	for _, char := range value {
+		frequencies[char]++
+		length++
+	}
+	entropy := 0.0
+	for _, count := range frequencies {
+		probability := float64(count) / float64(length)
+		entropy -= probability * math.Log2(probability)
+	}
+	return entropy
+}
+
+// Environment variable names are matched case-insensitively, the same way build-publish matches its env-include and env-exclude patterns.
+func matchAnyWildcardPattern(patterns []string, name string) (bool, error) {
+	for _, pattern := range patterns {
+		match, err := stringutils.MatchWildcardPattern(strings.ToLower(pattern), strings.ToLower(name))
+		if err != nil {
+			return false, err
+		}
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
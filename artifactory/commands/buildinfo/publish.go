@@ -0,0 +1,200 @@
+package buildinfo
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/json"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	corebuildinfo "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/signing"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	biconf "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// BuildPublishCommand publishes the build-info using the build-publish command of jfrog-cli-core.
+// If a signing key is provided, a detached signature over the canonicalized build-info is attached to the build-info file.
+// The locally generated build-info is signed, after verifying that the published build-info is identical to it.
+type BuildPublishCommand struct {
+	*corebuildinfo.BuildPublishCommand
+	buildConfiguration *build.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	config             *biconf.Configuration
+	signKeyPath        string
+}
+
+func NewBuildPublishCommand() *BuildPublishCommand {
+	return &BuildPublishCommand{BuildPublishCommand: corebuildinfo.NewBuildPublishCommand()}
+}
+
+func (bpc *BuildPublishCommand) SetConfig(config *biconf.Configuration) *BuildPublishCommand {
+	bpc.config = config
+	bpc.BuildPublishCommand.SetConfig(config)
+	return bpc
+}
+
+func (bpc *BuildPublishCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildPublishCommand {
+	bpc.serverDetails = serverDetails
+	bpc.BuildPublishCommand.SetServerDetails(serverDetails)
+	return bpc
+}
+
+func (bpc *BuildPublishCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildPublishCommand {
+	bpc.buildConfiguration = buildConfiguration
+	bpc.BuildPublishCommand.SetBuildConfiguration(buildConfiguration)
+	return bpc
+}
+
+func (bpc *BuildPublishCommand) SetDetailedSummary(detailedSummary bool) *BuildPublishCommand {
+	bpc.BuildPublishCommand.SetDetailedSummary(detailedSummary)
+	return bpc
+}
+
+// Path to a PEM encoded private key, used for signing the published build-info.
+func (bpc *BuildPublishCommand) SetSignKeyPath(signKeyPath string) *BuildPublishCommand {
+	bpc.signKeyPath = signKeyPath
+	return bpc
+}
+
+func (bpc *BuildPublishCommand) Run() error {
+	var signer crypto.Signer
+	var generatedBuildInfo *buildinfo.BuildInfo
+	if bpc.signKeyPath != "" {
+		// Load the key before publishing, to avoid publishing an unsigned build-info due to an invalid key.
+		var err error
+		if signer, err = signing.LoadSigningKey(bpc.signKeyPath); err != nil {
+			return err
+		}
+		// The build-info is generated before publishing, since the publish command removes the local build-info.
+		if generatedBuildInfo, err = bpc.generateBuildInfo(); err != nil {
+			return err
+		}
+	}
+	if err := bpc.BuildPublishCommand.Run(); err != nil {
+		return err
+	}
+	if signer == nil {
+		return nil
+	}
+	if bpc.config != nil && bpc.config.DryRun {
+		log.Info("[Dry run] The build-info will be signed after it is published.")
+		return nil
+	}
+	// The build number is read after publishing, since it may be set by the publish command.
+	buildNumber, err := bpc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	generatedBuildInfo.Number = buildNumber
+	servicesManager, err := utils.CreateServiceManager(bpc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	return SignPublishedBuild(servicesManager, signer, generatedBuildInfo, bpc.buildConfiguration.GetProject())
+}
+
+// Generates the build-info from the local build-info files, in the same way the publish command of jfrog-cli-core does.
+func (bpc *BuildPublishCommand) generateBuildInfo() (*buildinfo.BuildInfo, error) {
+	buildName, err := bpc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return nil, err
+	}
+	buildNumber, err := bpc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return nil, err
+	}
+	localBuild, err := build.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, bpc.buildConfiguration.GetProject())
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	localBuild.SetAgentName(coreutils.GetCliUserAgentName())
+	localBuild.SetAgentVersion(coreutils.GetCliUserAgentVersion())
+	localBuild.SetBuildAgentVersion(coreutils.GetClientAgentVersion())
+	localBuild.SetPrincipal(bpc.serverDetails.User)
+	if bpc.config != nil {
+		localBuild.SetBuildUrl(bpc.config.BuildUrl)
+	}
+	generatedBuildInfo, err := localBuild.ToBuildInfo()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if bpc.config != nil {
+		if err = generatedBuildInfo.IncludeEnv(strings.Split(bpc.config.EnvInclude, ";")...); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if err = generatedBuildInfo.ExcludeEnv(strings.Split(bpc.config.EnvExclude, ";")...); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	return generatedBuildInfo, nil
+}
+
+// Signs the generated build-info, and stores the signature as properties of the published build-info file.
+// The build-info isn't signed if the published build-info differs from it, since it may have been modified after it was published.
+func SignPublishedBuild(servicesManager artifactory.ArtifactoryServicesManager, signer crypto.Signer, generatedBuildInfo *buildinfo.BuildInfo, projectKey string) error {
+	buildName, buildNumber := generatedBuildInfo.Name, generatedBuildInfo.Number
+	publishedBuildJson, buildStarted, err := getPublishedBuildForSignature(servicesManager, buildName, buildNumber, projectKey)
+	if err != nil {
+		return err
+	}
+	signature, algorithm, err := signGeneratedBuildInfo(signer, generatedBuildInfo, publishedBuildJson)
+	if err != nil {
+		return err
+	}
+	buildInfoFilePath, err := getBuildInfoFilePath(servicesManager, buildName, buildNumber, buildStarted, projectKey)
+	if err != nil {
+		return err
+	}
+	properties := map[string]string{BuildSignatureProperty: encodeSignature(signature), BuildSignatureAlgorithmProperty: algorithm}
+	if err = setFileProperties(servicesManager, buildInfoFilePath, properties); err != nil {
+		return err
+	}
+	log.Info("Build-info of", buildName+"/"+buildNumber, "successfully signed.")
+	return nil
+}
+
+// Signs the canonicalized generated build-info, after verifying that the canonicalized published build-info is identical to it.
+func signGeneratedBuildInfo(signer crypto.Signer, generatedBuildInfo *buildinfo.BuildInfo, publishedBuildJson []byte) (signature []byte, algorithm string, err error) {
+	generatedBuildJson, err := json.Marshal(struct {
+		BuildInfo *buildinfo.BuildInfo `json:"buildInfo"`
+	}{generatedBuildInfo})
+	if err != nil {
+		return nil, "", errorutils.CheckError(err)
+	}
+	canonicalBuildInfo, err := CanonicalizeBuildInfo(generatedBuildJson)
+	if err != nil {
+		return nil, "", err
+	}
+	canonicalPublishedBuildInfo, err := CanonicalizeBuildInfo(publishedBuildJson)
+	if err != nil {
+		return nil, "", err
+	}
+	if !bytes.Equal(canonicalBuildInfo, canonicalPublishedBuildInfo) {
+		return nil, "", errorutils.CheckErrorf("the build-info of %s/%s in Artifactory differs from the published build-info, so it isn't signed. "+
+			"It may have been modified after it was published", generatedBuildInfo.Name, generatedBuildInfo.Number)
+	}
+	return SignBuildInfo(signer, canonicalBuildInfo)
+}
+
+// Returns the published build-info JSON and its start time.
+func getPublishedBuildForSignature(servicesManager artifactory.ArtifactoryServicesManager, buildName, buildNumber, projectKey string) (publishedBuildJson []byte, buildStarted string, err error) {
+	publishedBuildJson, found, err := GetPublishedBuildJson(servicesManager, buildName, buildNumber, projectKey)
+	if err != nil {
+		return
+	}
+	if !found {
+		return nil, "", errorutils.CheckErrorf("build %s/%s was not found in Artifactory", buildName, buildNumber)
+	}
+	publishedBuild := new(PublishedBuild)
+	if err = json.Unmarshal(publishedBuildJson, publishedBuild); err != nil {
+		return nil, "", errorutils.CheckError(err)
+	}
+	return publishedBuildJson, publishedBuild.BuildInfo.Started, nil
+}
@@ -0,0 +1,41 @@
+package buildinfo
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignGeneratedBuildInfo(t *testing.T) {
+	signer, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	generatedBuildInfo := &buildinfo.BuildInfo{Name: "my-build", Number: "1", Started: "2024-05-20T12:00:00.000+0000",
+		Modules: []buildinfo.Module{{Id: "module", Artifacts: []buildinfo.Artifact{{Name: "a.jar", Checksum: buildinfo.Checksum{Sha1: "abc"}}}}}}
+	publishedBuild := func(buildInfo *buildinfo.BuildInfo) []byte {
+		publishedBuild := new(PublishedBuild)
+		publishedBuild.Uri = "http://localhost:8081/artifactory/api/build/my-build/1"
+		publishedBuild.BuildInfo.BuildInfo = *buildInfo
+		publishedBuild.BuildInfo.Statuses = []PromotionStatus{{Status: "Released", Repository: "release-local"}}
+		content, err := json.Marshal(publishedBuild)
+		require.NoError(t, err)
+		return content
+	}
+
+	// The published build-info may differ only by its mutable fields.
+	signature, algorithm, err := signGeneratedBuildInfo(signer, generatedBuildInfo, publishedBuild(generatedBuildInfo))
+	require.NoError(t, err)
+	canonical, err := CanonicalizeBuildInfo(publishedBuild(generatedBuildInfo))
+	require.NoError(t, err)
+	assert.NoError(t, VerifyBuildInfoSignature(signer.Public(), canonical, signature, algorithm))
+
+	modifiedBuildInfo := *generatedBuildInfo
+	modifiedBuildInfo.Modules = []buildinfo.Module{{Id: "module", Artifacts: []buildinfo.Artifact{{Name: "b.jar", Checksum: buildinfo.Checksum{Sha1: "def"}}}}}
+	_, _, err = signGeneratedBuildInfo(signer, generatedBuildInfo, publishedBuild(&modifiedBuildInfo))
+	assert.ErrorContains(t, err, "differs from the published build-info")
+}
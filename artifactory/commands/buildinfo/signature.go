@@ -0,0 +1,187 @@
+package buildinfo
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli/utils/signing"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The signature is stored as properties of the build-info JSON file, in the build-info repository.
+	BuildSignatureProperty          = "build.signature"
+	BuildSignatureAlgorithmProperty = "build.signature.algorithm"
+
+	rsaSignatureAlgorithm     = "RSA-SHA256"
+	ecdsaSignatureAlgorithm   = "ECDSA-SHA256"
+	ed25519SignatureAlgorithm = "ED25519"
+)
+
+// Fields which Artifactory may change after the build-info is published, and are therefore excluded from the signed content.
+var mutableBuildInfoFields = []string{"statuses"}
+
+// Returns a canonical form of a published build-info, as returned by Artifactory's build-info API.
+// The canonical form is the JSON of the build-info, excluding its mutable fields, with the object keys sorted and no insignificant whitespace.
+func CanonicalizeBuildInfo(publishedBuildJson []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(publishedBuildJson))
+	// Keep numbers as they are, to avoid precision loss.
+	decoder.UseNumber()
+	var publishedBuild struct {
+		BuildInfo map[string]interface{} `json:"buildInfo"`
+	}
+	if err := decoder.Decode(&publishedBuild); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if publishedBuild.BuildInfo == nil {
+		return nil, errorutils.CheckErrorf("the build-info content is missing")
+	}
+	for _, field := range mutableBuildInfoFields {
+		delete(publishedBuild.BuildInfo, field)
+	}
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	// Maps are encoded with sorted keys.
+	if err := encoder.Encode(publishedBuild.BuildInfo); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// Signs the canonicalized build-info, and returns the signature with the name of the signature algorithm.
+func SignBuildInfo(signer crypto.Signer, canonicalBuildInfo []byte) (signature []byte, algorithm string, err error) {
+	if algorithm, err = getSignatureAlgorithm(signer.Public()); err != nil {
+		return nil, "", err
+	}
+	signature, err = signing.Sign(signer, canonicalBuildInfo)
+	return signature, algorithm, err
+}
+
+// Verifies the signature of the canonicalized build-info.
+func VerifyBuildInfoSignature(publicKey crypto.PublicKey, canonicalBuildInfo, signature []byte, algorithm string) error {
+	expectedAlgorithm, err := getSignatureAlgorithm(publicKey)
+	if err != nil {
+		return err
+	}
+	if algorithm != expectedAlgorithm || !signing.Verify(publicKey, canonicalBuildInfo, signature) {
+		return errorutils.CheckErrorf("the build-info signature is invalid. The build-info may have been modified after it was published, or signed by a different key")
+	}
+	return nil
+}
+
+func getSignatureAlgorithm(publicKey crypto.PublicKey) (string, error) {
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		return rsaSignatureAlgorithm, nil
+	case *ecdsa.PublicKey:
+		return ecdsaSignatureAlgorithm, nil
+	case ed25519.PublicKey:
+		return ed25519SignatureAlgorithm, nil
+	default:
+		return "", errorutils.CheckErrorf("unsupported key type %T", publicKey)
+	}
+}
+
+// Encodes the signature to a value which can be stored as an Artifactory property, without escaping.
+func encodeSignature(signature []byte) string {
+	return base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func decodeSignature(encodedSignature string) ([]byte, error) {
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	return signature, errorutils.CheckError(err)
+}
+
+// Returns the path of the build-info JSON file in the build-info repository.
+func getBuildInfoFilePath(servicesManager artifactory.ArtifactoryServicesManager, buildName, buildNumber, buildStarted, projectKey string) (filePath string, err error) {
+	buildTime, err := time.Parse(buildinfo.TimeFormat, buildStarted)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	aqlQuery := servicesutils.CreateAqlQueryForBuildInfoJson(projectKey, buildName, buildNumber, strconv.FormatInt(buildTime.UnixMilli(), 10))
+	stream, err := servicesManager.Aql(aqlQuery)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(stream.Close()))
+	}()
+	aqlResults, err := io.ReadAll(stream)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	parsedResult := new(servicesutils.AqlSearchResult)
+	if err = json.Unmarshal(aqlResults, parsedResult); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if len(parsedResult.Results) == 0 {
+		return "", errorutils.CheckErrorf("the build-info file of build '%s/%s' could not be found", buildName, buildNumber)
+	}
+	result := parsedResult.Results[0]
+	return path.Join(result.Repo, result.Path, result.Name), nil
+}
+
+func setFileProperties(servicesManager artifactory.ArtifactoryServicesManager, filePath string, properties map[string]string) error {
+	var props []string
+	for key, value := range properties {
+		props = append(props, key+"="+value)
+	}
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	requestFullUrl, err := clientutils.BuildUrl(serviceDetails.GetUrl(), path.Join("api/storage", filePath), map[string]string{"properties": strings.Join(props, ";")})
+	if err != nil {
+		return err
+	}
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	log.Debug("Sending PUT request to:", requestFullUrl)
+	resp, body, err := servicesManager.Client().SendPut(requestFullUrl, nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusNoContent, http.StatusOK)
+}
+
+func getFileProperties(servicesManager artifactory.ArtifactoryServicesManager, filePath string) (map[string][]string, error) {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	requestFullUrl, err := clientutils.BuildUrl(serviceDetails.GetUrl(), path.Join("api/storage", filePath), map[string]string{"properties": ""})
+	if err != nil {
+		return nil, err
+	}
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	log.Debug("Sending GET request to:", requestFullUrl)
+	resp, body, _, err := servicesManager.Client().SendGet(requestFullUrl, true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	// Artifactory returns 404 if the file has no properties.
+	if resp.StatusCode == http.StatusNotFound {
+		return map[string][]string{}, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	response := struct {
+		Properties map[string][]string `json:"properties,omitempty"`
+	}{}
+	if err = json.Unmarshal(body, &response); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return response.Properties, nil
+}
@@ -0,0 +1,70 @@
+package buildinfo
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPublishedBuild = `{
+  "uri": "http://localhost:8081/artifactory/api/build/my-build/1",
+  "buildInfo": {
+    "version": "1.0.1",
+    "name": "my-build",
+    "number": "1",
+    "started": "2024-05-20T12:00:00.000+0000",
+    "durationMillis": 12345678901234567,
+    "modules": [{"id": "module", "artifacts": [{"name": "a<b>.jar", "sha1": "abc"}]}],
+    "statuses": [{"status": "Released", "repository": "release-local"}]
+  }
+}`
+
+func TestCanonicalizeBuildInfo(t *testing.T) {
+	canonical, err := CanonicalizeBuildInfo([]byte(testPublishedBuild))
+	require.NoError(t, err)
+	assert.Equal(t, `{"durationMillis":12345678901234567,"modules":[{"artifacts":[{"name":"a<b>.jar","sha1":"abc"}],"id":"module"}],`+
+		`"name":"my-build","number":"1","started":"2024-05-20T12:00:00.000+0000","version":"1.0.1"}`, string(canonical))
+
+	_, err = CanonicalizeBuildInfo([]byte(`{"uri": "http://localhost:8081/artifactory/api/build/my-build/1"}`))
+	assert.Error(t, err)
+}
+
+func TestSignAndVerifyBuildInfo(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	canonical, err := CanonicalizeBuildInfo([]byte(testPublishedBuild))
+	require.NoError(t, err)
+	tampered, err := CanonicalizeBuildInfo([]byte(`{"buildInfo": {"name": "my-build", "number": "2"}}`))
+	require.NoError(t, err)
+
+	for name, key := range map[string]crypto.Signer{"rsa": rsaKey, "ecdsa": ecdsaKey, "ed25519": ed25519Key} {
+		t.Run(name, func(t *testing.T) {
+			privateKeyPath, publicKeyPath := signing.WriteTestKeys(t, key)
+			signer, err := signing.LoadSigningKey(privateKeyPath)
+			require.NoError(t, err)
+			publicKey, err := signing.LoadVerificationKey(publicKeyPath)
+			require.NoError(t, err)
+
+			signature, algorithm, err := SignBuildInfo(signer, canonical)
+			require.NoError(t, err)
+			decoded, err := decodeSignature(encodeSignature(signature))
+			require.NoError(t, err)
+			assert.NoError(t, VerifyBuildInfoSignature(publicKey, canonical, decoded, algorithm))
+			assert.Error(t, VerifyBuildInfoSignature(publicKey, tampered, decoded, algorithm))
+			assert.Error(t, VerifyBuildInfoSignature(publicKey, canonical, decoded, "unknown"))
+		})
+	}
+}
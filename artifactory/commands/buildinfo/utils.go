@@ -0,0 +1,151 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A single run of a build, as returned by Artifactory's build runs API.
+type BuildRun struct {
+	Number  string
+	Started time.Time
+}
+
+type buildRunsResponse struct {
+	BuildsNumbers []struct {
+		Uri     string `json:"uri,omitempty"`
+		Started string `json:"started,omitempty"`
+	} `json:"buildsNumbers,omitempty"`
+}
+
+// The promotion status of a published build.
+type PromotionStatus struct {
+	Status     string `json:"status,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Timestamp  string `json:"timestamp,omitempty"`
+	User       string `json:"user,omitempty"`
+}
+
+// A published build-info, including the fields Artifactory adds after the build is published.
+// The build-info entity used for publishing doesn't include these fields.
+type PublishedBuild struct {
+	Uri       string `json:"uri,omitempty"`
+	BuildInfo struct {
+		buildinfo.BuildInfo
+		Statuses []PromotionStatus `json:"statuses,omitempty"`
+	} `json:"buildInfo,omitempty"`
+}
+
+// Returns all the runs of the provided build, sorted from the newest to the oldest.
+// If the build was not found, an empty slice is returned.
+func GetBuildRuns(servicesManager artifactory.ArtifactoryServicesManager, buildName, projectKey string) ([]BuildRun, error) {
+	body, found, err := sendBuildApiGet(servicesManager, path.Join("api/build", buildName), projectKey)
+	if err != nil || !found {
+		return []BuildRun{}, err
+	}
+	response := new(buildRunsResponse)
+	if err = json.Unmarshal(body, response); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	runs := make([]BuildRun, 0, len(response.BuildsNumbers))
+	for _, buildNumber := range response.BuildsNumbers {
+		started, err := time.Parse(buildinfo.TimeFormat, buildNumber.Started)
+		if err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse the start time '%s' of build %s%s: %s", buildNumber.Started, buildName, buildNumber.Uri, err.Error())
+		}
+		runs = append(runs, BuildRun{Number: path.Base(buildNumber.Uri), Started: started})
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].Started.After(runs[j].Started)
+	})
+	return runs, nil
+}
+
+// Returns the published build-info, including its promotion statuses.
+// If the build was not found, returns found=false (with error nil).
+func GetPublishedBuild(servicesManager artifactory.ArtifactoryServicesManager, buildName, buildNumber, projectKey string) (publishedBuild *PublishedBuild, found bool, err error) {
+	body, found, err := GetPublishedBuildJson(servicesManager, buildName, buildNumber, projectKey)
+	if err != nil || !found {
+		return nil, found, err
+	}
+	publishedBuild = new(PublishedBuild)
+	if err = json.Unmarshal(body, publishedBuild); err != nil {
+		return nil, true, errorutils.CheckError(err)
+	}
+	return publishedBuild, true, nil
+}
+
+// Returns the published build-info, as returned by Artifactory.
+// If the build was not found, returns found=false (with error nil).
+func GetPublishedBuildJson(servicesManager artifactory.ArtifactoryServicesManager, buildName, buildNumber, projectKey string) (body []byte, found bool, err error) {
+	return sendBuildApiGet(servicesManager, path.Join("api/build", buildName, buildNumber), projectKey)
+}
+
+func sendBuildApiGet(servicesManager artifactory.ArtifactoryServicesManager, restApi, projectKey string) (body []byte, found bool, err error) {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	queryParams := make(map[string]string)
+	if projectKey != "" {
+		queryParams["project"] = projectKey
+	}
+	requestFullUrl, err := clientutils.BuildUrl(serviceDetails.GetUrl(), restApi, queryParams)
+	if err != nil {
+		return nil, false, err
+	}
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	log.Debug("Sending GET request to:", requestFullUrl)
+	resp, body, _, err := servicesManager.Client().SendGet(requestFullUrl, true, &httpClientDetails)
+	if err != nil {
+		return nil, false, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		log.Debug("Artifactory response:", resp.Status)
+		return nil, false, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, false, err
+	}
+	return body, true, nil
+}
+
+// Returns the branch of the build. The branch is read from the provided build property if set,
+// and otherwise from the first VCS entry which has a branch.
+func getBuildBranch(vcsList []buildinfo.Vcs, properties buildinfo.Env, branchProperty string) string {
+	if branchProperty != "" {
+		return properties[branchProperty]
+	}
+	for _, vcs := range vcsList {
+		if vcs.Branch != "" {
+			return vcs.Branch
+		}
+	}
+	return ""
+}
+
+func splitAndTrim(value, separator string) (values []string) {
+	for _, item := range strings.Split(value, separator) {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return
+}
+
+func isInSlice(value string, slice []string) bool {
+	for _, item := range slice {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package buildinfo
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/signing"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// BuildVerifyCommand verifies that a published build-info hasn't been modified since it was signed by the build-publish command.
+type BuildVerifyCommand struct {
+	buildConfiguration *build.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	publicKeyPath      string
+}
+
+func NewBuildVerifyCommand() *BuildVerifyCommand {
+	return &BuildVerifyCommand{}
+}
+
+func (bvc *BuildVerifyCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildVerifyCommand {
+	bvc.serverDetails = serverDetails
+	return bvc
+}
+
+func (bvc *BuildVerifyCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildVerifyCommand {
+	bvc.buildConfiguration = buildConfiguration
+	return bvc
+}
+
+// Path to a PEM encoded public key or certificate, matching the key used for signing the build-info.
+func (bvc *BuildVerifyCommand) SetPublicKeyPath(publicKeyPath string) *BuildVerifyCommand {
+	bvc.publicKeyPath = publicKeyPath
+	return bvc
+}
+
+func (bvc *BuildVerifyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bvc.serverDetails, nil
+}
+
+func (bvc *BuildVerifyCommand) CommandName() string {
+	return "rt_build_verify"
+}
+
+func (bvc *BuildVerifyCommand) Run() error {
+	buildName, err := bvc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bvc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	publicKey, err := signing.LoadVerificationKey(bvc.publicKeyPath)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(bvc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	projectKey := bvc.buildConfiguration.GetProject()
+	publishedBuildJson, buildStarted, err := getPublishedBuildForSignature(servicesManager, buildName, buildNumber, projectKey)
+	if err != nil {
+		return err
+	}
+	buildInfoFilePath, err := getBuildInfoFilePath(servicesManager, buildName, buildNumber, buildStarted, projectKey)
+	if err != nil {
+		return err
+	}
+	properties, err := getFileProperties(servicesManager, buildInfoFilePath)
+	if err != nil {
+		return err
+	}
+	encodedSignature, algorithm := getFirstValue(properties[BuildSignatureProperty]), getFirstValue(properties[BuildSignatureAlgorithmProperty])
+	if encodedSignature == "" {
+		return errorutils.CheckErrorf("build %s/%s is not signed", buildName, buildNumber)
+	}
+	signature, err := decodeSignature(encodedSignature)
+	if err != nil {
+		return err
+	}
+	canonicalBuildInfo, err := CanonicalizeBuildInfo(publishedBuildJson)
+	if err != nil {
+		return err
+	}
+	if err = VerifyBuildInfoSignature(publicKey, canonicalBuildInfo, signature, algorithm); err != nil {
+		return err
+	}
+	log.Info("The signature of build", buildName+"/"+buildNumber, "is valid.")
+	return nil
+}
+
+func getFirstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
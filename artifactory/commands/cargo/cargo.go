@@ -0,0 +1,352 @@
+package cargo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/checksums"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The names of the registries which are added to the Cargo configuration.
+	resolutionRegistryName = "artifactory"
+	deploymentRegistryName = "artifactory-deploy"
+
+	cargoModuleType buildinfo.ModuleType = "cargo"
+)
+
+// A semantic version, as Cargo requires the versions of the crates to be.
+var semverRegExp = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*)?(?:\+[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*)?$`)
+
+// Runs any Cargo command, with the crates resolved from an Artifactory Cargo repository through its sparse index.
+// The publish command deploys the crate to the deployment repository.
+// If build-info collection was requested, the dependencies are collected from Cargo.lock, and the published crates are recorded as artifacts.
+type CargoCommand struct {
+	cmdName               string
+	args                  []string
+	configFilePath        string
+	executablePath        string
+	workingDirectory      string
+	repo                  string
+	deployRepo            string
+	serverDetails         *config.ServerDetails
+	deployerServerDetails *config.ServerDetails
+	buildConfiguration    *build.BuildConfiguration
+}
+
+func NewCargoCommand(cmdName string) *CargoCommand {
+	return &CargoCommand{cmdName: cmdName}
+}
+
+func (cc *CargoCommand) SetConfigFilePath(configFilePath string) *CargoCommand {
+	cc.configFilePath = configFilePath
+	return cc
+}
+
+func (cc *CargoCommand) SetArgs(args []string) *CargoCommand {
+	cc.args = args
+	return cc
+}
+
+func (cc *CargoCommand) ServerDetails() (*config.ServerDetails, error) {
+	if cc.isPublish() {
+		return cc.deployerServerDetails, nil
+	}
+	return cc.serverDetails, nil
+}
+
+func (cc *CargoCommand) CommandName() string {
+	return "rt_cargo_" + cc.cmdName
+}
+
+func (cc *CargoCommand) isPublish() bool {
+	return cc.cmdName == "publish"
+}
+
+func (cc *CargoCommand) Init() (err error) {
+	log.Debug("Preparing to read the config file", cc.configFilePath)
+	vConfig, err := project.ReadConfigFile(cc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	resolverParams, err := project.GetRepoConfigByPrefix(cc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	cc.repo = resolverParams.TargetRepo()
+	if cc.serverDetails, err = resolverParams.ServerDetails(); err != nil {
+		return err
+	}
+	if cc.isPublish() {
+		deployerParams, err := project.GetRepoConfigByPrefix(cc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		cc.deployRepo = deployerParams.TargetRepo()
+		if cc.deployerServerDetails, err = deployerParams.ServerDetails(); err != nil {
+			return err
+		}
+	}
+	cc.args, cc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(cc.args)
+	return err
+}
+
+func (cc *CargoCommand) Run() (err error) {
+	log.Info("Running cargo " + cc.cmdName + "...")
+	if cc.executablePath, err = exec.LookPath("cargo"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if cc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return err
+	}
+	collectBuildInfo, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	runStarted := time.Now()
+	if err = cc.runCargo(); err != nil {
+		return err
+	}
+	if collectBuildInfo {
+		if cc.isPublish() {
+			err = cc.collectPublishedCrates(runStarted)
+		} else {
+			err = cc.collectDependencies()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	log.Info("cargo " + cc.cmdName + " finished successfully.")
+	return nil
+}
+
+func (cc *CargoCommand) runCargo() error {
+	env := os.Environ()
+	configArgs, resolutionEnv, err := createRegistryConfig(resolutionRegistryName, cc.serverDetails, cc.repo)
+	if err != nil {
+		return err
+	}
+	// crates.io is replaced by the Artifactory registry, so that all crates are resolved from Artifactory.
+	configArgs = append(configArgs, "--config", fmt.Sprintf(`source.crates-io.replace-with=%q`, resolutionRegistryName))
+	env = append(env, resolutionEnv...)
+	cmdArgs := []string{cc.cmdName}
+	if cc.isPublish() {
+		deployConfigArgs, deploymentEnv, err := createRegistryConfig(deploymentRegistryName, cc.deployerServerDetails, cc.deployRepo)
+		if err != nil {
+			return err
+		}
+		configArgs = append(configArgs, deployConfigArgs...)
+		env = append(env, deploymentEnv...)
+		if !hasRegistryFlag(cc.args) {
+			cmdArgs = append(cmdArgs, "--registry", deploymentRegistryName)
+		}
+	}
+	configArgs = append(configArgs, "--config", `registry.global-credential-providers=["cargo:token"]`)
+
+	command := exec.Command(cc.executablePath, append(append(configArgs, cmdArgs...), cc.args...)...)
+	command.Dir = cc.workingDirectory
+	command.Env = env
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	if err = command.Run(); err != nil {
+		return errorutils.CheckErrorf("cargo %s failed: %s", cc.cmdName, err.Error())
+	}
+	return nil
+}
+
+// Returns the Cargo arguments which configure the sparse registry of the Artifactory repository,
+// and the environment variables with the credentials of the registry. The credentials are not passed as arguments, to keep them out of the process list.
+func createRegistryConfig(registryName string, serverDetails *config.ServerDetails, repo string) (configArgs, env []string, err error) {
+	indexUrl := GetSparseIndexUrl(serverDetails.GetArtifactoryUrl(), repo)
+	configArgs = []string{"--config", fmt.Sprintf(`registries.%s.index=%q`, registryName, indexUrl)}
+	token, err := getRegistryToken(serverDetails)
+	if err != nil || token == "" {
+		return
+	}
+	envName := "CARGO_REGISTRIES_" + strings.ToUpper(strings.ReplaceAll(registryName, "-", "_")) + "_TOKEN"
+	return configArgs, []string{envName + "=" + token}, nil
+}
+
+func GetSparseIndexUrl(artifactoryUrl, repo string) string {
+	return "sparse+" + strings.TrimSuffix(artifactoryUrl, "/") + "/api/cargo/" + repo + "/index/"
+}
+
+// Cargo sends the registry token as the value of the Authorization header.
+func getRegistryToken(serverDetails *config.ServerDetails) (string, error) {
+	if serverDetails.GetAccessToken() != "" {
+		return "Bearer " + serverDetails.GetAccessToken(), nil
+	}
+	if serverDetails.GetUser() != "" && serverDetails.GetPassword() != "" {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(serverDetails.GetUser()+":"+serverDetails.GetPassword())), nil
+	}
+	if serverDetails.SshKeyPath != "" {
+		return "", errorutils.CheckErrorf("SSH authentication is not supported in this command")
+	}
+	return "", nil
+}
+
+func hasRegistryFlag(args []string) bool {
+	for _, arg := range args {
+		if arg == "--registry" || strings.HasPrefix(arg, "--registry=") || arg == "--index" || strings.HasPrefix(arg, "--index=") {
+			return true
+		}
+	}
+	return false
+}
+
+func (cc *CargoCommand) collectDependencies() error {
+	lockfilePath := filepath.Join(cc.workingDirectory, CargoLockFileName)
+	if !projectconfig.LockfileExists(lockfilePath) {
+		return nil
+	}
+	dependencies, err := ParseLockfile(lockfilePath)
+	if err != nil {
+		return err
+	}
+	if err = projectconfig.SetChecksums(cc.serverDetails, checksums.Sha256Field, dependencies); err != nil {
+		return err
+	}
+	moduleId, err := cc.getModuleId(lockfilePath)
+	if err != nil {
+		return err
+	}
+	return projectconfig.SaveModule(cc.buildConfiguration, cargoModuleType, moduleId, func(partial *buildinfo.Partial) {
+		partial.Dependencies = dependencies
+	})
+}
+
+// The published crates are the ones which were packaged by this run, in the package directory of the target directory.
+func (cc *CargoCommand) collectPublishedCrates(runStarted time.Time) error {
+	targetDir := cc.getTargetDirectory()
+	crateFiles, err := filepath.Glob(filepath.Join(targetDir, "package", "*.crate"))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	var artifacts []buildinfo.Artifact
+	for _, crateFile := range crateFiles {
+		fileInfo, err := os.Stat(crateFile)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if fileInfo.ModTime().Before(runStarted) {
+			continue
+		}
+		artifact, err := createCrateArtifact(crateFile)
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	if len(artifacts) == 0 {
+		log.Warn("No published crates were found in " + filepath.Join(targetDir, "package") + ", so no artifacts are added to the build-info.")
+		return nil
+	}
+	moduleId := cc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		crateName, crateVersion := splitCrateFileName(artifacts[0].Name)
+		moduleId = crateName + ":" + crateVersion
+	}
+	return projectconfig.SaveModule(cc.buildConfiguration, cargoModuleType, moduleId, func(partial *buildinfo.Partial) {
+		partial.Artifacts = artifacts
+	})
+}
+
+// Returns the target directory of the package, as provided by --target-dir or reported by 'cargo metadata'. It's the target directory of the workspace root for workspace members,
+// and it may be set by CARGO_TARGET_DIR or by the Cargo configuration. If it can't be read, the target directory in the working directory is assumed.
+func (cc *CargoCommand) getTargetDirectory() string {
+	if _, _, targetDir, err := coreutils.FindFlag("--target-dir", cc.args); err == nil && targetDir != "" {
+		if !filepath.IsAbs(targetDir) {
+			targetDir = filepath.Join(cc.workingDirectory, targetDir)
+		}
+		return targetDir
+	}
+	metadataArgs := []string{"metadata", "--format-version", "1", "--no-deps"}
+	if _, _, manifestPath, err := coreutils.FindFlag("--manifest-path", cc.args); err == nil && manifestPath != "" {
+		metadataArgs = append(metadataArgs, "--manifest-path", manifestPath)
+	}
+	command := exec.Command(cc.executablePath, metadataArgs...)
+	command.Dir = cc.workingDirectory
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	output, err := command.Output()
+	if err == nil {
+		var targetDir string
+		if targetDir, err = parseTargetDirectory(output); err == nil {
+			return targetDir
+		}
+	}
+	log.Warn("Failed reading the target directory by 'cargo metadata': " + err.Error())
+	if targetDir := os.Getenv("CARGO_TARGET_DIR"); targetDir != "" {
+		return targetDir
+	}
+	return filepath.Join(cc.workingDirectory, "target")
+}
+
+func parseTargetDirectory(metadataOutput []byte) (string, error) {
+	var metadata struct {
+		TargetDirectory string `json:"target_directory"`
+	}
+	if err := json.Unmarshal(metadataOutput, &metadata); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if metadata.TargetDirectory == "" {
+		return "", errorutils.CheckErrorf("the output of 'cargo metadata' has no target directory")
+	}
+	return metadata.TargetDirectory, nil
+}
+
+// Artifactory stores the crates in the crates/<name>/<name>-<version>.crate path of the Cargo repository.
+func createCrateArtifact(crateFile string) (buildinfo.Artifact, error) {
+	fileDetails, err := fileutils.GetFileDetails(crateFile, true)
+	if err != nil {
+		return buildinfo.Artifact{}, err
+	}
+	fileName := filepath.Base(crateFile)
+	crateName, _ := splitCrateFileName(fileName)
+	return buildinfo.Artifact{
+		Name:     fileName,
+		Type:     crateFileType,
+		Path:     "crates/" + crateName + "/" + fileName,
+		Checksum: buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256},
+	}, nil
+}
+
+// Returns the name and version of the crate from its file name, for example: my-crate-1.0.0.crate.
+// Both the crate names and the pre-release versions may include hyphens, so the version is the longest suffix after a hyphen which is a semantic version.
+func splitCrateFileName(fileName string) (name, version string) {
+	baseName := strings.TrimSuffix(fileName, ".crate")
+	for i := 1; i < len(baseName); i++ {
+		if baseName[i-1] == '-' && semverRegExp.MatchString(baseName[i:]) {
+			return baseName[:i-1], baseName[i:]
+		}
+	}
+	return baseName, ""
+}
+
+// The module ID is taken from the --module option, or from the package in Cargo.toml.
+func (cc *CargoCommand) getModuleId(lockfilePath string) (string, error) {
+	if cc.buildConfiguration.GetModule() != "" {
+		return cc.buildConfiguration.GetModule(), nil
+	}
+	moduleId, err := getRootPackageId(filepath.Join(cc.workingDirectory, CargoManifestFileName), lockfilePath)
+	if err != nil || moduleId != "" {
+		return moduleId, err
+	}
+	return filepath.Base(cc.workingDirectory), nil
+}
@@ -0,0 +1,66 @@
+package cargo
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRegistryConfig(t *testing.T) {
+	configArgs, env, err := createRegistryConfig("artifactory-deploy", &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: "token"}, "cargo-local")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--config", `registries.artifactory-deploy.index="sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-local/index/"`}, configArgs)
+	assert.Equal(t, []string{"CARGO_REGISTRIES_ARTIFACTORY_DEPLOY_TOKEN=Bearer token"}, env)
+
+	_, env, err = createRegistryConfig("artifactory", &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "user", Password: "pass"}, "cargo-virtual")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CARGO_REGISTRIES_ARTIFACTORY_TOKEN=Basic dXNlcjpwYXNz"}, env)
+
+	// Anonymous access.
+	_, env, err = createRegistryConfig("artifactory", &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}, "cargo-virtual")
+	require.NoError(t, err)
+	assert.Empty(t, env)
+}
+
+func TestSplitCrateFileName(t *testing.T) {
+	tests := []struct {
+		fileName        string
+		expectedName    string
+		expectedVersion string
+	}{
+		{"serde-1.0.196.crate", "serde", "1.0.196"},
+		{"my-service-0.1.0.crate", "my-service", "0.1.0"},
+		{"tokio-1-compat-2.0.0-rc.1.crate", "tokio-1-compat", "2.0.0-rc.1"},
+		{"foo-2d-1.0.0.crate", "foo-2d", "1.0.0"},
+		{"foo-1.0.0-2.crate", "foo", "1.0.0-2"},
+		{"foo-1.0.0-beta-2.crate", "foo", "1.0.0-beta-2"},
+		{"foo-1.0.0+build.5.crate", "foo", "1.0.0+build.5"},
+		{"foo-1-2-3.crate", "foo-1-2-3", ""},
+		{"foo.crate", "foo", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.fileName, func(t *testing.T) {
+			name, version := splitCrateFileName(test.fileName)
+			assert.Equal(t, test.expectedName, name)
+			assert.Equal(t, test.expectedVersion, version)
+		})
+	}
+}
+
+func TestHasRegistryFlag(t *testing.T) {
+	assert.True(t, hasRegistryFlag([]string{"--registry", "other"}))
+	assert.True(t, hasRegistryFlag([]string{"--registry=other"}))
+	assert.False(t, hasRegistryFlag([]string{"--allow-dirty"}))
+}
+
+func TestParseTargetDirectory(t *testing.T) {
+	// The target directory of a workspace member is the target directory of the workspace root.
+	targetDir, err := parseTargetDirectory([]byte(`{"packages":[{"name":"member"}],"workspace_root":"/work","target_directory":"/work/target","version":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, "/work/target", targetDir)
+
+	_, err = parseTargetDirectory([]byte(`{"packages":[]}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+package cargo
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	CargoLockFileName     = "Cargo.lock"
+	CargoManifestFileName = "Cargo.toml"
+	crateFileType         = "crate"
+)
+
+type cargoLockfile struct {
+	Packages []cargoPackage `toml:"package"`
+}
+
+type cargoPackage struct {
+	Name         string   `toml:"name"`
+	Version      string   `toml:"version"`
+	Source       string   `toml:"source"`
+	Checksum     string   `toml:"checksum"`
+	Dependencies []string `toml:"dependencies"`
+}
+
+func (cp *cargoPackage) id() string {
+	return cp.Name + ":" + cp.Version
+}
+
+// Packages without a source are members of the workspace, or local path dependencies.
+func (cp *cargoPackage) isLocal() bool {
+	return cp.Source == ""
+}
+
+// Only packages from registries are resolved from Artifactory. Git dependencies are skipped.
+func (cp *cargoPackage) isFromRegistry() bool {
+	return strings.HasPrefix(cp.Source, "registry+") || strings.HasPrefix(cp.Source, "sparse+")
+}
+
+// Reads the Cargo.lock file, and returns the registry dependencies of the local packages.
+// The SHA-256 checksums of the dependencies are taken from the lockfile.
+func ParseLockfile(lockfilePath string) ([]buildinfo.Dependency, error) {
+	lockfile := new(cargoLockfile)
+	if _, err := toml.DecodeFile(lockfilePath, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing %s: %s", lockfilePath, err.Error())
+	}
+	return lockfile.dependencies(), nil
+}
+
+func (cl *cargoLockfile) dependencies() []buildinfo.Dependency {
+	packagesByName := make(map[string][]*cargoPackage)
+	var queue []*cargoPackage
+	pathsToRoot := make(map[*cargoPackage][]string)
+	for i := range cl.Packages {
+		pkg := &cl.Packages[i]
+		packagesByName[pkg.Name] = append(packagesByName[pkg.Name], pkg)
+		if pkg.isLocal() {
+			queue = append(queue, pkg)
+			pathsToRoot[pkg] = []string{}
+		}
+	}
+
+	dependencies := make(map[string]*buildinfo.Dependency)
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		requestedBy := append([]string{parent.id()}, pathsToRoot[parent]...)
+		for _, dependencyRef := range parent.Dependencies {
+			pkg := resolveDependencyRef(dependencyRef, packagesByName)
+			// Local packages are traversed as roots.
+			if pkg == nil || !pkg.isFromRegistry() {
+				continue
+			}
+			dependency, exists := dependencies[pkg.id()]
+			if !exists {
+				dependency = &buildinfo.Dependency{Id: pkg.id(), Type: crateFileType, Checksum: buildinfo.Checksum{Sha256: pkg.Checksum}}
+				dependencies[pkg.id()] = dependency
+			}
+			dependency.RequestedBy = append(dependency.RequestedBy, requestedBy)
+			if _, visited := pathsToRoot[pkg]; !visited {
+				pathsToRoot[pkg] = requestedBy
+				queue = append(queue, pkg)
+			}
+		}
+	}
+
+	result := make([]buildinfo.Dependency, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		result = append(result, *dependency)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
+
+// Dependencies are referenced by name, and also by version and source if the lockfile includes several packages with the same name.
+// For example: "serde", "syn 1.0.109" or "syn 2.0.48 (registry+https://github.com/rust-lang/crates.io-index)".
+func resolveDependencyRef(dependencyRef string, packagesByName map[string][]*cargoPackage) *cargoPackage {
+	fields := strings.Fields(dependencyRef)
+	if len(fields) == 0 {
+		return nil
+	}
+	candidates := packagesByName[fields[0]]
+	for _, candidate := range candidates {
+		if len(fields) == 1 || candidate.Version == fields[1] {
+			if len(fields) < 3 || "("+candidate.Source+")" == fields[2] {
+				return candidate
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the ID of the package which Cargo.toml describes, as it is listed in Cargo.lock.
+// If Cargo.toml describes a virtual workspace, an empty string is returned.
+func getRootPackageId(manifestPath, lockfilePath string) (string, error) {
+	var manifest struct {
+		Package struct {
+			Name string `toml:"name"`
+		} `toml:"package"`
+	}
+	if _, err := toml.DecodeFile(manifestPath, &manifest); err != nil {
+		return "", errorutils.CheckErrorf("failed parsing %s: %s", manifestPath, err.Error())
+	}
+	if manifest.Package.Name == "" {
+		return "", nil
+	}
+	lockfile := new(cargoLockfile)
+	if _, err := toml.DecodeFile(lockfilePath, lockfile); err != nil {
+		return "", errorutils.CheckErrorf("failed parsing %s: %s", lockfilePath, err.Error())
+	}
+	for _, pkg := range lockfile.Packages {
+		if pkg.Name == manifest.Package.Name && pkg.isLocal() {
+			return pkg.id(), nil
+		}
+	}
+	return manifest.Package.Name, nil
+}
@@ -0,0 +1,103 @@
+package cargo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCargoLock = `# This file is automatically @generated by Cargo.
+# It is not intended for manual editing.
+version = 3
+
+[[package]]
+name = "my-service"
+version = "0.1.0"
+dependencies = [
+ "my-utils",
+ "serde",
+ "syn 2.0.48",
+]
+
+[[package]]
+name = "my-utils"
+version = "0.2.0"
+dependencies = [
+ "internal-git",
+ "syn 1.0.109",
+]
+
+[[package]]
+name = "internal-git"
+version = "0.3.0"
+source = "git+https://github.com/acme/internal-git#0123456"
+
+[[package]]
+name = "serde"
+version = "1.0.196"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "870026e60fa08c69f064aa766c10f10b1d62db9ccd4d0abb206472bee0ce3b32"
+dependencies = [
+ "syn 2.0.48",
+]
+
+[[package]]
+name = "syn"
+version = "1.0.109"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "72b64191b275b66ffe2469e8af2c1cfe3bafa67b529ead792a6d0160888b4237"
+
+[[package]]
+name = "syn"
+version = "2.0.48"
+source = "sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-virtual/index/"
+checksum = "0f3531638e407dfc0814761abb7c00a5b54992b849452a0646b7f65c9f770f3f"
+`
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	filePath := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+	return filePath
+}
+
+func TestParseLockfile(t *testing.T) {
+	lockfilePath := writeTestFile(t, t.TempDir(), CargoLockFileName, testCargoLock)
+	dependencies, err := ParseLockfile(lockfilePath)
+	require.NoError(t, err)
+	assert.Equal(t, []buildinfo.Dependency{
+		{
+			Id: "serde:1.0.196", Type: "crate",
+			Checksum:    buildinfo.Checksum{Sha256: "870026e60fa08c69f064aa766c10f10b1d62db9ccd4d0abb206472bee0ce3b32"},
+			RequestedBy: [][]string{{"my-service:0.1.0"}},
+		},
+		{
+			Id: "syn:1.0.109", Type: "crate",
+			Checksum:    buildinfo.Checksum{Sha256: "72b64191b275b66ffe2469e8af2c1cfe3bafa67b529ead792a6d0160888b4237"},
+			RequestedBy: [][]string{{"my-utils:0.2.0"}},
+		},
+		{
+			Id: "syn:2.0.48", Type: "crate",
+			Checksum:    buildinfo.Checksum{Sha256: "0f3531638e407dfc0814761abb7c00a5b54992b849452a0646b7f65c9f770f3f"},
+			RequestedBy: [][]string{{"my-service:0.1.0"}, {"serde:1.0.196", "my-service:0.1.0"}},
+		},
+	}, dependencies)
+}
+
+func TestGetRootPackageId(t *testing.T) {
+	dir := t.TempDir()
+	lockfilePath := writeTestFile(t, dir, CargoLockFileName, testCargoLock)
+	manifestPath := writeTestFile(t, dir, CargoManifestFileName, "[package]\nname = \"my-service\"\nversion.workspace = true\n")
+	rootPackageId, err := getRootPackageId(manifestPath, lockfilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "my-service:0.1.0", rootPackageId)
+
+	// A virtual workspace has no package.
+	manifestPath = writeTestFile(t, dir, CargoManifestFileName, "[workspace]\nmembers = [\"my-service\", \"my-utils\"]\n")
+	rootPackageId, err = getRootPackageId(manifestPath, lockfilePath)
+	require.NoError(t, err)
+	assert.Empty(t, rootPackageId)
+}
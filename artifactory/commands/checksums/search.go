@@ -0,0 +1,83 @@
+package checksums
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The AQL fields which files can be searched by.
+const (
+	Sha256Field = "sha256"
+	Sha1Field   = "actual_sha1"
+)
+
+// The number of checksums searched in a single AQL query, to keep the queries short.
+const searchBatchSize = 100
+
+var hexRegExp = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+// Searches Artifactory for files with the given checksums, and returns the full checksums of the files which were found.
+// The returned map is keyed by the searched checksums, in lowercase. field is either Sha256Field or Sha1Field.
+func Search(servicesManager artifactory.ArtifactoryServicesManager, field string, values []string) (map[string]buildinfo.Checksum, error) {
+	found := make(map[string]buildinfo.Checksum)
+	// Values which aren't checksums are skipped, since they are embedded in the query.
+	var checksums []string
+	for _, value := range values {
+		if hexRegExp.MatchString(value) {
+			checksums = append(checksums, strings.ToLower(value))
+		}
+	}
+	values = checksums
+	for start := 0; start < len(values); start += searchBatchSize {
+		end := min(start+searchBatchSize, len(values))
+		results, err := searchBatch(servicesManager, field, values[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			checksum := buildinfo.Checksum{Sha1: result.Actual_Sha1, Md5: result.Actual_Md5, Sha256: result.Sha256}
+			if field == Sha256Field {
+				found[result.Sha256] = checksum
+			} else {
+				found[result.Actual_Sha1] = checksum
+			}
+		}
+	}
+	return found, nil
+}
+
+func searchBatch(servicesManager artifactory.ArtifactoryServicesManager, field string, values []string) (results []servicesutils.ResultItem, err error) {
+	stream, err := servicesManager.Aql(createChecksumsAqlQuery(field, values))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(stream.Close()))
+	}()
+	aqlResults, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	parsedResult := new(servicesutils.AqlSearchResult)
+	if err = json.Unmarshal(aqlResults, parsedResult); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return parsedResult.Results, nil
+}
+
+func createChecksumsAqlQuery(field string, values []string) string {
+	conditions := make([]string, 0, len(values))
+	for _, value := range values {
+		conditions = append(conditions, fmt.Sprintf(`{"%s":"%s"}`, field, value))
+	}
+	return fmt.Sprintf(`items.find({"$or":[%s]}).include("sha256","actual_sha1","actual_md5")`, strings.Join(conditions, ","))
+}
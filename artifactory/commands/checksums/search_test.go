@@ -0,0 +1,12 @@
+package checksums
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateChecksumsAqlQuery(t *testing.T) {
+	assert.Equal(t, `items.find({"$or":[{"sha256":"abc"},{"sha256":"def"}]}).include("sha256","actual_sha1","actual_md5")`,
+		createChecksumsAqlQuery(Sha256Field, []string{"abc", "def"}))
+}
@@ -0,0 +1,323 @@
+package composer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/checksums"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The name of the repository which is added to the global Composer configuration.
+	resolutionRepositoryName = "artifactory"
+
+	composerModuleType buildinfo.ModuleType = "composer"
+)
+
+// The commands which install packages and update composer.lock, so their dependencies are collected.
+var installCommands = map[string]bool{"install": true, "i": true, "update": true, "u": true, "upgrade": true, "require": true, "r": true}
+
+// Runs any Composer command, with the packages resolved from an Artifactory Composer repository, which replaces packagist.org.
+// The publish command archives the package and uploads it to the deployment repository.
+// If build-info collection was requested, the dependencies are collected from composer.lock, and the published archive is recorded as an artifact.
+type ComposerCommand struct {
+	cmdName               string
+	args                  []string
+	configFilePath        string
+	executablePath        string
+	workingDirectory      string
+	repo                  string
+	deployRepo            string
+	serverDetails         *config.ServerDetails
+	deployerServerDetails *config.ServerDetails
+	buildConfiguration    *build.BuildConfiguration
+}
+
+func NewComposerCommand(cmdName string) *ComposerCommand {
+	return &ComposerCommand{cmdName: cmdName}
+}
+
+func (cc *ComposerCommand) SetConfigFilePath(configFilePath string) *ComposerCommand {
+	cc.configFilePath = configFilePath
+	return cc
+}
+
+func (cc *ComposerCommand) SetArgs(args []string) *ComposerCommand {
+	cc.args = args
+	return cc
+}
+
+func (cc *ComposerCommand) ServerDetails() (*config.ServerDetails, error) {
+	if cc.isPublish() {
+		return cc.deployerServerDetails, nil
+	}
+	return cc.serverDetails, nil
+}
+
+func (cc *ComposerCommand) CommandName() string {
+	return "rt_composer_" + cc.cmdName
+}
+
+func (cc *ComposerCommand) isPublish() bool {
+	return cc.cmdName == "publish" || cc.cmdName == "p"
+}
+
+// The resolution repository is required by all commands but publish, which requires the deployment repository.
+func (cc *ComposerCommand) Init() (err error) {
+	log.Debug("Preparing to read the config file", cc.configFilePath)
+	vConfig, err := project.ReadConfigFile(cc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	if cc.isPublish() {
+		deployerParams, err := project.GetRepoConfigByPrefix(cc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		cc.deployRepo = deployerParams.TargetRepo()
+		if cc.deployerServerDetails, err = deployerParams.ServerDetails(); err != nil {
+			return err
+		}
+	} else {
+		resolverParams, err := project.GetRepoConfigByPrefix(cc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		cc.repo = resolverParams.TargetRepo()
+		if cc.serverDetails, err = resolverParams.ServerDetails(); err != nil {
+			return err
+		}
+	}
+	cc.args, cc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(cc.args)
+	return err
+}
+
+func (cc *ComposerCommand) Run() (err error) {
+	log.Info("Running composer " + cc.cmdName + "...")
+	if cc.executablePath, err = exec.LookPath("composer"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if cc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return err
+	}
+	collectBuildInfo, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if cc.isPublish() {
+		err = cc.publish(collectBuildInfo)
+	} else {
+		err = cc.runWithResolution(collectBuildInfo)
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("composer " + cc.cmdName + " finished successfully.")
+	return nil
+}
+
+func (cc *ComposerCommand) runWithResolution(collectBuildInfo bool) error {
+	if err := cc.configureRepository(); err != nil {
+		return err
+	}
+	authEnv, err := createAuthEnv(cc.serverDetails)
+	if err != nil {
+		return err
+	}
+	return projectconfig.RunWithResolution(func() error {
+		return cc.runComposer(append(os.Environ(), authEnv...), append([]string{cc.cmdName}, cc.args...)...)
+	}, collectBuildInfo, installCommands[cc.cmdName], cc.collectDependencies)
+}
+
+// Adds the Artifactory repository to the global Composer configuration, and disables packagist.org, so that all packages are resolved from Artifactory.
+// The credentials aren't stored in the configuration. They're passed to Composer through the COMPOSER_AUTH environment variable.
+func (cc *ComposerCommand) configureRepository() error {
+	repositoryUrl := GetRepositoryUrl(cc.serverDetails.GetArtifactoryUrl(), cc.repo)
+	for _, configArgs := range [][]string{
+		{"repositories." + resolutionRepositoryName, "composer", repositoryUrl},
+		{"repo.packagist", "false"},
+	} {
+		command := exec.Command(cc.executablePath, append([]string{"config", "--global"}, configArgs...)...)
+		log.Debug("Running command:", strings.Join(command.Args, " "))
+		if output, err := command.CombinedOutput(); err != nil {
+			return errorutils.CheckErrorf("failed configuring the Composer repository: %s", strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+func GetRepositoryUrl(artifactoryUrl, repo string) string {
+	return strings.TrimSuffix(artifactoryUrl, "/") + "/api/composer/" + repo
+}
+
+// Returns the COMPOSER_AUTH environment variable, with the basic authentication credentials of the Artifactory host.
+func createAuthEnv(serverDetails *config.ServerDetails) ([]string, error) {
+	username, password := projectconfig.GetBasicAuthCredentials(serverDetails)
+	if password == "" {
+		return nil, nil
+	}
+	artifactoryUrl, err := url.Parse(serverDetails.GetArtifactoryUrl())
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	composerAuth, err := json.Marshal(map[string]any{
+		"http-basic": map[string]any{
+			artifactoryUrl.Host: map[string]string{"username": username, "password": password},
+		},
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return []string{"COMPOSER_AUTH=" + string(composerAuth)}, nil
+}
+
+func (cc *ComposerCommand) runComposer(env []string, args ...string) error {
+	command := exec.Command(cc.executablePath, args...)
+	command.Dir = cc.workingDirectory
+	command.Env = env
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	if err := command.Run(); err != nil {
+		return errorutils.CheckErrorf("composer %s failed: %s", cc.cmdName, err.Error())
+	}
+	return nil
+}
+
+func (cc *ComposerCommand) collectDependencies() error {
+	lockfilePath := filepath.Join(cc.workingDirectory, ComposerLockFileName)
+	if !projectconfig.LockfileExists(lockfilePath) {
+		return nil
+	}
+	manifestPath := filepath.Join(cc.workingDirectory, ComposerManifestFileName)
+	moduleId, err := cc.getModuleId(manifestPath)
+	if err != nil {
+		return err
+	}
+	dependencies, err := ParseLockfile(lockfilePath, manifestPath, moduleId)
+	if err != nil {
+		return err
+	}
+	if err = projectconfig.SetChecksums(cc.serverDetails, checksums.Sha1Field, dependencies); err != nil {
+		return err
+	}
+	return projectconfig.SaveModule(cc.buildConfiguration, composerModuleType, moduleId, func(partial *buildinfo.Partial) {
+		partial.Dependencies = dependencies
+	})
+}
+
+// Archives the package with 'composer archive', and uploads the archive to the deployment repository, under <vendor>/<name>.
+// Artifactory reads the package metadata from composer.json in the archive. If composer.json has no version, it's taken from the --version option.
+func (cc *ComposerCommand) publish(collectBuildInfo bool) (err error) {
+	args := append([]string(nil), cc.args...)
+	flagIndex, valueIndex, version, err := coreutils.FindFlag("--version", args)
+	if err != nil {
+		return err
+	}
+	coreutils.RemoveFlagFromCommand(&args, flagIndex, valueIndex)
+	manifest, err := readManifest(filepath.Join(cc.workingDirectory, ComposerManifestFileName))
+	if err != nil {
+		return err
+	}
+	if manifest.Name == "" {
+		return errorutils.CheckErrorf("the package name is missing from %s", ComposerManifestFileName)
+	}
+	if version == "" {
+		version = manifest.Version
+	}
+	if version == "" {
+		return errorutils.CheckErrorf("the package version is missing. Set it in %s, or use the --version option", ComposerManifestFileName)
+	}
+
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	archiveName := strings.ReplaceAll(manifest.Name, "/", "-") + "-" + version
+	archiveArgs := append([]string{"archive", "--format=zip", "--dir=" + tempDir, "--file=" + archiveName}, args...)
+	if err = cc.runComposer(os.Environ(), archiveArgs...); err != nil {
+		return err
+	}
+	archivePath := filepath.Join(tempDir, archiveName+".zip")
+
+	targetPath := manifest.Name + "/" + archiveName + ".zip"
+	log.Info("Uploading " + manifest.Name + ":" + version + " to " + cc.deployRepo + "...")
+	servicesManager, err := utils.CreateServiceManager(cc.deployerServerDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	uploadParams := services.NewUploadParams()
+	uploadParams.Pattern = archivePath
+	uploadParams.Target = cc.deployRepo + "/" + targetPath
+	uploadParams.TargetProps = servicesutils.NewProperties()
+	uploadParams.TargetProps.AddProperty("composer.version", version)
+	if collectBuildInfo {
+		if uploadParams.BuildProps, err = build.CreateBuildPropsFromConfiguration(cc.buildConfiguration); err != nil {
+			return err
+		}
+	}
+	uploaded, failed, err := servicesManager.UploadFiles(uploadParams)
+	if err != nil {
+		return err
+	}
+	if failed > 0 || uploaded == 0 {
+		return errorutils.CheckErrorf("failed uploading %s to %s", archiveName+".zip", cc.deployRepo)
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	fileDetails, err := fileutils.GetFileDetails(archivePath, true)
+	if err != nil {
+		return err
+	}
+	moduleId := cc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = manifest.Name + ":" + version
+	}
+	return projectconfig.SaveModule(cc.buildConfiguration, composerModuleType, moduleId, func(partial *buildinfo.Partial) {
+		partial.Artifacts = []buildinfo.Artifact{{
+			Name:                   archiveName + ".zip",
+			Type:                   "zip",
+			Path:                   targetPath,
+			OriginalDeploymentRepo: cc.deployRepo,
+			Checksum:               buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256},
+		}}
+	})
+}
+
+// The module ID is taken from the --module option, or from the package name and version in composer.json.
+func (cc *ComposerCommand) getModuleId(manifestPath string) (string, error) {
+	if cc.buildConfiguration.GetModule() != "" {
+		return cc.buildConfiguration.GetModule(), nil
+	}
+	manifest, err := readManifest(manifestPath)
+	if err != nil {
+		return "", err
+	}
+	if manifest.Name == "" {
+		return filepath.Base(cc.workingDirectory), nil
+	}
+	if manifest.Version == "" {
+		return manifest.Name, nil
+	}
+	return manifest.Name + ":" + manifest.Version, nil
+}
@@ -0,0 +1,23 @@
+package composer
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAuthEnv(t *testing.T) {
+	env, err := createAuthEnv(&config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "admin", Password: "password"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`COMPOSER_AUTH={"http-basic":{"acme.jfrog.io":{"password":"password","username":"admin"}}}`}, env)
+
+	env, err = createAuthEnv(&config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"})
+	require.NoError(t, err)
+	assert.Empty(t, env)
+}
+
+func TestGetRepositoryUrl(t *testing.T) {
+	assert.Equal(t, "https://acme.jfrog.io/artifactory/api/composer/php-virtual", GetRepositoryUrl("https://acme.jfrog.io/artifactory/", "php-virtual"))
+}
@@ -0,0 +1,141 @@
+package composer
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	ComposerLockFileName     = "composer.lock"
+	ComposerManifestFileName = "composer.json"
+)
+
+type composerManifest struct {
+	Name       string            `json:"name"`
+	Version    string            `json:"version"`
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+type composerLockfile struct {
+	Packages    []composerPackage `json:"packages"`
+	PackagesDev []composerPackage `json:"packages-dev"`
+}
+
+type composerPackage struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Require map[string]string `json:"require"`
+	Dist    struct {
+		Type   string `json:"type"`
+		Shasum string `json:"shasum"`
+	} `json:"dist"`
+}
+
+func (cp *composerPackage) id() string {
+	return cp.Name + ":" + cp.Version
+}
+
+// Platform packages, such as php, ext-json and composer-plugin-api, aren't installed by Composer. Their names have no vendor.
+func isPlatformPackage(name string) bool {
+	return !strings.Contains(name, "/")
+}
+
+func readManifest(manifestPath string) (*composerManifest, error) {
+	manifest := new(composerManifest)
+	if err := readJsonFile(manifestPath, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Reads composer.lock, and returns the dependencies which are required by the project in composer.json, directly or transitively.
+// Packages from the packages-dev section have the dev scope. The SHA-1 checksums of the dist archives are taken from the lockfile, if they're listed.
+func ParseLockfile(lockfilePath, manifestPath, rootModuleId string) ([]buildinfo.Dependency, error) {
+	manifest, err := readManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	lockfile := new(composerLockfile)
+	if err = readJsonFile(lockfilePath, lockfile); err != nil {
+		return nil, err
+	}
+	packages := make(map[string]*composerPackage)
+	scopes := make(map[string]string)
+	for scope, scopePackages := range map[string][]composerPackage{"prod": lockfile.Packages, "dev": lockfile.PackagesDev} {
+		for i := range scopePackages {
+			name := strings.ToLower(scopePackages[i].Name)
+			packages[name] = &scopePackages[i]
+			scopes[name] = scope
+		}
+	}
+
+	dependencies := make(map[string]*buildinfo.Dependency)
+	pathsToRoot := make(map[string][]string)
+	type queuedPackage struct {
+		name        string
+		requestedBy []string
+	}
+	var queue []queuedPackage
+	for _, requirements := range []map[string]string{manifest.Require, manifest.RequireDev} {
+		for _, name := range sortedNames(requirements) {
+			queue = append(queue, queuedPackage{strings.ToLower(name), []string{rootModuleId}})
+		}
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		pkg, exists := packages[current.name]
+		if isPlatformPackage(current.name) || !exists {
+			continue
+		}
+		dependency, exists := dependencies[current.name]
+		if !exists {
+			dependency = &buildinfo.Dependency{Id: pkg.id(), Type: pkg.Dist.Type, Scopes: []string{scopes[current.name]}, Checksum: buildinfo.Checksum{Sha1: pkg.Dist.Shasum}}
+			dependencies[current.name] = dependency
+		}
+		dependency.RequestedBy = append(dependency.RequestedBy, current.requestedBy)
+		if _, visited := pathsToRoot[current.name]; visited {
+			continue
+		}
+		pathsToRoot[current.name] = current.requestedBy
+		requestedBy := append([]string{pkg.id()}, current.requestedBy...)
+		for _, name := range sortedNames(pkg.Require) {
+			queue = append(queue, queuedPackage{strings.ToLower(name), requestedBy})
+		}
+	}
+
+	result := make([]buildinfo.Dependency, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		result = append(result, *dependency)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+	return result, nil
+}
+
+func sortedNames(requirements map[string]string) []string {
+	names := make([]string, 0, len(requirements))
+	for name := range requirements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func readJsonFile(filePath string, target any) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = json.Unmarshal(content, target); err != nil {
+		return errorutils.CheckErrorf("failed parsing %s: %s", filePath, err.Error())
+	}
+	return nil
+}
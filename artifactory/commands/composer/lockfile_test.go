@@ -0,0 +1,84 @@
+package composer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const composerJson = `{
+  "name": "acme/my-app",
+  "version": "1.0.0",
+  "require": {
+    "php": ">=8.1",
+    "ext-json": "*",
+    "Monolog/Monolog": "^3.0"
+  },
+  "require-dev": {
+    "phpunit/phpunit": "^10.0"
+  }
+}`
+
+const composerLock = `{
+  "packages": [
+    {
+      "name": "monolog/monolog",
+      "version": "3.5.0",
+      "require": {"php": ">=8.1", "psr/log": "^2.0 || ^3.0"},
+      "dist": {"type": "zip", "url": "https://api.github.com/repos/Seldaek/monolog/zipball/c915e2", "reference": "c915e2", "shasum": "0123456789abcdef0123456789abcdef01234567"}
+    },
+    {
+      "name": "psr/log",
+      "version": "3.0.0",
+      "dist": {"type": "zip", "url": "https://api.github.com/repos/php-fig/log/zipball/fe5ea3", "reference": "fe5ea3", "shasum": ""}
+    }
+  ],
+  "packages-dev": [
+    {
+      "name": "phpunit/phpunit",
+      "version": "10.5.0",
+      "require": {"psr/log": "^3.0"},
+      "dist": {"type": "zip", "shasum": ""}
+    }
+  ]
+}`
+
+func TestParseLockfile(t *testing.T) {
+	projectDir := t.TempDir()
+	manifestPath := filepath.Join(projectDir, ComposerManifestFileName)
+	lockfilePath := filepath.Join(projectDir, ComposerLockFileName)
+	require.NoError(t, os.WriteFile(manifestPath, []byte(composerJson), 0644))
+	require.NoError(t, os.WriteFile(lockfilePath, []byte(composerLock), 0644))
+
+	dependencies, err := ParseLockfile(lockfilePath, manifestPath, "acme/my-app:1.0.0")
+	require.NoError(t, err)
+	expected := []entities.Dependency{
+		{
+			Id:          "monolog/monolog:3.5.0",
+			Type:        "zip",
+			Scopes:      []string{"prod"},
+			Checksum:    entities.Checksum{Sha1: "0123456789abcdef0123456789abcdef01234567"},
+			RequestedBy: [][]string{{"acme/my-app:1.0.0"}},
+		},
+		{
+			Id:          "phpunit/phpunit:10.5.0",
+			Type:        "zip",
+			Scopes:      []string{"dev"},
+			RequestedBy: [][]string{{"acme/my-app:1.0.0"}},
+		},
+		{
+			Id:     "psr/log:3.0.0",
+			Type:   "zip",
+			Scopes: []string{"prod"},
+			RequestedBy: [][]string{
+				{"monolog/monolog:3.5.0", "acme/my-app:1.0.0"},
+				{"phpunit/phpunit:10.5.0", "acme/my-app:1.0.0"},
+			},
+		},
+	}
+	assert.Equal(t, expected, dependencies)
+}
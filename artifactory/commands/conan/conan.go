@@ -0,0 +1,425 @@
+package conan
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The names of the remotes which are added to the Conan configuration.
+	resolutionRemoteName = "artifactory"
+	deploymentRemoteName = "artifactory-deploy"
+
+	conanModuleType buildinfo.ModuleType = "conan"
+)
+
+// Runs any Conan 2 command, with the packages resolved from an Artifactory Conan repository, which is added as the first Conan remote.
+// The upload command uploads to the deployment repository, unless another remote is specified.
+// If build-info collection was requested, the dependencies of install and create are collected from the Conan graph, or from the lockfile,
+// and the uploaded recipes and packages are recorded as artifacts.
+type ConanCommand struct {
+	cmdName               string
+	args                  []string
+	configFilePath        string
+	executablePath        string
+	workingDirectory      string
+	repo                  string
+	deployRepo            string
+	serverDetails         *config.ServerDetails
+	deployerServerDetails *config.ServerDetails
+	buildConfiguration    *build.BuildConfiguration
+}
+
+func NewConanCommand(cmdName string) *ConanCommand {
+	return &ConanCommand{cmdName: cmdName}
+}
+
+func (cc *ConanCommand) SetConfigFilePath(configFilePath string) *ConanCommand {
+	cc.configFilePath = configFilePath
+	return cc
+}
+
+func (cc *ConanCommand) SetArgs(args []string) *ConanCommand {
+	cc.args = args
+	return cc
+}
+
+func (cc *ConanCommand) ServerDetails() (*config.ServerDetails, error) {
+	if cc.isUpload() {
+		return cc.deployerServerDetails, nil
+	}
+	return cc.serverDetails, nil
+}
+
+func (cc *ConanCommand) CommandName() string {
+	return "rt_conan_" + cc.cmdName
+}
+
+func (cc *ConanCommand) isUpload() bool {
+	return cc.cmdName == "upload"
+}
+
+func (cc *ConanCommand) collectsDependencies() bool {
+	return cc.cmdName == "install" || cc.cmdName == "create"
+}
+
+// The resolution repository is required by all commands but upload, which requires the deployment repository.
+func (cc *ConanCommand) Init() (err error) {
+	log.Debug("Preparing to read the config file", cc.configFilePath)
+	vConfig, err := project.ReadConfigFile(cc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	if cc.isUpload() {
+		deployerParams, err := project.GetRepoConfigByPrefix(cc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		cc.deployRepo = deployerParams.TargetRepo()
+		if cc.deployerServerDetails, err = deployerParams.ServerDetails(); err != nil {
+			return err
+		}
+	} else {
+		resolverParams, err := project.GetRepoConfigByPrefix(cc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		cc.repo = resolverParams.TargetRepo()
+		if cc.serverDetails, err = resolverParams.ServerDetails(); err != nil {
+			return err
+		}
+	}
+	cc.args, cc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(cc.args)
+	return err
+}
+
+func (cc *ConanCommand) Run() (err error) {
+	log.Info("Running conan " + cc.cmdName + "...")
+	if cc.executablePath, err = exec.LookPath("conan"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if cc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return err
+	}
+	collectBuildInfo, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	env := os.Environ()
+	cmdArgs := append([]string{cc.cmdName}, cc.args...)
+	if cc.isUpload() {
+		if err = cc.addRemote(deploymentRemoteName, cc.deployerServerDetails, cc.deployRepo, false); err != nil {
+			return err
+		}
+		env = append(env, createCredentialsEnv(deploymentRemoteName, cc.deployerServerDetails)...)
+		if !hasRemoteFlag(cc.args) {
+			cmdArgs = append(cmdArgs, "--remote", deploymentRemoteName)
+		}
+	} else {
+		if err = cc.addRemote(resolutionRemoteName, cc.serverDetails, cc.repo, true); err != nil {
+			return err
+		}
+		env = append(env, createCredentialsEnv(resolutionRemoteName, cc.serverDetails)...)
+	}
+
+	// The graph and the uploaded packages are read from the JSON output of Conan.
+	captureJson := collectBuildInfo && (cc.collectsDependencies() || cc.isUpload())
+	format, printJson, err := getFormat(cc.args)
+	if err != nil {
+		return err
+	}
+	if captureJson && format == "" {
+		cmdArgs = append(cmdArgs, "--format=json")
+	}
+	captureJson = captureJson && (format == "" || format == "json")
+	output, err := cc.runConan(env, cmdArgs, captureJson, printJson)
+	if err != nil {
+		return err
+	}
+	if collectBuildInfo {
+		if cc.isUpload() {
+			err = cc.collectUploadedArtifacts(output)
+		} else if cc.collectsDependencies() {
+			err = cc.collectDependencies(output)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	log.Info("conan " + cc.cmdName + " finished successfully.")
+	return nil
+}
+
+// Adds the remote of the Artifactory repository, or updates it if it already exists.
+// The credentials aren't stored in the Conan configuration. They're passed to Conan through environment variables.
+func (cc *ConanCommand) addRemote(remoteName string, serverDetails *config.ServerDetails, repo string, first bool) error {
+	args := []string{"remote", "add", remoteName, GetRemoteUrl(serverDetails.GetArtifactoryUrl(), repo), "--force"}
+	if first {
+		args = append(args, "--index", "0")
+	}
+	command := exec.Command(cc.executablePath, args...)
+	command.Dir = cc.workingDirectory
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	if output, err := command.CombinedOutput(); err != nil {
+		return errorutils.CheckErrorf("failed adding the %s Conan remote: %s", remoteName, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func GetRemoteUrl(artifactoryUrl, repo string) string {
+	return strings.TrimSuffix(artifactoryUrl, "/") + "/api/conan/" + repo
+}
+
+// Conan reads the credentials of a remote from the CONAN_LOGIN_USERNAME_<REMOTE> and CONAN_PASSWORD_<REMOTE> environment variables.
+func createCredentialsEnv(remoteName string, serverDetails *config.ServerDetails) []string {
+	username, password := projectconfig.GetBasicAuthCredentials(serverDetails)
+	if password == "" {
+		return nil
+	}
+	envSuffix := strings.ToUpper(strings.ReplaceAll(remoteName, "-", "_"))
+	return []string{"CONAN_LOGIN_USERNAME_" + envSuffix + "=" + username, "CONAN_PASSWORD_" + envSuffix + "=" + password}
+}
+
+// Runs Conan with the given arguments. If captureOutput is true, the standard output is returned, and is printed only if printOutput is true.
+func (cc *ConanCommand) runConan(env, args []string, captureOutput, printOutput bool) ([]byte, error) {
+	output := new(bytes.Buffer)
+	command := exec.Command(cc.executablePath, args...)
+	command.Dir = cc.workingDirectory
+	command.Env = env
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if captureOutput {
+		command.Stdout = output
+		if printOutput {
+			command.Stdout = io.MultiWriter(os.Stdout, output)
+		}
+	}
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	if err := command.Run(); err != nil {
+		return nil, errorutils.CheckErrorf("conan %s failed: %s", cc.cmdName, err.Error())
+	}
+	return output.Bytes(), nil
+}
+
+// Returns the value of the --format option, and whether its output should be printed, which is the case if the format was set by the user.
+func getFormat(args []string) (format string, isSet bool, err error) {
+	for _, flagName := range []string{"--format", "-f"} {
+		_, _, format, err = coreutils.FindFlag(flagName, args)
+		if err != nil || format != "" {
+			return format, format != "", err
+		}
+	}
+	return "", false, nil
+}
+
+func hasRemoteFlag(args []string) bool {
+	for _, arg := range args {
+		if arg == "-r" || arg == "--remote" || strings.HasPrefix(arg, "-r=") || strings.HasPrefix(arg, "--remote=") {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the value of the --remote option, or an empty string if it isn't set.
+func getRemote(args []string) string {
+	for _, flagName := range []string{"--remote", "-r"} {
+		if _, _, remote, err := coreutils.FindFlag(flagName, args); err == nil && remote != "" {
+			return remote
+		}
+	}
+	return ""
+}
+
+func (cc *ConanCommand) collectDependencies(graphOutput []byte) error {
+	moduleId := cc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = filepath.Base(cc.workingDirectory)
+	}
+	var references []resolvedReference
+	var err error
+	if len(graphOutput) > 0 {
+		log.Info("Collecting dependencies from the Conan graph...")
+		if references, moduleId, err = cc.getGraphReferences(graphOutput, moduleId); err != nil {
+			return err
+		}
+	} else {
+		// The graph isn't available if another output format was requested, so the lockfile is used instead.
+		_, _, lockfilePath, err := coreutils.FindFlag("--lockfile-out", cc.args)
+		if err != nil {
+			return err
+		}
+		if lockfilePath == "" {
+			log.Warn("The Conan graph isn't available since the output format isn't JSON, and no --lockfile-out was given, so the dependencies are not collected into the build-info.")
+			return nil
+		}
+		log.Info("Collecting dependencies from " + lockfilePath + "...")
+		content, err := os.ReadFile(filepath.Join(cc.workingDirectory, lockfilePath))
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if references, err = parseLockfile(content); err != nil {
+			return err
+		}
+	}
+	servicesManager, err := utils.CreateServiceManager(cc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	var dependencies []buildinfo.Dependency
+	var missingDependencies []string
+	for _, reference := range references {
+		referenceDependencies := cc.getReferenceDependencies(servicesManager, reference)
+		if len(referenceDependencies) == 0 {
+			missingDependencies = append(missingDependencies, reference.recipeId())
+			referenceDependencies = []buildinfo.Dependency{{Id: reference.recipeId(), Scopes: []string{reference.Context}, RequestedBy: reference.RequestedBy}}
+		}
+		dependencies = append(dependencies, referenceDependencies...)
+	}
+	commandUtils.PrintMissingDependencies(missingDependencies)
+	return projectconfig.SaveModule(cc.buildConfiguration, conanModuleType, moduleId, func(partial *buildinfo.Partial) {
+		partial.Dependencies = dependencies
+	})
+}
+
+// For conan create, the module is the created reference, and only its requirements are dependencies.
+func (cc *ConanCommand) getGraphReferences(graphOutput []byte, moduleId string) ([]resolvedReference, string, error) {
+	graph, err := parseGraph(graphOutput)
+	if err != nil {
+		return nil, "", err
+	}
+	rootNodeIds := []string{rootNodeId}
+	if cc.cmdName == "create" {
+		if createdNodeIds := graph.createdNodeIds(); len(createdNodeIds) > 0 {
+			rootNodeIds = createdNodeIds
+			if cc.buildConfiguration.GetModule() == "" {
+				created, err := parseReference(graph.Graph.Nodes[createdNodeIds[0]].Ref)
+				if err != nil {
+					return nil, "", err
+				}
+				moduleId = created.String()
+			}
+		}
+	}
+	references, err := graph.resolveReferences(rootNodeIds, moduleId)
+	return references, moduleId, err
+}
+
+// Returns a dependency for each file of the recipe, and of its package if the package was resolved rather than built.
+func (cc *ConanCommand) getReferenceDependencies(servicesManager artifactory.ArtifactoryServicesManager, reference resolvedReference) []buildinfo.Dependency {
+	if reference.RecipeRevision == "" {
+		return nil
+	}
+	var dependencies []buildinfo.Dependency
+	addFiles := func(id, folderPath string) {
+		for _, file := range getFolderFiles(servicesManager, cc.repo, folderPath) {
+			dependencies = append(dependencies, buildinfo.Dependency{
+				Id:          id + " :: " + file.Name,
+				Type:        file.Type,
+				Scopes:      []string{reference.Context},
+				RequestedBy: reference.RequestedBy,
+				Checksum:    file.Checksum,
+			})
+		}
+	}
+	addFiles(reference.recipeId(), reference.recipePath())
+	if reference.hasPackage() {
+		addFiles(reference.packageId(), reference.packagePath())
+	}
+	return dependencies
+}
+
+func (cc *ConanCommand) collectUploadedArtifacts(uploadOutput []byte) error {
+	if len(uploadOutput) == 0 {
+		log.Warn("The uploaded packages aren't available since the output format isn't JSON, so no artifacts are added to the build-info.")
+		return nil
+	}
+	references, err := parseUploadOutput(uploadOutput)
+	if err != nil {
+		return err
+	}
+	if len(references) == 0 {
+		log.Warn("No recipes were uploaded, so no artifacts are added to the build-info.")
+		return nil
+	}
+	if remote := getRemote(cc.args); remote != "" && remote != deploymentRemoteName {
+		log.Warn("The packages were uploaded to the " + remote + " remote rather than to the deployment repository, so no artifacts are added to the build-info.")
+		return nil
+	}
+	servicesManager, err := utils.CreateServiceManager(cc.deployerServerDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	var artifacts []buildinfo.Artifact
+	for _, reference := range references {
+		folderPath := reference.recipePath()
+		if reference.hasPackage() {
+			folderPath = reference.packagePath()
+		}
+		for _, file := range getFolderFiles(servicesManager, cc.deployRepo, folderPath) {
+			artifacts = append(artifacts, buildinfo.Artifact{
+				Name:                   file.Name,
+				Type:                   file.Type,
+				Path:                   folderPath + "/" + file.Name,
+				OriginalDeploymentRepo: cc.deployRepo,
+				Checksum:               file.Checksum,
+			})
+		}
+	}
+	moduleId := cc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = references[0].String()
+	}
+	return projectconfig.SaveModule(cc.buildConfiguration, conanModuleType, moduleId, func(partial *buildinfo.Partial) {
+		partial.Artifacts = artifacts
+	})
+}
+
+type repositoryFile struct {
+	Name     string
+	Type     string
+	Checksum buildinfo.Checksum
+}
+
+// Returns the files of a folder in the repository, with their checksums. If the folder can't be read, no files are returned.
+func getFolderFiles(servicesManager artifactory.ArtifactoryServicesManager, repo, folderPath string) []repositoryFile {
+	folderInfo, err := servicesManager.FolderInfo(repo + "/" + folderPath)
+	if err != nil {
+		log.Debug("Failed reading " + repo + "/" + folderPath + ": " + err.Error())
+		return nil
+	}
+	var files []repositoryFile
+	for _, child := range folderInfo.Children {
+		if child.Folder {
+			continue
+		}
+		fileName := strings.TrimPrefix(child.Uri, "/")
+		fileInfo, err := servicesManager.FileInfo(repo + "/" + folderPath + "/" + fileName)
+		if err != nil {
+			log.Debug("Failed reading " + repo + "/" + folderPath + "/" + fileName + ": " + err.Error())
+			continue
+		}
+		files = append(files, repositoryFile{
+			Name:     fileName,
+			Type:     strings.TrimPrefix(path.Ext(fileName), "."),
+			Checksum: buildinfo.Checksum{Sha1: fileInfo.Checksums.Sha1, Md5: fileInfo.Checksums.Md5, Sha256: fileInfo.Checksums.Sha256},
+		})
+	}
+	return files
+}
@@ -0,0 +1,83 @@
+package conan
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	reference, err := parseReference("cmake/3.28.1@tools/stable#e5f6%1700000002.0")
+	require.NoError(t, err)
+	assert.Equal(t, conanReference{Name: "cmake", Version: "3.28.1", User: "tools", Channel: "stable", RecipeRevision: "e5f6"}, reference)
+	assert.Equal(t, "cmake/3.28.1@tools/stable", reference.String())
+	assert.Equal(t, "cmake/3.28.1@tools/stable#e5f6", reference.recipeId())
+	assert.Equal(t, "tools/cmake/3.28.1/stable/e5f6/export", reference.recipePath())
+
+	reference, err = parseReference("zlib/1.3.1")
+	require.NoError(t, err)
+	assert.Equal(t, "zlib/1.3.1", reference.recipeId())
+	reference.RecipeRevision, reference.PackageId, reference.PackageRevision = "c3d4", "p2", "r2"
+	assert.Equal(t, "zlib/1.3.1#c3d4:p2#r2", reference.packageId())
+	assert.Equal(t, "_/zlib/1.3.1/_/c3d4/package/p2/r2", reference.packagePath())
+
+	_, err = parseReference("conanfile")
+	assert.Error(t, err)
+}
+
+func TestParseUploadOutput(t *testing.T) {
+	output := `{
+  "artifactory-deploy": {
+    "my-lib/1.0": {
+      "revisions": {
+        "aaaa": {
+          "timestamp": 1700000000.0,
+          "upload": true,
+          "packages": {
+            "p1": {"info": {"settings": {"os": "Linux"}}, "revisions": {"r1": {"timestamp": 1700000001.0, "upload": true}}},
+            "p0": {"info": {"settings": {"os": "Windows"}}, "revisions": {"r0": {"timestamp": 1700000002.0, "upload": true}}}
+          }
+        }
+      }
+    }
+  }
+}`
+	references, err := parseUploadOutput([]byte(output))
+	require.NoError(t, err)
+	assert.Equal(t, []conanReference{
+		{Name: "my-lib", Version: "1.0", RecipeRevision: "aaaa"},
+		{Name: "my-lib", Version: "1.0", RecipeRevision: "aaaa", PackageId: "p0", PackageRevision: "r0"},
+		{Name: "my-lib", Version: "1.0", RecipeRevision: "aaaa", PackageId: "p1", PackageRevision: "r1"},
+	}, references)
+}
+
+func TestCreateCredentialsEnv(t *testing.T) {
+	env := createCredentialsEnv(deploymentRemoteName, &config.ServerDetails{User: "admin", Password: "password"})
+	assert.Equal(t, []string{"CONAN_LOGIN_USERNAME_ARTIFACTORY_DEPLOY=admin", "CONAN_PASSWORD_ARTIFACTORY_DEPLOY=password"}, env)
+	assert.Empty(t, createCredentialsEnv(resolutionRemoteName, &config.ServerDetails{}))
+}
+
+func TestGetFormat(t *testing.T) {
+	testCases := []struct {
+		args           []string
+		expectedFormat string
+	}{
+		{[]string{".", "--build=missing"}, ""},
+		{[]string{".", "--format=json"}, "json"},
+		{[]string{".", "-f", "html"}, "html"},
+	}
+	for _, testCase := range testCases {
+		format, isSet, err := getFormat(testCase.args)
+		require.NoError(t, err)
+		assert.Equal(t, testCase.expectedFormat, format)
+		assert.Equal(t, testCase.expectedFormat != "", isSet)
+	}
+}
+
+func TestGetRemote(t *testing.T) {
+	assert.Equal(t, "conancenter", getRemote([]string{"*", "-r", "conancenter", "-c"}))
+	assert.Equal(t, "other", getRemote([]string{"*", "--remote=other"}))
+	assert.Empty(t, getRemote([]string{"*", "-c"}))
+}
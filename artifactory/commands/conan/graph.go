@@ -0,0 +1,167 @@
+package conan
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The ID of the root node of the graph, which is the consumer conanfile or the command line requirements.
+const rootNodeId = "0"
+
+// The binary statuses of packages which were taken from the cache or downloaded, rather than built locally or skipped.
+var resolvedBinaries = map[string]bool{"Cache": true, "Download": true, "Update": true}
+
+// The dependency graph printed by 'conan install/create --format=json'.
+type conanGraph struct {
+	Graph struct {
+		Nodes map[string]graphNode `json:"nodes"`
+	} `json:"graph"`
+}
+
+type graphNode struct {
+	Ref             string               `json:"ref"`
+	RecipeRevision  string               `json:"rrev"`
+	PackageId       string               `json:"package_id"`
+	PackageRevision string               `json:"prev"`
+	Binary          string               `json:"binary"`
+	Context         string               `json:"context"`
+	Dependencies    map[string]graphEdge `json:"dependencies"`
+}
+
+type graphEdge struct {
+	Direct bool `json:"direct"`
+}
+
+// A reference which the project depends on, with the package of the reference if it was resolved from a remote or the cache.
+type resolvedReference struct {
+	conanReference
+	Context     string
+	RequestedBy [][]string
+}
+
+func parseGraph(content []byte) (*conanGraph, error) {
+	graph := new(conanGraph)
+	if err := json.Unmarshal(content, graph); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the Conan graph: %s", err.Error())
+	}
+	if _, exists := graph.Graph.Nodes[rootNodeId]; !exists {
+		return nil, errorutils.CheckErrorf("the Conan graph has no root node")
+	}
+	return graph, nil
+}
+
+// Returns the node IDs of the references which are created by 'conan create'. They are the direct requirements of the root node.
+func (cg *conanGraph) createdNodeIds() []string {
+	var nodeIds []string
+	for nodeId, edge := range cg.Graph.Nodes[rootNodeId].Dependencies {
+		if edge.Direct {
+			nodeIds = append(nodeIds, nodeId)
+		}
+	}
+	sortNodeIds(nodeIds)
+	return nodeIds
+}
+
+// Returns the references which are required by the given root nodes, directly or transitively.
+// The root nodes are excluded, and rootModuleId is the root of all the dependency paths.
+func (cg *conanGraph) resolveReferences(rootNodeIds []string, rootModuleId string) ([]resolvedReference, error) {
+	pathsToRoot := make(map[string][]string)
+	queue := append([]string(nil), rootNodeIds...)
+	for _, nodeId := range rootNodeIds {
+		pathsToRoot[nodeId] = []string{rootModuleId}
+	}
+	references := make(map[string]*resolvedReference)
+	for len(queue) > 0 {
+		parentId := queue[0]
+		queue = queue[1:]
+		parent := cg.Graph.Nodes[parentId]
+		childIds := make([]string, 0, len(parent.Dependencies))
+		for childId := range parent.Dependencies {
+			childIds = append(childIds, childId)
+		}
+		sortNodeIds(childIds)
+		for _, childId := range childIds {
+			child, exists := cg.Graph.Nodes[childId]
+			if !exists {
+				continue
+			}
+			reference, exists := references[childId]
+			if !exists {
+				conanRef, err := parseReference(child.Ref)
+				if err != nil {
+					return nil, err
+				}
+				if conanRef.RecipeRevision == "" {
+					conanRef.RecipeRevision = child.RecipeRevision
+				}
+				if resolvedBinaries[child.Binary] {
+					conanRef.PackageId, conanRef.PackageRevision = child.PackageId, child.PackageRevision
+				}
+				reference = &resolvedReference{conanReference: conanRef, Context: child.Context}
+				references[childId] = reference
+			}
+			reference.RequestedBy = append(reference.RequestedBy, pathsToRoot[parentId])
+			if _, visited := pathsToRoot[childId]; !visited {
+				pathsToRoot[childId] = append([]string{reference.recipeId()}, pathsToRoot[parentId]...)
+				queue = append(queue, childId)
+			}
+		}
+	}
+	// Root nodes which are also required by other roots aren't dependencies.
+	for _, nodeId := range rootNodeIds {
+		delete(references, nodeId)
+	}
+	return sortReferences(references), nil
+}
+
+// The lockfile created by 'conan lock create' or by the --lockfile-out option.
+// It lists the references without the dependency paths and the packages.
+type conanLockfile struct {
+	Requires       []string `json:"requires"`
+	BuildRequires  []string `json:"build_requires"`
+	PythonRequires []string `json:"python_requires"`
+}
+
+func parseLockfile(content []byte) ([]resolvedReference, error) {
+	lockfile := new(conanLockfile)
+	if err := json.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the Conan lockfile: %s", err.Error())
+	}
+	references := make(map[string]*resolvedReference)
+	for context, refs := range map[string][]string{"host": lockfile.Requires, "build": lockfile.BuildRequires, "python": lockfile.PythonRequires} {
+		for _, ref := range refs {
+			conanRef, err := parseReference(ref)
+			if err != nil {
+				return nil, err
+			}
+			references[conanRef.recipeId()] = &resolvedReference{conanReference: conanRef, Context: context}
+		}
+	}
+	return sortReferences(references), nil
+}
+
+func sortReferences(references map[string]*resolvedReference) []resolvedReference {
+	result := make([]resolvedReference, 0, len(references))
+	for _, reference := range references {
+		result = append(result, *reference)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].recipeId() < result[j].recipeId()
+	})
+	return result
+}
+
+// The node IDs are numbers, which are sorted numerically, so the traversal follows the order of the graph.
+func sortNodeIds(nodeIds []string) {
+	sort.Slice(nodeIds, func(i, j int) bool {
+		first, firstErr := strconv.Atoi(nodeIds[i])
+		second, secondErr := strconv.Atoi(nodeIds[j])
+		if firstErr != nil || secondErr != nil {
+			return nodeIds[i] < nodeIds[j]
+		}
+		return first < second
+	})
+}
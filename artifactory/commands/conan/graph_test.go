@@ -0,0 +1,86 @@
+package conan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The graph of a project which requires openssl, which requires zlib. cmake is a tool requirement, and its binary was built locally.
+const installGraph = `{
+  "graph": {
+    "nodes": {
+      "0": {"ref": "conanfile", "id": "0", "recipe": "Consumer", "rrev": null, "package_id": null, "prev": null, "binary": null, "context": "host",
+        "dependencies": {"1": {"ref": "openssl/3.2.1", "direct": true}, "2": {"ref": "zlib/1.3.1", "direct": false}, "3": {"ref": "cmake/3.28.1", "direct": true, "build": true}}},
+      "1": {"ref": "openssl/3.2.1#a1b2", "id": "1", "recipe": "Downloaded", "rrev": "a1b2", "package_id": "p1", "prev": "r1", "binary": "Download", "context": "host",
+        "dependencies": {"2": {"ref": "zlib/1.3.1", "direct": true}}},
+      "2": {"ref": "zlib/1.3.1#c3d4", "id": "2", "recipe": "Cache", "rrev": "c3d4", "package_id": "p2", "prev": "r2", "binary": "Cache", "context": "host", "dependencies": {}},
+      "3": {"ref": "cmake/3.28.1@tools/stable#e5f6", "id": "3", "recipe": "Downloaded", "rrev": "e5f6", "package_id": "p3", "prev": null, "binary": "Build", "context": "build", "dependencies": {}}
+    },
+    "root": {"0": "None"}
+  }
+}`
+
+func TestResolveReferences(t *testing.T) {
+	graph, err := parseGraph([]byte(installGraph))
+	require.NoError(t, err)
+	references, err := graph.resolveReferences([]string{rootNodeId}, "my-app")
+	require.NoError(t, err)
+	expected := []resolvedReference{
+		{
+			conanReference: conanReference{Name: "cmake", Version: "3.28.1", User: "tools", Channel: "stable", RecipeRevision: "e5f6"},
+			Context:        "build",
+			RequestedBy:    [][]string{{"my-app"}},
+		},
+		{
+			conanReference: conanReference{Name: "openssl", Version: "3.2.1", RecipeRevision: "a1b2", PackageId: "p1", PackageRevision: "r1"},
+			Context:        "host",
+			RequestedBy:    [][]string{{"my-app"}},
+		},
+		{
+			conanReference: conanReference{Name: "zlib", Version: "1.3.1", RecipeRevision: "c3d4", PackageId: "p2", PackageRevision: "r2"},
+			Context:        "host",
+			RequestedBy:    [][]string{{"my-app"}, {"openssl/3.2.1#a1b2", "my-app"}},
+		},
+	}
+	assert.Equal(t, expected, references)
+}
+
+func TestResolveCreatedReferences(t *testing.T) {
+	graph, err := parseGraph([]byte(`{"graph": {"nodes": {
+      "0": {"ref": "", "dependencies": {"1": {"direct": true}}},
+      "1": {"ref": "my-lib/1.0#aaaa", "rrev": "aaaa", "package_id": "p0", "binary": "Build", "dependencies": {"2": {"direct": true}}},
+      "2": {"ref": "zlib/1.3.1#c3d4", "rrev": "c3d4", "package_id": "p2", "prev": "r2", "binary": "Download", "context": "host"}
+    }}}`))
+	require.NoError(t, err)
+	createdNodeIds := graph.createdNodeIds()
+	assert.Equal(t, []string{"1"}, createdNodeIds)
+	references, err := graph.resolveReferences(createdNodeIds, "my-lib/1.0")
+	require.NoError(t, err)
+	require.Len(t, references, 1)
+	assert.Equal(t, "zlib/1.3.1#c3d4:p2#r2", references[0].packageId())
+	assert.Equal(t, [][]string{{"my-lib/1.0"}}, references[0].RequestedBy)
+}
+
+func TestParseGraphWithoutRoot(t *testing.T) {
+	_, err := parseGraph([]byte(`{"graph": {"nodes": {}}}`))
+	assert.ErrorContains(t, err, "no root node")
+}
+
+func TestParseLockfile(t *testing.T) {
+	lockfile := `{
+    "version": "0.5",
+    "requires": ["zlib/1.3.1#c3d4%1700000000.0", "openssl/3.2.1#a1b2%1700000001.0"],
+    "build_requires": ["cmake/3.28.1@tools/stable#e5f6%1700000002.0"],
+    "python_requires": []
+}`
+	references, err := parseLockfile([]byte(lockfile))
+	require.NoError(t, err)
+	expected := []resolvedReference{
+		{conanReference: conanReference{Name: "cmake", Version: "3.28.1", User: "tools", Channel: "stable", RecipeRevision: "e5f6"}, Context: "build"},
+		{conanReference: conanReference{Name: "openssl", Version: "3.2.1", RecipeRevision: "a1b2"}, Context: "host"},
+		{conanReference: conanReference{Name: "zlib", Version: "1.3.1", RecipeRevision: "c3d4"}, Context: "host"},
+	}
+	assert.Equal(t, expected, references)
+}
@@ -0,0 +1,77 @@
+package conan
+
+import (
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Artifactory stores references without a user and channel under "_".
+const emptyUserOrChannel = "_"
+
+// A recipe reference, such as zlib/1.2.13@user/channel#<recipe revision>, optionally with a package of the recipe.
+type conanReference struct {
+	Name            string
+	Version         string
+	User            string
+	Channel         string
+	RecipeRevision  string
+	PackageId       string
+	PackageRevision string
+}
+
+// Parses a reference in the format name/version[@user/channel][#revision[%timestamp]], as printed by Conan and listed in lockfiles.
+func parseReference(ref string) (conanReference, error) {
+	reference := conanReference{}
+	ref, revision, _ := strings.Cut(ref, "#")
+	reference.RecipeRevision, _, _ = strings.Cut(revision, "%")
+	nameVersion, userChannel, hasUserChannel := strings.Cut(ref, "@")
+	var found bool
+	if reference.Name, reference.Version, found = strings.Cut(nameVersion, "/"); !found || reference.Name == "" || reference.Version == "" {
+		return reference, errorutils.CheckErrorf("unexpected Conan reference '%s'", ref)
+	}
+	if hasUserChannel {
+		reference.User, reference.Channel, _ = strings.Cut(userChannel, "/")
+	}
+	return reference, nil
+}
+
+func (cr *conanReference) String() string {
+	ref := cr.Name + "/" + cr.Version
+	if cr.User != "" {
+		ref += "@" + cr.User + "/" + cr.Channel
+	}
+	return ref
+}
+
+func (cr *conanReference) recipeId() string {
+	if cr.RecipeRevision == "" {
+		return cr.String()
+	}
+	return cr.String() + "#" + cr.RecipeRevision
+}
+
+func (cr *conanReference) hasPackage() bool {
+	return cr.PackageId != "" && cr.PackageRevision != ""
+}
+
+func (cr *conanReference) packageId() string {
+	return cr.recipeId() + ":" + cr.PackageId + "#" + cr.PackageRevision
+}
+
+// Returns the path of the recipe files in an Artifactory Conan repository: <user>/<name>/<version>/<channel>/<revision>/export.
+func (cr *conanReference) recipePath() string {
+	return strings.Join([]string{orEmpty(cr.User), cr.Name, cr.Version, orEmpty(cr.Channel), cr.RecipeRevision, "export"}, "/")
+}
+
+// Returns the path of the package files in an Artifactory Conan repository: <user>/<name>/<version>/<channel>/<revision>/package/<package ID>/<package revision>.
+func (cr *conanReference) packagePath() string {
+	return strings.Join([]string{orEmpty(cr.User), cr.Name, cr.Version, orEmpty(cr.Channel), cr.RecipeRevision, "package", cr.PackageId, cr.PackageRevision}, "/")
+}
+
+func orEmpty(userOrChannel string) string {
+	if userOrChannel == "" {
+		return emptyUserOrChannel
+	}
+	return userOrChannel
+}
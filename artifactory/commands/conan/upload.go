@@ -0,0 +1,54 @@
+package conan
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The package list printed by 'conan upload --format=json', keyed by the remote and then by the reference, for example:
+// {"artifactory": {"zlib/1.2.13": {"revisions": {"<rrev>": {"packages": {"<package ID>": {"revisions": {"<prev>": {}}}}}}}}}
+type uploadedPackageList map[string]map[string]struct {
+	Revisions map[string]struct {
+		Packages map[string]struct {
+			Revisions map[string]json.RawMessage `json:"revisions"`
+		} `json:"packages"`
+	} `json:"revisions"`
+}
+
+// Returns the uploaded recipe revisions, and the uploaded packages of each revision.
+// Recipe revisions are returned without a package ID, and before their packages.
+func parseUploadOutput(content []byte) ([]conanReference, error) {
+	packageList := uploadedPackageList{}
+	if err := json.Unmarshal(content, &packageList); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the output of conan upload: %s", err.Error())
+	}
+	var references []conanReference
+	for _, recipes := range packageList {
+		for ref, recipe := range recipes {
+			recipeRef, err := parseReference(ref)
+			if err != nil {
+				return nil, err
+			}
+			for recipeRevision, revision := range recipe.Revisions {
+				recipeRef.RecipeRevision = recipeRevision
+				references = append(references, recipeRef)
+				for packageId, pkg := range revision.Packages {
+					for packageRevision := range pkg.Revisions {
+						packageRef := recipeRef
+						packageRef.PackageId, packageRef.PackageRevision = packageId, packageRevision
+						references = append(references, packageRef)
+					}
+				}
+			}
+		}
+	}
+	sort.Slice(references, func(i, j int) bool {
+		if references[i].recipeId() != references[j].recipeId() {
+			return references[i].recipeId() < references[j].recipeId()
+		}
+		return references[i].PackageId+references[i].PackageRevision < references[j].PackageId+references[j].PackageRevision
+	})
+	return references, nil
+}
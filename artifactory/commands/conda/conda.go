@@ -0,0 +1,410 @@
+package conda
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/bzip2"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The publish command isn't a conda command. It's implemented by the CLI, since conda has no command which uploads packages.
+	publishCommandName = "publish"
+	noarchSubdir       = "noarch"
+	// The metadata file in conda packages, which includes the subdir (platform) of the package.
+	indexFilePath = "info/index.json"
+
+	condaModuleType buildinfo.ModuleType = "conda"
+)
+
+// The commands which install packages into an environment, so their dependencies are collected.
+var installCommands = map[string]bool{"create": true, "install": true, "update": true, "upgrade": true}
+
+// The operating systems of the conda subdirs, such as linux-64 and osx-arm64.
+var subdirPlatforms = []string{"linux", "osx", "win", "emscripten", "wasi", "zos"}
+
+// Runs any conda command, with the channels resolved from an Artifactory Conda repository.
+// The publish command uploads packages to the deployment repository, under the subdir of each package.
+// If build-info collection was requested, the packages of the environment are recorded as dependencies, and the published packages are recorded as artifacts.
+type CondaCommand struct {
+	cmdName               string
+	args                  []string
+	configFilePath        string
+	executablePath        string
+	workingDirectory      string
+	repo                  string
+	deployRepo            string
+	serverDetails         *config.ServerDetails
+	deployerServerDetails *config.ServerDetails
+	buildConfiguration    *build.BuildConfiguration
+}
+
+func NewCondaCommand(cmdName string) *CondaCommand {
+	return &CondaCommand{cmdName: cmdName}
+}
+
+func (cc *CondaCommand) SetConfigFilePath(configFilePath string) *CondaCommand {
+	cc.configFilePath = configFilePath
+	return cc
+}
+
+func (cc *CondaCommand) SetArgs(args []string) *CondaCommand {
+	cc.args = args
+	return cc
+}
+
+func (cc *CondaCommand) ServerDetails() (*config.ServerDetails, error) {
+	if cc.isPublish() {
+		return cc.deployerServerDetails, nil
+	}
+	return cc.serverDetails, nil
+}
+
+func (cc *CondaCommand) CommandName() string {
+	return "rt_conda_" + cc.cmdName
+}
+
+func (cc *CondaCommand) isPublish() bool {
+	return cc.cmdName == publishCommandName
+}
+
+// The resolution repository is required by all commands but publish, which requires the deployment repository.
+func (cc *CondaCommand) Init() (err error) {
+	log.Debug("Preparing to read the config file", cc.configFilePath)
+	vConfig, err := project.ReadConfigFile(cc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	if cc.isPublish() {
+		deployerParams, err := project.GetRepoConfigByPrefix(cc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		cc.deployRepo = deployerParams.TargetRepo()
+		if cc.deployerServerDetails, err = deployerParams.ServerDetails(); err != nil {
+			return err
+		}
+	} else {
+		resolverParams, err := project.GetRepoConfigByPrefix(cc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		cc.repo = resolverParams.TargetRepo()
+		if cc.serverDetails, err = resolverParams.ServerDetails(); err != nil {
+			return err
+		}
+	}
+	cc.args, cc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(cc.args)
+	return err
+}
+
+func (cc *CondaCommand) Run() (err error) {
+	log.Info("Running conda " + cc.cmdName + "...")
+	if cc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return err
+	}
+	collectBuildInfo, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if cc.isPublish() {
+		err = cc.publish(collectBuildInfo)
+	} else {
+		err = cc.runWithResolution(collectBuildInfo)
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("conda " + cc.cmdName + " finished successfully.")
+	return nil
+}
+
+// conda reads its configuration from CONDA_<KEY> environment variables, which take precedence over the .condarc files.
+// The channel alias is set to the Artifactory repository, so channels which are referenced by name, such as conda-forge, are resolved from it.
+// The default channels are resolved from the main and r channels of the repository.
+func createCondaEnv(serverDetails *config.ServerDetails, repo string) ([]string, error) {
+	channelAlias, err := url.Parse(GetChannelAlias(serverDetails.GetArtifactoryUrl(), repo))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if username, password := projectconfig.GetBasicAuthCredentials(serverDetails); password != "" {
+		channelAlias.User = url.UserPassword(username, password)
+	}
+	return []string{
+		"CONDA_CHANNEL_ALIAS=" + channelAlias.String(),
+		"CONDA_DEFAULT_CHANNELS=" + channelAlias.String() + "/main," + channelAlias.String() + "/r",
+	}, nil
+}
+
+func GetChannelAlias(artifactoryUrl, repo string) string {
+	return strings.TrimSuffix(artifactoryUrl, "/") + "/api/conda/" + repo
+}
+
+func (cc *CondaCommand) runWithResolution(collectBuildInfo bool) (err error) {
+	if cc.executablePath, err = exec.LookPath("conda"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	env, err := createCondaEnv(cc.serverDetails, cc.repo)
+	if err != nil {
+		return err
+	}
+	return projectconfig.RunWithResolution(func() error {
+		command := cc.createCondaCommand(append(os.Environ(), env...), append([]string{cc.cmdName}, cc.args...)...)
+		command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+		log.Debug("Running command:", strings.Join(command.Args, " "))
+		if err := command.Run(); err != nil {
+			return errorutils.CheckErrorf("conda %s failed: %s", cc.cmdName, err.Error())
+		}
+		return nil
+	}, collectBuildInfo, installCommands[cc.cmdName], cc.collectDependencies)
+}
+
+func (cc *CondaCommand) createCondaCommand(env []string, args ...string) *exec.Cmd {
+	command := exec.Command(cc.executablePath, args...)
+	command.Dir = cc.workingDirectory
+	command.Env = env
+	return command
+}
+
+// Lists the packages of the environment with 'conda list --explicit --md5', which includes the URLs of the packages.
+// The environment is the one which was selected by the --name or --prefix option of the command, or the active environment.
+func (cc *CondaCommand) collectDependencies() error {
+	log.Info("Collecting the packages of the environment...")
+	listArgs := []string{"list", "--explicit", "--md5"}
+	environmentName := ""
+	for _, flagNames := range [][]string{{"-n", "--name"}, {"-p", "--prefix"}} {
+		for _, flagName := range flagNames {
+			_, _, value, err := coreutils.FindFlag(flagName, cc.args)
+			if err != nil {
+				return err
+			}
+			if value != "" {
+				listArgs = append(listArgs, flagName, value)
+				environmentName = filepath.Base(value)
+			}
+		}
+	}
+	command := cc.createCondaCommand(os.Environ(), listArgs...)
+	stderr := new(bytes.Buffer)
+	command.Stderr = stderr
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	output, err := command.Output()
+	if err != nil {
+		return errorutils.CheckErrorf("failed listing the packages of the environment: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	moduleId := cc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = environmentName
+	}
+	if moduleId == "" {
+		moduleId = filepath.Base(cc.workingDirectory)
+	}
+	packages, err := ParseExplicitLockfile(bytes.NewReader(output), moduleId)
+	if err != nil {
+		return err
+	}
+	dependencies, err := cc.getDependenciesWithChecksums(packages)
+	if err != nil {
+		return err
+	}
+	return projectconfig.SaveModule(cc.buildConfiguration, condaModuleType, moduleId, func(partial *buildinfo.Partial) {
+		partial.Dependencies = dependencies
+	})
+}
+
+// The checksums of the packages which were downloaded from the Artifactory repository are read from Artifactory, by the path of the package in the repository.
+func (cc *CondaCommand) getDependenciesWithChecksums(packages []explicitPackage) ([]buildinfo.Dependency, error) {
+	servicesManager, err := utils.CreateServiceManager(cc.serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	channelAlias := GetChannelAlias(cc.serverDetails.GetArtifactoryUrl(), cc.repo) + "/"
+	dependencies := make([]buildinfo.Dependency, 0, len(packages))
+	var missingDependencies []string
+	for _, pkg := range packages {
+		dependency := pkg.Dependency
+		relativePath, found := strings.CutPrefix(pkg.Url, channelAlias)
+		if !found {
+			log.Debug(dependency.Id + " was not downloaded from the Artifactory repository: " + pkg.Url)
+			missingDependencies = append(missingDependencies, dependency.Id)
+			dependencies = append(dependencies, dependency)
+			continue
+		}
+		fileInfo, err := servicesManager.FileInfo(cc.repo + "/" + relativePath)
+		if err != nil {
+			log.Debug("Failed reading the checksums of " + dependency.Id + ": " + err.Error())
+			missingDependencies = append(missingDependencies, dependency.Id)
+		} else {
+			dependency.Checksum = buildinfo.Checksum{Sha1: fileInfo.Checksums.Sha1, Md5: fileInfo.Checksums.Md5, Sha256: fileInfo.Checksums.Sha256}
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	commandUtils.PrintMissingDependencies(missingDependencies)
+	return dependencies, nil
+}
+
+// Uploads the packages to the deployment repository, under the subdir of each package, such as linux-64 or noarch.
+// The subdir is taken from the --subdir option, from the directory of the package if it's named after a subdir, as conda-build does,
+// or from the metadata of .tar.bz2 packages.
+func (cc *CondaCommand) publish(collectBuildInfo bool) error {
+	args := append([]string(nil), cc.args...)
+	flagIndex, valueIndex, subdir, err := coreutils.FindFlag("--subdir", args)
+	if err != nil {
+		return err
+	}
+	coreutils.RemoveFlagFromCommand(&args, flagIndex, valueIndex)
+	var packageFiles []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return errorutils.CheckErrorf("unsupported option for the publish command: %s", arg)
+		}
+		packageFiles = append(packageFiles, arg)
+	}
+	if len(packageFiles) == 0 {
+		return errorutils.CheckErrorf("the publish command expects the conda package files to upload. Use conda-build to build them")
+	}
+
+	servicesManager, err := utils.CreateServiceManager(cc.deployerServerDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	var buildProps string
+	if collectBuildInfo {
+		if buildProps, err = build.CreateBuildPropsFromConfiguration(cc.buildConfiguration); err != nil {
+			return err
+		}
+	}
+	var artifacts []buildinfo.Artifact
+	for _, packageFile := range packageFiles {
+		if !filepath.IsAbs(packageFile) {
+			packageFile = filepath.Join(cc.workingDirectory, packageFile)
+		}
+		fileName := filepath.Base(packageFile)
+		name, _, extension := splitPackageFileName(fileName)
+		if name == "" {
+			return errorutils.CheckErrorf("%s is not a conda package. Conda packages are .conda or .tar.bz2 files", fileName)
+		}
+		packageSubdir := subdir
+		if packageSubdir == "" {
+			if packageSubdir, err = getPackageSubdir(packageFile); err != nil {
+				return err
+			}
+		}
+		targetPath := packageSubdir + "/" + fileName
+		log.Info("Uploading " + fileName + " to " + cc.deployRepo + "/" + packageSubdir + "...")
+		uploadParams := services.NewUploadParams()
+		uploadParams.Pattern = packageFile
+		uploadParams.Target = cc.deployRepo + "/" + targetPath
+		uploadParams.BuildProps = buildProps
+		uploaded, failed, err := servicesManager.UploadFiles(uploadParams)
+		if err != nil {
+			return err
+		}
+		if failed > 0 || uploaded == 0 {
+			return errorutils.CheckErrorf("failed uploading %s to %s", fileName, cc.deployRepo)
+		}
+		fileDetails, err := fileutils.GetFileDetails(packageFile, true)
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, buildinfo.Artifact{
+			Name:                   fileName,
+			Type:                   strings.TrimPrefix(extension, "."),
+			Path:                   targetPath,
+			OriginalDeploymentRepo: cc.deployRepo,
+			Checksum:               buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256},
+		})
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	moduleId := cc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		name, version, _ := splitPackageFileName(artifacts[0].Name)
+		moduleId = name + ":" + version
+	}
+	return projectconfig.SaveModule(cc.buildConfiguration, condaModuleType, moduleId, func(partial *buildinfo.Partial) {
+		partial.Artifacts = artifacts
+	})
+}
+
+func getPackageSubdir(packageFile string) (string, error) {
+	if directoryName := filepath.Base(filepath.Dir(packageFile)); isSubdir(directoryName) {
+		return directoryName, nil
+	}
+	if strings.HasSuffix(packageFile, ".tar.bz2") {
+		return readSubdirFromTarBz2(packageFile)
+	}
+	return "", errorutils.CheckErrorf("the subdir of %s could not be determined. Use the --subdir option to set it", filepath.Base(packageFile))
+}
+
+// Subdirs are either noarch, or <platform>-<architecture>, such as linux-64, osx-arm64 and win-64.
+func isSubdir(name string) bool {
+	if name == noarchSubdir {
+		return true
+	}
+	platform, architecture, found := strings.Cut(name, "-")
+	if !found || architecture == "" {
+		return false
+	}
+	for _, subdirPlatform := range subdirPlatforms {
+		if platform == subdirPlatform {
+			return true
+		}
+	}
+	return false
+}
+
+func readSubdirFromTarBz2(packageFile string) (subdir string, err error) {
+	file, err := os.Open(packageFile)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	tarReader := tar.NewReader(bzip2.NewReader(file))
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return "", errorutils.CheckErrorf("%s was not found in %s", indexFilePath, filepath.Base(packageFile))
+		}
+		if err != nil {
+			return "", errorutils.CheckErrorf("failed reading %s: %s", filepath.Base(packageFile), err.Error())
+		}
+		if strings.TrimPrefix(header.Name, "./") != indexFilePath {
+			continue
+		}
+		var index struct {
+			Subdir string `json:"subdir"`
+		}
+		if err = json.NewDecoder(tarReader).Decode(&index); err != nil {
+			return "", errorutils.CheckErrorf("failed parsing %s in %s: %s", indexFilePath, filepath.Base(packageFile), err.Error())
+		}
+		if index.Subdir == "" {
+			return "", errorutils.CheckErrorf("the subdir is missing from %s in %s", indexFilePath, filepath.Base(packageFile))
+		}
+		return index.Subdir, nil
+	}
+}
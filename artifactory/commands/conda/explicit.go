@@ -0,0 +1,107 @@
+package conda
+
+import (
+	"bufio"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const explicitMarker = "@EXPLICIT"
+
+// The extensions of conda packages: the .conda format, and the legacy .tar.bz2 format.
+var packageExtensions = []string{".conda", ".tar.bz2"}
+
+var md5Pattern = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+
+// A package in an explicit lockfile, with the URL which it was downloaded from.
+type explicitPackage struct {
+	buildinfo.Dependency
+	Url string
+}
+
+// Parses an explicit lockfile, as created by 'conda list --explicit --md5'. For example:
+//
+//	# platform: linux-64
+//	@EXPLICIT
+//	https://conda.anaconda.org/conda-forge/linux-64/zlib-1.3.1-h4ab18f5_1.conda#9653f1bf3766164d0e65fa723cabbc54
+//
+// The MD5 checksum after the hash sign is optional. Newer versions of conda may list a SHA-256 checksum instead, as sha256:<checksum>.
+func ParseExplicitLockfile(reader io.Reader, rootModuleId string) ([]explicitPackage, error) {
+	scanner := bufio.NewScanner(reader)
+	explicit := false
+	var packages []explicitPackage
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line == explicitMarker {
+			explicit = true
+			continue
+		}
+		if !explicit {
+			return nil, errorutils.CheckErrorf("the lockfile is not an explicit lockfile, since it doesn't start with %s", explicitMarker)
+		}
+		pkg, err := parsePackageLine(line, rootModuleId)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, pkg)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Id < packages[j].Id
+	})
+	return packages, nil
+}
+
+func parsePackageLine(line, rootModuleId string) (explicitPackage, error) {
+	packageUrl, checksum, _ := strings.Cut(line, "#")
+	parsedUrl, err := url.Parse(packageUrl)
+	if err != nil {
+		return explicitPackage{}, errorutils.CheckErrorf("invalid package URL in the explicit lockfile: %s", packageUrl)
+	}
+	parsedUrl.User = nil
+	name, version, extension := splitPackageFileName(path.Base(parsedUrl.Path))
+	if name == "" {
+		return explicitPackage{}, errorutils.CheckErrorf("unexpected package file name in the explicit lockfile: %s", path.Base(parsedUrl.Path))
+	}
+	pkg := explicitPackage{
+		Dependency: buildinfo.Dependency{Id: name + ":" + version, Type: strings.TrimPrefix(extension, "."), RequestedBy: [][]string{{rootModuleId}}},
+		Url:        parsedUrl.String(),
+	}
+	if sha256, found := strings.CutPrefix(checksum, "sha256:"); found {
+		pkg.Sha256 = sha256
+	} else if md5Pattern.MatchString(checksum) {
+		pkg.Md5 = checksum
+	}
+	return pkg, nil
+}
+
+// Returns the name, version and extension of the package from its file name, which is <name>-<version>-<build>.<extension>.
+// The name may include hyphens, but the version and build may not.
+func splitPackageFileName(fileName string) (name, version, extension string) {
+	for _, packageExtension := range packageExtensions {
+		if strings.HasSuffix(fileName, packageExtension) {
+			extension = packageExtension
+			break
+		}
+	}
+	if extension == "" {
+		return "", "", ""
+	}
+	parts := strings.Split(strings.TrimSuffix(fileName, extension), "-")
+	if len(parts) < 3 {
+		return "", "", ""
+	}
+	return strings.Join(parts[:len(parts)-2], "-"), parts[len(parts)-2], extension
+}
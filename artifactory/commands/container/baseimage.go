@@ -0,0 +1,380 @@
+package container
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/container"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	containerutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	baseImageFlag = "--base-image"
+	// The Dockerfile which the base image of the pushed image is identified by, if it isn't provided.
+	DefaultDockerfile = "Dockerfile"
+
+	baseImageProperty       = "docker.base.image"
+	baseImageDigestProperty = "docker.base.image.digest"
+	baseImageDependencyType = "docker"
+)
+
+// Extracts the base image of the pushed image from the arguments of docker push, which docker doesn't accept.
+func ExtractBaseImageFromArgs(args []string) (cleanArgs []string, baseImage string, err error) {
+	cleanArgs = append([]string(nil), args...)
+	baseImage, err = extractFlagValue(&cleanArgs, baseImageFlag)
+	return
+}
+
+// Returns the base image of the final stage of the Dockerfile, which is the image that the pushed image is built from.
+// The stages which the final stage is built from are followed, and the arguments declared before the first FROM are replaced by their defaults.
+// An empty base image is returned if the Dockerfile doesn't exist, or if the base image is scratch or isn't pulled from a registry, such as Artifactory.
+func DetectBaseImage(dockerfilePath string) (string, error) {
+	content, err := os.ReadFile(dockerfilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", errorutils.CheckError(err)
+	}
+	baseImage := parseBaseImage(string(content))
+	if baseImage == "" {
+		return "", nil
+	}
+	if registry, _, found := strings.Cut(baseImage, "/"); !found || (!strings.ContainsAny(registry, ".:") && registry != "localhost") {
+		log.Debug("The base image of " + dockerfilePath + " isn't pulled from a registry, so its layers aren't identified.")
+		return "", nil
+	}
+	log.Info("The base image " + baseImage + " was identified by the FROM instruction of " + dockerfilePath + ".")
+	return baseImage, nil
+}
+
+// Returns the image of the FROM instruction of the final stage, or an empty string if it can't be resolved.
+func parseBaseImage(dockerfile string) string {
+	args := make(map[string]string)
+	stages := make(map[string]string)
+	baseImage, seenFrom := "", false
+	for _, instruction := range readDockerfileInstructions(dockerfile) {
+		fields := strings.Fields(instruction)
+		switch strings.ToUpper(fields[0]) {
+		case "ARG":
+			// Only the arguments which are declared before the first FROM can be used by the FROM instructions.
+			if seenFrom {
+				continue
+			}
+			for _, arg := range fields[1:] {
+				if name, value, found := strings.Cut(arg, "="); found {
+					args[name] = strings.Trim(value, `"'`)
+				}
+			}
+		case "FROM":
+			seenFrom = true
+			var operands []string
+			for _, field := range fields[1:] {
+				if !strings.HasPrefix(field, "--") {
+					operands = append(operands, field)
+				}
+			}
+			if len(operands) == 0 {
+				continue
+			}
+			baseImage = expandDockerfileArgs(operands[0], args)
+			// A stage which is built from a previous stage has the base image of that stage.
+			if stageBaseImage, isStage := stages[strings.ToLower(baseImage)]; isStage {
+				baseImage = stageBaseImage
+			}
+			if len(operands) == 3 && strings.EqualFold(operands[1], "AS") {
+				stages[strings.ToLower(operands[2])] = baseImage
+			}
+		}
+	}
+	if baseImage == "scratch" {
+		return ""
+	}
+	return baseImage
+}
+
+// Returns the instructions of the Dockerfile, with their continuation lines joined, and without comments and empty lines.
+func readDockerfileInstructions(dockerfile string) []string {
+	var instructions []string
+	var instruction strings.Builder
+	for _, line := range strings.Split(dockerfile, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if continued, found := strings.CutSuffix(line, "\\"); found {
+			instruction.WriteString(continued + " ")
+			continue
+		}
+		instruction.WriteString(line)
+		instructions = append(instructions, instruction.String())
+		instruction.Reset()
+	}
+	if instruction.Len() > 0 {
+		instructions = append(instructions, instruction.String())
+	}
+	return instructions
+}
+
+// Replaces the $NAME, ${NAME} and ${NAME:-default} arguments. An empty string is returned if an argument has no value.
+func expandDockerfileArgs(value string, args map[string]string) string {
+	resolved := true
+	expanded := os.Expand(value, func(name string) string {
+		name, defaultValue, hasDefault := strings.Cut(name, ":-")
+		if argValue := args[name]; argValue != "" {
+			return argValue
+		}
+		if !hasDefault {
+			resolved = false
+		}
+		return defaultValue
+	})
+	if !resolved {
+		return ""
+	}
+	return expanded
+}
+
+// Records the pushed image in the build-info, with the layers of its base image as dependencies, rather than as artifacts.
+// The base image is the FROM image of the Dockerfile, as pulled through Artifactory. Its manifest, of the platform of the pushed image,
+// is recorded as a dependency with its digest, so that the images which need a rebuild when the base image is vulnerable can be found.
+// The build properties are set on the layers which the image adds to its base image only.
+// If the layers of the base image can't be identified, the image is recorded with all its layers as artifacts, as 'jf docker push' records it.
+func SaveImageBuildInfoWithBaseImage(image, baseImage string, serverDetails *config.ServerDetails, skipLogin bool, buildConfiguration *build.BuildConfiguration) error {
+	commandBase := &container.ContainerCommandBase{}
+	commandBase.SetImageTag(image).SetServerDetails(serverDetails).SetBuildConfiguration(buildConfiguration)
+	buildInfo, imageLayers, err := createPushedImageBuildInfo(commandBase)
+	if err != nil || buildInfo == nil {
+		return err
+	}
+	baseLayers, err := identifyBaseLayers(image, baseImage, serverDetails, skipLogin, &buildInfo.Modules[0])
+	if err != nil {
+		log.Warn("The layers of the base image " + baseImage + " couldn't be identified. All the layers of the image are recorded as artifacts: " + err.Error())
+	}
+	if err = setItemsBuildProperties(serverDetails, buildConfiguration, excludeLayers(imageLayers, baseLayers)); err != nil {
+		return err
+	}
+	return saveBuildInfo(buildConfiguration, buildInfo)
+}
+
+// Returns the layers of the base image, by the manifest which was pushed for the image.
+func identifyBaseLayers(image, baseImage string, serverDetails *config.ServerDetails, skipLogin bool, module *buildinfo.Module) ([]ociDescriptor, error) {
+	digest, err := GetPushedDigest(image)
+	if err != nil {
+		return nil, err
+	}
+	reference, err := parseOciReference(image)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newRegistryClient(reference, serverDetails, skipLogin)
+	if err != nil {
+		return nil, err
+	}
+	descriptor, content, err := client.fetchManifest(digest)
+	if err != nil {
+		return nil, err
+	}
+	if descriptor == nil {
+		return nil, errorutils.CheckErrorf("the pushed manifest %s of %s wasn't found", digest, image)
+	}
+	return setBaseImageDependencies(client, content, baseImage, serverDetails, skipLogin, module)
+}
+
+// Collects the build-info of the pushed local image, as 'jf docker push' collects it, without setting the build properties on its layers.
+// Returns the build-info and the layers of the image in Artifactory.
+func createPushedImageBuildInfo(commandBase *container.ContainerCommandBase) (*buildinfo.BuildInfo, []servicesutils.ResultItem, error) {
+	buildConfiguration := commandBase.BuildConfiguration()
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return nil, nil, err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+	project := buildConfiguration.GetProject()
+	serviceManager, err := utils.CreateServiceManager(commandBase.ServerDetails(), -1, 0, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	repo, err := commandBase.GetRepo()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, project); err != nil {
+		return nil, nil, err
+	}
+	builder, err := containerutils.NewLocalAgentBuildInfoBuilder(containerutils.NewImage(commandBase.ImageTag()), repo, buildName, buildNumber, project, serviceManager,
+		containerutils.Push, containerutils.NewManager(containerutils.DockerClient))
+	if err != nil {
+		return nil, nil, err
+	}
+	builder.SetSkipTaggingLayers(true)
+	buildInfo, err := builder.Build(buildConfiguration.GetModule())
+	if err != nil || buildInfo == nil {
+		return nil, nil, err
+	}
+	return buildInfo, *builder.GetLayers(), nil
+}
+
+// Returns the layers in Artifactory, except for the layers of the manifest.
+func excludeLayers(items []servicesutils.ResultItem, layers []ociDescriptor) []servicesutils.ResultItem {
+	excluded := make(map[string]bool, len(layers))
+	for _, layer := range layers {
+		excluded[blobFileName(layer.Digest)] = true
+	}
+	var remaining []servicesutils.ResultItem
+	for _, item := range items {
+		if !excluded[item.Name] {
+			remaining = append(remaining, item)
+		}
+	}
+	return remaining
+}
+
+// Returns the layers of the base image, which are moved from the artifacts of the module to its dependencies.
+func setBaseImageDependencies(client *registryClient, imageManifestContent []byte, baseImage string, serverDetails *config.ServerDetails, skipLogin bool, module *buildinfo.Module) ([]ociDescriptor, error) {
+	imageManifest := new(ociManifest)
+	if err := json.Unmarshal(imageManifestContent, imageManifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the manifest of the pushed image: %s", err.Error())
+	}
+	imagePlatform, err := fetchPlatform(client, imageManifest)
+	if err != nil {
+		return nil, err
+	}
+	baseReference, baseDigest, baseContent, err := fetchBaseImageManifest(baseImage, imagePlatform, serverDetails, skipLogin)
+	if err != nil {
+		return nil, err
+	}
+	baseManifest := new(ociManifest)
+	if err = json.Unmarshal(baseContent, baseManifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the manifest of the base image %s: %s", baseImage, err.Error())
+	}
+	baseLayers := countBaseLayers(imageManifest, baseManifest)
+	if baseLayers < len(baseManifest.Layers) {
+		log.Warn(fmt.Sprintf("The image isn't based on %s@%s, since its first layers differ from the layers of the base image. "+
+			"The base image tag may have been updated after the image was built. All the layers of the image are recorded as artifacts.", baseReference.String(), baseDigest))
+		return nil, nil
+	}
+	baseChecksum, err := calcChecksum(baseContent)
+	if err != nil {
+		return nil, err
+	}
+	moveBaseLayersToDependencies(module, imageManifest.Layers[:baseLayers])
+	module.Dependencies = append([]buildinfo.Dependency{{Id: baseReference.String(), Type: baseImageDependencyType, Checksum: baseChecksum}}, module.Dependencies...)
+	addModuleProperty(module, baseImageProperty, baseReference.String())
+	addModuleProperty(module, baseImageDigestProperty, baseDigest)
+	logLayersReport(imageManifest.Layers, baseLayers, baseReference.String()+"@"+baseDigest)
+	return imageManifest.Layers[:baseLayers], nil
+}
+
+// Returns the platform of the image, from its config.
+func fetchPlatform(client *registryClient, manifest *ociManifest) (*platform, error) {
+	var buffer bytes.Buffer
+	if err := client.fetchBlob(manifest.Config.Digest, &buffer); err != nil {
+		return nil, err
+	}
+	imagePlatform := new(platform)
+	if err := json.Unmarshal(buffer.Bytes(), imagePlatform); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the config of the pushed image: %s", err.Error())
+	}
+	return imagePlatform, nil
+}
+
+// Pulls the manifest of the base image through Artifactory. If the base image is a multi-platform image, the manifest of the platform is pulled.
+func fetchBaseImageManifest(baseImage string, imagePlatform *platform, serverDetails *config.ServerDetails, skipLogin bool) (*ociReference, string, []byte, error) {
+	reference, err := parseOciReference(baseImage)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	client, err := newRegistryClient(reference, serverDetails, skipLogin)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	descriptor, content, err := client.fetchManifest(reference.Ref())
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if descriptor == nil {
+		return nil, "", nil, errorutils.CheckErrorf("the base image %s wasn't found. Provide the base image as it's pulled through Artifactory", baseImage)
+	}
+	if descriptor.MediaType != ociImageIndexMediaType && descriptor.MediaType != dockerManifestListMedia {
+		return reference, descriptor.Digest, content, nil
+	}
+	index, err := parseImageIndex(content, descriptor.Digest)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	for _, manifest := range index.Manifests {
+		if manifest.Platform != nil && manifest.Platform.Os == imagePlatform.Os && manifest.Platform.Architecture == imagePlatform.Architecture &&
+			(imagePlatform.Variant == "" || manifest.Platform.Variant == imagePlatform.Variant) {
+			platformDescriptor, platformContent, err := client.fetchManifest(manifest.Digest)
+			if err != nil {
+				return nil, "", nil, err
+			}
+			if platformDescriptor == nil {
+				return nil, "", nil, errorutils.CheckErrorf("the %s manifest %s of the base image %s wasn't found", imagePlatform.String(), manifest.Digest, baseImage)
+			}
+			return reference, manifest.Digest, platformContent, nil
+		}
+	}
+	return nil, "", nil, errorutils.CheckErrorf("the base image %s has no manifest of the %s platform of the pushed image", baseImage, imagePlatform.String())
+}
+
+// Returns the number of the first layers of the image, which are the layers of the base image.
+func countBaseLayers(imageManifest, baseManifest *ociManifest) int {
+	count := 0
+	for count < len(baseManifest.Layers) && count < len(imageManifest.Layers) && baseManifest.Layers[count].Digest == imageManifest.Layers[count].Digest {
+		count++
+	}
+	return count
+}
+
+// Moves the artifacts of the base image layers to the dependencies of the module, so that only the layers of the image itself are its artifacts.
+func moveBaseLayersToDependencies(module *buildinfo.Module, baseLayers []ociDescriptor) {
+	baseLayerNames := make(map[string]bool, len(baseLayers))
+	for _, layer := range baseLayers {
+		baseLayerNames[blobFileName(layer.Digest)] = true
+	}
+	// The layers which are already dependencies, as identified by the history of the image config, and the layers which are repeated in the image are recorded once.
+	recordedDependencies := make(map[string]bool, len(module.Dependencies))
+	for _, dependency := range module.Dependencies {
+		recordedDependencies[dependency.Id] = true
+	}
+	var artifacts []buildinfo.Artifact
+	for _, artifact := range module.Artifacts {
+		if !baseLayerNames[artifact.Name] {
+			artifacts = append(artifacts, artifact)
+			continue
+		}
+		if !recordedDependencies[artifact.Name] {
+			module.Dependencies = append(module.Dependencies, buildinfo.Dependency{Id: artifact.Name, Type: artifact.Type, Checksum: artifact.Checksum})
+			recordedDependencies[artifact.Name] = true
+		}
+	}
+	module.Artifacts = artifacts
+}
+
+func logLayersReport(layers []ociDescriptor, baseLayers int, baseImage string) {
+	var baseSize, ownSize int64
+	for i, layer := range layers {
+		if i < baseLayers {
+			baseSize += layer.Size
+		} else {
+			ownSize += layer.Size
+		}
+	}
+	log.Info(fmt.Sprintf("%d of the %d layers of the image (%s) are the layers of the base image %s. The image adds %d layers (%s).",
+		baseLayers, len(layers), servicesutils.ConvertIntToStorageSizeString(baseSize), baseImage, len(layers)-baseLayers, servicesutils.ConvertIntToStorageSizeString(ownSize)))
+}
@@ -0,0 +1,145 @@
+package container
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractBaseImageFromArgs(t *testing.T) {
+	args := []string{"my.jfrog.io/docker-local/app:1.0", "--base-image=my.jfrog.io/docker-remote/alpine:3.19", "--quiet"}
+	cleanArgs, baseImage, err := ExtractBaseImageFromArgs(args)
+	require.NoError(t, err)
+	assert.Equal(t, "my.jfrog.io/docker-remote/alpine:3.19", baseImage)
+	assert.Equal(t, []string{"my.jfrog.io/docker-local/app:1.0", "--quiet"}, cleanArgs)
+	assert.Len(t, args, 3)
+}
+
+func TestSetBaseImageDependencies(t *testing.T) {
+	registry := newTestRegistry(t, true)
+	baseLayers := []ociDescriptor{testLayer("base-1"), testLayer("base-2")}
+	appLayer := testLayer("app")
+	// The base image is a multi-platform image, whose amd64 manifest is the base of the pushed image.
+	amd64Digest := addTestManifest(t, registry, &ociManifest{SchemaVersion: 2, MediaType: ociManifestMediaType, Layers: baseLayers})
+	arm64Digest := addTestManifest(t, registry, &ociManifest{SchemaVersion: 2, MediaType: ociManifestMediaType, Layers: []ociDescriptor{testLayer("arm64")}})
+	baseIndex := map[string]interface{}{"schemaVersion": 2, "mediaType": ociImageIndexMediaType, "manifests": []manifestDescriptor{
+		{MediaType: ociManifestMediaType, Digest: arm64Digest, Platform: &platform{Os: "linux", Architecture: "arm64", Variant: "v8"}},
+		{MediaType: ociManifestMediaType, Digest: amd64Digest, Platform: &platform{Os: "linux", Architecture: "amd64"}},
+	}}
+	indexContent, err := json.Marshal(baseIndex)
+	require.NoError(t, err)
+	registry.manifests["3.19"] = indexContent
+	baseImage := registry.host() + "/" + testRegistryRepo + "/alpine:3.19"
+
+	config := []byte(`{"architecture":"amd64","os":"linux"}`)
+	registry.blobs[calcDigest(config)] = config
+	imageManifest := &ociManifest{SchemaVersion: 2, MediaType: ociManifestMediaType, Config: ociDescriptor{Digest: calcDigest(config)}, Layers: append(append([]ociDescriptor{}, baseLayers...), appLayer)}
+	imageContent, err := json.Marshal(imageManifest)
+	require.NoError(t, err)
+
+	newModule := func() *buildinfo.Module {
+		module := &buildinfo.Module{Id: "app:1.0", Type: buildinfo.Docker, Artifacts: []buildinfo.Artifact{{Name: "manifest.json"}, {Name: blobFileName(calcDigest(config))}}}
+		for _, layer := range imageManifest.Layers {
+			module.Artifacts = append(module.Artifacts, buildinfo.Artifact{Name: blobFileName(layer.Digest), Checksum: buildinfo.Checksum{Sha256: strings.TrimPrefix(layer.Digest, "sha256:")}})
+		}
+		return module
+	}
+	ref, err := parseOciReference(registry.host() + "/" + testRegistryRepo + "/app:1.0")
+	require.NoError(t, err)
+	client, err := newRegistryClient(ref, registry.serverDetails(), false)
+	require.NoError(t, err)
+
+	module := newModule()
+	// A base layer, which the history of the image config identifies as a dependency, is recorded once.
+	module.Dependencies = []buildinfo.Dependency{{Id: blobFileName(baseLayers[0].Digest)}}
+	identifiedLayers, err := setBaseImageDependencies(client, imageContent, baseImage, registry.serverDetails(), false, module)
+	require.NoError(t, err)
+	assert.Equal(t, baseLayers, identifiedLayers)
+	var artifactNames, dependencyIds []string
+	for _, artifact := range module.Artifacts {
+		artifactNames = append(artifactNames, artifact.Name)
+	}
+	for _, dependency := range module.Dependencies {
+		dependencyIds = append(dependencyIds, dependency.Id)
+	}
+	assert.Equal(t, []string{"manifest.json", blobFileName(calcDigest(config)), blobFileName(appLayer.Digest)}, artifactNames)
+	assert.Equal(t, []string{baseImage, blobFileName(baseLayers[0].Digest), blobFileName(baseLayers[1].Digest)}, dependencyIds)
+	assert.Equal(t, strings.TrimPrefix(amd64Digest, "sha256:"), module.Dependencies[0].Sha256)
+	assert.Equal(t, strings.TrimPrefix(baseLayers[1].Digest, "sha256:"), module.Dependencies[2].Sha256)
+	assert.Equal(t, map[string]string{baseImageProperty: baseImage, baseImageDigestProperty: amd64Digest}, module.Properties)
+
+	// An image which isn't based on the base image is recorded with all its layers as artifacts.
+	registry.manifests["3.19"] = registry.manifests[arm64Digest]
+	module = newModule()
+	identifiedLayers, err = setBaseImageDependencies(client, imageContent, baseImage, registry.serverDetails(), false, module)
+	require.NoError(t, err)
+	assert.Empty(t, identifiedLayers)
+	assert.Len(t, module.Artifacts, 5)
+	assert.Empty(t, module.Dependencies)
+	assert.False(t, registry.unauthorizedAccess)
+}
+
+func TestDetectBaseImage(t *testing.T) {
+	dockerfilePath := filepath.Join(t.TempDir(), "Dockerfile")
+	baseImage, err := DetectBaseImage(dockerfilePath)
+	require.NoError(t, err)
+	assert.Empty(t, baseImage)
+
+	require.NoError(t, os.WriteFile(dockerfilePath, []byte("ARG REGISTRY=my.jfrog.io\n"+
+		"FROM --platform=$BUILDPLATFORM ${REGISTRY}/docker-remote/golang:1.22 AS builder\n"+
+		"RUN go build \\\n    -o /app .\n"+
+		"# FROM my.jfrog.io/docker-remote/ignored:1.0\n"+
+		"FROM builder AS final\n"+
+		"COPY --from=builder /app /app\n"), 0644))
+	baseImage, err = DetectBaseImage(dockerfilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "my.jfrog.io/docker-remote/golang:1.22", baseImage)
+
+	require.NoError(t, os.WriteFile(dockerfilePath, []byte("FROM alpine:3.19\n"), 0644))
+	baseImage, err = DetectBaseImage(dockerfilePath)
+	require.NoError(t, err)
+	assert.Empty(t, baseImage)
+}
+
+func TestParseBaseImage(t *testing.T) {
+	assert.Equal(t, "my.jfrog.io/docker-remote/alpine:3.19", parseBaseImage("FROM my.jfrog.io/docker-remote/golang:1.22 AS builder\nFROM my.jfrog.io/docker-remote/alpine:3.19\n"))
+	assert.Equal(t, "my.jfrog.io/docker-remote/alpine:3.19", parseBaseImage("from ${BASE:-my.jfrog.io/docker-remote/alpine:3.19}\n"))
+	// Arguments which are declared after the first FROM, or without a default, aren't resolved.
+	assert.Empty(t, parseBaseImage("FROM scratch\nARG BASE=my.jfrog.io/docker-remote/alpine:3.19\nFROM $BASE\n"))
+	assert.Empty(t, parseBaseImage("ARG BASE\nFROM $BASE\n"))
+	assert.Empty(t, parseBaseImage("FROM my.jfrog.io/docker-remote/golang:1.22 AS builder\nFROM scratch\n"))
+}
+
+func TestExcludeLayers(t *testing.T) {
+	baseLayer, appLayer := testLayer("base"), testLayer("app")
+	items := []servicesutils.ResultItem{{Name: "manifest.json"}, {Name: blobFileName(baseLayer.Digest)}, {Name: blobFileName(appLayer.Digest)}}
+	assert.Equal(t, []servicesutils.ResultItem{{Name: "manifest.json"}, {Name: blobFileName(appLayer.Digest)}}, excludeLayers(items, []ociDescriptor{baseLayer}))
+	assert.Equal(t, items, excludeLayers(items, nil))
+}
+
+func TestCountBaseLayers(t *testing.T) {
+	image := &ociManifest{Layers: []ociDescriptor{testLayer("a"), testLayer("b"), testLayer("c")}}
+	assert.Equal(t, 2, countBaseLayers(image, &ociManifest{Layers: []ociDescriptor{testLayer("a"), testLayer("b")}}))
+	assert.Equal(t, 1, countBaseLayers(image, &ociManifest{Layers: []ociDescriptor{testLayer("a"), testLayer("x")}}))
+	assert.Equal(t, 0, countBaseLayers(image, &ociManifest{Layers: []ociDescriptor{testLayer("x")}}))
+}
+
+func testLayer(content string) ociDescriptor {
+	return ociDescriptor{MediaType: ociDefaultLayerMediaType, Digest: calcDigest([]byte(content)), Size: int64(len(content))}
+}
+
+// Adds the manifest to the test registry, and returns its digest.
+func addTestManifest(t *testing.T, registry *testRegistry, manifest *ociManifest) string {
+	content, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	digest := calcDigest(content)
+	registry.manifests[digest] = content
+	return digest
+}
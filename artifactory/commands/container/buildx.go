@@ -0,0 +1,381 @@
+package container
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/container"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	containerutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Buildx adds attestation manifests to the image index, which are annotated with the digest of the image manifest they attest.
+	referenceTypeAnnotation   = "vnd.docker.reference.type"
+	referenceDigestAnnotation = "vnd.docker.reference.digest"
+	attestationManifestType   = "attestation-manifest"
+
+	ociImageIndexMediaType  = "application/vnd.oci.image.index.v1+json"
+	dockerManifestListMedia = "application/vnd.docker.distribution.manifest.list.v2+json"
+	metadataFileFlag        = "--metadata-file"
+
+	imageIndexDigestProperty    = "docker.image.index.digest"
+	imagePlatformProperty       = "docker.image.platform"
+	imageManifestDigestProperty = "docker.manifest.digest"
+)
+
+// Runs 'docker buildx build' with the image pushed to Artifactory, and records the pushed image in the build-info.
+// For a multi-platform image, the image index is recorded in a module of the image, and the image of each platform in a sub-module,
+// which is named after the platform. For example: linux/arm64/v8/my-image:1.0
+type BuildxCommand struct {
+	container.ContainerCommand
+	// The options of the docker client, which precede the buildx command.
+	globalArgs []string
+	args       []string
+	// Returns the raw manifest of the image reference, as pushed to the registry.
+	inspectRaw func(reference string) ([]byte, error)
+}
+
+func NewBuildxCommand() *BuildxCommand {
+	return &BuildxCommand{ContainerCommand: *container.NewContainerManagerCommand(containerutils.DockerClient), inspectRaw: inspectRaw}
+}
+
+func (bc *BuildxCommand) SetGlobalArgs(globalArgs []string) *BuildxCommand {
+	bc.globalArgs = globalArgs
+	return bc
+}
+
+// Sets the arguments of docker buildx, following the buildx command. For example: build --platform linux/amd64,linux/arm64 -t <image> --push .
+func (bc *BuildxCommand) SetArgs(args []string) *BuildxCommand {
+	bc.args = args
+	return bc
+}
+
+func (bc *BuildxCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bc.ContainerCommand.ServerDetails(), nil
+}
+
+func (bc *BuildxCommand) CommandName() string {
+	return "rt_docker_buildx"
+}
+
+// The options of docker buildx build, which determine the pushed image.
+type buildxOptions struct {
+	tags         []string
+	push         bool
+	metadataFile string
+}
+
+// Splits the docker arguments into the options of the docker client, and the arguments which follow the buildx command.
+func SplitBuildxArgs(dockerArgs []string) (globalArgs, buildxArgs []string) {
+	for i, arg := range dockerArgs {
+		if arg == "buildx" {
+			return dockerArgs[:i], dockerArgs[i+1:]
+		}
+	}
+	return dockerArgs, nil
+}
+
+// Returns true if the arguments are of the 'docker buildx build' command.
+func IsBuildxBuild(args []string) bool {
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			return arg == "build" || arg == "b"
+		}
+	}
+	return false
+}
+
+func parseBuildxOptions(args []string) (options buildxOptions, err error) {
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		switch name {
+		case "-t", "--tag", "-o", "--output", metadataFileFlag:
+			if !hasValue {
+				if i+1 == len(args) {
+					return options, errorutils.CheckErrorf("the %s option requires a value", name)
+				}
+				i++
+				value = args[i]
+			}
+		case "--push":
+			options.push = !hasValue || value == "true"
+			continue
+		default:
+			continue
+		}
+		switch name {
+		case "-t", "--tag":
+			options.tags = append(options.tags, value)
+		case "-o", "--output":
+			options.push = options.push || isPushOutput(value)
+		default:
+			options.metadataFile = value
+		}
+	}
+	return
+}
+
+// Returns true if the output of buildx is pushed to the registry. For example: type=registry or type=image,push=true
+func isPushOutput(output string) bool {
+	attributes := make(map[string]string)
+	for _, attribute := range strings.Split(output, ",") {
+		key, value, _ := strings.Cut(attribute, "=")
+		attributes[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return attributes["type"] == "registry" || (attributes["type"] == "image" && attributes["push"] == "true")
+}
+
+func (bc *BuildxCommand) Run() (err error) {
+	options, err := parseBuildxOptions(bc.args)
+	if err != nil {
+		return err
+	}
+	collectBuildInfo, err := bc.BuildConfiguration().IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if collectBuildInfo && (!options.push || len(options.tags) == 0) {
+		log.Warn("The build-info of docker buildx is collected only for images which are pushed with a tag, using --push or --output type=registry. Build-info collection is skipped.")
+		collectBuildInfo = false
+	}
+	args := bc.args
+	if len(options.tags) > 0 {
+		bc.SetImageTag(options.tags[0])
+		if err = bc.PerformLogin(bc.ContainerCommand.ServerDetails(), containerutils.DockerClient); err != nil {
+			return err
+		}
+	}
+	if collectBuildInfo && options.metadataFile == "" {
+		// The metadata file of buildx includes the digest of the pushed image, or image index.
+		var tempDir string
+		if tempDir, err = fileutils.CreateTempDir(); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+		}()
+		options.metadataFile = filepath.Join(tempDir, "metadata.json")
+		args = append(append([]string{}, args...), metadataFileFlag, options.metadataFile)
+	}
+	if err = containerutils.NewManager(containerutils.DockerClient).RunNativeCmd(append(append(append([]string{}, bc.globalArgs...), "buildx"), args...)); err != nil || !collectBuildInfo {
+		return err
+	}
+	return bc.collectBuildInfo(options.metadataFile)
+}
+
+func (bc *BuildxCommand) collectBuildInfo(metadataFile string) error {
+	image, digest, err := readBuildxMetadata(metadataFile)
+	if err != nil {
+		return err
+	}
+	return saveImageBuildInfo(&bc.ContainerCommandBase, image, digest, func() ([]byte, error) {
+		return bc.inspectRaw(image + "@" + digest)
+	})
+}
+
+// Records the image, which was pushed to Artifactory with the digest, in the build-info, and sets the build properties on its layers.
+// The raw image index is read if the image is a multi-platform image, to name the modules of its platforms.
+func saveImageBuildInfo(commandBase *container.ContainerCommandBase, image, digest string, readIndex func() ([]byte, error)) error {
+	buildInfo, err := createImageBuildInfo(commandBase, image, digest, readIndex)
+	if err != nil {
+		return err
+	}
+	return saveBuildInfo(commandBase.BuildConfiguration(), buildInfo)
+}
+
+func createImageBuildInfo(commandBase *container.ContainerCommandBase, image, digest string, readIndex func() ([]byte, error)) (*buildinfo.BuildInfo, error) {
+	commandBase.SetImageTag(image)
+	log.Info("Collecting the build-info of " + image + "@" + digest + "...")
+	buildConfiguration := commandBase.BuildConfiguration()
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return nil, err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return nil, err
+	}
+	project := buildConfiguration.GetProject()
+	serviceManager, err := utils.CreateServiceManager(commandBase.ServerDetails(), -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := commandBase.GetRepo()
+	if err != nil {
+		return nil, err
+	}
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, project); err != nil {
+		return nil, err
+	}
+	builder, err := containerutils.NewRemoteAgentBuildInfoBuilder(containerutils.NewImage(image), repo, buildName, buildNumber, project, serviceManager, digest)
+	if err != nil {
+		return nil, err
+	}
+	buildInfo, err := builder.Build(buildConfiguration.GetModule())
+	if err != nil {
+		return nil, err
+	}
+	if len(buildInfo.Modules) > 1 {
+		rawIndex, err := readIndex()
+		if err != nil {
+			return nil, err
+		}
+		index, err := parseImageIndex(rawIndex, digest)
+		if err != nil {
+			return nil, err
+		}
+		if err = setPlatformModules(buildInfo, index, digest); err != nil {
+			return nil, err
+		}
+	} else if len(buildInfo.Modules) == 1 {
+		addModuleProperty(&buildInfo.Modules[0], imageManifestDigestProperty, digest)
+	}
+	return buildInfo, nil
+}
+
+func saveBuildInfo(buildConfiguration *build.BuildConfiguration, buildInfo *buildinfo.BuildInfo) error {
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	return build.SaveBuildInfo(buildName, buildNumber, buildConfiguration.GetProject(), buildInfo)
+}
+
+// Returns the first tag of the pushed image and its digest, from the metadata file of buildx.
+func readBuildxMetadata(metadataFile string) (image, digest string, err error) {
+	content, err := os.ReadFile(metadataFile)
+	if err != nil {
+		return "", "", errorutils.CheckError(err)
+	}
+	var metadata struct {
+		ImageName string `json:"image.name"`
+		Digest    string `json:"containerimage.digest"`
+	}
+	if err = json.Unmarshal(content, &metadata); err != nil {
+		return "", "", errorutils.CheckErrorf("failed parsing the buildx metadata file %s: %s", metadataFile, err.Error())
+	}
+	// All the tags of the image are listed, separated by commas.
+	image, _, _ = strings.Cut(metadata.ImageName, ",")
+	if image == "" || metadata.Digest == "" {
+		return "", "", errorutils.CheckErrorf("the buildx metadata file %s doesn't include the name and the digest of the pushed image", metadataFile)
+	}
+	return strings.TrimSpace(image), metadata.Digest, nil
+}
+
+// An OCI image index or a Docker manifest list.
+type imageIndex struct {
+	MediaType string               `json:"mediaType"`
+	Manifests []manifestDescriptor `json:"manifests"`
+}
+
+type manifestDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Platform    *platform         `json:"platform,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type platform struct {
+	Architecture string `json:"architecture"`
+	Os           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+func (p *platform) String() string {
+	if p == nil {
+		return "unknown/unknown"
+	}
+	if p.Variant == "" {
+		return p.Os + "/" + p.Architecture
+	}
+	return p.Os + "/" + p.Architecture + "/" + p.Variant
+}
+
+// Parses the image index, and verifies that it's the index of the digest.
+func parseImageIndex(rawIndex []byte, digest string) (*imageIndex, error) {
+	if actualDigest := calcDigest(rawIndex); actualDigest != digest {
+		// The raw manifest may be printed with a trailing newline.
+		if trimmed := bytes.TrimSuffix(rawIndex, []byte("\n")); calcDigest(trimmed) != digest {
+			return nil, errorutils.CheckErrorf("the digest of the image index %s doesn't match the pushed digest %s", actualDigest, digest)
+		}
+	}
+	index := new(imageIndex)
+	if err := json.Unmarshal(rawIndex, index); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the image index %s: %s", digest, err.Error())
+	}
+	if index.MediaType != "" && index.MediaType != ociImageIndexMediaType && index.MediaType != dockerManifestListMedia {
+		return nil, errorutils.CheckErrorf("expected %s to be an image index, but its media type is %s", digest, index.MediaType)
+	}
+	return index, nil
+}
+
+// The build-info of a multi-platform image has the module of the image index, followed by a module per manifest of the index, in the order of the index.
+// The modules of the manifests are named after their platforms, including the variants, and the attestation manifests are named after the platforms of the images they attest.
+// The digests of the index and of the manifests are added to the properties of the modules.
+func setPlatformModules(buildInfo *buildinfo.BuildInfo, index *imageIndex, digest string) error {
+	if len(buildInfo.Modules) != len(index.Manifests)+1 {
+		return errorutils.CheckErrorf("expected %d platform images of the image index %s in Artifactory, but found %d", len(index.Manifests), digest, len(buildInfo.Modules)-1)
+	}
+	indexModule := &buildInfo.Modules[0]
+	addModuleProperty(indexModule, imageIndexDigestProperty, digest)
+	platforms := make(map[string]*platform)
+	for _, manifest := range index.Manifests {
+		platforms[manifest.Digest] = manifest.Platform
+	}
+	for i, manifest := range index.Manifests {
+		module := &buildInfo.Modules[i+1]
+		platformName := manifest.Platform.String()
+		if manifest.Annotations[referenceTypeAnnotation] == attestationManifestType {
+			platformName = platforms[manifest.Annotations[referenceDigestAnnotation]].String() + "/attestation"
+		} else {
+			addModuleProperty(module, imagePlatformProperty, platformName)
+		}
+		module.Id = platformName + "/" + indexModule.Id
+		addModuleProperty(module, imageManifestDigestProperty, manifest.Digest)
+	}
+	return nil
+}
+
+func calcDigest(content []byte) string {
+	checksum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(checksum[:])
+}
+
+func addModuleProperty(module *buildinfo.Module, key, value string) {
+	properties, ok := module.Properties.(map[string]string)
+	if !ok || properties == nil {
+		properties = make(map[string]string)
+	}
+	properties[key] = value
+	module.Properties = properties
+}
+
+func inspectRaw(reference string) ([]byte, error) {
+	output, err := exec.Command("docker", "buildx", "imagetools", "inspect", "--raw", reference).Output()
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) {
+			return nil, errorutils.CheckErrorf("failed inspecting %s: %s", reference, strings.TrimSpace(string(exitError.Stderr)))
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return output, nil
+}
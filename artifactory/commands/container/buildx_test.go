@@ -0,0 +1,104 @@
+package container
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const multiPlatformIndex = `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.index.v1+json",
+  "manifests": [
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:amd64", "size": 1, "platform": {"architecture": "amd64", "os": "linux"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:arm64", "size": 1, "platform": {"architecture": "arm64", "os": "linux", "variant": "v8"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:att", "size": 1, "platform": {"architecture": "unknown", "os": "unknown"},
+      "annotations": {"vnd.docker.reference.digest": "sha256:arm64", "vnd.docker.reference.type": "attestation-manifest"}}
+  ]
+}`
+
+func TestIsBuildxBuild(t *testing.T) {
+	assert.True(t, IsBuildxBuild([]string{"build", "--push", "."}))
+	assert.True(t, IsBuildxBuild([]string{"--builder", "b"}))
+	assert.False(t, IsBuildxBuild([]string{"imagetools", "inspect", "image"}))
+	assert.False(t, IsBuildxBuild(nil))
+}
+
+func TestSplitBuildxArgs(t *testing.T) {
+	globalArgs, buildxArgs := SplitBuildxArgs([]string{"--context", "ci", "buildx", "build", "."})
+	assert.Equal(t, []string{"--context", "ci"}, globalArgs)
+	assert.Equal(t, []string{"build", "."}, buildxArgs)
+}
+
+func TestParseBuildxOptions(t *testing.T) {
+	options, err := parseBuildxOptions([]string{"build", "--platform", "linux/amd64,linux/arm64", "-t", "acme.jfrog.io/docker/app:1.0", "--tag=acme.jfrog.io/docker/app:latest", "--push", "."})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme.jfrog.io/docker/app:1.0", "acme.jfrog.io/docker/app:latest"}, options.tags)
+	assert.True(t, options.push)
+	assert.Empty(t, options.metadataFile)
+
+	options, err = parseBuildxOptions([]string{"build", "-t", "app:1.0", "--output", "type=image,name=app:1.0,push=true", "--metadata-file", "metadata.json", "."})
+	require.NoError(t, err)
+	assert.True(t, options.push)
+	assert.Equal(t, "metadata.json", options.metadataFile)
+
+	options, err = parseBuildxOptions([]string{"build", "-t", "app:1.0", "-o", "type=docker", "."})
+	require.NoError(t, err)
+	assert.False(t, options.push)
+
+	_, err = parseBuildxOptions([]string{"build", "-t"})
+	assert.Error(t, err)
+}
+
+func TestReadBuildxMetadata(t *testing.T) {
+	metadataFile := filepath.Join(t.TempDir(), "metadata.json")
+	require.NoError(t, os.WriteFile(metadataFile, []byte(`{"containerimage.digest": "sha256:abc", "image.name": "acme.jfrog.io/docker/app:1.0,acme.jfrog.io/docker/app:latest"}`), 0644))
+	image, digest, err := readBuildxMetadata(metadataFile)
+	require.NoError(t, err)
+	assert.Equal(t, "acme.jfrog.io/docker/app:1.0", image)
+	assert.Equal(t, "sha256:abc", digest)
+
+	require.NoError(t, os.WriteFile(metadataFile, []byte(`{"containerimage.digest": "sha256:abc"}`), 0644))
+	_, _, err = readBuildxMetadata(metadataFile)
+	assert.ErrorContains(t, err, "doesn't include the name and the digest")
+}
+
+func TestParseImageIndex(t *testing.T) {
+	digest := calcDigest([]byte(multiPlatformIndex))
+	index, err := parseImageIndex([]byte(multiPlatformIndex), digest)
+	require.NoError(t, err)
+	assert.Len(t, index.Manifests, 3)
+	// A trailing newline isn't part of the index.
+	_, err = parseImageIndex([]byte(multiPlatformIndex+"\n"), digest)
+	assert.NoError(t, err)
+
+	_, err = parseImageIndex([]byte(multiPlatformIndex), "sha256:other")
+	assert.ErrorContains(t, err, "doesn't match the pushed digest")
+}
+
+func TestSetPlatformModules(t *testing.T) {
+	digest := calcDigest([]byte(multiPlatformIndex))
+	index, err := parseImageIndex([]byte(multiPlatformIndex), digest)
+	require.NoError(t, err)
+	buildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{
+		{Id: "app:1.0", Properties: map[string]string{"docker.image.tag": "acme.jfrog.io/docker/app:1.0"}},
+		{Id: "linux/amd64/app:1.0"},
+		{Id: "linux/arm64/app:1.0"},
+		{Id: "unknown/unknown/app:1.0"},
+	}}
+	require.NoError(t, setPlatformModules(buildInfo, index, digest))
+	assert.Equal(t, map[string]string{"docker.image.tag": "acme.jfrog.io/docker/app:1.0", imageIndexDigestProperty: digest}, buildInfo.Modules[0].Properties)
+	assert.Equal(t, "linux/amd64/app:1.0", buildInfo.Modules[1].Id)
+	assert.Equal(t, "linux/arm64/v8/app:1.0", buildInfo.Modules[2].Id)
+	assert.Equal(t, map[string]string{imagePlatformProperty: "linux/arm64/v8", imageManifestDigestProperty: "sha256:arm64"}, buildInfo.Modules[2].Properties)
+	assert.Equal(t, "linux/arm64/v8/attestation/app:1.0", buildInfo.Modules[3].Id)
+	assert.Equal(t, map[string]string{imageManifestDigestProperty: "sha256:att"}, buildInfo.Modules[3].Properties)
+
+	// The platform images which weren't found in Artifactory.
+	buildInfo.Modules = buildInfo.Modules[:2]
+	assert.ErrorContains(t, setPlatformModules(buildInfo, index, digest), "expected 3 platform images")
+}
@@ -0,0 +1,656 @@
+package container
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	biutils "github.com/jfrog/build-info-go/utils"
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/container"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The artifact type of artifacts with an empty config, which are pushed without --artifact-type.
+	ociDefaultArtifactType = "application/vnd.unknown.artifact.v1"
+
+	artifactTypeFlag = "--artifact-type"
+	annotationFlag   = "--annotation"
+	configFlag       = "--config"
+	subjectFlag      = "--subject"
+	outputFlag       = "--output"
+
+	imageTagProperty         = "docker.image.tag"
+	ociArtifactTypeProperty  = "oci.artifact.type"
+	ociSubjectDigestProperty = "oci.subject.digest"
+	ociManifestArtifactName  = "manifest.json"
+	ociManifestArtifactType  = "json"
+)
+
+// The common parts of the 'jf oci' commands, which push, pull and discover artifacts of any media type in Artifactory Docker and OCI repositories.
+// The registry is accessed with the credentials of the server, as used by 'jf docker' for 'docker login', so no container runtime is required.
+type ociCommand struct {
+	container.ContainerCommandBase
+	skipLogin bool
+	args      []string
+	reference *ociReference
+}
+
+func (oc *ociCommand) SetSkipLogin(skipLogin bool) {
+	oc.skipLogin = skipLogin
+}
+
+func (oc *ociCommand) SetArgs(args []string) {
+	oc.args = args
+}
+
+func (oc *ociCommand) ServerDetails() (*config.ServerDetails, error) {
+	return oc.ContainerCommandBase.ServerDetails(), nil
+}
+
+// Parses the reference of the command, which is the first argument after the flags are extracted.
+func (oc *ociCommand) parseReference(args []string, expectedArgs string) (otherArgs []string, err error) {
+	if len(args) == 0 {
+		return nil, errorutils.CheckErrorf("wrong number of arguments. Expected: %s", expectedArgs)
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return nil, errorutils.CheckErrorf("unknown option %s", arg)
+		}
+	}
+	if oc.reference, err = parseOciReference(args[0]); err != nil {
+		return nil, err
+	}
+	oc.SetImageTag(oc.reference.String())
+	return args[1:], nil
+}
+
+func (oc *ociCommand) createRegistryClient() (*registryClient, error) {
+	return newRegistryClient(oc.reference, oc.ContainerCommandBase.ServerDetails(), oc.skipLogin)
+}
+
+// Returns the module of the artifact, which is named after its repository and tag, unless --module is provided.
+func (oc *ociCommand) createModule(properties map[string]string) buildinfo.Module {
+	moduleId := oc.BuildConfiguration().GetModule()
+	if moduleId == "" {
+		moduleId = path.Base(oc.reference.repository)
+		if oc.reference.tag != "" {
+			moduleId += ":" + oc.reference.tag
+		} else {
+			moduleId += "@" + oc.reference.digest
+		}
+	}
+	properties[imageTagProperty] = oc.reference.String()
+	return buildinfo.Module{Id: moduleId, Type: buildinfo.Docker, Properties: properties}
+}
+
+func (oc *ociCommand) saveBuildInfo(module buildinfo.Module) error {
+	buildConfiguration := oc.BuildConfiguration()
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	project := buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, project); err != nil {
+		return err
+	}
+	return build.SaveBuildInfo(buildName, buildNumber, project, &buildinfo.BuildInfo{Modules: []buildinfo.Module{module}})
+}
+
+// Extracts all the values of a flag, which may be provided more than once.
+func extractFlagValues(args *[]string, flagName string) (values []string, err error) {
+	for {
+		flagIndex, valueIndex, value, err := coreutils.FindFlag(flagName, *args)
+		if err != nil || flagIndex == -1 {
+			return values, err
+		}
+		coreutils.RemoveFlagFromCommand(args, flagIndex, valueIndex)
+		values = append(values, value)
+	}
+}
+
+// Extracts the value of a flag, which may be provided once.
+func extractFlagValue(args *[]string, flagName string) (string, error) {
+	values, err := extractFlagValues(args, flagName)
+	if err != nil || len(values) == 0 {
+		return "", err
+	}
+	if len(values) > 1 {
+		return "", errorutils.CheckErrorf("the %s option can be provided only once", flagName)
+	}
+	return values[0], nil
+}
+
+// Splits a file argument, such as 'sbom.json:application/spdx+json', to the path of the file and its media type.
+// The media type is optional. Windows drive letters aren't mistaken for media types, since media types include a slash.
+func splitFileMediaType(fileArg string) (filePath, mediaType string) {
+	separator := strings.LastIndex(fileArg, ":")
+	if separator > 1 && strings.Contains(fileArg[separator+1:], "/") {
+		return fileArg[:separator], fileArg[separator+1:]
+	}
+	return fileArg, ""
+}
+
+// A file which is pushed as a blob of an artifact.
+type ociFile struct {
+	path       string
+	descriptor ociDescriptor
+	checksum   buildinfo.Checksum
+}
+
+func newOciFile(fileArg, defaultMediaType string) (*ociFile, error) {
+	filePath, mediaType := splitFileMediaType(fileArg)
+	if mediaType == "" {
+		mediaType = defaultMediaType
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if info.IsDir() {
+		return nil, errorutils.CheckErrorf("%s is a directory. Only files can be pushed, so archive the directory first", filePath)
+	}
+	checksums, err := biutils.GetFileChecksums(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return &ociFile{
+		path:       filePath,
+		descriptor: ociDescriptor{MediaType: mediaType, Digest: "sha256:" + checksums[biutils.SHA256], Size: info.Size()},
+		checksum:   buildinfo.Checksum{Md5: checksums[biutils.MD5], Sha1: checksums[biutils.SHA1], Sha256: checksums[biutils.SHA256]},
+	}, nil
+}
+
+func calcChecksum(data []byte) (buildinfo.Checksum, error) {
+	checksums, err := biutils.CalcChecksums(bytes.NewReader(data))
+	if err != nil {
+		return buildinfo.Checksum{}, errorutils.CheckError(err)
+	}
+	return buildinfo.Checksum{Md5: checksums[biutils.MD5], Sha1: checksums[biutils.SHA1], Sha256: checksums[biutils.SHA256]}, nil
+}
+
+// The name of a blob in Artifactory. For example: sha256__30daa5c11544632449b01f450bebfef6b89644e9e683258ed05797abe7c32a6e
+func blobFileName(digest string) string {
+	return strings.Replace(digest, ":", "__", 1)
+}
+
+// Pushes files to an OCI registry as the blobs of an artifact, with an OCI image manifest of the artifact type.
+// The manifest may refer to a subject manifest, such as the image which an SBOM or a signature describes.
+type OciPushCommand struct {
+	ociCommand
+	files        []string
+	configFile   string
+	artifactType string
+	annotations  map[string]string
+	subject      string
+}
+
+func NewOciPushCommand() *OciPushCommand {
+	return &OciPushCommand{}
+}
+
+func (opc *OciPushCommand) CommandName() string {
+	return "rt_oci_push"
+}
+
+// Parses the arguments: <reference> <file>[:<media type>]... [--artifact-type=<type>] [--config=<file>[:<media type>]] [--annotation=<key>=<value>]... [--subject=<tag or digest>]
+func (opc *OciPushCommand) Init() (err error) {
+	args := append([]string{}, opc.args...)
+	if opc.artifactType, err = extractFlagValue(&args, artifactTypeFlag); err != nil {
+		return
+	}
+	if opc.configFile, err = extractFlagValue(&args, configFlag); err != nil {
+		return
+	}
+	if opc.subject, err = extractFlagValue(&args, subjectFlag); err != nil {
+		return
+	}
+	annotations, err := extractFlagValues(&args, annotationFlag)
+	if err != nil {
+		return
+	}
+	if opc.annotations, err = parseAnnotations(annotations); err != nil {
+		return
+	}
+	if opc.files, err = opc.parseReference(args, "<reference> <file>[:<media type>]..."); err != nil {
+		return
+	}
+	if opc.reference.digest != "" {
+		return errorutils.CheckErrorf("artifacts are pushed to tags, so the reference %s can't include a digest", opc.reference.String())
+	}
+	if len(opc.files) == 0 && opc.subject == "" {
+		return errorutils.CheckErrorf("no files to push were provided")
+	}
+	return
+}
+
+func parseAnnotations(annotations []string) (map[string]string, error) {
+	if len(annotations) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]string, len(annotations))
+	for _, annotation := range annotations {
+		key, value, found := strings.Cut(annotation, "=")
+		if !found || key == "" {
+			return nil, errorutils.CheckErrorf("invalid annotation '%s'. The expected format is <key>=<value>", annotation)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+func (opc *OciPushCommand) Run() error {
+	configFile, layers, err := opc.readFiles()
+	if err != nil {
+		return err
+	}
+	client, err := opc.createRegistryClient()
+	if err != nil {
+		return err
+	}
+	manifest := &ociManifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		ArtifactType:  opc.artifactType,
+		Config:        ociDescriptor{MediaType: ociEmptyMediaType, Digest: calcDigest(ociEmptyConfig), Size: int64(len(ociEmptyConfig))},
+		Annotations:   opc.annotations,
+	}
+	if configFile != nil {
+		manifest.Config = configFile.descriptor
+		if err = pushFile(client, configFile); err != nil {
+			return err
+		}
+	} else {
+		if manifest.ArtifactType == "" {
+			// An artifact type is required when the config is empty.
+			manifest.ArtifactType = ociDefaultArtifactType
+		}
+		if err = client.pushBlob(manifest.Config, bytes.NewReader(ociEmptyConfig)); err != nil {
+			return err
+		}
+	}
+	for _, layer := range layers {
+		log.Info("Uploading", layer.path, "as", layer.descriptor.Digest+"...")
+		if err = pushFile(client, layer); err != nil {
+			return err
+		}
+		manifest.Layers = append(manifest.Layers, layer.descriptor)
+	}
+	if len(manifest.Layers) == 0 {
+		// An artifact without files, such as an attestation which consists of its annotations, has the empty blob as its only layer.
+		manifest.Layers = []ociDescriptor{manifest.Config}
+	}
+	if opc.subject != "" {
+		if manifest.Subject, err = opc.resolveSubject(client); err != nil {
+			return err
+		}
+	}
+	manifestContent, err := json.Marshal(manifest)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	digest, subjectProcessed, err := client.pushManifest(opc.reference.Ref(), ociManifestMediaType, manifestContent)
+	if err != nil {
+		return err
+	}
+	if manifest.Subject != nil && !subjectProcessed {
+		log.Debug("The registry didn't process the subject of the manifest. Adding the manifest to the referrers tag of", manifest.Subject.Digest)
+		referrer := ociDescriptor{MediaType: ociManifestMediaType, Digest: digest, Size: int64(len(manifestContent)), ArtifactType: manifest.ArtifactType, Annotations: manifest.Annotations}
+		if err = client.addToReferrersTag(manifest.Subject.Digest, referrer); err != nil {
+			return err
+		}
+	}
+	log.Info("Pushed " + opc.reference.String() + "@" + digest)
+
+	collectBuildInfo, err := opc.BuildConfiguration().IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	return opc.collectBuildInfo(client, manifest, manifestContent, digest, append(layers, configFile))
+}
+
+// Reads the config file, if provided, and the files of the layers, which are annotated with their file names.
+func (opc *OciPushCommand) readFiles() (configFile *ociFile, layers []*ociFile, err error) {
+	if opc.configFile != "" {
+		if configFile, err = newOciFile(opc.configFile, ociEmptyMediaType); err != nil {
+			return
+		}
+	}
+	for _, fileArg := range opc.files {
+		layer, err := newOciFile(fileArg, ociDefaultLayerMediaType)
+		if err != nil {
+			return nil, nil, err
+		}
+		layer.descriptor.Annotations = map[string]string{ociTitleAnnotation: filepath.Base(layer.path)}
+		layers = append(layers, layer)
+	}
+	return
+}
+
+func pushFile(client *registryClient, file *ociFile) (err error) {
+	reader, err := os.Open(file.path)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer ioutils.Close(reader, &err)
+	return client.pushBlob(file.descriptor, reader)
+}
+
+// Returns the descriptor of the subject manifest, which is referenced by its tag or digest in the repository of the pushed artifact.
+func (opc *OciPushCommand) resolveSubject(client *registryClient) (*ociDescriptor, error) {
+	ref := strings.TrimPrefix(opc.subject, "@")
+	if separator := strings.LastIndex(ref, ":"); !strings.HasPrefix(ref, "sha256:") && separator >= 0 {
+		// The subject may be provided as a tag.
+		ref = ref[separator+1:]
+	}
+	subject, _, err := client.fetchManifest(ref)
+	if err != nil {
+		return nil, err
+	}
+	if subject == nil {
+		return nil, errorutils.CheckErrorf("the subject %s wasn't found in %s", opc.subject, opc.reference.registry+"/"+opc.reference.repository)
+	}
+	return subject, nil
+}
+
+// Records the manifest and the blobs of the artifact as the artifacts of its module, and sets the build properties on them in Artifactory.
+func (opc *OciPushCommand) collectBuildInfo(client *registryClient, manifest *ociManifest, manifestContent []byte, digest string, files []*ociFile) error {
+	repo := client.artifactoryRepo
+	if repo == "" {
+		var err error
+		if repo, err = opc.GetRepo(); err != nil {
+			return err
+		}
+	}
+	folder := path.Join(artifactoryImagePath(opc.reference, repo), blobFileName(opc.reference.Ref()))
+	manifestChecksum, err := calcChecksum(manifestContent)
+	if err != nil {
+		return err
+	}
+	artifacts := []buildinfo.Artifact{{Name: ociManifestArtifactName, Type: ociManifestArtifactType, Checksum: manifestChecksum}}
+	if manifest.Config.MediaType == ociEmptyMediaType {
+		emptyChecksum, err := calcChecksum(ociEmptyConfig)
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, buildinfo.Artifact{Name: blobFileName(manifest.Config.Digest), Checksum: emptyChecksum})
+	}
+	added := map[string]bool{}
+	for _, file := range files {
+		if file == nil || added[file.descriptor.Digest] {
+			continue
+		}
+		added[file.descriptor.Digest] = true
+		artifacts = append(artifacts, buildinfo.Artifact{Name: blobFileName(file.descriptor.Digest), Checksum: file.checksum})
+	}
+	for i := range artifacts {
+		artifacts[i].Path = path.Join(folder, artifacts[i].Name)
+		artifacts[i].OriginalDeploymentRepo = repo
+	}
+	if err = opc.setBuildProperties(repo, artifacts); err != nil {
+		return err
+	}
+	properties := map[string]string{imageManifestDigestProperty: digest, ociArtifactTypeProperty: manifest.ArtifactType}
+	if manifest.Subject != nil {
+		properties[ociSubjectDigestProperty] = manifest.Subject.Digest
+	}
+	module := opc.createModule(properties)
+	module.Artifacts = artifacts
+	return opc.saveBuildInfo(module)
+}
+
+// Sets the build properties on the manifest and the blobs of the artifact in Artifactory, as 'jf docker push' sets them on the image layers.
+func (opc *OciPushCommand) setBuildProperties(repo string, artifacts []buildinfo.Artifact) error {
+	var items []servicesutils.ResultItem
+	for _, artifact := range artifacts {
+		items = append(items, servicesutils.ResultItem{Repo: repo, Path: path.Dir(artifact.Path), Name: artifact.Name, Type: "file"})
+	}
+	return setItemsBuildProperties(opc.ContainerCommandBase.ServerDetails(), opc.BuildConfiguration(), items)
+}
+
+// Sets the build properties on the files in Artifactory.
+func setItemsBuildProperties(serverDetails *config.ServerDetails, buildConfiguration *build.BuildConfiguration, items []servicesutils.ResultItem) (err error) {
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return
+	}
+	props, err := build.CreateBuildProperties(buildName, buildNumber, buildConfiguration.GetProject())
+	if err != nil {
+		return
+	}
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return
+	}
+	for _, item := range items {
+		writer.Write(item)
+	}
+	if err = writer.Close(); err != nil {
+		return
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer ioutils.Close(reader, &err)
+	serviceManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return
+	}
+	_, err = serviceManager.SetProps(services.PropsParams{Reader: reader, Props: props})
+	return
+}
+
+// Pulls the files of an artifact, which are the layers annotated with their file names, into the output directory.
+// The content of each file is verified against its digest.
+type OciPullCommand struct {
+	ociCommand
+	outputDir string
+}
+
+func NewOciPullCommand() *OciPullCommand {
+	return &OciPullCommand{}
+}
+
+func (opc *OciPullCommand) CommandName() string {
+	return "rt_oci_pull"
+}
+
+// Parses the arguments: <reference> [--output=<directory>]
+func (opc *OciPullCommand) Init() (err error) {
+	args := append([]string{}, opc.args...)
+	if opc.outputDir, err = extractFlagValue(&args, outputFlag); err != nil {
+		return
+	}
+	if opc.outputDir == "" {
+		opc.outputDir = "."
+	}
+	otherArgs, err := opc.parseReference(args, "<reference>")
+	if err != nil {
+		return
+	}
+	if len(otherArgs) > 0 {
+		return errorutils.CheckErrorf("unexpected arguments: %s", strings.Join(otherArgs, " "))
+	}
+	return
+}
+
+func (opc *OciPullCommand) Run() error {
+	client, err := opc.createRegistryClient()
+	if err != nil {
+		return err
+	}
+	descriptor, manifestContent, err := client.fetchManifest(opc.reference.Ref())
+	if err != nil {
+		return err
+	}
+	if descriptor == nil {
+		return errorutils.CheckErrorf("%s wasn't found", opc.reference.String())
+	}
+	if descriptor.MediaType == ociImageIndexMediaType || descriptor.MediaType == dockerManifestListMedia {
+		return errorutils.CheckErrorf("%s is an image index. Pull one of its manifests by its digest", opc.reference.String())
+	}
+	manifest := new(ociManifest)
+	if err = json.Unmarshal(manifestContent, manifest); err != nil {
+		return errorutils.CheckErrorf("failed parsing the manifest of %s: %s", opc.reference.String(), err.Error())
+	}
+	if err = os.MkdirAll(opc.outputDir, 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	manifestChecksum, err := calcChecksum(manifestContent)
+	if err != nil {
+		return err
+	}
+	dependencies := []buildinfo.Dependency{{Id: ociManifestArtifactName, Type: ociManifestArtifactType, Checksum: manifestChecksum}}
+	pulled := 0
+	for _, layer := range manifest.Layers {
+		title := layer.Annotations[ociTitleAnnotation]
+		if title == "" {
+			log.Debug("Skipping the layer", layer.Digest, "which has no file name annotation.")
+			continue
+		}
+		checksum, err := pullFile(client, layer, opc.outputDir, title)
+		if err != nil {
+			return err
+		}
+		pulled++
+		dependencies = append(dependencies, buildinfo.Dependency{Id: blobFileName(layer.Digest), Checksum: checksum})
+	}
+	if pulled == 0 {
+		log.Warn("None of the layers of " + opc.reference.String() + " is annotated with a file name, so no files were pulled.")
+	}
+	log.Info("Pulled", pulled, "files of", opc.reference.String()+"@"+descriptor.Digest)
+
+	collectBuildInfo, err := opc.BuildConfiguration().IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	properties := map[string]string{imageManifestDigestProperty: descriptor.Digest}
+	if manifest.ArtifactType != "" {
+		properties[ociArtifactTypeProperty] = manifest.ArtifactType
+	}
+	module := opc.createModule(properties)
+	module.Dependencies = dependencies
+	return opc.saveBuildInfo(module)
+}
+
+// Downloads the blob into a temporary file in the output directory, and renames it to its file name once its digest is verified.
+func pullFile(client *registryClient, layer ociDescriptor, outputDir, fileName string) (checksum buildinfo.Checksum, err error) {
+	if !filepath.IsLocal(fileName) {
+		return checksum, errorutils.CheckErrorf("the file name '%s' of the layer %s is outside the output directory", fileName, layer.Digest)
+	}
+	targetPath := filepath.Join(outputDir, fileName)
+	if err = os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return checksum, errorutils.CheckError(err)
+	}
+	tempFile, err := os.CreateTemp(filepath.Dir(targetPath), "."+filepath.Base(fileName)+".*.part")
+	if err != nil {
+		return checksum, errorutils.CheckError(err)
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, errorutils.CheckError(os.Remove(tempFile.Name())))
+		}
+	}()
+	log.Info("Downloading", fileName, "from", layer.Digest+"...")
+	err = client.fetchBlob(layer.Digest, tempFile)
+	if err = errors.Join(err, errorutils.CheckError(tempFile.Close())); err != nil {
+		return
+	}
+	checksums, err := biutils.GetFileChecksums(tempFile.Name())
+	if err != nil {
+		return checksum, errorutils.CheckError(err)
+	}
+	if actualDigest := "sha256:" + checksums[biutils.SHA256]; actualDigest != layer.Digest {
+		return checksum, errorutils.CheckErrorf("the digest of the downloaded file %s is %s, while its expected digest is %s", fileName, actualDigest, layer.Digest)
+	}
+	if err = os.Rename(tempFile.Name(), targetPath); err != nil {
+		return checksum, errorutils.CheckError(err)
+	}
+	return buildinfo.Checksum{Md5: checksums[biutils.MD5], Sha1: checksums[biutils.SHA1], Sha256: checksums[biutils.SHA256]}, nil
+}
+
+// Lists the referrers of an artifact or an image, such as its SBOMs and signatures, optionally filtered by their artifact type.
+type OciDiscoverCommand struct {
+	ociCommand
+	artifactType string
+	referrers    []ociDescriptor
+}
+
+func NewOciDiscoverCommand() *OciDiscoverCommand {
+	return &OciDiscoverCommand{}
+}
+
+func (odc *OciDiscoverCommand) CommandName() string {
+	return "rt_oci_discover"
+}
+
+// Parses the arguments: <reference> [--artifact-type=<type>]
+func (odc *OciDiscoverCommand) Init() (err error) {
+	args := append([]string{}, odc.args...)
+	if odc.artifactType, err = extractFlagValue(&args, artifactTypeFlag); err != nil {
+		return
+	}
+	otherArgs, err := odc.parseReference(args, "<reference>")
+	if err != nil {
+		return
+	}
+	if len(otherArgs) > 0 {
+		return errorutils.CheckErrorf("unexpected arguments: %s", strings.Join(otherArgs, " "))
+	}
+	return
+}
+
+func (odc *OciDiscoverCommand) Run() error {
+	client, err := odc.createRegistryClient()
+	if err != nil {
+		return err
+	}
+	digest := odc.reference.digest
+	if digest == "" {
+		descriptor, _, err := client.fetchManifest(odc.reference.tag)
+		if err != nil {
+			return err
+		}
+		if descriptor == nil {
+			return errorutils.CheckErrorf("%s wasn't found", odc.reference.String())
+		}
+		digest = descriptor.Digest
+	}
+	if odc.referrers, err = client.fetchReferrers(digest, odc.artifactType); err != nil {
+		return err
+	}
+	sort.SliceStable(odc.referrers, func(i, j int) bool {
+		return odc.referrers[i].ArtifactType < odc.referrers[j].ArtifactType
+	})
+	log.Debug("Found", len(odc.referrers), "referrers of", digest)
+	if odc.referrers == nil {
+		odc.referrers = []ociDescriptor{}
+	}
+	output, err := json.MarshalIndent(odc.referrers, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(string(output))
+	return nil
+}
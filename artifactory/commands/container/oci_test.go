@@ -0,0 +1,145 @@
+package container
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitFileMediaType(t *testing.T) {
+	tests := []struct {
+		fileArg   string
+		filePath  string
+		mediaType string
+	}{
+		{"sbom.json", "sbom.json", ""},
+		{"sbom.json:application/spdx+json", "sbom.json", "application/spdx+json"},
+		{`C:\work\sbom.json`, `C:\work\sbom.json`, ""},
+		{`C:\work\sbom.json:application/spdx+json`, `C:\work\sbom.json`, "application/spdx+json"},
+		{"dir/model:v1.bin", "dir/model:v1.bin", ""},
+	}
+	for _, test := range tests {
+		filePath, mediaType := splitFileMediaType(test.fileArg)
+		assert.Equal(t, test.filePath, filePath, test.fileArg)
+		assert.Equal(t, test.mediaType, mediaType, test.fileArg)
+	}
+}
+
+func TestOciPushCommandInit(t *testing.T) {
+	pushCommand := NewOciPushCommand()
+	pushCommand.SetArgs([]string{"my.jfrog.io/docker-local/sbom:1.0", "--artifact-type=application/spdx+json", "sbom.json:application/spdx+json",
+		"--annotation", "org.opencontainers.image.source=https://github.com/org/app", "--annotation=team=platform", "--subject", "app:1.0"})
+	require.NoError(t, pushCommand.Init())
+	assert.Equal(t, []string{"sbom.json:application/spdx+json"}, pushCommand.files)
+	assert.Equal(t, "application/spdx+json", pushCommand.artifactType)
+	assert.Equal(t, "app:1.0", pushCommand.subject)
+	assert.Equal(t, map[string]string{"org.opencontainers.image.source": "https://github.com/org/app", "team": "platform"}, pushCommand.annotations)
+	assert.Equal(t, "docker-local/sbom", pushCommand.reference.repository)
+
+	for _, args := range [][]string{
+		{"my.jfrog.io/docker-local/sbom:1.0"},
+		{"my.jfrog.io/docker-local/sbom@sha256:abc", "sbom.json"},
+		{"my.jfrog.io/docker-local/sbom:1.0", "sbom.json", "--annotation", "no-value"},
+		{"my.jfrog.io/docker-local/sbom:1.0", "sbom.json", "--unknown"},
+	} {
+		pushCommand = NewOciPushCommand()
+		pushCommand.SetArgs(args)
+		assert.Error(t, pushCommand.Init(), args)
+	}
+}
+
+func TestOciPushPullAndDiscover(t *testing.T) {
+	for _, test := range []struct {
+		name              string
+		supportsReferrers bool
+	}{
+		{"referrers API", true},
+		{"referrers tag", false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			registry := newTestRegistry(t, test.supportsReferrers)
+			tempDir := t.TempDir()
+			modelPath := filepath.Join(tempDir, "model.bin")
+			require.NoError(t, os.WriteFile(modelPath, []byte("weights"), 0644))
+			sbomPath := filepath.Join(tempDir, "sbom.json")
+			require.NoError(t, os.WriteFile(sbomPath, []byte(`{"spdxVersion":"SPDX-2.3"}`), 0644))
+			reference := registry.host() + "/" + testRegistryRepo + "/models/classifier"
+
+			// Push the model, and an SBOM which refers to it.
+			modelPush := NewOciPushCommand()
+			runOciCommand(t, registry, modelPush, &modelPush.ociCommand, []string{reference + ":1.0", modelPath + ":application/vnd.example.model.v1", "--artifact-type", "application/vnd.example.model"})
+			sbomPush := NewOciPushCommand()
+			runOciCommand(t, registry, sbomPush, &sbomPush.ociCommand, []string{reference + ":1.0-sbom", sbomPath + ":application/spdx+json",
+				"--artifact-type=application/spdx+json", "--annotation=org.opencontainers.image.created=2024-01-01T00:00:00Z", "--subject=1.0"})
+
+			manifest := new(ociManifest)
+			require.NoError(t, json.Unmarshal(registry.manifests["1.0-sbom"], manifest))
+			assert.Equal(t, "application/spdx+json", manifest.ArtifactType)
+			assert.Equal(t, ociEmptyMediaType, manifest.Config.MediaType)
+			assert.Equal(t, map[string]string{"org.opencontainers.image.created": "2024-01-01T00:00:00Z"}, manifest.Annotations)
+			require.Len(t, manifest.Layers, 1)
+			assert.Equal(t, "sbom.json", manifest.Layers[0].Annotations[ociTitleAnnotation])
+			require.NotNil(t, manifest.Subject)
+			modelDigest := calcDigest(registry.manifests["1.0"])
+			assert.Equal(t, modelDigest, manifest.Subject.Digest)
+
+			// Discover the SBOM by the model.
+			discover := NewOciDiscoverCommand()
+			runOciCommand(t, registry, discover, &discover.ociCommand, []string{reference + ":1.0", "--artifact-type", "application/spdx+json"})
+			require.Len(t, discover.referrers, 1)
+			assert.Equal(t, calcDigest(registry.manifests["1.0-sbom"]), discover.referrers[0].Digest)
+
+			// Pull the model by its digest.
+			outputDir := filepath.Join(tempDir, "out")
+			pull := NewOciPullCommand()
+			runOciCommand(t, registry, pull, &pull.ociCommand, []string{reference + "@" + modelDigest, "--output", outputDir})
+			content, err := os.ReadFile(filepath.Join(outputDir, "model.bin"))
+			require.NoError(t, err)
+			assert.Equal(t, "weights", string(content))
+			assert.False(t, registry.unauthorizedAccess)
+		})
+	}
+}
+
+type testOciCommand interface {
+	Init() error
+	Run() error
+}
+
+// Runs the command against the test registry, without build-info collection.
+func runOciCommand(t *testing.T, registry *testRegistry, command testOciCommand, base *ociCommand, args []string) {
+	base.SetArgs(args)
+	base.SetServerDetails(registry.serverDetails()).SetBuildConfiguration(build.NewBuildConfiguration("", "", "", ""))
+	require.NoError(t, command.Init())
+	require.NoError(t, command.Run())
+}
+
+func TestPullFileOutsideOutputDir(t *testing.T) {
+	registry := newTestRegistry(t, true)
+	ref, err := parseOciReference(registry.host() + "/" + testRegistryRepo + "/app:1.0")
+	require.NoError(t, err)
+	client, err := newRegistryClient(ref, registry.serverDetails(), true)
+	require.NoError(t, err)
+	_, err = pullFile(client, ociDescriptor{Digest: calcDigest([]byte("content"))}, t.TempDir(), "../outside.txt")
+	assert.ErrorContains(t, err, "outside the output directory")
+}
+
+func TestPullFileDigestMismatch(t *testing.T) {
+	registry := newTestRegistry(t, true)
+	ref, err := parseOciReference(registry.host() + "/" + testRegistryRepo + "/app:1.0")
+	require.NoError(t, err)
+	client, err := newRegistryClient(ref, registry.serverDetails(), false)
+	require.NoError(t, err)
+	registry.blobs[calcDigest([]byte("expected"))] = []byte("tampered")
+	outputDir := t.TempDir()
+	_, err = pullFile(client, ociDescriptor{Digest: calcDigest([]byte("expected"))}, outputDir, "file.txt")
+	assert.ErrorContains(t, err, "expected digest")
+	entries, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,317 @@
+package container
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/gofrog/unarchive"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	fromOciLayoutFlag = "--from-oci-layout"
+
+	ociLayoutFileName    = "oci-layout"
+	ociLayoutIndexName   = "index.json"
+	ociLayoutBlobsDir    = "blobs"
+	ociRefNameAnnotation = "org.opencontainers.image.ref.name"
+)
+
+// The digests of the blobs of a layout are validated, since they are used as paths in the layout.
+var ociDigestRegex = regexp.MustCompile(`^(sha256:[a-f0-9]{64}|sha512:[a-f0-9]{128})$`)
+
+// A local OCI image layout, such as the output of 'kaniko --oci-layout-path', 'buildah push <image> oci:<dir>',
+// 'podman save --format oci-archive' or 'docker buildx build --output type=oci'.
+type ociLayout struct {
+	dir   string
+	index ociIndex
+	// The temporary directory, into which the layout tarball was extracted.
+	tempDir string
+}
+
+// Opens a layout directory, or a layout tarball, which is extracted into a temporary directory.
+func openOciLayout(layoutPath string) (layout *ociLayout, err error) {
+	info, err := os.Stat(layoutPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	layout = &ociLayout{dir: layoutPath}
+	if !info.IsDir() {
+		if layout.tempDir, err = fileutils.CreateTempDir(); err != nil {
+			return nil, err
+		}
+		layout.dir = layout.tempDir
+		defer func() {
+			if err != nil {
+				err = errors.Join(err, layout.close())
+			}
+		}()
+		if err = extractOciLayout(layoutPath, layout.tempDir); err != nil {
+			return nil, err
+		}
+	}
+	if err = layout.readIndex(); err != nil {
+		return nil, err
+	}
+	return layout, nil
+}
+
+func extractOciLayout(tarballPath, targetDir string) error {
+	archiveName := filepath.Base(tarballPath)
+	if !unarchive.IsSupportedArchive(archiveName) {
+		// OCI archives are tarballs, which may be saved without an extension, such as the output of 'podman save -o image'.
+		archiveName += ".tar"
+	}
+	log.Debug("Extracting the OCI layout", tarballPath, "to", targetDir)
+	unarchiver := &unarchive.Unarchiver{}
+	if err := unarchiver.Unarchive(tarballPath, archiveName, targetDir); err != nil {
+		return errorutils.CheckErrorf("failed extracting the OCI layout tarball %s: %s", tarballPath, err.Error())
+	}
+	return nil
+}
+
+func (ol *ociLayout) readIndex() error {
+	content, err := os.ReadFile(filepath.Join(ol.dir, ociLayoutFileName))
+	if err != nil {
+		return errorutils.CheckErrorf("%s isn't an OCI image layout, since it has no %s file", ol.dir, ociLayoutFileName)
+	}
+	var layoutFile struct {
+		ImageLayoutVersion string `json:"imageLayoutVersion"`
+	}
+	if err = json.Unmarshal(content, &layoutFile); err != nil || layoutFile.ImageLayoutVersion == "" {
+		return errorutils.CheckErrorf("the %s file of the OCI image layout is invalid", ociLayoutFileName)
+	}
+	if content, err = os.ReadFile(filepath.Join(ol.dir, ociLayoutIndexName)); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = json.Unmarshal(content, &ol.index); err != nil {
+		return errorutils.CheckErrorf("failed parsing the %s file of the OCI image layout: %s", ociLayoutIndexName, err.Error())
+	}
+	return nil
+}
+
+func (ol *ociLayout) close() error {
+	if ol.tempDir == "" {
+		return nil
+	}
+	return fileutils.RemoveTempDir(ol.tempDir)
+}
+
+// Returns the manifest of the layout, which is annotated with the tag. If the layout has a single manifest, it's returned regardless of its annotation.
+func (ol *ociLayout) selectManifest(tag string) (*ociDescriptor, error) {
+	var refNames []string
+	for i, manifest := range ol.index.Manifests {
+		refName := manifest.Annotations[ociRefNameAnnotation]
+		if refName == "" {
+			continue
+		}
+		// The reference name may be the tag, or a full reference, such as docker.io/library/app:1.0.
+		if refName == tag || strings.HasSuffix(refName, ":"+tag) {
+			return &ol.index.Manifests[i], nil
+		}
+		refNames = append(refNames, refName)
+	}
+	switch len(ol.index.Manifests) {
+	case 0:
+		return nil, errorutils.CheckErrorf("the OCI image layout has no manifests")
+	case 1:
+		return &ol.index.Manifests[0], nil
+	default:
+		return nil, errorutils.CheckErrorf("the OCI image layout has %d manifests, and none of them is annotated with the tag '%s'. The annotated references are: %s",
+			len(ol.index.Manifests), tag, strings.Join(refNames, ", "))
+	}
+}
+
+// Returns the path of the blob in the layout, after verifying that it exists with the expected size.
+func (ol *ociLayout) blobPath(descriptor ociDescriptor) (string, error) {
+	if !ociDigestRegex.MatchString(descriptor.Digest) {
+		return "", errorutils.CheckErrorf("invalid digest '%s' in the OCI image layout", descriptor.Digest)
+	}
+	algorithm, encoded, _ := strings.Cut(descriptor.Digest, ":")
+	blobPath := filepath.Join(ol.dir, ociLayoutBlobsDir, algorithm, encoded)
+	info, err := os.Stat(blobPath)
+	if err != nil {
+		return "", errorutils.CheckErrorf("the blob %s wasn't found in the OCI image layout: %s", descriptor.Digest, err.Error())
+	}
+	if info.Size() != descriptor.Size {
+		return "", errorutils.CheckErrorf("the size of the blob %s in the OCI image layout is %d, while its expected size is %d", descriptor.Digest, info.Size(), descriptor.Size)
+	}
+	return blobPath, nil
+}
+
+// Reads a manifest or an index from the layout, and verifies its digest.
+func (ol *ociLayout) readManifest(descriptor ociDescriptor) ([]byte, error) {
+	blobPath, err := ol.blobPath(descriptor)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(blobPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if actualDigest := calcDigest(content); strings.HasPrefix(descriptor.Digest, "sha256:") && actualDigest != descriptor.Digest {
+		return nil, errorutils.CheckErrorf("the digest of the manifest %s in the OCI image layout is %s", descriptor.Digest, actualDigest)
+	}
+	return content, nil
+}
+
+// Pushes the manifest or the index of the layout to the reference, after pushing its blobs, or the manifests of the index.
+// Returns the content of the pushed manifest or index.
+func (ol *ociLayout) push(client *registryClient, descriptor ociDescriptor, ref string) ([]byte, error) {
+	content, err := ol.readManifest(descriptor)
+	if err != nil {
+		return nil, err
+	}
+	mediaType := descriptor.MediaType
+	if mediaType == "" {
+		var manifest struct {
+			MediaType string `json:"mediaType"`
+		}
+		if err = json.Unmarshal(content, &manifest); err != nil {
+			return nil, errorutils.CheckErrorf("failed parsing the manifest %s in the OCI image layout: %s", descriptor.Digest, err.Error())
+		}
+		mediaType = manifest.MediaType
+	}
+	switch mediaType {
+	case ociImageIndexMediaType, dockerManifestListMedia:
+		index := new(ociIndex)
+		if err = json.Unmarshal(content, index); err != nil {
+			return nil, errorutils.CheckErrorf("failed parsing the image index %s in the OCI image layout: %s", descriptor.Digest, err.Error())
+		}
+		for _, manifest := range index.Manifests {
+			if _, err = ol.push(client, manifest, manifest.Digest); err != nil {
+				return nil, err
+			}
+		}
+	case ociManifestMediaType, dockerManifestMediaType:
+		manifest := new(ociManifest)
+		if err = json.Unmarshal(content, manifest); err != nil {
+			return nil, errorutils.CheckErrorf("failed parsing the manifest %s in the OCI image layout: %s", descriptor.Digest, err.Error())
+		}
+		for _, blob := range append([]ociDescriptor{manifest.Config}, manifest.Layers...) {
+			if err = ol.pushBlob(client, blob); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, errorutils.CheckErrorf("unsupported media type '%s' of the manifest %s in the OCI image layout", mediaType, descriptor.Digest)
+	}
+	if _, _, err = client.pushManifest(ref, mediaType, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func (ol *ociLayout) pushBlob(client *registryClient, descriptor ociDescriptor) (err error) {
+	blobPath, err := ol.blobPath(descriptor)
+	if err != nil {
+		return err
+	}
+	log.Debug("Uploading the blob", descriptor.Digest+"...")
+	reader, err := os.Open(blobPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer ioutils.Close(reader, &err)
+	return client.pushBlob(descriptor, reader)
+}
+
+// Pushes an image from a local OCI image layout directory or tarball to Artifactory, and records it in the build-info.
+// The image is pushed by the credentials of the server, so no container runtime is required.
+// This suits images which are built without a Docker daemon, by kaniko, buildah or podman, in rootless CI sandboxes.
+// An image, which was already pushed to Artifactory by a Tekton task or is tracked by an OpenShift image stream, is only recorded.
+type ContainerBuildInfoCommand struct {
+	ociCommand
+	layoutPath       string
+	tektonResultsDir string
+	imageStream      string
+}
+
+func NewContainerBuildInfoCommand() *ContainerBuildInfoCommand {
+	return &ContainerBuildInfoCommand{}
+}
+
+func (cbc *ContainerBuildInfoCommand) CommandName() string {
+	return "rt_container_build_info"
+}
+
+// Parses the arguments: <image tag> --from-oci-layout=<directory or tarball>, [<image tag>] --from-tekton-results=<directory>
+// or [<image tag>] --from-image-stream=[<namespace>/]<image stream>:<tag>
+func (cbc *ContainerBuildInfoCommand) Init() (err error) {
+	args := append([]string{}, cbc.args...)
+	if cbc.layoutPath, err = extractFlagValue(&args, fromOciLayoutFlag); err != nil {
+		return
+	}
+	if cbc.tektonResultsDir, err = extractFlagValue(&args, fromTektonResultsFlag); err != nil {
+		return
+	}
+	if cbc.imageStream, err = extractFlagValue(&args, fromImageStreamFlag); err != nil {
+		return
+	}
+	sources := 0
+	for _, source := range []string{cbc.layoutPath, cbc.tektonResultsDir, cbc.imageStream} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return errorutils.CheckErrorf("exactly one of the %s, %s and %s options is expected", fromOciLayoutFlag, fromTektonResultsFlag, fromImageStreamFlag)
+	}
+	// The image tag of a pushed image is optional, since it's read from the Tekton results or the image stream.
+	if cbc.layoutPath == "" && len(args) == 0 {
+		return
+	}
+	otherArgs, err := cbc.parseReference(args, "<image tag>")
+	if err != nil {
+		return
+	}
+	if len(otherArgs) > 0 {
+		return errorutils.CheckErrorf("unexpected arguments: %s", strings.Join(otherArgs, " "))
+	}
+	if cbc.reference.digest != "" {
+		return errorutils.CheckErrorf("images are recorded by tags, so the image tag %s can't include a digest", cbc.reference.String())
+	}
+	return
+}
+
+func (cbc *ContainerBuildInfoCommand) Run() (err error) {
+	if cbc.layoutPath == "" {
+		return cbc.recordPushedImage()
+	}
+	layout, err := openOciLayout(cbc.layoutPath)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, layout.close())
+	}()
+	descriptor, err := layout.selectManifest(cbc.reference.tag)
+	if err != nil {
+		return
+	}
+	client, err := cbc.createRegistryClient()
+	if err != nil {
+		return
+	}
+	log.Info("Pushing " + descriptor.Digest + " from the OCI image layout " + cbc.layoutPath + " to " + cbc.reference.String() + "...")
+	content, err := layout.push(client, *descriptor, cbc.reference.tag)
+	if err != nil {
+		return
+	}
+	log.Info("Pushed " + cbc.reference.String() + "@" + descriptor.Digest)
+
+	collectBuildInfo, err := cbc.BuildConfiguration().IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return
+	}
+	return saveImageBuildInfo(&cbc.ContainerCommandBase, cbc.reference.String(), descriptor.Digest, func() ([]byte, error) {
+		return content, nil
+	})
+}
@@ -0,0 +1,146 @@
+package container
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The fixture is a multi-platform image, whose amd64 and arm64 manifests share a layer.
+var (
+	ociLayoutFixture   = filepath.Join("testdata", "ocilayout")
+	fixtureIndexDigest = "sha256:0c3e5c276af9db35bd9bf80744813de955374b99148028d1a9bdf82d4d7eb3cc"
+	fixtureManifests   = []string{"sha256:1402cd3d95a8b71b3b94a56c0790cbd9e7c8ed0011dc8e92a338d793c0e7d3b4", "sha256:0e163fd70690a202e4360b6c0509241c9d6425060e4203b68bbea6ca99c3fc81"}
+	fixtureLayerDigest = "sha256:5dc257a4f713f1433540febc3b3e4a7f9bddc062040afc1ff93cdbf858fa61ef"
+)
+
+func TestContainerBuildInfoCommandInit(t *testing.T) {
+	buildInfoCommand := NewContainerBuildInfoCommand()
+	buildInfoCommand.SetArgs([]string{"--from-oci-layout", "./out", "my.jfrog.io/docker-local/app:1.0"})
+	require.NoError(t, buildInfoCommand.Init())
+	assert.Equal(t, "./out", buildInfoCommand.layoutPath)
+	assert.Equal(t, "docker-local/app", buildInfoCommand.reference.repository)
+
+	for _, args := range [][]string{
+		{"my.jfrog.io/docker-local/app:1.0"},
+		{"--from-oci-layout=./out"},
+		{"--from-oci-layout=./out", "my.jfrog.io/docker-local/app@sha256:abc"},
+		{"--from-oci-layout=./out", "my.jfrog.io/docker-local/app:1.0", "other"},
+	} {
+		buildInfoCommand = NewContainerBuildInfoCommand()
+		buildInfoCommand.SetArgs(args)
+		assert.Error(t, buildInfoCommand.Init(), args)
+	}
+}
+
+func TestPushOciLayout(t *testing.T) {
+	tarballDir := t.TempDir()
+	for _, test := range []struct {
+		name       string
+		layoutPath string
+	}{
+		{"directory", ociLayoutFixture},
+		{"tarball", createLayoutTarball(t, filepath.Join(tarballDir, "image.tar"))},
+		{"tarball without extension", createLayoutTarball(t, filepath.Join(tarballDir, "image"))},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			registry := newTestRegistry(t, true)
+			buildInfoCommand := NewContainerBuildInfoCommand()
+			runOciCommand(t, registry, buildInfoCommand, &buildInfoCommand.ociCommand,
+				[]string{"--from-oci-layout=" + test.layoutPath, registry.host() + "/" + testRegistryRepo + "/app:1.0"})
+
+			assert.Equal(t, fixtureIndexDigest, calcDigest(registry.manifests["1.0"]))
+			index := new(ociIndex)
+			require.NoError(t, json.Unmarshal(registry.manifests["1.0"], index))
+			require.Len(t, index.Manifests, 2)
+			for _, digest := range fixtureManifests {
+				require.Contains(t, registry.manifests, digest)
+				manifest := new(ociManifest)
+				require.NoError(t, json.Unmarshal(registry.manifests[digest], manifest))
+				assert.Contains(t, registry.blobs, manifest.Config.Digest)
+			}
+			assert.Contains(t, registry.blobs, fixtureLayerDigest)
+			assert.False(t, registry.unauthorizedAccess)
+		})
+	}
+}
+
+func TestOciLayoutSelectManifest(t *testing.T) {
+	manifest := func(digest, refName string) ociDescriptor {
+		return ociDescriptor{MediaType: ociManifestMediaType, Digest: digest, Annotations: map[string]string{ociRefNameAnnotation: refName}}
+	}
+	layout := &ociLayout{index: ociIndex{Manifests: []ociDescriptor{manifest("sha256:a", "1.0"), manifest("sha256:b", "docker.io/library/app:2.0")}}}
+	selected, err := layout.selectManifest("1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:a", selected.Digest)
+	selected, err = layout.selectManifest("2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:b", selected.Digest)
+	_, err = layout.selectManifest("3.0")
+	assert.ErrorContains(t, err, "none of them is annotated with the tag '3.0'")
+
+	// A single manifest is selected regardless of its annotation, as kaniko doesn't annotate it.
+	layout = &ociLayout{index: ociIndex{Manifests: []ociDescriptor{{MediaType: ociManifestMediaType, Digest: "sha256:c"}}}}
+	selected, err = layout.selectManifest("1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:c", selected.Digest)
+}
+
+func TestOciLayoutBlobPath(t *testing.T) {
+	layout, err := openOciLayout(ociLayoutFixture)
+	require.NoError(t, err)
+	_, err = layout.blobPath(ociDescriptor{Digest: fixtureLayerDigest, Size: 10240})
+	assert.NoError(t, err)
+	_, err = layout.blobPath(ociDescriptor{Digest: fixtureLayerDigest, Size: 100})
+	assert.ErrorContains(t, err, "expected size is 100")
+	_, err = layout.blobPath(ociDescriptor{Digest: "sha256:../../oci-layout"})
+	assert.ErrorContains(t, err, "invalid digest")
+
+	_, err = openOciLayout(filepath.Join("testdata", "ocilayout", "blobs"))
+	assert.ErrorContains(t, err, "isn't an OCI image layout")
+}
+
+// Archives the layout fixture, as 'podman save --format oci-archive' does.
+func createLayoutTarball(t *testing.T, tarballPath string) string {
+	tarball, err := os.Create(tarballPath)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, tarball.Close())
+	}()
+	writer := tar.NewWriter(tarball)
+	require.NoError(t, filepath.WalkDir(ociLayoutFixture, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || path == ociLayoutFixture {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(ociLayoutFixture, path)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relativePath)
+		if err = writer.WriteHeader(header); err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		_, err = writer.Write(content)
+		return err
+	}))
+	require.NoError(t, writer.Close())
+	return tarballPath
+}
@@ -0,0 +1,165 @@
+package container
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	fromTektonResultsFlag = "--from-tekton-results"
+	fromImageStreamFlag   = "--from-image-stream"
+
+	// The results of the kaniko and buildah Tekton tasks, which are written to /tekton/results.
+	tektonImageUrlResult    = "IMAGE_URL"
+	tektonImageDigestResult = "IMAGE_DIGEST"
+
+	dockerImageKind = "DockerImage"
+)
+
+// Returns the image and its digest from the results directory of a Tekton task, which built and pushed the image.
+// The image URL may include the digest, as the image name with digest file of kaniko does.
+func readTektonResults(resultsDir string) (image, digest string, err error) {
+	readResult := func(name string) (string, error) {
+		content, err := os.ReadFile(filepath.Join(resultsDir, name))
+		if err != nil {
+			return "", errorutils.CheckErrorf("failed reading the %s result of the Tekton task: %s", name, err.Error())
+		}
+		return strings.TrimSpace(string(content)), nil
+	}
+	if image, err = readResult(tektonImageUrlResult); err != nil {
+		return
+	}
+	image, digest, _ = strings.Cut(image, "@")
+	if digest == "" {
+		if digest, err = readResult(tektonImageDigestResult); err != nil {
+			return
+		}
+	}
+	if image == "" || digest == "" {
+		return "", "", errorutils.CheckErrorf("the Tekton results in %s don't include the URL and the digest of the pushed image", resultsDir)
+	}
+	return
+}
+
+// The fields of an OpenShift image stream tag, which identify its image.
+type imageStreamTag struct {
+	Tag *struct {
+		From *struct {
+			Kind string `json:"kind"`
+			Name string `json:"name"`
+		} `json:"from"`
+	} `json:"tag"`
+	Image struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		DockerImageReference string `json:"dockerImageReference"`
+	} `json:"image"`
+}
+
+// Returns the image, which the image stream tag tracks, and its digest, by 'oc get istag'.
+// The image stream tag is provided as [<namespace>/]<image stream>:<tag>.
+func getImageStreamImage(imageStream string) (image, digest string, err error) {
+	ocArgs := []string{"get", "istag"}
+	if namespace, name, found := strings.Cut(imageStream, "/"); found {
+		ocArgs = append(ocArgs, name, "--namespace", namespace)
+	} else {
+		ocArgs = append(ocArgs, imageStream)
+	}
+	ocArgs = append(ocArgs, "--output", "json")
+	log.Debug("Running command: oc", strings.Join(ocArgs, " "))
+	output, err := exec.Command("oc", ocArgs...).Output()
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) {
+			return "", "", errorutils.CheckErrorf("failed getting the image stream tag %s: %s", imageStream, strings.TrimSpace(string(exitError.Stderr)))
+		}
+		return "", "", errorutils.CheckError(err)
+	}
+	return parseImageStreamTag(output, imageStream)
+}
+
+// The image of the image stream tag is the external image, which the tag tracks, such as an image imported from Artifactory by 'oc tag --source=docker'.
+// The image of a tag, which an OpenShift build pushed to the internal registry, is unknown, so it must be provided as an argument.
+func parseImageStreamTag(content []byte, imageStream string) (image, digest string, err error) {
+	istag := new(imageStreamTag)
+	if err = json.Unmarshal(content, istag); err != nil {
+		return "", "", errorutils.CheckErrorf("failed parsing the image stream tag %s: %s", imageStream, err.Error())
+	}
+	digest = istag.Image.Metadata.Name
+	if digest == "" {
+		return "", "", errorutils.CheckErrorf("the image stream tag %s has no image", imageStream)
+	}
+	if istag.Tag != nil && istag.Tag.From != nil && istag.Tag.From.Kind == dockerImageKind {
+		image, _, _ = strings.Cut(istag.Tag.From.Name, "@")
+	}
+	return
+}
+
+// Returns the tagged image and its digest, from the source of the image and the image argument, which overrides the image of the source.
+func (cbc *ContainerBuildInfoCommand) resolvePushedImage() (image, digest string, err error) {
+	if cbc.tektonResultsDir != "" {
+		image, digest, err = readTektonResults(cbc.tektonResultsDir)
+	} else {
+		image, digest, err = getImageStreamImage(cbc.imageStream)
+	}
+	if err != nil {
+		return
+	}
+	if cbc.reference != nil {
+		image = cbc.reference.String()
+	}
+	if image == "" {
+		return "", "", errorutils.CheckErrorf("the image stream tag %s doesn't track an external image. Provide the image tag in Artifactory as an argument", cbc.imageStream)
+	}
+	reference, err := parseOciReference(image)
+	if err != nil {
+		return
+	}
+	if reference.digest != "" && reference.digest != digest {
+		return "", "", errorutils.CheckErrorf("the image %s doesn't match the digest %s of the built image", image, digest)
+	}
+	if reference.tag == "" {
+		return "", "", errorutils.CheckErrorf("the image %s has no tag. Provide the image tag in Artifactory as an argument", image)
+	}
+	reference.digest = ""
+	cbc.reference = reference
+	return reference.String(), digest, nil
+}
+
+// Records an image, which was already pushed to Artifactory by a Tekton task or tracked by an OpenShift image stream, in the build-info.
+func (cbc *ContainerBuildInfoCommand) recordPushedImage() error {
+	image, digest, err := cbc.resolvePushedImage()
+	if err != nil {
+		return err
+	}
+	client, err := cbc.createRegistryClient()
+	if err != nil {
+		return err
+	}
+	descriptor, content, err := client.fetchManifest(digest)
+	if err != nil {
+		return err
+	}
+	if descriptor == nil {
+		return errorutils.CheckErrorf("the image %s@%s wasn't found in Artifactory", image, digest)
+	}
+	collectBuildInfo, err := cbc.BuildConfiguration().IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if !collectBuildInfo {
+		log.Warn("The image " + image + "@" + digest + " isn't recorded, since no build name and build number were provided.")
+		return nil
+	}
+	return saveImageBuildInfo(&cbc.ContainerCommandBase, image, digest, func() ([]byte, error) {
+		return content, nil
+	})
+}
@@ -0,0 +1,102 @@
+package container
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTektonResults(t *testing.T) {
+	resultsDir := t.TempDir()
+	_, _, err := readTektonResults(resultsDir)
+	assert.ErrorContains(t, err, "failed reading the IMAGE_URL result")
+
+	writeTektonResult(t, resultsDir, tektonImageUrlResult, "my.jfrog.io/docker-local/app:1.0\n")
+	writeTektonResult(t, resultsDir, tektonImageDigestResult, "sha256:abc\n")
+	image, digest, err := readTektonResults(resultsDir)
+	require.NoError(t, err)
+	assert.Equal(t, "my.jfrog.io/docker-local/app:1.0", image)
+	assert.Equal(t, "sha256:abc", digest)
+
+	// The image URL may include the digest, as written by the image name with digest file of kaniko.
+	writeTektonResult(t, resultsDir, tektonImageUrlResult, "my.jfrog.io/docker-local/app:2.0@sha256:def")
+	image, digest, err = readTektonResults(resultsDir)
+	require.NoError(t, err)
+	assert.Equal(t, "my.jfrog.io/docker-local/app:2.0", image)
+	assert.Equal(t, "sha256:def", digest)
+}
+
+func TestParseImageStreamTag(t *testing.T) {
+	// A tag, which tracks an image imported from Artifactory.
+	image, digest, err := parseImageStreamTag([]byte(`{"kind":"ImageStreamTag","tag":{"name":"1.0","from":{"kind":"DockerImage","name":"my.jfrog.io/docker-local/app:1.0"}},
+		"image":{"metadata":{"name":"sha256:abc"},"dockerImageReference":"my.jfrog.io/docker-local/app@sha256:abc"}}`), "app:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "my.jfrog.io/docker-local/app:1.0", image)
+	assert.Equal(t, "sha256:abc", digest)
+
+	// A tag, which an OpenShift build pushed to the internal registry.
+	image, digest, err = parseImageStreamTag([]byte(`{"kind":"ImageStreamTag","tag":null,
+		"image":{"metadata":{"name":"sha256:def"},"dockerImageReference":"image-registry.openshift-image-registry.svc:5000/ns/app@sha256:def"}}`), "ns/app:1.0")
+	require.NoError(t, err)
+	assert.Empty(t, image)
+	assert.Equal(t, "sha256:def", digest)
+
+	_, _, err = parseImageStreamTag([]byte(`{"kind":"ImageStreamTag","image":{}}`), "app:1.0")
+	assert.ErrorContains(t, err, "has no image")
+}
+
+func TestContainerBuildInfoCommandInitPushedImage(t *testing.T) {
+	buildInfoCommand := NewContainerBuildInfoCommand()
+	buildInfoCommand.SetArgs([]string{"--from-tekton-results=/tekton/results"})
+	require.NoError(t, buildInfoCommand.Init())
+	assert.Equal(t, "/tekton/results", buildInfoCommand.tektonResultsDir)
+	assert.Nil(t, buildInfoCommand.reference)
+
+	buildInfoCommand = NewContainerBuildInfoCommand()
+	buildInfoCommand.SetArgs([]string{"--from-image-stream", "ns/app:1.0", "my.jfrog.io/docker-local/app:1.0"})
+	require.NoError(t, buildInfoCommand.Init())
+	assert.Equal(t, "ns/app:1.0", buildInfoCommand.imageStream)
+	assert.Equal(t, "docker-local/app", buildInfoCommand.reference.repository)
+
+	buildInfoCommand = NewContainerBuildInfoCommand()
+	buildInfoCommand.SetArgs([]string{"--from-tekton-results=/tekton/results", "--from-image-stream=app:1.0"})
+	assert.ErrorContains(t, buildInfoCommand.Init(), "exactly one of")
+}
+
+func TestRecordTektonImage(t *testing.T) {
+	registry := newTestRegistry(t, true)
+	digest := addTestManifest(t, registry, &ociManifest{SchemaVersion: 2, MediaType: ociManifestMediaType, Layers: []ociDescriptor{testLayer("app")}})
+	image := registry.host() + "/" + testRegistryRepo + "/app:1.0"
+	resultsDir := t.TempDir()
+	writeTektonResult(t, resultsDir, tektonImageUrlResult, image)
+	writeTektonResult(t, resultsDir, tektonImageDigestResult, digest)
+
+	buildInfoCommand := NewContainerBuildInfoCommand()
+	runOciCommand(t, registry, buildInfoCommand, &buildInfoCommand.ociCommand, []string{"--from-tekton-results=" + resultsDir})
+	assert.Equal(t, image, buildInfoCommand.reference.String())
+	assert.False(t, registry.unauthorizedAccess)
+
+	// The image argument overrides the image URL of the results, and must match the digest of the built image.
+	buildInfoCommand = NewContainerBuildInfoCommand()
+	buildInfoCommand.SetArgs([]string{"--from-tekton-results=" + resultsDir, registry.host() + "/" + testRegistryRepo + "/app:2.0"})
+	require.NoError(t, buildInfoCommand.Init())
+	resolvedImage, resolvedDigest, err := buildInfoCommand.resolvePushedImage()
+	require.NoError(t, err)
+	assert.Equal(t, registry.host()+"/"+testRegistryRepo+"/app:2.0", resolvedImage)
+	assert.Equal(t, digest, resolvedDigest)
+
+	writeTektonResult(t, resultsDir, tektonImageUrlResult, image+"@sha256:other")
+	buildInfoCommand = NewContainerBuildInfoCommand()
+	buildInfoCommand.SetArgs([]string{"--from-tekton-results=" + resultsDir})
+	require.NoError(t, buildInfoCommand.Init())
+	buildInfoCommand.SetServerDetails(registry.serverDetails()).SetBuildConfiguration(build.NewBuildConfiguration("", "", "", ""))
+	assert.ErrorContains(t, buildInfoCommand.Run(), "wasn't found in Artifactory")
+}
+
+func writeTektonResult(t *testing.T, resultsDir, name, value string) {
+	require.NoError(t, os.WriteFile(filepath.Join(resultsDir, name), []byte(value), 0600))
+}
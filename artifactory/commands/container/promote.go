@@ -0,0 +1,338 @@
+package container
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The date format accepted by --created-after, in addition to RFC 3339.
+	createdAfterDateFormat = "2006-01-02"
+	// Multi-platform images are stored in Artifactory with a list.manifest.json file, rather than a manifest.json file.
+	listManifestFileName = "list.manifest.json"
+	manifestFileName     = "manifest.json"
+)
+
+// The platform manifests of a multi-platform image are stored by Artifactory under folders named by their digests, rather than by tags.
+var digestFolderRegex = regexp.MustCompile(`^sha256(:|__)[a-f0-9]+$`)
+
+var placeholderRegex = regexp.MustCompile(`\{(\d+)}`)
+
+// Promotes multiple tags of Docker images between repositories, in a single command.
+// The tags are selected by a regular expression, by their creation date, or by the build which pushed them,
+// and may be renamed in the target repository, using placeholders of the groups of the regular expression.
+type DockerBulkPromoteCommand struct {
+	serverDetails *config.ServerDetails
+	// The source and the target of the promotion. The target tag may include {1}, {2}... placeholders.
+	params       services.DockerPromoteParams
+	tagsRegex    *regexp.Regexp
+	createdAfter time.Time
+	build        string
+	project      string
+	dryRun       bool
+	// The promotions which were planned by the last run.
+	promotions []DockerPromotion
+}
+
+// A single tag, which is promoted from the source to the target.
+type DockerPromotion struct {
+	SourceImage  string `col-name:"Source Image"`
+	SourceTag    string `col-name:"Source Tag"`
+	SourceDigest string `col-name:"Source Manifest"`
+	Created      string `col-name:"Created"`
+	TargetImage  string `col-name:"Target Image"`
+	TargetTag    string `col-name:"Target Tag"`
+	// The digest of the manifest which the target tag currently refers to, if it exists. Filled by dry runs only.
+	TargetDigest string `col-name:"Existing Target Manifest"`
+}
+
+func NewDockerBulkPromoteCommand() *DockerBulkPromoteCommand {
+	return &DockerBulkPromoteCommand{}
+}
+
+func (dbp *DockerBulkPromoteCommand) SetServerDetails(serverDetails *config.ServerDetails) *DockerBulkPromoteCommand {
+	dbp.serverDetails = serverDetails
+	return dbp
+}
+
+func (dbp *DockerBulkPromoteCommand) SetParams(params services.DockerPromoteParams) *DockerBulkPromoteCommand {
+	dbp.params = params
+	return dbp
+}
+
+func (dbp *DockerBulkPromoteCommand) SetTagsRegex(tagsRegex string) (*DockerBulkPromoteCommand, error) {
+	if tagsRegex == "" {
+		dbp.tagsRegex = nil
+		return dbp, nil
+	}
+	compiled, err := regexp.Compile(tagsRegex)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("invalid tags regular expression '%s': %s", tagsRegex, err.Error())
+	}
+	dbp.tagsRegex = compiled
+	return dbp, nil
+}
+
+// Sets the date or the time, after which the promoted tags were created. Accepts the YYYY-MM-DD and the RFC 3339 formats.
+func (dbp *DockerBulkPromoteCommand) SetCreatedAfter(createdAfter string) (*DockerBulkPromoteCommand, error) {
+	if createdAfter == "" {
+		dbp.createdAfter = time.Time{}
+		return dbp, nil
+	}
+	parsed, err := time.Parse(createdAfterDateFormat, createdAfter)
+	if err != nil {
+		if parsed, err = time.Parse(time.RFC3339, createdAfter); err != nil {
+			return nil, errorutils.CheckErrorf("invalid date '%s'. The expected format is YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ", createdAfter)
+		}
+	}
+	dbp.createdAfter = parsed
+	return dbp, nil
+}
+
+// Sets the build, whose images are promoted, in the build-name/build-number format.
+func (dbp *DockerBulkPromoteCommand) SetBuild(build string) *DockerBulkPromoteCommand {
+	dbp.build = build
+	return dbp
+}
+
+func (dbp *DockerBulkPromoteCommand) SetProject(project string) *DockerBulkPromoteCommand {
+	dbp.project = project
+	return dbp
+}
+
+func (dbp *DockerBulkPromoteCommand) SetDryRun(dryRun bool) *DockerBulkPromoteCommand {
+	dbp.dryRun = dryRun
+	return dbp
+}
+
+func (dbp *DockerBulkPromoteCommand) Promotions() []DockerPromotion {
+	return dbp.promotions
+}
+
+func (dbp *DockerBulkPromoteCommand) CommandName() string {
+	return "rt_docker_promote"
+}
+
+func (dbp *DockerBulkPromoteCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dbp.serverDetails, nil
+}
+
+func (dbp *DockerBulkPromoteCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(dbp.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	buildName, buildNumber, err := dbp.resolveBuild(servicesManager)
+	if err != nil {
+		return err
+	}
+	manifests, err := findManifests(servicesManager, dbp.params.SourceRepo, dbp.params.SourceDockerImage, buildName, buildNumber)
+	if err != nil {
+		return err
+	}
+	if dbp.promotions, err = dbp.planPromotions(manifests); err != nil {
+		return err
+	}
+	if len(dbp.promotions) == 0 {
+		log.Warn("No Docker image tags in the '" + dbp.params.SourceRepo + "' repository matched the promotion criteria.")
+		return nil
+	}
+	if dbp.dryRun {
+		return dbp.listPromotions(servicesManager)
+	}
+	for i, promotion := range dbp.promotions {
+		params := services.NewDockerPromoteParams(promotion.SourceImage, dbp.params.SourceRepo, dbp.params.TargetRepo)
+		params.SourceTag = promotion.SourceTag
+		params.TargetDockerImage = promotion.TargetImage
+		params.TargetTag = promotion.TargetTag
+		params.Copy = dbp.params.Copy
+		if err = servicesManager.PromoteDocker(params); err != nil {
+			return errors.Join(err, errorutils.CheckErrorf("failed promoting %s:%s. %d of %d tags were promoted", promotion.SourceImage, promotion.SourceTag, i, len(dbp.promotions)))
+		}
+	}
+	log.Info(fmt.Sprintf("Promoted %d Docker image tags from '%s' to '%s'.", len(dbp.promotions), dbp.params.SourceRepo, dbp.params.TargetRepo))
+	return nil
+}
+
+func (dbp *DockerBulkPromoteCommand) resolveBuild(servicesManager artifactory.ArtifactoryServicesManager) (buildName, buildNumber string, err error) {
+	if dbp.build == "" {
+		return
+	}
+	commonConf, err := servicesutils.NewCommonConfImpl(servicesManager.GetConfig().GetServiceDetails())
+	if err != nil {
+		return
+	}
+	if buildName, buildNumber, err = servicesutils.GetBuildNameAndNumberFromBuildIdentifier(dbp.build, dbp.project, commonConf); err != nil {
+		return
+	}
+	if buildNumber == "" {
+		err = errorutils.CheckErrorf("the build '%s' wasn't found in Artifactory", dbp.build)
+	}
+	return
+}
+
+// A manifest of an image tag in the source repository.
+type dockerManifestItem struct {
+	image   string
+	tag     string
+	digest  string
+	created time.Time
+}
+
+// Returns the manifests of all the tags of the image in the repository, or of all the images if the image is empty.
+// If the build is provided, only the manifests which are artifacts of the build are returned.
+func findManifests(servicesManager artifactory.ArtifactoryServicesManager, repo, image, buildName, buildNumber string) ([]dockerManifestItem, error) {
+	reader, err := servicesManager.Aql(createManifestsQuery(repo, image, buildName, buildNumber))
+	if err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(reader)
+	if err = errors.Join(errorutils.CheckError(err), errorutils.CheckError(reader.Close())); err != nil {
+		return nil, err
+	}
+	result := new(servicesutils.AqlSearchResult)
+	if err = json.Unmarshal(content, result); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var manifests []dockerManifestItem
+	for _, item := range result.Results {
+		manifest := dockerManifestItem{image: path.Dir(item.Path), tag: path.Base(item.Path), digest: "sha256:" + item.Sha256}
+		if manifest.image == "." || digestFolderRegex.MatchString(manifest.tag) || (image != "" && manifest.image != image) {
+			continue
+		}
+		if manifest.created, err = time.Parse(time.RFC3339, item.Created); err != nil {
+			return nil, errorutils.CheckErrorf("failed parsing the creation time of %s/%s: %s", item.Path, item.Name, err.Error())
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
+
+func createManifestsQuery(repo, image, buildName, buildNumber string) string {
+	query := fmt.Sprintf(`"repo":%s,"name":{"$in":[%s,%s]}`, strconv.Quote(repo), strconv.Quote(manifestFileName), strconv.Quote(listManifestFileName))
+	if image != "" {
+		query += fmt.Sprintf(`,"path":{"$match":%s}`, strconv.Quote(image+"/*"))
+	}
+	if buildName != "" {
+		query += fmt.Sprintf(`,"artifact.module.build.name":%s,"artifact.module.build.number":%s`, strconv.Quote(buildName), strconv.Quote(buildNumber))
+	}
+	return `items.find({` + query + `}).include("repo","path","name","created","sha256")`
+}
+
+// Selects the manifests which match the criteria of the promotion, and determines their target tags.
+func (dbp *DockerBulkPromoteCommand) planPromotions(manifests []dockerManifestItem) ([]DockerPromotion, error) {
+	promotionsByTarget := map[string]DockerPromotion{}
+	for _, manifest := range manifests {
+		if dbp.params.SourceTag != "" && manifest.tag != dbp.params.SourceTag {
+			continue
+		}
+		if !dbp.createdAfter.IsZero() && !manifest.created.After(dbp.createdAfter) {
+			continue
+		}
+		targetTag := manifest.tag
+		if dbp.tagsRegex != nil {
+			groups := dbp.tagsRegex.FindStringSubmatch(manifest.tag)
+			if groups == nil {
+				continue
+			}
+			if dbp.params.TargetTag != "" {
+				targetTag = replacePlaceholders(dbp.params.TargetTag, groups)
+			}
+		} else if dbp.params.TargetTag != "" {
+			targetTag = dbp.params.TargetTag
+		}
+		promotion := DockerPromotion{
+			SourceImage:  manifest.image,
+			SourceTag:    manifest.tag,
+			SourceDigest: manifest.digest,
+			Created:      manifest.created.UTC().Format(time.RFC3339),
+			TargetImage:  manifest.image,
+			TargetTag:    targetTag,
+		}
+		if dbp.params.TargetDockerImage != "" {
+			promotion.TargetImage = dbp.params.TargetDockerImage
+		}
+		target := promotion.TargetImage + ":" + promotion.TargetTag
+		if existing, exists := promotionsByTarget[target]; exists {
+			// A multi-platform image is stored with a list manifest. The tag appears once, unless two tags are promoted to the same target.
+			if existing.SourceImage == promotion.SourceImage && existing.SourceTag == promotion.SourceTag {
+				continue
+			}
+			return nil, errorutils.CheckErrorf("both %s:%s and %s:%s would be promoted to %s. Narrow the tags criteria, or change the target tag",
+				existing.SourceImage, existing.SourceTag, promotion.SourceImage, promotion.SourceTag, target)
+		}
+		promotionsByTarget[target] = promotion
+	}
+	promotions := make([]DockerPromotion, 0, len(promotionsByTarget))
+	for _, promotion := range promotionsByTarget {
+		promotions = append(promotions, promotion)
+	}
+	sort.Slice(promotions, func(i, j int) bool {
+		if promotions[i].SourceImage != promotions[j].SourceImage {
+			return promotions[i].SourceImage < promotions[j].SourceImage
+		}
+		return promotions[i].SourceTag < promotions[j].SourceTag
+	})
+	return promotions, nil
+}
+
+// Replaces the {1}, {2}... placeholders in the target tag with the groups of the tags regular expression.
+func replacePlaceholders(targetTag string, groups []string) string {
+	return placeholderRegex.ReplaceAllStringFunc(targetTag, func(placeholder string) string {
+		index, err := strconv.Atoi(placeholder[1 : len(placeholder)-1])
+		if err != nil || index >= len(groups) {
+			return placeholder
+		}
+		return groups[index]
+	})
+}
+
+// Prints the source manifests and the manifests which the target tags currently refer to, without promoting them.
+func (dbp *DockerBulkPromoteCommand) listPromotions(servicesManager artifactory.ArtifactoryServicesManager) error {
+	targetDigests := map[string]string{}
+	for _, targetImage := range dbp.targetImages() {
+		targetManifests, err := findManifests(servicesManager, dbp.params.TargetRepo, targetImage, "", "")
+		if err != nil {
+			return err
+		}
+		for _, manifest := range targetManifests {
+			targetDigests[manifest.image+":"+manifest.tag] = manifest.digest
+		}
+	}
+	for i := range dbp.promotions {
+		dbp.promotions[i].TargetDigest = targetDigests[dbp.promotions[i].TargetImage+":"+dbp.promotions[i].TargetTag]
+	}
+	action := "moved"
+	if dbp.params.Copy {
+		action = "copied"
+	}
+	title := fmt.Sprintf("[Dry run] %d Docker image tags would be %s from '%s' to '%s':", len(dbp.promotions), action, dbp.params.SourceRepo, dbp.params.TargetRepo)
+	return coreutils.PrintTable(dbp.promotions, title, "", false)
+}
+
+func (dbp *DockerBulkPromoteCommand) targetImages() []string {
+	var images []string
+	for _, promotion := range dbp.promotions {
+		if !slices.Contains(images, promotion.TargetImage) {
+			images = append(images, promotion.TargetImage)
+		}
+	}
+	return images
+}
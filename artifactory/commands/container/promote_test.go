@@ -0,0 +1,156 @@
+package container
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplacePlaceholders(t *testing.T) {
+	groups := []string{"1.2.3-rc1", "1.2.3", "1"}
+	assert.Equal(t, "1.2.3", replacePlaceholders("{1}", groups))
+	assert.Equal(t, "1.2.3-final-1", replacePlaceholders("{1}-final-{2}", groups))
+	// Placeholders without a matching group are kept.
+	assert.Equal(t, "1.2.3-{3}", replacePlaceholders("{1}-{3}", groups))
+}
+
+func TestCreateManifestsQuery(t *testing.T) {
+	assert.Equal(t, `items.find({"repo":"docker-dev","name":{"$in":["manifest.json","list.manifest.json"]},"path":{"$match":"org/app/*"}}).include("repo","path","name","created","sha256")`,
+		createManifestsQuery("docker-dev", "org/app", "", ""))
+	assert.Equal(t, `items.find({"repo":"docker-dev","name":{"$in":["manifest.json","list.manifest.json"]},"artifact.module.build.name":"release","artifact.module.build.number":"7"}).include("repo","path","name","created","sha256")`,
+		createManifestsQuery("docker-dev", "", "release", "7"))
+}
+
+func TestPlanPromotions(t *testing.T) {
+	day := func(date string) time.Time {
+		parsed, err := time.Parse(createdAfterDateFormat, date)
+		require.NoError(t, err)
+		return parsed
+	}
+	manifests := []dockerManifestItem{
+		{image: "app", tag: "1.2.3-rc1", digest: "sha256:a", created: day("2024-01-01")},
+		{image: "app", tag: "1.2.4-rc1", digest: "sha256:b", created: day("2024-02-01")},
+		{image: "app", tag: "1.2.4-rc2", digest: "sha256:c", created: day("2024-03-01")},
+		{image: "app", tag: "latest", digest: "sha256:c", created: day("2024-03-01")},
+	}
+	tests := []struct {
+		name         string
+		tagsRegex    string
+		createdAfter string
+		sourceTag    string
+		targetTag    string
+		expected     []string
+	}{
+		{"all tags", "", "", "", "", []string{"1.2.3-rc1>1.2.3-rc1", "1.2.4-rc1>1.2.4-rc1", "1.2.4-rc2>1.2.4-rc2", "latest>latest"}},
+		{"tags regex", `-rc\d+$`, "", "", "", []string{"1.2.3-rc1>1.2.3-rc1", "1.2.4-rc1>1.2.4-rc1", "1.2.4-rc2>1.2.4-rc2"}},
+		{"created after", "", "2024-01-15", "", "", []string{"1.2.4-rc1>1.2.4-rc1", "1.2.4-rc2>1.2.4-rc2", "latest>latest"}},
+		{"retag", `^(\d+\.\d+\.\d+)-rc\d+$`, "2024-02-15", "", "{1}", []string{"1.2.4-rc2>1.2.4"}},
+		{"source tag", "", "", "latest", "stable", []string{"latest>stable"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			promoteCommand := NewDockerBulkPromoteCommand()
+			params := services.NewDockerPromoteParams("app", "docker-dev", "docker-prod")
+			params.SourceTag = test.sourceTag
+			params.TargetTag = test.targetTag
+			promoteCommand.SetParams(params)
+			_, err := promoteCommand.SetTagsRegex(test.tagsRegex)
+			require.NoError(t, err)
+			_, err = promoteCommand.SetCreatedAfter(test.createdAfter)
+			require.NoError(t, err)
+			promotions, err := promoteCommand.planPromotions(manifests)
+			require.NoError(t, err)
+			var actual []string
+			for _, promotion := range promotions {
+				assert.Equal(t, "app", promotion.TargetImage)
+				actual = append(actual, promotion.SourceTag+">"+promotion.TargetTag)
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	// Two release candidates of the same version can't be promoted to the same tag.
+	promoteCommand := NewDockerBulkPromoteCommand()
+	params := services.NewDockerPromoteParams("app", "docker-dev", "docker-prod")
+	params.TargetTag = "{1}"
+	promoteCommand.SetParams(params)
+	_, err := promoteCommand.SetTagsRegex(`^(\d+\.\d+\.\d+)-rc\d+$`)
+	require.NoError(t, err)
+	_, err = promoteCommand.planPromotions(manifests)
+	assert.ErrorContains(t, err, "would be promoted to app:1.2.4")
+}
+
+func TestSetCreatedAfter(t *testing.T) {
+	promoteCommand := NewDockerBulkPromoteCommand()
+	_, err := promoteCommand.SetCreatedAfter("2024-01-15")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), promoteCommand.createdAfter)
+	_, err = promoteCommand.SetCreatedAfter("2024-01-15T10:30:00Z")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), promoteCommand.createdAfter)
+	_, err = promoteCommand.SetCreatedAfter("15/01/2024")
+	assert.Error(t, err)
+	_, err = promoteCommand.SetTagsRegex("(")
+	assert.Error(t, err)
+}
+
+func TestDockerBulkPromote(t *testing.T) {
+	var promoted []services.DockerPromoteBody
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/api/search/aql":
+			if strings.Contains(string(body), `"repo":"docker-prod"`) {
+				_, _ = w.Write([]byte(`{"results":[{"repo":"docker-prod","path":"app/1.0.0","name":"manifest.json","created":"2023-12-01T10:00:00.000Z","sha256":"old"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"results":[
+				{"repo":"docker-dev","path":"app/1.0.0-rc1","name":"manifest.json","created":"2024-01-01T10:00:00.000Z","sha256":"rc1"},
+				{"repo":"docker-dev","path":"app/1.1.0-rc1","name":"list.manifest.json","created":"2024-02-01T10:00:00.000Z","sha256":"multi"},
+				{"repo":"docker-dev","path":"app/sha256:0a1b","name":"manifest.json","created":"2024-02-01T10:00:00.000Z","sha256":"0a1b"},
+				{"repo":"docker-dev","path":"app/nested/1.0.0-rc1","name":"manifest.json","created":"2024-02-01T10:00:00.000Z","sha256":"nested"}]}`))
+		case "/api/docker/docker-dev/v2/promote":
+			var promoteBody services.DockerPromoteBody
+			require.NoError(t, json.Unmarshal(body, &promoteBody))
+			promoted = append(promoted, promoteBody)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	params := services.NewDockerPromoteParams("app", "docker-dev", "docker-prod")
+	params.TargetTag = "{1}"
+	params.Copy = true
+	newCommand := func(dryRun bool) *DockerBulkPromoteCommand {
+		promoteCommand := NewDockerBulkPromoteCommand()
+		promoteCommand.SetParams(params).SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).SetDryRun(dryRun)
+		_, err := promoteCommand.SetTagsRegex(`^(.+)-rc\d+$`)
+		require.NoError(t, err)
+		return promoteCommand
+	}
+
+	// The dry run lists the manifests, without promoting them.
+	promoteCommand := newCommand(true)
+	require.NoError(t, promoteCommand.Run())
+	assert.Empty(t, promoted)
+	assert.Equal(t, []DockerPromotion{
+		{SourceImage: "app", SourceTag: "1.0.0-rc1", SourceDigest: "sha256:rc1", Created: "2024-01-01T10:00:00Z", TargetImage: "app", TargetTag: "1.0.0", TargetDigest: "sha256:old"},
+		{SourceImage: "app", SourceTag: "1.1.0-rc1", SourceDigest: "sha256:multi", Created: "2024-02-01T10:00:00Z", TargetImage: "app", TargetTag: "1.1.0"},
+	}, promoteCommand.Promotions())
+
+	require.NoError(t, newCommand(false).Run())
+	assert.Equal(t, []services.DockerPromoteBody{
+		{TargetRepo: "docker-prod", DockerRepository: "app", TargetDockerRepository: "app", Tag: "1.0.0-rc1", TargetTag: "1.0.0", Copy: true},
+		{TargetRepo: "docker-prod", DockerRepository: "app", TargetDockerRepository: "app", Tag: "1.1.0-rc1", TargetTag: "1.1.0", Copy: true},
+	}, promoted)
+}
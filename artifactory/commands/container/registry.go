@@ -0,0 +1,502 @@
+package container
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ociManifestMediaType     = "application/vnd.oci.image.manifest.v1+json"
+	ociEmptyMediaType        = "application/vnd.oci.empty.v1+json"
+	ociDefaultLayerMediaType = "application/vnd.oci.image.layer.v1.tar"
+	dockerManifestMediaType  = "application/vnd.docker.distribution.manifest.v2+json"
+	// The annotation of a layer, which holds the name of the file it was pushed from.
+	ociTitleAnnotation = "org.opencontainers.image.title"
+
+	// Artifactory returns the key of the repository of the manifest in this response header.
+	artifactoryDockerRegistryHeader = "X-Artifactory-Docker-Registry"
+	// Registries which support the referrers API return this header in the response of a manifest with a subject.
+	ociSubjectHeader = "OCI-Subject"
+
+	registryRequestTimeout = 30 * time.Minute
+)
+
+// The content of the empty config of artifacts: '{}'.
+var ociEmptyConfig = []byte("{}")
+
+// A reference to a manifest in an OCI registry. For example: my.jfrog.io/docker-local/sbom:1.0 or my.jfrog.io/docker-local/sbom@sha256:...
+type ociReference struct {
+	registry   string
+	repository string
+	tag        string
+	digest     string
+}
+
+func parseOciReference(reference string) (*ociReference, error) {
+	registry, repository, found := strings.Cut(reference, "/")
+	if !found || registry == "" || repository == "" {
+		return nil, errorutils.CheckErrorf("invalid reference '%s'. The expected format is <registry>/<repository>[:<tag>|@<digest>]", reference)
+	}
+	ref := &ociReference{registry: registry}
+	if name, digest, found := strings.Cut(repository, "@"); found {
+		repository = name
+		ref.digest = digest
+		if !strings.HasPrefix(digest, "sha256:") {
+			return nil, errorutils.CheckErrorf("invalid reference '%s'. Only sha256 digests are supported", reference)
+		}
+	}
+	if separator := strings.LastIndex(repository, ":"); separator > strings.LastIndex(repository, "/") {
+		repository, ref.tag = repository[:separator], repository[separator+1:]
+	}
+	if ref.tag == "" && ref.digest == "" {
+		ref.tag = "latest"
+	}
+	ref.repository = repository
+	return ref, nil
+}
+
+// Returns the digest of the reference, or its tag if it has no digest.
+func (ref *ociReference) Ref() string {
+	if ref.digest != "" {
+		return ref.digest
+	}
+	return ref.tag
+}
+
+func (ref *ociReference) String() string {
+	if ref.digest != "" {
+		return ref.registry + "/" + ref.repository + "@" + ref.digest
+	}
+	return ref.registry + "/" + ref.repository + ":" + ref.tag
+}
+
+// The descriptor of a blob or a manifest, as referenced by manifests and indexes.
+type ociDescriptor struct {
+	MediaType    string            `json:"mediaType"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+type ociManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        ociDescriptor     `json:"config"`
+	Layers        []ociDescriptor   `json:"layers"`
+	Subject       *ociDescriptor    `json:"subject,omitempty"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+type ociIndex struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	Manifests     []ociDescriptor   `json:"manifests"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// A client of the OCI distribution API of an Artifactory Docker or OCI repository.
+// The client authenticates with the same credentials that 'jf docker' uses for 'docker login'.
+type registryClient struct {
+	httpClient *http.Client
+	// For example: https://my.jfrog.io/v2/docker-local/sbom
+	repositoryUrl string
+	// The scope of the registry token, which is requested when the registry challenges the client.
+	scope    string
+	username string
+	password string
+	// The bearer token, which replaces the basic authentication once the registry issues it.
+	token string
+	// The key of the Artifactory repository, as returned by Artifactory in the responses of the manifests.
+	artifactoryRepo string
+}
+
+// Creates a client of the repository of the reference, and logs in to the registry unless skipLogin is set.
+// The client trusts the same certificates, and uses the same client certificate and proxy, as the clients of the configured server.
+func newRegistryClient(reference *ociReference, serverDetails *config.ServerDetails, skipLogin bool) (*registryClient, error) {
+	scheme := "https"
+	if strings.HasPrefix(serverDetails.GetArtifactoryUrl(), "http://") {
+		scheme = "http"
+	}
+	certsPath, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := httpclient.ClientBuilder().
+		SetCertificatesPath(certsPath).
+		SetInsecureTls(serverDetails.InsecureTls).
+		SetClientCertPath(serverDetails.ClientCertPath).
+		SetClientCertKeyPath(serverDetails.ClientCertKeyPath).
+		SetOverallRequestTimeout(registryRequestTimeout).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	client := &registryClient{
+		httpClient:    httpClient.GetClient(),
+		repositoryUrl: scheme + "://" + reference.registry + "/v2/" + reference.repository,
+		scope:         "repository:" + reference.repository + ":pull,push",
+	}
+	if skipLogin {
+		return client, nil
+	}
+	// The credentials of the server are sent to its own registry only. Other registries, such as the registry of a base image, are accessed anonymously.
+	if isServerRegistry(reference.registry, serverDetails) {
+		client.username, client.password = projectconfig.GetBasicAuthCredentials(serverDetails)
+	} else {
+		log.Debug("The registry", reference.registry, "isn't the registry of the configured server, so it's accessed anonymously.")
+	}
+	return client, client.login()
+}
+
+// Returns whether the registry is the host of the configured server, or a subdomain of it, as Artifactory serves Docker repositories by subdomains.
+func isServerRegistry(registry string, serverDetails *config.ServerDetails) bool {
+	for _, serverUrl := range []string{serverDetails.GetArtifactoryUrl(), serverDetails.GetUrl()} {
+		parsedUrl, err := url.Parse(serverUrl)
+		if err != nil || parsedUrl.Host == "" {
+			continue
+		}
+		if strings.EqualFold(registry, parsedUrl.Host) || strings.HasSuffix(strings.ToLower(registry), "."+strings.ToLower(parsedUrl.Host)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Pings the registry, and requests a token if the registry challenges the client for a bearer token, as 'docker login' does.
+func (rc *registryClient) login() error {
+	pingUrl := rc.repositoryUrl[:strings.Index(rc.repositoryUrl, "/v2/")+len("/v2/")]
+	response, err := rc.send(http.MethodGet, pingUrl, nil, nil)
+	if err != nil {
+		return err
+	}
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusUnauthorized {
+		return nil
+	}
+	realm, params := parseBearerChallenge(response.Header.Get("WWW-Authenticate"))
+	if realm == "" {
+		return errorutils.CheckErrorf("the registry %s rejected the credentials: %s", pingUrl, response.Status)
+	}
+	tokenUrl, err := url.Parse(realm)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	query := tokenUrl.Query()
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	query.Set("scope", rc.scope)
+	tokenUrl.RawQuery = query.Encode()
+	response, err = rc.send(http.MethodGet, tokenUrl.String(), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		return errorutils.CheckErrorf("failed logging in to the registry %s: %s", pingUrl, response.Status)
+	}
+	var tokenResponse struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err = json.NewDecoder(response.Body).Decode(&tokenResponse); err != nil {
+		return errorutils.CheckErrorf("failed parsing the token response of %s: %s", tokenUrl.Redacted(), err.Error())
+	}
+	if rc.token = tokenResponse.Token; rc.token == "" {
+		rc.token = tokenResponse.AccessToken
+	}
+	return nil
+}
+
+// Parses a challenge such as: Bearer realm="https://my.jfrog.io/v2/token",service="my.jfrog.io"
+func parseBearerChallenge(challenge string) (realm string, params map[string]string) {
+	scheme, rest, found := strings.Cut(challenge, " ")
+	if !found || !strings.EqualFold(scheme, "bearer") {
+		return "", nil
+	}
+	params = make(map[string]string)
+	for _, param := range strings.Split(rest, ",") {
+		if key, value, found := strings.Cut(strings.TrimSpace(param), "="); found {
+			params[strings.ToLower(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return params["realm"], params
+}
+
+func (rc *registryClient) newRequest(method, requestUrl string, body io.Reader, headers map[string]string) (*http.Request, error) {
+	request, err := http.NewRequest(method, requestUrl, body)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	if rc.token != "" {
+		request.Header.Set("Authorization", "Bearer "+rc.token)
+	} else if rc.password != "" {
+		request.SetBasicAuth(rc.username, rc.password)
+	}
+	return request, nil
+}
+
+func (rc *registryClient) send(method, requestUrl string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	request, err := rc.newRequest(method, requestUrl, body, headers)
+	if err != nil {
+		return nil, err
+	}
+	return rc.do(request)
+}
+
+func (rc *registryClient) do(request *http.Request) (*http.Response, error) {
+	log.Debug("Sending", request.Method, "request to", request.URL.Redacted())
+	response, err := rc.httpClient.Do(request)
+	return response, errorutils.CheckError(err)
+}
+
+// Returns an error with the status and the body of an unexpected response, and closes it.
+func unexpectedResponse(response *http.Response, action string) error {
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+	return errorutils.CheckErrorf("failed %s: %s %s", action, response.Status, strings.TrimSpace(string(body)))
+}
+
+func (rc *registryClient) blobExists(digest string) (bool, error) {
+	response, err := rc.send(http.MethodHead, rc.repositoryUrl+"/blobs/"+digest, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	_ = response.Body.Close()
+	switch response.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errorutils.CheckErrorf("failed checking the existence of the blob %s: %s", digest, response.Status)
+	}
+}
+
+// Uploads a blob in a single request, after starting an upload session. The blob isn't uploaded if the registry already has it.
+func (rc *registryClient) pushBlob(descriptor ociDescriptor, content io.Reader) error {
+	exists, err := rc.blobExists(descriptor.Digest)
+	if err != nil || exists {
+		return err
+	}
+	response, err := rc.send(http.MethodPost, rc.repositoryUrl+"/blobs/uploads/", nil, nil)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode != http.StatusAccepted {
+		return unexpectedResponse(response, "starting the upload of the blob "+descriptor.Digest)
+	}
+	_ = response.Body.Close()
+	location, err := response.Location()
+	if err != nil {
+		return errorutils.CheckErrorf("the registry didn't return the location of the upload of the blob %s", descriptor.Digest)
+	}
+	query := location.Query()
+	query.Set("digest", descriptor.Digest)
+	location.RawQuery = query.Encode()
+	// The content is closed by its owner, rather than by the HTTP client.
+	request, err := rc.newRequest(http.MethodPut, location.String(), io.NopCloser(content), map[string]string{"Content-Type": "application/octet-stream"})
+	if err != nil {
+		return err
+	}
+	request.ContentLength = descriptor.Size
+	if response, err = rc.do(request); err != nil {
+		return err
+	}
+	if response.StatusCode != http.StatusCreated {
+		return unexpectedResponse(response, "uploading the blob "+descriptor.Digest)
+	}
+	_ = response.Body.Close()
+	return nil
+}
+
+// Uploads the manifest under the tag or the digest, and returns its digest.
+// The second returned value is true if the registry processed the subject of the manifest, as registries which support the referrers API do.
+func (rc *registryClient) pushManifest(ref, mediaType string, content []byte) (digest string, subjectProcessed bool, err error) {
+	response, err := rc.send(http.MethodPut, rc.repositoryUrl+"/manifests/"+ref, bytes.NewReader(content), map[string]string{"Content-Type": mediaType})
+	if err != nil {
+		return "", false, err
+	}
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		return "", false, unexpectedResponse(response, "uploading the manifest "+ref)
+	}
+	_ = response.Body.Close()
+	rc.setArtifactoryRepo(response)
+	digest = calcDigest(content)
+	if returnedDigest := response.Header.Get("Docker-Content-Digest"); returnedDigest != "" && returnedDigest != digest {
+		return "", false, errorutils.CheckErrorf("the registry returned the digest %s for the manifest %s, whose digest is %s", returnedDigest, ref, digest)
+	}
+	return digest, response.Header.Get(ociSubjectHeader) != "", nil
+}
+
+// Returns the manifest or the index of the tag or the digest. The content of the manifest is verified if it's fetched by its digest.
+// Returns a nil descriptor if the manifest doesn't exist.
+func (rc *registryClient) fetchManifest(ref string) (*ociDescriptor, []byte, error) {
+	accept := strings.Join([]string{ociManifestMediaType, ociImageIndexMediaType, dockerManifestMediaType, dockerManifestListMedia}, ", ")
+	response, err := rc.send(http.MethodGet, rc.repositoryUrl+"/manifests/"+ref, nil, map[string]string{"Accept": accept})
+	if err != nil {
+		return nil, nil, err
+	}
+	if response.StatusCode == http.StatusNotFound {
+		_ = response.Body.Close()
+		return nil, nil, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, nil, unexpectedResponse(response, "fetching the manifest "+ref)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	rc.setArtifactoryRepo(response)
+	digest := calcDigest(content)
+	if strings.HasPrefix(ref, "sha256:") && digest != ref {
+		return nil, nil, errorutils.CheckErrorf("the digest of the fetched manifest %s doesn't match the requested digest %s", digest, ref)
+	}
+	mediaType, _, _ := strings.Cut(response.Header.Get("Content-Type"), ";")
+	var manifest struct {
+		MediaType string `json:"mediaType"`
+	}
+	if err = json.Unmarshal(content, &manifest); err != nil {
+		return nil, nil, errorutils.CheckErrorf("failed parsing the manifest %s: %s", ref, err.Error())
+	}
+	if manifest.MediaType != "" {
+		mediaType = manifest.MediaType
+	}
+	return &ociDescriptor{MediaType: mediaType, Digest: digest, Size: int64(len(content))}, content, nil
+}
+
+// Writes the content of the blob to the writer.
+func (rc *registryClient) fetchBlob(digest string, writer io.Writer) error {
+	response, err := rc.send(http.MethodGet, rc.repositoryUrl+"/blobs/"+digest, nil, nil)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode != http.StatusOK {
+		return unexpectedResponse(response, "downloading the blob "+digest)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	_, err = io.Copy(writer, response.Body)
+	return errorutils.CheckError(err)
+}
+
+// Returns the manifests which refer to the subject digest, optionally filtered by their artifact type.
+// If the registry doesn't support the referrers API, the referrers are read from the index of the referrers tag.
+func (rc *registryClient) fetchReferrers(digest, artifactType string) ([]ociDescriptor, error) {
+	referrersUrl := rc.repositoryUrl + "/referrers/" + digest
+	if artifactType != "" {
+		referrersUrl += "?artifactType=" + url.QueryEscape(artifactType)
+	}
+	response, err := rc.send(http.MethodGet, referrersUrl, nil, map[string]string{"Accept": ociImageIndexMediaType})
+	if err != nil {
+		return nil, err
+	}
+	var index *ociIndex
+	switch response.StatusCode {
+	case http.StatusOK:
+		defer func() {
+			_ = response.Body.Close()
+		}()
+		index = new(ociIndex)
+		if err = json.NewDecoder(response.Body).Decode(index); err != nil {
+			return nil, errorutils.CheckErrorf("failed parsing the referrers of %s: %s", digest, err.Error())
+		}
+	case http.StatusNotFound:
+		_ = response.Body.Close()
+		log.Debug("The registry doesn't support the referrers API. Reading the referrers tag of", digest)
+		if index, err = rc.fetchReferrersTagIndex(digest); err != nil || index == nil {
+			return nil, err
+		}
+	default:
+		return nil, unexpectedResponse(response, "fetching the referrers of "+digest)
+	}
+	var referrers []ociDescriptor
+	for _, descriptor := range index.Manifests {
+		// Registries may ignore the artifact type filter.
+		if artifactType == "" || descriptor.ArtifactType == artifactType {
+			referrers = append(referrers, descriptor)
+		}
+	}
+	return referrers, nil
+}
+
+// The referrers tag schema of the OCI distribution spec, for registries which don't support the referrers API. For example: sha256-<hex>
+func referrersTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1)
+}
+
+func (rc *registryClient) fetchReferrersTagIndex(digest string) (*ociIndex, error) {
+	descriptor, content, err := rc.fetchManifest(referrersTag(digest))
+	if err != nil || descriptor == nil {
+		return nil, err
+	}
+	index := new(ociIndex)
+	if err = json.Unmarshal(content, index); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the referrers tag of %s: %s", digest, err.Error())
+	}
+	return index, nil
+}
+
+// Adds the referrer to the index of the referrers tag of the subject.
+func (rc *registryClient) addToReferrersTag(subjectDigest string, referrer ociDescriptor) error {
+	index, err := rc.fetchReferrersTagIndex(subjectDigest)
+	if err != nil {
+		return err
+	}
+	if index == nil {
+		index = &ociIndex{SchemaVersion: 2, MediaType: ociImageIndexMediaType}
+	}
+	for _, descriptor := range index.Manifests {
+		if descriptor.Digest == referrer.Digest {
+			return nil
+		}
+	}
+	index.Manifests = append(index.Manifests, referrer)
+	content, err := json.Marshal(index)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, _, err = rc.pushManifest(referrersTag(subjectDigest), ociImageIndexMediaType, content)
+	return err
+}
+
+func (rc *registryClient) setArtifactoryRepo(response *http.Response) {
+	if repo := response.Header.Get(artifactoryDockerRegistryHeader); repo != "" {
+		rc.artifactoryRepo = repo
+	}
+}
+
+// Returns the path of the repository in the Artifactory repository, which is the path in the reference without the repository key.
+// The key is part of the path when Artifactory is accessed by the repository path method, rather than by a subdomain or a port.
+func artifactoryImagePath(reference *ociReference, repo string) string {
+	if path, found := strings.CutPrefix(reference.repository, repo+"/"); found {
+		return path
+	}
+	return reference.repository
+}
@@ -0,0 +1,252 @@
+package container
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRegistryUser  = "user"
+	testRegistryToken = "registry-token"
+	testRegistryRepo  = "docker-local"
+)
+
+// An in-memory OCI registry, which issues a bearer token to the test user, as Artifactory does.
+type testRegistry struct {
+	*httptest.Server
+	mutex              sync.Mutex
+	blobs              map[string][]byte
+	manifests          map[string][]byte
+	supportsReferrers  bool
+	unauthorizedAccess bool
+}
+
+func newTestRegistry(t *testing.T, supportsReferrers bool) *testRegistry {
+	registry := &testRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}, supportsReferrers: supportsReferrers}
+	registry.Server = httptest.NewServer(http.HandlerFunc(registry.handle))
+	t.Cleanup(registry.Close)
+	return registry
+}
+
+func (tr *testRegistry) host() string {
+	return strings.TrimPrefix(tr.URL, "http://")
+}
+
+func (tr *testRegistry) serverDetails() *config.ServerDetails {
+	return &config.ServerDetails{ArtifactoryUrl: tr.URL + "/artifactory/", User: testRegistryUser, Password: "password"}
+}
+
+func (tr *testRegistry) handle(w http.ResponseWriter, r *http.Request) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	if r.URL.Path == "/v2/token" {
+		if user, password, ok := r.BasicAuth(); !ok || user != testRegistryUser || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"token":"` + testRegistryToken + `"}`))
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+testRegistryToken {
+		tr.unauthorizedAccess = r.URL.Path != "/v2/"
+		w.Header().Set("WWW-Authenticate", `Bearer realm="`+tr.URL+`/v2/token",service="test-registry"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case r.URL.Path == "/v2/":
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/blobs/uploads/"):
+		w.Header().Set("Location", strings.TrimSuffix(r.URL.Path, "/")+"/1")
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/blobs/uploads/1"):
+		content, _ := io.ReadAll(r.Body)
+		if digest := r.URL.Query().Get("digest"); calcDigest(content) != digest {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		tr.blobs[calcDigest(content)] = content
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(r.URL.Path, "/blobs/"):
+		content, exists := tr.blobs[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(content)
+		}
+	case strings.Contains(r.URL.Path, "/manifests/"):
+		tr.handleManifest(w, r)
+	case strings.Contains(r.URL.Path, "/referrers/") && tr.supportsReferrers:
+		tr.handleReferrers(w, r)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (tr *testRegistry) handleManifest(w http.ResponseWriter, r *http.Request) {
+	ref := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	if r.Method == http.MethodPut {
+		content, _ := io.ReadAll(r.Body)
+		digest := calcDigest(content)
+		tr.manifests[ref], tr.manifests[digest] = content, content
+		var manifest ociManifest
+		if json.Unmarshal(content, &manifest) == nil && manifest.Subject != nil && tr.supportsReferrers {
+			w.Header().Set(ociSubjectHeader, manifest.Subject.Digest)
+		}
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.Header().Set(artifactoryDockerRegistryHeader, testRegistryRepo)
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	content, exists := tr.manifests[ref]
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var manifest struct {
+		MediaType string `json:"mediaType"`
+	}
+	_ = json.Unmarshal(content, &manifest)
+	w.Header().Set("Content-Type", manifest.MediaType)
+	_, _ = w.Write(content)
+}
+
+func (tr *testRegistry) handleReferrers(w http.ResponseWriter, r *http.Request) {
+	subjectDigest := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	index := ociIndex{SchemaVersion: 2, MediaType: ociImageIndexMediaType, Manifests: []ociDescriptor{}}
+	for ref, content := range tr.manifests {
+		var manifest ociManifest
+		if !strings.HasPrefix(ref, "sha256:") || json.Unmarshal(content, &manifest) != nil || manifest.Subject == nil || manifest.Subject.Digest != subjectDigest {
+			continue
+		}
+		index.Manifests = append(index.Manifests, ociDescriptor{MediaType: manifest.MediaType, Digest: ref, Size: int64(len(content)), ArtifactType: manifest.ArtifactType})
+	}
+	content, _ := json.Marshal(index)
+	w.Header().Set("Content-Type", ociImageIndexMediaType)
+	_, _ = w.Write(content)
+}
+
+func TestParseOciReference(t *testing.T) {
+	tests := []struct {
+		reference  string
+		repository string
+		tag        string
+		digest     string
+	}{
+		{"my.jfrog.io/docker-local/sbom:1.0", "docker-local/sbom", "1.0", ""},
+		{"my.jfrog.io/docker-local/sbom", "docker-local/sbom", "latest", ""},
+		{"localhost:8082/docker-local/sbom:1.0", "docker-local/sbom", "1.0", ""},
+		{"localhost:8082/docker-local/sbom", "docker-local/sbom", "latest", ""},
+		{"my.jfrog.io/docker-local/sbom@sha256:abc", "docker-local/sbom", "", "sha256:abc"},
+		{"my.jfrog.io/docker-local/sbom:1.0@sha256:abc", "docker-local/sbom", "1.0", "sha256:abc"},
+	}
+	for _, test := range tests {
+		t.Run(test.reference, func(t *testing.T) {
+			ref, err := parseOciReference(test.reference)
+			require.NoError(t, err)
+			assert.Equal(t, test.repository, ref.repository)
+			assert.Equal(t, test.tag, ref.tag)
+			assert.Equal(t, test.digest, ref.digest)
+		})
+	}
+	_, err := parseOciReference("sbom:1.0")
+	assert.Error(t, err)
+	_, err = parseOciReference("my.jfrog.io/docker-local/sbom@md5:abc")
+	assert.Error(t, err)
+}
+
+func TestParseBearerChallenge(t *testing.T) {
+	realm, params := parseBearerChallenge(`Bearer realm="https://my.jfrog.io/v2/token",service="my.jfrog.io"`)
+	assert.Equal(t, "https://my.jfrog.io/v2/token", realm)
+	assert.Equal(t, "my.jfrog.io", params["service"])
+	realm, _ = parseBearerChallenge(`Basic realm="Artifactory Realm"`)
+	assert.Empty(t, realm)
+}
+
+func TestArtifactoryImagePath(t *testing.T) {
+	// The repository path method.
+	ref, err := parseOciReference("my.jfrog.io/docker-local/tools/sbom:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "tools/sbom", artifactoryImagePath(ref, "docker-local"))
+	// The subdomain method.
+	ref, err = parseOciReference("docker-local.my.jfrog.io/tools/sbom:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "tools/sbom", artifactoryImagePath(ref, "docker-local"))
+}
+
+func TestRegistryClientReferrersTag(t *testing.T) {
+	registry := newTestRegistry(t, false)
+	ref, err := parseOciReference(registry.host() + "/" + testRegistryRepo + "/app:1.0")
+	require.NoError(t, err)
+	client, err := newRegistryClient(ref, registry.serverDetails(), false)
+	require.NoError(t, err)
+
+	subjectDigest := calcDigest([]byte("subject"))
+	referrers, err := client.fetchReferrers(subjectDigest, "")
+	require.NoError(t, err)
+	assert.Empty(t, referrers)
+
+	sbom := ociDescriptor{MediaType: ociManifestMediaType, Digest: calcDigest([]byte("sbom")), Size: 4, ArtifactType: "application/spdx+json"}
+	signature := ociDescriptor{MediaType: ociManifestMediaType, Digest: calcDigest([]byte("signature")), Size: 9, ArtifactType: "application/vnd.dev.cosign.artifact.sig.v1+json"}
+	require.NoError(t, client.addToReferrersTag(subjectDigest, sbom))
+	require.NoError(t, client.addToReferrersTag(subjectDigest, signature))
+	// Adding a referrer twice doesn't duplicate it.
+	require.NoError(t, client.addToReferrersTag(subjectDigest, sbom))
+
+	referrers, err = client.fetchReferrers(subjectDigest, "")
+	require.NoError(t, err)
+	assert.Equal(t, []ociDescriptor{sbom, signature}, referrers)
+	referrers, err = client.fetchReferrers(subjectDigest, "application/spdx+json")
+	require.NoError(t, err)
+	assert.Equal(t, []ociDescriptor{sbom}, referrers)
+	assert.False(t, registry.unauthorizedAccess)
+}
+
+func TestRegistryClientInsecureTls(t *testing.T) {
+	registry := &testRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+	registry.Server = httptest.NewTLSServer(http.HandlerFunc(registry.handle))
+	t.Cleanup(registry.Close)
+	ref, err := parseOciReference(strings.TrimPrefix(registry.URL, "https://") + "/docker-local/sbom:1.0")
+	require.NoError(t, err)
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: registry.URL + "/artifactory/", User: testRegistryUser, Password: "password"}
+
+	// The certificate of the test server is self-signed, so it is trusted only if the server is configured with insecure TLS.
+	_, err = newRegistryClient(ref, serverDetails, false)
+	assert.ErrorContains(t, err, "certificate")
+	serverDetails.InsecureTls = true
+	_, err = newRegistryClient(ref, serverDetails, false)
+	assert.NoError(t, err)
+}
+
+func TestIsServerRegistry(t *testing.T) {
+	serverDetails := &config.ServerDetails{Url: "https://my.jfrog.io/", ArtifactoryUrl: "https://my.jfrog.io/artifactory/"}
+	assert.True(t, isServerRegistry("my.jfrog.io", serverDetails))
+	assert.True(t, isServerRegistry("docker-remote.my.jfrog.io", serverDetails))
+	assert.False(t, isServerRegistry("docker.io", serverDetails))
+	assert.False(t, isServerRegistry("notmy.jfrog.io", serverDetails))
+	assert.False(t, isServerRegistry("my.jfrog.io.example.com", serverDetails))
+}
+
+func TestRegistryClientOtherRegistry(t *testing.T) {
+	registry := newTestRegistry(t, true)
+	ref, err := parseOciReference(registry.host() + "/docker-remote/alpine:3.19")
+	require.NoError(t, err)
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "http://my.jfrog.io/artifactory/", User: testRegistryUser, Password: "password"}
+
+	// The credentials of the server aren't sent to a registry of another host.
+	client, err := newRegistryClient(ref, serverDetails, false)
+	assert.ErrorContains(t, err, "failed logging in")
+	assert.Empty(t, client.username)
+	assert.Empty(t, client.password)
+}
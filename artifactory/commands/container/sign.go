@@ -0,0 +1,369 @@
+package container
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/container"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	containerutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/signing"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	SignKeyFlag   = "--sign-key"
+	VerifyKeyFlag = "--verify-key"
+	// The formats of cosign signatures, which are stored in the 'sha256-<hex>.sig' tag of the signed manifest.
+	simpleSigningMediaType    = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	cosignSignatureType       = "cosign container image signature"
+	ociImageConfigMediaType   = "application/vnd.oci.image.config.v1+json"
+	cosignSignatureTagSuffix  = ".sig"
+)
+
+// The payload which is signed, in the simple signing format of cosign.
+type simpleSigningPayload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+// Extracts the path of the signing or the verification key from the arguments of docker push or docker pull, which docker doesn't accept.
+func ExtractKeyFromArgs(args []string, keyFlag string) (cleanArgs []string, keyPath string, err error) {
+	cleanArgs = append([]string(nil), args...)
+	flagIndex, valueIndex, keyPath, err := coreutils.FindFlag(keyFlag, cleanArgs)
+	if err != nil {
+		return nil, "", err
+	}
+	coreutils.RemoveFlagFromCommand(&cleanArgs, flagIndex, valueIndex)
+	return cleanArgs, keyPath, nil
+}
+
+// Signs the pushed manifest of the image in the registry with the private key, and stores the signature in the format of cosign,
+// in the 'sha256-<hex>.sig' tag of the same repository. The signature can be verified by 'cosign verify --key' and by 'jf docker pull --verify-key'.
+// The digest is the digest of the pushed manifest, rather than the manifest which the tag refers to when signing, since the tag may be moved after the push.
+func SignImage(image, digest string, serverDetails *config.ServerDetails, skipLogin bool, keyPath string) error {
+	signer, err := signing.LoadSigningKey(keyPath)
+	if err != nil {
+		return err
+	}
+	reference, err := parseOciReference(image)
+	if err != nil {
+		return err
+	}
+	client, err := newRegistryClient(reference, serverDetails, skipLogin)
+	if err != nil {
+		return err
+	}
+	log.Info("Signing " + reference.registry + "/" + reference.repository + "@" + digest + "...")
+	payload, err := createSimpleSigningPayload(reference, digest)
+	if err != nil {
+		return err
+	}
+	signature, err := signing.Sign(signer, payload)
+	if err != nil {
+		return err
+	}
+	if err = pushSignature(client, digest, payload, signature); err != nil {
+		return err
+	}
+	log.Info("Pushed the signature of " + digest + " to the " + signatureTag(digest) + " tag.")
+	return nil
+}
+
+// Returns the digest of the manifest which docker pushed for the image, from the repository digests of the local image.
+func GetPushedDigest(image string) (string, error) {
+	reference, err := parseOciReference(image)
+	if err != nil {
+		return "", err
+	}
+	output, err := exec.Command("docker", "image", "inspect", "--format", "{{json .RepoDigests}}", image).Output()
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) {
+			return "", errorutils.CheckErrorf("failed inspecting %s: %s", image, strings.TrimSpace(string(exitError.Stderr)))
+		}
+		return "", errorutils.CheckError(err)
+	}
+	return parsePushedDigest(output, reference)
+}
+
+func parsePushedDigest(repoDigestsJson []byte, reference *ociReference) (string, error) {
+	var repoDigests []string
+	if err := json.Unmarshal(repoDigestsJson, &repoDigests); err != nil {
+		return "", errorutils.CheckErrorf("failed parsing the repository digests of %s: %s", reference.String(), err.Error())
+	}
+	for _, repoDigest := range repoDigests {
+		if digest, found := strings.CutPrefix(repoDigest, reference.registry+"/"+reference.repository+"@"); found {
+			return digest, nil
+		}
+	}
+	return "", errorutils.CheckErrorf("the digest of the pushed image %s wasn't found in the repository digests of the local image", reference.String())
+}
+
+// Verifies that the manifest which the image tag refers to in the registry has a cosign signature, which is verified by the public key.
+// Returns the digest of the verified manifest, or an error if none of the signatures is verified.
+func VerifyImage(image string, serverDetails *config.ServerDetails, skipLogin bool, keyPath string) (string, error) {
+	publicKey, err := signing.LoadVerificationKey(keyPath)
+	if err != nil {
+		return "", err
+	}
+	client, reference, digest, err := resolveImage(image, serverDetails, skipLogin)
+	if err != nil {
+		return "", err
+	}
+	log.Info("Verifying the signatures of " + reference.registry + "/" + reference.repository + "@" + digest + "...")
+	if err = verifySignatures(client, reference, digest, publicKey); err != nil {
+		return "", errors.Join(errorutils.CheckErrorf("refusing to use the image %s", image), err)
+	}
+	log.Info("The signature of " + digest + " was verified.")
+	return digest, nil
+}
+
+// Pulls the image by the digest which its signature was verified for, rather than by its tag, which may be moved after the verification.
+// The pulled image is tagged locally with the tag of the image, and is recorded in the build-info by its tag, as 'jf docker pull' records it.
+func PullVerifiedImage(pullCommand *container.PullCommand, dockerArgs []string, image, digest string) error {
+	reference, err := parseOciReference(image)
+	if err != nil {
+		return err
+	}
+	verifiedImage := reference.registry + "/" + reference.repository + "@" + digest
+	// The build-info is collected once the pulled image is tagged.
+	buildConfiguration := pullCommand.BuildConfiguration()
+	pullCommand.SetCmdParams(replaceImageArg(dockerArgs, image, verifiedImage))
+	pullCommand.SetBuildConfiguration(build.NewBuildConfiguration("", "", "", ""))
+	if err = commands.Exec(pullCommand); err != nil {
+		return err
+	}
+	containerManager := containerutils.NewManager(containerutils.DockerClient)
+	if err = containerManager.RunNativeCmd([]string{"tag", verifiedImage, image}); err != nil {
+		return err
+	}
+	pullCommand.SetBuildConfiguration(buildConfiguration)
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	return savePulledImageBuildInfo(pullCommand, image, containerManager)
+}
+
+// Returns the arguments of docker pull, with the image replaced.
+func replaceImageArg(args []string, image, replacement string) []string {
+	replaced := append([]string(nil), args...)
+	if index := slices.Index(replaced, image); index >= 0 {
+		replaced[index] = replacement
+	}
+	return replaced
+}
+
+func savePulledImageBuildInfo(pullCommand *container.PullCommand, image string, containerManager containerutils.ContainerManager) error {
+	buildConfiguration := pullCommand.BuildConfiguration()
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	project := buildConfiguration.GetProject()
+	serviceManager, err := utils.CreateServiceManager(pullCommand.ContainerCommandBase.ServerDetails(), -1, 0, false)
+	if err != nil {
+		return err
+	}
+	repo, err := pullCommand.GetRepo()
+	if err != nil {
+		return err
+	}
+	builder, err := containerutils.NewLocalAgentBuildInfoBuilder(containerutils.NewImage(image), repo, buildName, buildNumber, project, serviceManager, containerutils.Pull, containerManager)
+	if err != nil {
+		return err
+	}
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, project); err != nil {
+		return err
+	}
+	buildInfo, err := builder.Build(buildConfiguration.GetModule())
+	if err != nil || buildInfo == nil {
+		return err
+	}
+	return build.SaveBuildInfo(buildName, buildNumber, project, buildInfo)
+}
+
+// Returns a registry client of the repository of the image, and the digest of the manifest which the image refers to.
+func resolveImage(image string, serverDetails *config.ServerDetails, skipLogin bool) (*registryClient, *ociReference, string, error) {
+	reference, err := parseOciReference(image)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	client, err := newRegistryClient(reference, serverDetails, skipLogin)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	descriptor, _, err := client.fetchManifest(reference.Ref())
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if descriptor == nil {
+		return nil, nil, "", errorutils.CheckErrorf("the image %s wasn't found in the registry", image)
+	}
+	return client, reference, descriptor.Digest, nil
+}
+
+func signatureTag(digest string) string {
+	return referrersTag(digest) + cosignSignatureTagSuffix
+}
+
+func createSimpleSigningPayload(reference *ociReference, digest string) ([]byte, error) {
+	payload := new(simpleSigningPayload)
+	payload.Critical.Identity.DockerReference = reference.registry + "/" + reference.repository
+	payload.Critical.Image.DockerManifestDigest = digest
+	payload.Critical.Type = cosignSignatureType
+	content, err := json.Marshal(payload)
+	return content, errorutils.CheckError(err)
+}
+
+// Adds the signature to the signatures manifest of the digest, which is created if it doesn't exist.
+// Each signature is a layer of the manifest, whose content is the signed payload and whose annotation is the signature.
+func pushSignature(client *registryClient, digest string, payload, signature []byte) error {
+	manifest, err := fetchSignaturesManifest(client, digest)
+	if err != nil {
+		return err
+	}
+	if manifest == nil {
+		manifest = &ociManifest{SchemaVersion: 2, MediaType: ociManifestMediaType}
+	}
+	layer := ociDescriptor{
+		MediaType:   simpleSigningMediaType,
+		Digest:      calcDigest(payload),
+		Size:        int64(len(payload)),
+		Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+	}
+	if err = client.pushBlob(layer, bytes.NewReader(payload)); err != nil {
+		return err
+	}
+	manifest.Layers = append(manifest.Layers, layer)
+	configContent, err := createSignaturesConfig(manifest.Layers)
+	if err != nil {
+		return err
+	}
+	manifest.Config = ociDescriptor{MediaType: ociImageConfigMediaType, Digest: calcDigest(configContent), Size: int64(len(configContent))}
+	if err = client.pushBlob(manifest.Config, bytes.NewReader(configContent)); err != nil {
+		return err
+	}
+	manifestContent, err := json.Marshal(manifest)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, _, err = client.pushManifest(signatureTag(digest), ociManifestMediaType, manifestContent)
+	return err
+}
+
+// The signatures manifest is an image, whose config lists its layers, as cosign creates it.
+func createSignaturesConfig(layers []ociDescriptor) ([]byte, error) {
+	var config struct {
+		Architecture string   `json:"architecture"`
+		Os           string   `json:"os"`
+		Config       struct{} `json:"config"`
+		RootFs       struct {
+			Type    string   `json:"type"`
+			DiffIds []string `json:"diff_ids"`
+		} `json:"rootfs"`
+	}
+	config.RootFs.Type = "layers"
+	for _, layer := range layers {
+		config.RootFs.DiffIds = append(config.RootFs.DiffIds, layer.Digest)
+	}
+	content, err := json.Marshal(config)
+	return content, errorutils.CheckError(err)
+}
+
+func fetchSignaturesManifest(client *registryClient, digest string) (*ociManifest, error) {
+	descriptor, content, err := client.fetchManifest(signatureTag(digest))
+	if err != nil || descriptor == nil {
+		return nil, err
+	}
+	manifest := new(ociManifest)
+	if err = json.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the signatures of %s: %s", digest, err.Error())
+	}
+	return manifest, nil
+}
+
+// Returns nil if at least one of the signatures of the digest is verified by the public key, and its payload refers to the digest and to the repository.
+func verifySignatures(client *registryClient, reference *ociReference, digest string, publicKey crypto.PublicKey) error {
+	manifest, err := fetchSignaturesManifest(client, digest)
+	if err != nil {
+		return err
+	}
+	if manifest == nil {
+		return errorutils.CheckErrorf("no signatures were found for %s", digest)
+	}
+	var failures []string
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != simpleSigningMediaType {
+			continue
+		}
+		if err = verifySignature(client, reference, digest, layer, publicKey); err != nil {
+			log.Debug("Signature", layer.Digest, "wasn't verified:", err.Error())
+			failures = append(failures, err.Error())
+			continue
+		}
+		return nil
+	}
+	if len(failures) == 0 {
+		return errorutils.CheckErrorf("no signatures were found for %s", digest)
+	}
+	return errorutils.CheckErrorf("none of the %d signatures of %s was verified by the key:\n%s", len(failures), digest, strings.Join(failures, "\n"))
+}
+
+func verifySignature(client *registryClient, reference *ociReference, digest string, layer ociDescriptor, publicKey crypto.PublicKey) error {
+	signature, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+	if err != nil || len(signature) == 0 {
+		return errorutils.CheckErrorf("the signature %s isn't a valid base64 value", layer.Digest)
+	}
+	var buffer bytes.Buffer
+	if err = client.fetchBlob(layer.Digest, &buffer); err != nil {
+		return err
+	}
+	payload := buffer.Bytes()
+	if calcDigest(payload) != layer.Digest {
+		return errorutils.CheckErrorf("the content of the signed payload %s doesn't match its digest", layer.Digest)
+	}
+	if !signing.Verify(publicKey, payload, signature) {
+		return errorutils.CheckErrorf("the signature of the payload %s is invalid", layer.Digest)
+	}
+	signedPayload := new(simpleSigningPayload)
+	if err = json.Unmarshal(payload, signedPayload); err != nil {
+		return errorutils.CheckErrorf("failed parsing the signed payload %s: %s", layer.Digest, err.Error())
+	}
+	if signedPayload.Critical.Image.DockerManifestDigest != digest {
+		return errorutils.CheckErrorf("the payload %s is signed for %s, rather than for %s", layer.Digest, signedPayload.Critical.Image.DockerManifestDigest, digest)
+	}
+	if signedPayload.Critical.Type != cosignSignatureType {
+		return errorutils.CheckErrorf("unexpected signature type '%s' in the payload %s", signedPayload.Critical.Type, layer.Digest)
+	}
+	if identity := signedPayload.Critical.Identity.DockerReference; identity != reference.registry+"/"+reference.repository {
+		// Cosign verifies the digest, rather than the repository, since the image may be copied between registries.
+		log.Debug("The payload", layer.Digest, "is signed for the repository", identity)
+	}
+	return nil
+}
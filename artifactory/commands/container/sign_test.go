@@ -0,0 +1,101 @@
+package container
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractKeyFromArgs(t *testing.T) {
+	args := []string{"my.jfrog.io/docker-local/app:1.0", "--sign-key", "cosign.key", "--quiet"}
+	cleanArgs, keyPath, err := ExtractKeyFromArgs(args, SignKeyFlag)
+	require.NoError(t, err)
+	assert.Equal(t, "cosign.key", keyPath)
+	assert.Equal(t, []string{"my.jfrog.io/docker-local/app:1.0", "--quiet"}, cleanArgs)
+	// The original arguments aren't modified.
+	assert.Len(t, args, 4)
+
+	cleanArgs, keyPath, err = ExtractKeyFromArgs([]string{"my.jfrog.io/docker-local/app:1.0", "--verify-key=cosign.pub"}, VerifyKeyFlag)
+	require.NoError(t, err)
+	assert.Equal(t, "cosign.pub", keyPath)
+	assert.Equal(t, []string{"my.jfrog.io/docker-local/app:1.0"}, cleanArgs)
+
+	_, keyPath, err = ExtractKeyFromArgs([]string{"my.jfrog.io/docker-local/app:1.0"}, VerifyKeyFlag)
+	require.NoError(t, err)
+	assert.Empty(t, keyPath)
+}
+
+func TestSignAndVerifyImage(t *testing.T) {
+	registry := newTestRegistry(t, true)
+	image := registry.host() + "/" + testRegistryRepo + "/app:1.0"
+	imageManifest := []byte(`{"schemaVersion":2,"mediaType":"` + ociManifestMediaType + `","config":{},"layers":[]}`)
+	registry.manifests["1.0"] = imageManifest
+	imageDigest := calcDigest(imageManifest)
+	registry.manifests[imageDigest] = imageManifest
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privateKeyPath, publicKeyPath := signing.WriteTestKeys(t, key)
+	otherPrivateKeyPath, otherPublicKeyPath := signing.WriteTestKeys(t, otherKey)
+
+	// Verification fails before the image is signed.
+	_, err = VerifyImage(image, registry.serverDetails(), false, publicKeyPath)
+	assert.ErrorContains(t, err, "no signatures were found")
+
+	require.NoError(t, SignImage(image, imageDigest, registry.serverDetails(), false, privateKeyPath))
+	signatures := new(ociManifest)
+	require.NoError(t, json.Unmarshal(registry.manifests[signatureTag(imageDigest)], signatures))
+	require.Len(t, signatures.Layers, 1)
+	assert.Equal(t, simpleSigningMediaType, signatures.Layers[0].MediaType)
+	assert.NotEmpty(t, signatures.Layers[0].Annotations[cosignSignatureAnnotation])
+
+	verifiedDigest, err := VerifyImage(image, registry.serverDetails(), false, publicKeyPath)
+	assert.NoError(t, err)
+	assert.Equal(t, imageDigest, verifiedDigest)
+	_, err = VerifyImage(image, registry.serverDetails(), false, otherPublicKeyPath)
+	assert.ErrorContains(t, err, "refusing to use the image")
+	assert.ErrorContains(t, err, "is invalid")
+
+	// A second signature is added to the existing signatures, and either key verifies the image.
+	require.NoError(t, SignImage(image, imageDigest, registry.serverDetails(), false, otherPrivateKeyPath))
+	require.NoError(t, json.Unmarshal(registry.manifests[signatureTag(imageDigest)], signatures))
+	assert.Len(t, signatures.Layers, 2)
+	_, err = VerifyImage(image, registry.serverDetails(), false, otherPublicKeyPath)
+	assert.NoError(t, err)
+	_, err = VerifyImage(image, registry.serverDetails(), false, publicKeyPath)
+	assert.NoError(t, err)
+	assert.False(t, registry.unauthorizedAccess)
+
+	// The pushed digest is signed, even if the tag is moved to another manifest after the push.
+	movedManifest := []byte(`{"schemaVersion":2,"mediaType":"` + ociManifestMediaType + `","config":{},"layers":[],"annotations":{"moved":"true"}}`)
+	registry.manifests["1.0"] = movedManifest
+	registry.manifests[calcDigest(movedManifest)] = movedManifest
+	require.NoError(t, SignImage(image, imageDigest, registry.serverDetails(), false, privateKeyPath))
+	assert.Nil(t, registry.manifests[signatureTag(calcDigest(movedManifest))])
+	_, err = VerifyImage(image, registry.serverDetails(), false, publicKeyPath)
+	assert.ErrorContains(t, err, "no signatures were found")
+}
+
+func TestParsePushedDigest(t *testing.T) {
+	reference, err := parseOciReference("my.jfrog.io/docker-local/app:1.0")
+	require.NoError(t, err)
+	digest, err := parsePushedDigest([]byte(`["my.jfrog.io/docker-remote/app@sha256:aaa","my.jfrog.io/docker-local/app@sha256:bbb"]`), reference)
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:bbb", digest)
+	_, err = parsePushedDigest([]byte(`["my.jfrog.io/docker-local/other@sha256:aaa"]`), reference)
+	assert.ErrorContains(t, err, "wasn't found")
+}
+
+func TestReplaceImageArg(t *testing.T) {
+	args := []string{"pull", "my.jfrog.io/docker-local/app:1.0", "--quiet"}
+	assert.Equal(t, []string{"pull", "my.jfrog.io/docker-local/app@sha256:aaa", "--quiet"}, replaceImageArg(args, args[1], "my.jfrog.io/docker-local/app@sha256:aaa"))
+	assert.Equal(t, "my.jfrog.io/docker-local/app:1.0", args[1])
+}
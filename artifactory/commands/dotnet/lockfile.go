@@ -0,0 +1,388 @@
+package dotnet
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/build/utils/dotnet/solution"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	lockFileName      = "packages.lock.json"
+	packagesFolderEnv = "NUGET_PACKAGES"
+	// The dependencies of this type are the other projects of the solution, rather than packages.
+	projectDependencyType = "Project"
+)
+
+// The packages.lock.json file of a project, which NuGet writes when RestorePackagesWithLockFile is enabled.
+type lockFile struct {
+	Version int `json:"version"`
+	// The packages of each target framework, by their ID.
+	Dependencies map[string]map[string]lockedPackage `json:"dependencies"`
+}
+
+type lockedPackage struct {
+	// Direct, Transitive, CentralTransitive or Project.
+	Type      string `json:"type"`
+	Requested string `json:"requested,omitempty"`
+	Resolved  string `json:"resolved,omitempty"`
+	// The base64 SHA512 hash of the package.
+	ContentHash string `json:"contentHash,omitempty"`
+	// The dependencies of the package, by their ID, with their version ranges.
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+}
+
+// Returns the packages.lock.json files of the projects under the root directory, if the projects restore their packages with lock files. The bin and obj directories of the projects,
+// and the hidden directories, are skipped.
+func FindLockFiles(rootDir string) ([]string, error) {
+	var lockFilePaths []string
+	err := filepath.WalkDir(rootDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != rootDir && (name == "bin" || name == "obj" || name == "node_modules" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() == lockFileName {
+			lockFilePaths = append(lockFilePaths, path)
+		}
+		return nil
+	})
+	return lockFilePaths, errorutils.CheckError(err)
+}
+
+func readLockFile(lockFilePath string) (*lockFile, error) {
+	content, err := os.ReadFile(lockFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	lock := &lockFile{}
+	if err = json.Unmarshal(content, lock); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing %s: %s", lockFilePath, err.Error())
+	}
+	return lock, nil
+}
+
+// Returns the global packages folder, to which NuGet extracts the packages.
+func getPackagesFolder() (string, error) {
+	if packagesFolder := os.Getenv(packagesFolderEnv); packagesFolder != "" {
+		return packagesFolder, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.Join(homeDir, ".nuget", "packages"), nil
+}
+
+// A package of the lock file, in all the target frameworks in which it's resolved to the same version.
+type resolvedPackage struct {
+	Id          string
+	Version     string
+	ContentHash string
+	Frameworks  []string
+	// The ID of a package which depends on this package, in the shortest path from the project. Empty for the direct dependencies.
+	Parent string
+}
+
+// Returns the packages of the lock file, sorted by their ID and version.
+// The parent of each package is found by a breadth-first search from the direct dependencies of the project.
+func getResolvedPackages(lock *lockFile) []*resolvedPackage {
+	packagesByKey := make(map[string]*resolvedPackage)
+	frameworks := make([]string, 0, len(lock.Dependencies))
+	for framework := range lock.Dependencies {
+		frameworks = append(frameworks, framework)
+	}
+	sort.Strings(frameworks)
+	for _, framework := range frameworks {
+		packages := lock.Dependencies[framework]
+		// The package IDs are case-insensitive.
+		packagesById := make(map[string]string, len(packages))
+		var queue []string
+		for id, locked := range packages {
+			packagesById[strings.ToLower(id)] = id
+			if locked.Type == "Direct" {
+				queue = append(queue, id)
+			}
+		}
+		sort.Strings(queue)
+		parents := make(map[string]string)
+		visited := make(map[string]bool)
+		for _, id := range queue {
+			visited[id] = true
+		}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			children := make([]string, 0, len(packages[id].Dependencies))
+			for childId := range packages[id].Dependencies {
+				children = append(children, childId)
+			}
+			sort.Strings(children)
+			for _, childId := range children {
+				if childId, exists := packagesById[strings.ToLower(childId)]; exists && !visited[childId] {
+					visited[childId] = true
+					parents[childId] = id
+					queue = append(queue, childId)
+				}
+			}
+		}
+		for id, locked := range packages {
+			if locked.Type == projectDependencyType || locked.Resolved == "" {
+				continue
+			}
+			key := strings.ToLower(id) + ":" + locked.Resolved
+			resolved, exists := packagesByKey[key]
+			if !exists {
+				resolved = &resolvedPackage{Id: id, Version: locked.Resolved, ContentHash: locked.ContentHash, Parent: parents[id]}
+				packagesByKey[key] = resolved
+			}
+			resolved.Frameworks = append(resolved.Frameworks, framework)
+		}
+	}
+	resolvedPackages := make([]*resolvedPackage, 0, len(packagesByKey))
+	for _, resolved := range packagesByKey {
+		resolvedPackages = append(resolvedPackages, resolved)
+	}
+	sort.Slice(resolvedPackages, func(i, j int) bool {
+		if !strings.EqualFold(resolvedPackages[i].Id, resolvedPackages[j].Id) {
+			return strings.ToLower(resolvedPackages[i].Id) < strings.ToLower(resolvedPackages[j].Id)
+		}
+		return resolvedPackages[i].Version < resolvedPackages[j].Version
+	})
+	return resolvedPackages
+}
+
+// Returns the dependencies of the project by its lock file. The target frameworks in which each package is resolved are its scopes.
+// The checksums are calculated from the packages in the global packages folder, which are verified by the content hashes of the lock file.
+func getLockFileDependencies(lock *lockFile, moduleId, packagesFolder string) ([]buildinfo.Dependency, error) {
+	resolvedPackages := getResolvedPackages(lock)
+	versionsById := make(map[string]string, len(resolvedPackages))
+	for _, resolved := range resolvedPackages {
+		versionsById[resolved.Id] = resolved.Version
+	}
+	dependencies := make([]buildinfo.Dependency, 0, len(resolvedPackages))
+	var missingDependencies []string
+	for _, resolved := range resolvedPackages {
+		dependency := buildinfo.Dependency{Id: resolved.Id + ":" + resolved.Version, Type: "nupkg", Scopes: resolved.Frameworks}
+		// The requesting chain ends with the module, as in the build-info of the dotnet command.
+		var requestedBy []string
+		for parent := resolved.Parent; parent != "" && len(requestedBy) < len(resolvedPackages); parent = findParent(resolvedPackages, parent) {
+			requestedBy = append(requestedBy, parent+":"+versionsById[parent])
+		}
+		dependency.RequestedBy = [][]string{append(requestedBy, moduleId)}
+		checksum, err := getPackageChecksum(packagesFolder, resolved)
+		if err != nil {
+			return nil, err
+		}
+		if checksum == nil {
+			missingDependencies = append(missingDependencies, dependency.Id)
+		} else {
+			dependency.Checksum = *checksum
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	commandUtils.PrintMissingDependencies(missingDependencies)
+	return dependencies, nil
+}
+
+func findParent(resolvedPackages []*resolvedPackage, id string) string {
+	for _, resolved := range resolvedPackages {
+		if resolved.Id == id {
+			return resolved.Parent
+		}
+	}
+	return ""
+}
+
+// Returns the checksums of the package in the global packages folder, in which the IDs and versions of the packages are lowercase:
+// <packages folder>/<id>/<version>/<id>.<version>.nupkg. Returns nil if the package isn't in the folder.
+func getPackageChecksum(packagesFolder string, resolved *resolvedPackage) (*buildinfo.Checksum, error) {
+	id, version := strings.ToLower(resolved.Id), strings.ToLower(resolved.Version)
+	packagePath := filepath.Join(packagesFolder, id, version, id+"."+version+".nupkg")
+	exists, err := fileutils.IsFileExists(packagePath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	if resolved.ContentHash != "" {
+		contentHash, err := calcContentHash(packagePath)
+		if err != nil {
+			return nil, err
+		}
+		if contentHash != resolved.ContentHash {
+			return nil, errorutils.CheckErrorf("the content hash of %s doesn't match the content hash of %s for %s %s", packagePath, lockFileName, resolved.Id, resolved.Version)
+		}
+	}
+	fileDetails, err := fileutils.GetFileDetails(packagePath, true)
+	if err != nil {
+		return nil, err
+	}
+	return &buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256}, nil
+}
+
+func calcContentHash(packagePath string) (contentHash string, err error) {
+	file, err := os.Open(packagePath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	hash := sha512.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Returns the directory of the solution and the solution file, by the path argument of the dotnet command, as the dotnet command of the core finds them.
+// If the argument isn't a directory or a solution file, the solution is searched in the working directory.
+func getSolutionPath(argAndFlags []string) (solutionPath, slnFile string, err error) {
+	if solutionPath, err = os.Getwd(); err != nil {
+		return "", "", errorutils.CheckError(err)
+	}
+	if len(argAndFlags) == 0 || strings.HasPrefix(argAndFlags[0], "-") {
+		return solutionPath, "", nil
+	}
+	pathArg := argAndFlags[0]
+	if !filepath.IsAbs(pathArg) {
+		pathArg = filepath.Join(solutionPath, pathArg)
+	}
+	isDir, err := fileutils.IsDirExists(pathArg, false)
+	if err != nil || isDir {
+		return pathArg, "", err
+	}
+	isFile, err := fileutils.IsFileExists(pathArg, false)
+	if err != nil || !isFile {
+		return solutionPath, "", err
+	}
+	switch {
+	case strings.HasSuffix(pathArg, ".sln"):
+		return filepath.Dir(pathArg), filepath.Base(pathArg), nil
+	case strings.HasSuffix(filepath.Ext(pathArg), "proj") || strings.HasSuffix(pathArg, "packages.config"):
+		return filepath.Dir(pathArg), "", nil
+	}
+	return solutionPath, "", nil
+}
+
+// Returns the lock files of the restored projects of the solution which restore their packages with lock files, by the names of the projects,
+// and the modules of the other projects, whose dependencies are collected from their assets files.
+func splitLockedProjects(sol solution.Solution, moduleId string) (lockFilePaths map[string]string, modules []buildinfo.Module, err error) {
+	projects := sol.GetProjects()
+	lockFilePaths = make(map[string]string)
+	for _, project := range projects {
+		lockFilePath := filepath.Join(project.RootPath(), lockFileName)
+		exists, err := fileutils.IsFileExists(lockFilePath, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if exists {
+			lockFilePaths[project.Name()] = lockFilePath
+		}
+	}
+	if len(lockFilePaths) == len(projects) {
+		return lockFilePaths, nil, nil
+	}
+	buildInfo, err := sol.BuildInfo(moduleId, log.Logger)
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	// The solution has a module for each of its projects, in the order of the projects.
+	for i, module := range buildInfo.Modules {
+		if _, locked := lockFilePaths[projects[i].Name()]; !locked {
+			modules = append(modules, module)
+		}
+	}
+	return lockFilePaths, modules, nil
+}
+
+// Records the dependencies of the projects which were restored by the dotnet command, in a module per project, which is named after the project,
+// or in the module of the --module option. The dependencies of the projects which restore their packages with lock files are collected
+// from their packages.lock.json files, and the dependencies of the other projects are collected from their assets files, as the core collects them.
+func CollectDependencies(argAndFlags []string, buildConfiguration *build.BuildConfiguration) error {
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	solutionPath, slnFile, err := getSolutionPath(argAndFlags)
+	if err != nil {
+		return err
+	}
+	sol, err := solution.Load(solutionPath, slnFile, "", log.Logger)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	lockFilePaths, modules, err := splitLockedProjects(sol, buildConfiguration.GetModule())
+	if err != nil {
+		return err
+	}
+	if len(modules) > 0 {
+		buildInfoBuild, err := build.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, projectKey)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if err = buildInfoBuild.SaveBuildInfo(&buildinfo.BuildInfo{Modules: modules}); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	packagesFolder, err := getPackagesFolder()
+	if err != nil {
+		return err
+	}
+	projectNames := make([]string, 0, len(lockFilePaths))
+	for projectName := range lockFilePaths {
+		projectNames = append(projectNames, projectName)
+	}
+	sort.Strings(projectNames)
+	for _, projectName := range projectNames {
+		lockFilePath := lockFilePaths[projectName]
+		log.Info("Collecting the dependencies of " + lockFilePath + "...")
+		lock, err := readLockFile(lockFilePath)
+		if err != nil {
+			return err
+		}
+		moduleId := buildConfiguration.GetModule()
+		if moduleId == "" {
+			moduleId = projectName
+		}
+		dependencies, err := getLockFileDependencies(lock, moduleId, packagesFolder)
+		if err != nil {
+			return err
+		}
+		if err = build.SavePartialBuildInfo(buildName, buildNumber, projectKey, func(partial *buildinfo.Partial) {
+			partial.ModuleType = buildinfo.Nuget
+			partial.ModuleId = moduleId
+			partial.Dependencies = dependencies
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
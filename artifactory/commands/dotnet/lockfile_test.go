@@ -0,0 +1,117 @@
+package dotnet
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/build/utils/dotnet/solution"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindLockFiles(t *testing.T) {
+	// The lock file in the obj directory is skipped.
+	lockFilePaths, err := FindLockFiles("testdata")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("testdata", "MyApp", lockFileName)}, lockFilePaths)
+}
+
+func TestGetResolvedPackages(t *testing.T) {
+	lock, err := readLockFile(filepath.Join("testdata", "MyApp", lockFileName))
+	require.NoError(t, err)
+	resolvedPackages := getResolvedPackages(lock)
+	require.Len(t, resolvedPackages, 3)
+	assert.Equal(t, &resolvedPackage{Id: "Newtonsoft.Json", Version: "13.0.3", Frameworks: []string{"net6.0", "net8.0"},
+		ContentHash: "HrC5BXdl00IP9zeV+0Z848QWPAoCr9P3bDEZguI+gkLcBKAOxix/tLEAAHC+UvDNPv4a2d18lOReHMOagPa+zQ=="}, resolvedPackages[0])
+	assert.Equal(t, "Serilog", resolvedPackages[1].Id)
+	assert.Equal(t, "Serilog.Sinks.Console", resolvedPackages[1].Parent)
+	assert.Equal(t, []string{"net6.0"}, resolvedPackages[1].Frameworks)
+	assert.Equal(t, "Serilog.Sinks.Console", resolvedPackages[2].Id)
+	assert.Empty(t, resolvedPackages[2].Parent)
+}
+
+func TestGetLockFileDependencies(t *testing.T) {
+	packagesFolder := t.TempDir()
+	content := []byte("serilog")
+	packageDir := filepath.Join(packagesFolder, "serilog", "3.1.0")
+	require.NoError(t, os.MkdirAll(packageDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(packageDir, "serilog.3.1.0.nupkg"), content, 0644))
+	hash := sha512.Sum512(content)
+	lock := &lockFile{Dependencies: map[string]map[string]lockedPackage{"net8.0": {
+		"Serilog.Sinks.Console": {Type: "Direct", Resolved: "5.0.0", Dependencies: map[string]string{"Serilog": "3.1.0"}},
+		"Serilog":               {Type: "Transitive", Resolved: "3.1.0", ContentHash: base64.StdEncoding.EncodeToString(hash[:])},
+	}}}
+	dependencies, err := getLockFileDependencies(lock, "MyApp", packagesFolder)
+	require.NoError(t, err)
+	require.Len(t, dependencies, 2)
+	assert.Equal(t, "Serilog:3.1.0", dependencies[0].Id)
+	assert.Equal(t, []string{"net8.0"}, dependencies[0].Scopes)
+	assert.Equal(t, [][]string{{"Serilog.Sinks.Console:5.0.0", "MyApp"}}, dependencies[0].RequestedBy)
+	assert.NotEmpty(t, dependencies[0].Checksum.Sha1)
+	assert.NotEmpty(t, dependencies[0].Checksum.Sha256)
+	// The package which isn't in the packages folder has no checksums.
+	assert.Equal(t, "Serilog.Sinks.Console:5.0.0", dependencies[1].Id)
+	assert.Equal(t, [][]string{{"MyApp"}}, dependencies[1].RequestedBy)
+	assert.Equal(t, buildinfo.Checksum{}, dependencies[1].Checksum)
+
+	// A package which doesn't match the content hash of the lock file fails the collection.
+	lock.Dependencies["net8.0"]["Serilog"] = lockedPackage{Type: "Transitive", Resolved: "3.1.0", ContentHash: "other"}
+	_, err = getLockFileDependencies(lock, "MyApp", packagesFolder)
+	assert.ErrorContains(t, err, "doesn't match the content hash")
+}
+
+func TestSplitLockedProjects(t *testing.T) {
+	// A solution of two restored projects, of which only Locked restores its packages with a lock file.
+	solutionPath := t.TempDir()
+	solutionContent := `Project("{9A19103F-16F7-4668-BE54-9A1E7A4F7556}") = "Locked", "Locked/Locked.csproj", "{11111111-1111-1111-1111-111111111111}"
+EndProject
+Project("{9A19103F-16F7-4668-BE54-9A1E7A4F7556}") = "Unlocked", "Unlocked/Unlocked.csproj", "{22222222-2222-2222-2222-222222222222}"
+EndProject
+`
+	require.NoError(t, os.WriteFile(filepath.Join(solutionPath, "App.sln"), []byte(solutionContent), 0644))
+	assetsContent := []byte(`{"version":3,"targets":{},"libraries":{},"project":{"version":"1.0.0","restore":{"packagesPath":"` + filepath.ToSlash(t.TempDir()) + `"}}}`)
+	for _, projectName := range []string{"Locked", "Unlocked"} {
+		projectDir := filepath.Join(solutionPath, projectName)
+		require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "obj"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, projectName+".csproj"), []byte("<Project/>"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, "obj", "project.assets.json"), assetsContent, 0644))
+	}
+	lockFilePath := filepath.Join(solutionPath, "Locked", lockFileName)
+	require.NoError(t, os.WriteFile(lockFilePath, []byte(`{"version":1,"dependencies":{}}`), 0644))
+
+	sol, err := solution.Load(solutionPath, "", "", log.Logger)
+	require.NoError(t, err)
+	lockFilePaths, modules, err := splitLockedProjects(sol, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Locked": lockFilePath}, lockFilePaths)
+	require.Len(t, modules, 1)
+	assert.Equal(t, "Unlocked", modules[0].Id)
+}
+
+func TestGetSolutionPath(t *testing.T) {
+	workingDirectory, err := os.Getwd()
+	require.NoError(t, err)
+	testdataPath := filepath.Join(workingDirectory, "testdata")
+	projectPath := filepath.Join(testdataPath, "MyApp")
+	tests := []struct {
+		argAndFlags          []string
+		expectedSolutionPath string
+	}{
+		{nil, workingDirectory},
+		{[]string{"--no-cache"}, workingDirectory},
+		{[]string{"testdata"}, testdataPath},
+		{[]string{filepath.Join("testdata", "MyApp", "MyApp.csproj")}, projectPath},
+		{[]string{"missing.sln"}, workingDirectory},
+	}
+	for _, test := range tests {
+		solutionPath, slnFile, err := getSolutionPath(test.argAndFlags)
+		require.NoError(t, err)
+		assert.Equal(t, test.expectedSolutionPath, solutionPath, test.argAndFlags)
+		assert.Empty(t, slnFile)
+	}
+}
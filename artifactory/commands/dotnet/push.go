@@ -0,0 +1,363 @@
+package dotnet
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	packageExtension       = ".nupkg"
+	symbolPackageExtension = ".snupkg"
+	// The placeholder of the API key in the logged command.
+	maskedApiKey = "***"
+)
+
+// The options of 'dotnet nuget push' which set the destination of the packages, which is the configured repository.
+var sourceOptions = []string{"-s", "--source", "-ss", "--symbol-source", "-k", "--api-key", "-sk", "--symbol-api-key"}
+
+// Runs 'dotnet nuget push' for the packages, to the deployment repository, or to the resolution repository if no deployment repository is configured.
+// Artifactory authenticates the push by the API key, which is the username and the password of the server, separated by a colon.
+// The symbol packages (.snupkg) are pushed to the same repository, each one as a package of its own.
+// If build-info collection was requested, the pushed packages are recorded as artifacts, in a module per package ID and version.
+type NugetPushCommand struct {
+	// The package paths, which may be glob patterns, and the other options of 'dotnet nuget push'.
+	args               []string
+	configFilePath     string
+	executablePath     string
+	workingDirectory   string
+	repo               string
+	useNugetV2         bool
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewNugetPushCommand() *NugetPushCommand {
+	return &NugetPushCommand{}
+}
+
+func (npc *NugetPushCommand) SetConfigFilePath(configFilePath string) *NugetPushCommand {
+	npc.configFilePath = configFilePath
+	return npc
+}
+
+func (npc *NugetPushCommand) SetArgs(args []string) *NugetPushCommand {
+	npc.args = args
+	return npc
+}
+
+func (npc *NugetPushCommand) ServerDetails() (*config.ServerDetails, error) {
+	return npc.serverDetails, nil
+}
+
+func (npc *NugetPushCommand) CommandName() string {
+	return "rt_dotnet_nuget_push"
+}
+
+func (npc *NugetPushCommand) Init() (err error) {
+	log.Debug("Preparing to read the config file", npc.configFilePath)
+	vConfig, err := project.ReadConfigFile(npc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	npc.useNugetV2 = vConfig.GetBool(project.ProjectConfigResolverPrefix + ".nugetV2")
+	prefix := project.ProjectConfigResolverPrefix
+	if vConfig.IsSet(project.ProjectConfigDeployerPrefix) {
+		prefix = project.ProjectConfigDeployerPrefix
+	}
+	repoConfig, err := project.GetRepoConfigByPrefix(npc.configFilePath, prefix, vConfig)
+	if err != nil {
+		return err
+	}
+	npc.repo = repoConfig.TargetRepo()
+	if npc.serverDetails, err = repoConfig.ServerDetails(); err != nil {
+		return err
+	}
+	npc.args, npc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(npc.args)
+	return err
+}
+
+func (npc *NugetPushCommand) Run() (err error) {
+	if npc.executablePath, err = exec.LookPath("dotnet"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if npc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return err
+	}
+	collectBuildInfo, err := npc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	patterns, options, err := splitPushArgs(npc.args)
+	if err != nil {
+		return err
+	}
+	packagePaths, err := npc.expandPatterns(patterns)
+	if err != nil {
+		return err
+	}
+	sourceUrl, err := getSourceUrl(npc.serverDetails, npc.repo, npc.useNugetV2)
+	if err != nil {
+		return err
+	}
+	for _, packagePath := range packagePaths {
+		log.Info("Pushing " + filepath.Base(packagePath) + " to " + npc.repo + "...")
+		if err = npc.push(packagePath, sourceUrl, options); err != nil {
+			return err
+		}
+	}
+	if collectBuildInfo {
+		if err = npc.collectPushedArtifacts(packagePaths); err != nil {
+			return err
+		}
+	}
+	log.Info("dotnet nuget push finished successfully.")
+	return nil
+}
+
+// Splits the arguments into the package paths and the options. The options which set the destination of the packages aren't allowed.
+func splitPushArgs(args []string) (patterns, options []string, err error) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			patterns = append(patterns, arg)
+			continue
+		}
+		name, _, _ := strings.Cut(arg, "=")
+		for _, sourceOption := range sourceOptions {
+			if strings.EqualFold(name, sourceOption) {
+				return nil, nil, errorutils.CheckErrorf("the %s option can't be used, since the packages are pushed to the repository of the dotnet config", name)
+			}
+		}
+		options = append(options, arg)
+		// The options of 'dotnet nuget push' which take a value. The value is passed as the next argument, unless it's joined by '='.
+		if !strings.Contains(arg, "=") && (name == "-t" || name == "--timeout") && i+1 < len(args) {
+			i++
+			options = append(options, args[i])
+		}
+	}
+	if len(patterns) == 0 {
+		return nil, nil, errorutils.CheckErrorf("the path of the package to push is missing")
+	}
+	return patterns, options, nil
+}
+
+// Returns the paths of the packages which match the patterns. A symbol package which matches a pattern is pushed by its own path.
+func (npc *NugetPushCommand) expandPatterns(patterns []string) ([]string, error) {
+	var packagePaths []string
+	added := make(map[string]bool)
+	for _, pattern := range patterns {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(npc.workingDirectory, pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if len(matches) == 0 {
+			return nil, errorutils.CheckErrorf("no packages match %s", pattern)
+		}
+		for _, match := range matches {
+			extension := strings.ToLower(filepath.Ext(match))
+			if extension != packageExtension && extension != symbolPackageExtension {
+				return nil, errorutils.CheckErrorf("%s is not a NuGet package. NuGet packages are .nupkg or .snupkg files", match)
+			}
+			if !added[match] {
+				added[match] = true
+				packagePaths = append(packagePaths, match)
+			}
+		}
+	}
+	return packagePaths, nil
+}
+
+// Returns the URL of the NuGet API of the repository, as the dotnet command sets it as the package source.
+func getSourceUrl(serverDetails *config.ServerDetails, repo string, useNugetV2 bool) (string, error) {
+	sourceUrl, err := url.Parse(serverDetails.GetArtifactoryUrl())
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	nugetApi := "api/nuget/v3"
+	if useNugetV2 {
+		nugetApi = "api/nuget"
+	}
+	sourceUrl.Path = path.Join(sourceUrl.Path, nugetApi, repo)
+	return sourceUrl.String(), nil
+}
+
+// Pushes a single package. The symbol package of a package isn't pushed with it, since the symbol packages are pushed by their own paths.
+func (npc *NugetPushCommand) push(packagePath, sourceUrl string, options []string) error {
+	args := append([]string{"nuget", "push", packagePath, "--source", sourceUrl}, options...)
+	if strings.EqualFold(filepath.Ext(packagePath), packageExtension) {
+		args = append(args, "--no-symbols")
+	}
+	loggedArgs := args
+	if username, password := projectconfig.GetBasicAuthCredentials(npc.serverDetails); password != "" {
+		args = append(args, "--api-key", username+":"+password)
+		loggedArgs = append(append([]string(nil), loggedArgs...), "--api-key", maskedApiKey)
+	}
+	command := exec.Command(npc.executablePath, args...)
+	command.Dir = npc.workingDirectory
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+	log.Debug("Running command: dotnet", strings.Join(loggedArgs, " "))
+	if err := command.Run(); err != nil {
+		return errorutils.CheckErrorf("dotnet nuget push of %s failed: %s", filepath.Base(packagePath), err.Error())
+	}
+	return nil
+}
+
+// The identity of a package, by its nuspec file.
+type packageMetadata struct {
+	Id      string `xml:"metadata>id"`
+	Version string `xml:"metadata>version"`
+}
+
+// Returns the ID and version of the package, by the nuspec file in the root of the package archive.
+func readPackageMetadata(packagePath string) (metadata *packageMetadata, err error) {
+	reader, err := zip.OpenReader(packagePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	for _, file := range reader.File {
+		if strings.Contains(file.Name, "/") || !strings.EqualFold(filepath.Ext(file.Name), ".nuspec") {
+			continue
+		}
+		nuspec, err := file.Open()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		content, err := io.ReadAll(nuspec)
+		if err = errors.Join(errorutils.CheckError(err), errorutils.CheckError(nuspec.Close())); err != nil {
+			return nil, err
+		}
+		metadata = &packageMetadata{}
+		if err = xml.Unmarshal(content, metadata); err != nil {
+			return nil, errorutils.CheckErrorf("failed parsing %s of %s: %s", file.Name, packagePath, err.Error())
+		}
+		if metadata.Id == "" || metadata.Version == "" {
+			return nil, errorutils.CheckErrorf("the ID or the version of the package is missing from %s of %s", file.Name, packagePath)
+		}
+		return metadata, nil
+	}
+	return nil, errorutils.CheckErrorf("no nuspec file was found in %s", packagePath)
+}
+
+// Records the pushed packages as artifacts, in a module per package ID and version, or in the module of the --module option.
+// The path of each package in Artifactory is found by its checksum, since Artifactory stores the pushed packages by its NuGet layout.
+func (npc *NugetPushCommand) collectPushedArtifacts(packagePaths []string) error {
+	servicesManager, err := utils.CreateServiceManager(npc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	var moduleIds []string
+	artifactsByModule := make(map[string][]buildinfo.Artifact)
+	for _, packagePath := range packagePaths {
+		metadata, err := readPackageMetadata(packagePath)
+		if err != nil {
+			return err
+		}
+		fileDetails, err := fileutils.GetFileDetails(packagePath, true)
+		if err != nil {
+			return err
+		}
+		fileName := filepath.Base(packagePath)
+		artifact := buildinfo.Artifact{
+			Name:     fileName,
+			Type:     strings.TrimPrefix(strings.ToLower(filepath.Ext(fileName)), "."),
+			Checksum: buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256},
+		}
+		artifact.OriginalDeploymentRepo, artifact.Path, err = findDeployedPath(servicesManager, fileDetails.Checksum.Sha1)
+		if err != nil {
+			return err
+		}
+		if artifact.Path == "" {
+			log.Warn("The path of " + fileName + " in Artifactory wasn't found, so it's recorded by its name.")
+			artifact.Path, artifact.OriginalDeploymentRepo = fileName, npc.repo
+		}
+		moduleId := npc.buildConfiguration.GetModule()
+		if moduleId == "" {
+			moduleId = metadata.Id + ":" + metadata.Version
+		}
+		if _, exists := artifactsByModule[moduleId]; !exists {
+			moduleIds = append(moduleIds, moduleId)
+		}
+		artifactsByModule[moduleId] = append(artifactsByModule[moduleId], artifact)
+	}
+	buildName, err := npc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := npc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := npc.buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	for _, moduleId := range moduleIds {
+		artifacts := artifactsByModule[moduleId]
+		if err = build.SavePartialBuildInfo(buildName, buildNumber, projectKey, func(partial *buildinfo.Partial) {
+			partial.ModuleType = buildinfo.Nuget
+			partial.ModuleId = moduleId
+			partial.Artifacts = artifacts
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the repository and the path of the file with the checksum. If the repository is a virtual repository,
+// the package is stored in its default deployment repository, so the file is searched in all the repositories.
+func findDeployedPath(servicesManager artifactory.ArtifactoryServicesManager, sha1 string) (repo, filePath string, err error) {
+	reader, err := servicesManager.Aql(`items.find({"actual_sha1":"` + sha1 + `"}).include("repo","path","name","created").sort({"$desc":["created"]}).limit(1)`)
+	if err != nil {
+		return "", "", err
+	}
+	content, err := io.ReadAll(reader)
+	if err = errors.Join(errorutils.CheckError(err), errorutils.CheckError(reader.Close())); err != nil {
+		return "", "", err
+	}
+	var result struct {
+		Results []struct {
+			Repo string `json:"repo"`
+			Path string `json:"path"`
+			Name string `json:"name"`
+		} `json:"results"`
+	}
+	if err = json.Unmarshal(content, &result); err != nil {
+		return "", "", errorutils.CheckError(err)
+	}
+	if len(result.Results) == 0 {
+		return "", "", nil
+	}
+	item := result.Results[0]
+	if item.Path == "." {
+		return item.Repo, item.Name, nil
+	}
+	return item.Repo, item.Path + "/" + item.Name, nil
+}
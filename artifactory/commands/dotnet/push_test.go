@@ -0,0 +1,75 @@
+package dotnet
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPushArgs(t *testing.T) {
+	patterns, options, err := splitPushArgs([]string{"bin/*.nupkg", "--timeout", "600", "--skip-duplicate", "out/MyLib.1.0.0.snupkg"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bin/*.nupkg", "out/MyLib.1.0.0.snupkg"}, patterns)
+	assert.Equal(t, []string{"--timeout", "600", "--skip-duplicate"}, options)
+
+	_, _, err = splitPushArgs([]string{"MyLib.1.0.0.nupkg", "--source=https://api.nuget.org/v3/index.json"})
+	assert.ErrorContains(t, err, "--source")
+	_, _, err = splitPushArgs([]string{"-k", "key", "MyLib.1.0.0.nupkg"})
+	assert.ErrorContains(t, err, "-k")
+	_, _, err = splitPushArgs([]string{"--skip-duplicate"})
+	assert.Error(t, err)
+}
+
+func TestExpandPatterns(t *testing.T) {
+	workingDirectory := t.TempDir()
+	for _, fileName := range []string{"MyLib.1.0.0.nupkg", "MyLib.1.0.0.snupkg", "readme.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(workingDirectory, fileName), nil, 0644))
+	}
+	pushCommand := NewNugetPushCommand()
+	pushCommand.workingDirectory = workingDirectory
+	packagePaths, err := pushCommand.expandPatterns([]string{"*.nupkg", "*.*nupkg"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(workingDirectory, "MyLib.1.0.0.nupkg"), filepath.Join(workingDirectory, "MyLib.1.0.0.snupkg")}, packagePaths)
+
+	_, err = pushCommand.expandPatterns([]string{"*.md"})
+	assert.ErrorContains(t, err, "is not a NuGet package")
+	_, err = pushCommand.expandPatterns([]string{"missing.nupkg"})
+	assert.ErrorContains(t, err, "no packages match")
+}
+
+func TestGetSourceUrl(t *testing.T) {
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}
+	sourceUrl, err := getSourceUrl(serverDetails, "nuget-local", false)
+	require.NoError(t, err)
+	assert.Equal(t, "https://acme.jfrog.io/artifactory/api/nuget/v3/nuget-local", sourceUrl)
+	sourceUrl, err = getSourceUrl(serverDetails, "nuget-local", true)
+	require.NoError(t, err)
+	assert.Equal(t, "https://acme.jfrog.io/artifactory/api/nuget/nuget-local", sourceUrl)
+}
+
+func TestReadPackageMetadata(t *testing.T) {
+	packagePath := filepath.Join(t.TempDir(), "MyLib.1.0.0.nupkg")
+	file, err := os.Create(packagePath)
+	require.NoError(t, err)
+	writer := zip.NewWriter(file)
+	for name, content := range map[string]string{
+		"MyLib.nuspec":         `<?xml version="1.0"?><package xmlns="http://schemas.microsoft.com/packaging/2013/05/nuspec.xsd"><metadata><id>MyLib</id><version>1.0.0</version></metadata></package>`,
+		"lib/net8.0/MyLib.dll": "dll",
+	} {
+		entry, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, file.Close())
+
+	metadata, err := readPackageMetadata(packagePath)
+	require.NoError(t, err)
+	assert.Equal(t, &packageMetadata{Id: "MyLib", Version: "1.0.0"}, metadata)
+}
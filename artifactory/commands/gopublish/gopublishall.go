@@ -0,0 +1,175 @@
+package gopublish
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/golang"
+	commandutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Publishes all the Go modules of a multi-module repository: the modules of the go.work workspace in the current directory,
+// or the modules of all the go.mod files under it. The version of each module is resolved from the git tags with the prefix of the module.
+// The modules are published in dependency order, each one by the go-publish command of a single module, so they're all added to the same build-info.
+type GoPublishAllCommand struct {
+	configFilePath     string
+	buildConfiguration *build.BuildConfiguration
+	detailedSummary    bool
+	excludedPatterns   []string
+	serverDetails      *config.ServerDetails
+	result             *commandutils.Result
+}
+
+func NewGoPublishAllCommand() *GoPublishAllCommand {
+	return &GoPublishAllCommand{result: new(commandutils.Result)}
+}
+
+func (gpac *GoPublishAllCommand) SetConfigFilePath(configFilePath string) *GoPublishAllCommand {
+	gpac.configFilePath = configFilePath
+	return gpac
+}
+
+func (gpac *GoPublishAllCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *GoPublishAllCommand {
+	gpac.buildConfiguration = buildConfiguration
+	return gpac
+}
+
+func (gpac *GoPublishAllCommand) SetDetailedSummary(detailedSummary bool) *GoPublishAllCommand {
+	gpac.detailedSummary = detailedSummary
+	return gpac
+}
+
+func (gpac *GoPublishAllCommand) SetExcludedPatterns(excludedPatterns []string) *GoPublishAllCommand {
+	gpac.excludedPatterns = excludedPatterns
+	return gpac
+}
+
+func (gpac *GoPublishAllCommand) Result() *commandutils.Result {
+	return gpac.result
+}
+
+func (gpac *GoPublishAllCommand) ServerDetails() (*config.ServerDetails, error) {
+	return gpac.serverDetails, nil
+}
+
+func (gpac *GoPublishAllCommand) CommandName() string {
+	return "rt_go_publish_all"
+}
+
+// A module with the version in which it's published.
+type moduleVersion struct {
+	*goModule
+	Version string
+}
+
+func (gpac *GoPublishAllCommand) Run() (err error) {
+	if gpac.buildConfiguration.GetModule() != "" {
+		return errorutils.CheckErrorf("the --module option can't be used with --all, since each Go module is added to the build-info by its module path")
+	}
+	vConfig, err := project.ReadConfigFile(gpac.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	deployerParams, err := project.GetRepoConfigByPrefix(gpac.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	if gpac.serverDetails, err = deployerParams.ServerDetails(); err != nil {
+		return err
+	}
+	rootDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	modules, err := discoverModules(rootDir)
+	if err != nil {
+		return err
+	}
+	if len(modules) == 0 {
+		return errorutils.CheckErrorf("no Go modules were found in %s", rootDir)
+	}
+	// All the versions are resolved before publishing, so that nothing is published if the version of any module can't be resolved.
+	versions, err := resolveVersions(rootDir, sortByDependencies(modules))
+	if err != nil {
+		return err
+	}
+	var plan []string
+	for _, version := range versions {
+		plan = append(plan, version.Path+"@"+version.Version)
+	}
+	log.Info("Publishing", len(versions), "Go modules in dependency order:\n  "+strings.Join(plan, "\n  "))
+
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(os.Chdir(rootDir)))
+	}()
+	var readers []*content.ContentReader
+	defer func() {
+		if len(readers) > 0 {
+			gpac.setResultReader(readers)
+		}
+	}()
+	for _, version := range versions {
+		// The go-publish command publishes the module of the current directory.
+		if err = os.Chdir(version.Dir); err != nil {
+			return errorutils.CheckError(err)
+		}
+		goPublishCmd := golang.NewGoPublishCommand()
+		goPublishCmd.SetConfigFilePath(gpac.configFilePath).SetBuildConfiguration(gpac.buildConfiguration).SetVersion(version.Version).
+			SetDetailedSummary(gpac.detailedSummary).SetExcludedPatterns(gpac.excludedPatterns)
+		err = goPublishCmd.Run()
+		result := goPublishCmd.Result()
+		gpac.result.SetSuccessCount(gpac.result.SuccessCount() + result.SuccessCount())
+		gpac.result.SetFailCount(gpac.result.FailCount() + result.FailCount())
+		if result.Reader() != nil {
+			readers = append(readers, result.Reader())
+		}
+		if err != nil {
+			return errors.Join(errorutils.CheckErrorf("failed publishing %s@%s", version.Path, version.Version), err)
+		}
+	}
+	return nil
+}
+
+func resolveVersions(rootDir string, modules []*goModule) ([]moduleVersion, error) {
+	git := newGitRunner(rootDir)
+	repoRoot, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	repoRoot = strings.TrimSpace(repoRoot)
+	versions := make([]moduleVersion, 0, len(modules))
+	for _, module := range modules {
+		tagPrefix, err := getTagPrefix(repoRoot, module)
+		if err != nil {
+			return nil, err
+		}
+		version, err := resolveVersion(git, tagPrefix, module)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, moduleVersion{goModule: module, Version: version})
+	}
+	return versions, nil
+}
+
+// The transfer details of all the modules are merged into one reader, for the detailed summary.
+func (gpac *GoPublishAllCommand) setResultReader(readers []*content.ContentReader) {
+	mergedReader, err := content.MergeReaders(readers, content.DefaultKey)
+	if err != nil {
+		log.Warn("Failed merging the transfer details of the modules: " + err.Error())
+		return
+	}
+	for _, reader := range readers {
+		if closeErr := reader.Close(); closeErr != nil {
+			log.Debug("Failed closing the transfer details reader: " + closeErr.Error())
+		}
+	}
+	gpac.result.SetReader(mergedReader)
+}
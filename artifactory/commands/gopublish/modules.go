@@ -0,0 +1,159 @@
+package gopublish
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	goModFileName  = "go.mod"
+	goWorkFileName = "go.work"
+)
+
+// A Go module of a multi-module repository.
+type goModule struct {
+	// The module path, as declared in go.mod.
+	Path string
+	// The absolute path of the directory of go.mod.
+	Dir string
+	// The module paths which the module requires.
+	Requires []string
+}
+
+// Returns the modules of the workspace of go.work in the root directory. If there's no go.work file, the modules are found by their go.mod files.
+func discoverModules(rootDir string) ([]*goModule, error) {
+	workFilePath := filepath.Join(rootDir, goWorkFileName)
+	if content, err := os.ReadFile(workFilePath); err == nil {
+		log.Info("Reading the modules of the workspace from " + workFilePath + "...")
+		return readWorkspaceModules(rootDir, workFilePath, content)
+	} else if !os.IsNotExist(err) {
+		return nil, errorutils.CheckError(err)
+	}
+	log.Info("Searching for the go.mod files under " + rootDir + "...")
+	var moduleDirs []string
+	err := filepath.WalkDir(rootDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			// The vendor and testdata directories, and the directories which the go command ignores, don't include modules of the repository.
+			name := entry.Name()
+			if path != rootDir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() == goModFileName {
+			moduleDirs = append(moduleDirs, filepath.Dir(path))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return readModules(moduleDirs)
+}
+
+func readWorkspaceModules(rootDir, workFilePath string, content []byte) ([]*goModule, error) {
+	workFile, err := modfile.ParseWork(workFilePath, content, nil)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	moduleDirs := make([]string, 0, len(workFile.Use))
+	for _, use := range workFile.Use {
+		moduleDir := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(moduleDir) {
+			moduleDir = filepath.Join(rootDir, moduleDir)
+		}
+		moduleDirs = append(moduleDirs, filepath.Clean(moduleDir))
+	}
+	return readModules(moduleDirs)
+}
+
+func readModules(moduleDirs []string) ([]*goModule, error) {
+	modules := make([]*goModule, 0, len(moduleDirs))
+	for _, moduleDir := range moduleDirs {
+		goModPath := filepath.Join(moduleDir, goModFileName)
+		content, err := os.ReadFile(goModPath)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		modFile, err := modfile.ParseLax(goModPath, content, nil)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if modFile.Module == nil {
+			return nil, errorutils.CheckErrorf("the module path is missing from %s", goModPath)
+		}
+		module := &goModule{Path: modFile.Module.Mod.Path, Dir: moduleDir}
+		for _, require := range modFile.Require {
+			module.Requires = append(module.Requires, require.Mod.Path)
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+// Returns the modules in dependency order, so that each module is published after the modules of the repository which it requires.
+// The modules which don't depend on each other are sorted by their path.
+// Go allows cycles between modules. The modules of a cycle are published in the order of their paths, after the other modules.
+func sortByDependencies(modules []*goModule) []*goModule {
+	modulesByPath := make(map[string]*goModule, len(modules))
+	for _, module := range modules {
+		modulesByPath[module.Path] = module
+	}
+	// The number of modules of the repository which each module requires, and have not been published before it.
+	pendingRequires := make(map[string]int, len(modules))
+	dependents := make(map[string][]*goModule)
+	for _, module := range modules {
+		for _, require := range module.Requires {
+			if _, exists := modulesByPath[require]; exists && require != module.Path {
+				pendingRequires[module.Path]++
+				dependents[require] = append(dependents[require], module)
+			}
+		}
+	}
+	var ready []*goModule
+	for _, module := range modules {
+		if pendingRequires[module.Path] == 0 {
+			ready = append(ready, module)
+		}
+	}
+	sorted := make([]*goModule, 0, len(modules))
+	added := make(map[string]bool, len(modules))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return ready[i].Path < ready[j].Path })
+		module := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, module)
+		added[module.Path] = true
+		for _, dependent := range dependents[module.Path] {
+			if pendingRequires[dependent.Path]--; pendingRequires[dependent.Path] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+	if len(sorted) < len(modules) {
+		var cycle []*goModule
+		for _, module := range modules {
+			if !added[module.Path] {
+				cycle = append(cycle, module)
+			}
+		}
+		sort.Slice(cycle, func(i, j int) bool { return cycle[i].Path < cycle[j].Path })
+		var cyclePaths []string
+		for _, module := range cycle {
+			cyclePaths = append(cyclePaths, module.Path)
+		}
+		log.Warn("The following modules require each other, so they're published in the order of their paths: " + strings.Join(cyclePaths, ", "))
+		sorted = append(sorted, cycle...)
+	}
+	return sorted
+}
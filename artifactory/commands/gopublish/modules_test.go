@@ -0,0 +1,65 @@
+package gopublish
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, rootDir string, files map[string]string) {
+	for path, content := range files {
+		fullPath := filepath.Join(rootDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+}
+
+func getModulePaths(modules []*goModule) []string {
+	var paths []string
+	for _, module := range modules {
+		paths = append(paths, module.Path)
+	}
+	return paths
+}
+
+func TestDiscoverModulesByGoMod(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		"go.mod":                      "module example.com/repo\n\nrequire example.com/repo/lib v0.0.0\n",
+		"lib/go.mod":                  "module example.com/repo/lib\n",
+		"tools/cli/go.mod":            "module example.com/repo/tools/cli\n\nrequire (\n\texample.com/repo/lib v1.0.0\n\tgithub.com/spf13/cobra v1.8.0\n)\n",
+		"vendor/example.com/x/go.mod": "module example.com/x\n",
+		"lib/testdata/go.mod":         "module example.com/testdata\n",
+	})
+	modules, err := discoverModules(rootDir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"example.com/repo", "example.com/repo/lib", "example.com/repo/tools/cli"}, getModulePaths(modules))
+	assert.Equal(t, []string{"example.com/repo/lib", "example.com/repo", "example.com/repo/tools/cli"}, getModulePaths(sortByDependencies(modules)))
+}
+
+func TestDiscoverModulesByGoWork(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		"go.work":       "go 1.22\n\nuse (\n\t./api\n\t./core\n)\n",
+		"api/go.mod":    "module example.com/api\n\nrequire example.com/core v1.2.0\n",
+		"core/go.mod":   "module example.com/core\n",
+		"unused/go.mod": "module example.com/unused\n",
+	})
+	modules, err := discoverModules(rootDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com/api", "example.com/core"}, getModulePaths(modules))
+	assert.Equal(t, filepath.Join(rootDir, "core"), modules[1].Dir)
+	assert.Equal(t, []string{"example.com/core", "example.com/api"}, getModulePaths(sortByDependencies(modules)))
+}
+
+func TestSortByDependenciesWithCycle(t *testing.T) {
+	modules := []*goModule{
+		{Path: "example.com/b", Requires: []string{"example.com/c"}},
+		{Path: "example.com/c", Requires: []string{"example.com/b", "example.com/a"}},
+		{Path: "example.com/a"},
+	}
+	assert.Equal(t, []string{"example.com/a", "example.com/b", "example.com/c"}, getModulePaths(sortByDependencies(modules)))
+}
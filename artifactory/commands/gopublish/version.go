@@ -0,0 +1,114 @@
+package gopublish
+
+import (
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// Runs a git command in the repository, and returns its output.
+type gitRunner func(args ...string) (string, error)
+
+func newGitRunner(repoPath string) gitRunner {
+	return func(args ...string) (string, error) {
+		stdout, stderr, err := clientutils.NewGitManager(repoPath).ExecGit(append([]string{"-C", repoPath}, args...)...)
+		if err != nil {
+			return "", errorutils.CheckErrorf("'git %s' failed: %s %s", strings.Join(args, " "), err.Error(), stderr)
+		}
+		return stdout, nil
+	}
+}
+
+// Returns the prefix of the version tags of the module, which is the path of its directory in the repository, as the go command expects:
+// the tags of the module in sub/module are sub/module/v1.2.3. The tags of the module in the root directory have no prefix.
+// The major version subdirectory of a module, such as sub/module/v2 of example.com/repo/sub/module/v2, isn't part of the prefix.
+func getTagPrefix(repoRoot string, goModule *goModule) (string, error) {
+	// Git resolves the symbolic links in the path of the repository, such as /tmp on macOS.
+	moduleDir, err := filepath.EvalSymlinks(goModule.Dir)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if repoRoot, err = filepath.EvalSymlinks(repoRoot); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	relativeDir, err := filepath.Rel(repoRoot, moduleDir)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	relativeDir = filepath.ToSlash(relativeDir)
+	if _, pathMajor, ok := module.SplitPathVersion(goModule.Path); ok && strings.HasPrefix(pathMajor, "/") && path.Base(relativeDir) == pathMajor[1:] {
+		relativeDir = path.Dir(relativeDir)
+	}
+	if relativeDir == "." {
+		return "", nil
+	}
+	return relativeDir + "/", nil
+}
+
+// Returns the version of the module by the git tags with the prefix of the module.
+// If a tag of the module points at the current commit, its version is used. Otherwise, the module is published with a pseudo-version,
+// which is based on the latest tag of the module in the history of the current commit, the same way as the go command versions untagged commits.
+// Only the tags which match the major version of the module path are used.
+func resolveVersion(git gitRunner, tagPrefix string, goModule *goModule) (string, error) {
+	_, pathMajor, _ := module.SplitPathVersion(goModule.Path)
+	getVersions := func(args ...string) ([]string, error) {
+		output, err := git(append([]string{"tag", "--list", tagPrefix + "v*"}, args...)...)
+		if err != nil {
+			return nil, err
+		}
+		var versions []string
+		for _, tag := range strings.Fields(output) {
+			version := strings.TrimPrefix(tag, tagPrefix)
+			if semver.IsValid(version) && semver.Build(version) == "" && module.CheckPathMajor(version, pathMajor) == nil {
+				versions = append(versions, version)
+			}
+		}
+		return versions, nil
+	}
+
+	headVersions, err := getVersions("--points-at", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if len(headVersions) > 0 {
+		return getLatestVersion(headVersions), nil
+	}
+	previousVersions, err := getVersions("--merged", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	output, err := git("log", "-1", "--format=%H %ct", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(output)
+	if len(fields) != 2 || len(fields[0]) < 12 {
+		return "", errorutils.CheckErrorf("unexpected output of 'git log': %s", output)
+	}
+	commitTime, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	major := strings.TrimLeft(pathMajor, "/.")
+	pseudoVersion := module.PseudoVersion(major, getLatestVersion(previousVersions), time.Unix(commitTime, 0), fields[0][:12])
+	log.Warn("No " + tagPrefix + "v* tag of " + goModule.Path + " points at the current commit, so it's published with the pseudo-version " + pseudoVersion + ".")
+	return pseudoVersion, nil
+}
+
+func getLatestVersion(versions []string) string {
+	latest := ""
+	for _, version := range versions {
+		if latest == "" || semver.Compare(version, latest) > 0 {
+			latest = version
+		}
+	}
+	return latest
+}
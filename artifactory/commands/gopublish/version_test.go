@@ -0,0 +1,73 @@
+package gopublish
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTagPrefix(t *testing.T) {
+	repoRoot := t.TempDir()
+	writeFiles(t, repoRoot, map[string]string{"go.mod": "", "sub/module/go.mod": "", "sub/module/v2/go.mod": ""})
+	for _, testCase := range []struct {
+		dir, modulePath, expected string
+	}{
+		{".", "example.com/repo", ""},
+		{"sub/module", "example.com/repo/sub/module", "sub/module/"},
+		{"sub/module/v2", "example.com/repo/sub/module/v2", "sub/module/"},
+		{"sub/module", "example.com/repo/sub/module/v2", "sub/module/"},
+	} {
+		prefix, err := getTagPrefix(repoRoot, &goModule{Path: testCase.modulePath, Dir: filepath.Join(repoRoot, testCase.dir)})
+		require.NoError(t, err)
+		assert.Equal(t, testCase.expected, prefix, testCase.dir)
+	}
+}
+
+// Returns a git runner with the tags of the repository, where headTags point at the current commit, and mergedTags are in its history.
+func createGitRunner(headTags, mergedTags []string) gitRunner {
+	return func(args ...string) (string, error) {
+		switch {
+		case args[0] == "log":
+			return "0123456789abcdef0123456789abcdef01234567 1718000000\n", nil
+		case args[0] == "tag" && args[3] == "--points-at":
+			return filterTags(headTags, args[2]), nil
+		default:
+			return filterTags(mergedTags, args[2]), nil
+		}
+	}
+}
+
+func filterTags(tags []string, pattern string) string {
+	var filtered []string
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, strings.TrimSuffix(pattern, "*")) {
+			filtered = append(filtered, tag)
+		}
+	}
+	return strings.Join(filtered, "\n")
+}
+
+func TestResolveVersion(t *testing.T) {
+	mergedTags := []string{"v1.0.0", "v1.1.0", "sub/module/v0.3.0", "sub/module/v0.10.0", "sub/module/v2.0.0", "sub/module/v0.11.0-rc.1+build"}
+	module := &goModule{Path: "example.com/repo/sub/module"}
+
+	version, err := resolveVersion(createGitRunner([]string{"v1.1.0", "sub/module/v0.10.0", "sub/module/v0.9.0"}, mergedTags), "sub/module/", module)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.10.0", version)
+
+	// No tag of the module points at the current commit, so the pseudo-version is based on the latest tag of the same major version.
+	version, err = resolveVersion(createGitRunner([]string{"v1.1.0"}, mergedTags), "sub/module/", module)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.10.1-0.20240610061320-0123456789ab", version)
+
+	version, err = resolveVersion(createGitRunner(nil, mergedTags), "sub/module/", &goModule{Path: "example.com/repo/sub/module/v2"})
+	require.NoError(t, err)
+	assert.Equal(t, "v2.0.1-0.20240610061320-0123456789ab", version)
+
+	version, err = resolveVersion(createGitRunner(nil, nil), "", &goModule{Path: "example.com/repo"})
+	require.NoError(t, err)
+	assert.Equal(t, "v0.0.0-20240610061320-0123456789ab", version)
+}
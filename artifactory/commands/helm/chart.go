@@ -0,0 +1,157 @@
+package helm
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ChartManifestFileName = "Chart.yaml"
+	ChartLockFileName     = "Chart.lock"
+	// The lockfile of charts with apiVersion v1.
+	RequirementsLockFileName = "requirements.lock"
+
+	chartFileType = "tgz"
+)
+
+type chartMetadata struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+}
+
+func (cm *chartMetadata) id() string {
+	return cm.Name + ":" + cm.Version
+}
+
+type chartLock struct {
+	Dependencies []chartLockDependency `yaml:"dependencies"`
+}
+
+type chartLockDependency struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Repository string `yaml:"repository"`
+}
+
+// Local subcharts are part of the chart's sources, so they aren't dependencies.
+func (cld *chartLockDependency) isLocal() bool {
+	return cld.Repository == "" || strings.HasPrefix(cld.Repository, "file://")
+}
+
+// Returns the path of the lockfile of the chart, or an empty string if the chart has no lockfile.
+func getChartLockPath(chartDir string) (string, error) {
+	for _, lockFileName := range []string{ChartLockFileName, RequirementsLockFileName} {
+		lockfilePath := filepath.Join(chartDir, lockFileName)
+		exists, err := fileutils.IsFileExists(lockfilePath, false)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return lockfilePath, nil
+		}
+	}
+	return "", nil
+}
+
+// Reads the chart lockfile, and returns the dependencies of the chart, which are requested by rootModuleId.
+// Helm downloads the dependencies into the charts directory of the chart, so their checksums are calculated from the downloaded archives.
+func ParseChartLock(lockfilePath, rootModuleId string) ([]buildinfo.Dependency, error) {
+	content, err := os.ReadFile(lockfilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	lock := new(chartLock)
+	if err = yaml.Unmarshal(content, lock); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing %s: %s", lockfilePath, err.Error())
+	}
+	chartsDir := filepath.Join(filepath.Dir(lockfilePath), "charts")
+	var dependencies []buildinfo.Dependency
+	for _, lockDependency := range lock.Dependencies {
+		if lockDependency.isLocal() {
+			continue
+		}
+		dependency := buildinfo.Dependency{
+			Id:          lockDependency.Name + ":" + lockDependency.Version,
+			Type:        chartFileType,
+			RequestedBy: [][]string{{rootModuleId}},
+		}
+		archivePath := filepath.Join(chartsDir, lockDependency.Name+"-"+lockDependency.Version+".tgz")
+		exists, err := fileutils.IsFileExists(archivePath, false)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			fileDetails, err := fileutils.GetFileDetails(archivePath, true)
+			if err != nil {
+				return nil, err
+			}
+			dependency.Checksum = buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256}
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
+
+func readChartMetadata(chartDir string) (*chartMetadata, error) {
+	manifestPath := filepath.Join(chartDir, ChartManifestFileName)
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return parseChartMetadata(content, manifestPath)
+}
+
+// Reads Chart.yaml from a packaged chart. The archive includes a single top-level directory, named after the chart.
+func readChartMetadataFromArchive(archivePath string) (metadata *chartMetadata, err error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed reading %s: %s", archivePath, err.Error())
+	}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil, errorutils.CheckErrorf("%s wasn't found in %s", ChartManifestFileName, archivePath)
+		}
+		if err != nil {
+			return nil, errorutils.CheckErrorf("failed reading %s: %s", archivePath, err.Error())
+		}
+		if path.Base(header.Name) != ChartManifestFileName || strings.Count(strings.Trim(header.Name, "/"), "/") != 1 {
+			continue
+		}
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		return parseChartMetadata(content, archivePath)
+	}
+}
+
+func parseChartMetadata(content []byte, source string) (*chartMetadata, error) {
+	metadata := new(chartMetadata)
+	if err := yaml.Unmarshal(content, metadata); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the chart metadata of %s: %s", source, err.Error())
+	}
+	if metadata.Name == "" || metadata.Version == "" {
+		return nil, errorutils.CheckErrorf("the chart metadata of %s must include a name and a version", source)
+	}
+	return metadata, nil
+}
@@ -0,0 +1,102 @@
+package helm
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const chartLockContent = `dependencies:
+- name: common
+  repository: https://acme.jfrog.io/artifactory/api/helm/helm-virtual
+  version: 2.2.2
+- name: redis
+  repository: oci://acme.jfrog.io/helm-oci
+  version: 18.1.0
+- name: local-lib
+  repository: file://../local-lib
+  version: 0.1.0
+digest: sha256:aa38b2b2c66be4e4c4d1e2c2ba62d2e3d2e4cf1b3c1d60f2f2f8b0f6d2a2b9b1
+generated: "2024-03-01T10:00:00.000000+02:00"
+`
+
+func TestParseChartLock(t *testing.T) {
+	chartDir := t.TempDir()
+	lockfilePath := filepath.Join(chartDir, ChartLockFileName)
+	require.NoError(t, os.WriteFile(lockfilePath, []byte(chartLockContent), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(chartDir, "charts"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(chartDir, "charts", "common-2.2.2.tgz"), []byte("common"), 0644))
+
+	dependencies, err := ParseChartLock(lockfilePath, "my-chart:1.0.0")
+	require.NoError(t, err)
+	expected := []entities.Dependency{
+		{
+			Id:   "common:2.2.2",
+			Type: "tgz",
+			Checksum: entities.Checksum{
+				Sha1:   "94c8c21d08740f5da9eaa38d1f175c592692f0d1",
+				Md5:    "9efab2399c7c560b34de477b9aa0a465",
+				Sha256: "92a5dc04bd6f9fb8f29f8066fed8a5c1e81bc59ad48a11283b63736867e4f2a8",
+			},
+			RequestedBy: [][]string{{"my-chart:1.0.0"}},
+		},
+		// The chart which wasn't downloaded into the charts directory has no checksums.
+		{Id: "redis:18.1.0", Type: "tgz", RequestedBy: [][]string{{"my-chart:1.0.0"}}},
+	}
+	assert.Equal(t, expected, dependencies)
+}
+
+func TestGetChartLockPath(t *testing.T) {
+	chartDir := t.TempDir()
+	lockfilePath, err := getChartLockPath(chartDir)
+	require.NoError(t, err)
+	assert.Empty(t, lockfilePath)
+
+	require.NoError(t, os.WriteFile(filepath.Join(chartDir, RequirementsLockFileName), []byte(chartLockContent), 0644))
+	lockfilePath, err = getChartLockPath(chartDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(chartDir, RequirementsLockFileName), lockfilePath)
+}
+
+func TestReadChartMetadataFromArchive(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "my-chart-1.0.0.tgz")
+	writeChartArchive(t, archivePath, map[string]string{
+		"my-chart/charts/common/Chart.yaml": "name: common\nversion: 2.2.2\n",
+		"my-chart/Chart.yaml":               "apiVersion: v2\nname: my-chart\nversion: 1.0.0\n",
+	})
+	metadata, err := readChartMetadataFromArchive(archivePath)
+	require.NoError(t, err)
+	assert.Equal(t, "my-chart:1.0.0", metadata.id())
+
+	writeChartArchive(t, archivePath, map[string]string{"my-chart/values.yaml": ""})
+	_, err = readChartMetadataFromArchive(archivePath)
+	assert.ErrorContains(t, err, "Chart.yaml wasn't found")
+}
+
+func writeChartArchive(t *testing.T, archivePath string, files map[string]string) {
+	file, err := os.Create(archivePath)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, file.Close())
+	}()
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	// The nested Chart.yaml is written first, to verify that only the top-level one is read.
+	for _, name := range []string{"my-chart/charts/common/Chart.yaml", "my-chart/Chart.yaml", "my-chart/values.yaml"} {
+		content, exists := files[name]
+		if !exists {
+			continue
+		}
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}))
+		_, err = tarWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+}
@@ -0,0 +1,447 @@
+package helm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/checksums"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	helmModuleType buildinfo.ModuleType = "helm"
+	ociScheme                           = "oci://"
+)
+
+// Helm prints the digest of the pushed manifest, for example: "Digest: sha256:4a5b...".
+var pushDigestRegExp = regexp.MustCompile(`(?m)^Digest:\s*sha256:([0-9a-fA-F]{64})\s*$`)
+
+// Flags of the package and dependency commands which are followed by a value, so the value isn't mistaken for the chart path.
+var flagsWithValue = map[string]bool{
+	"--app-version": true, "-d": true, "--destination": true, "--key": true, "--keyring": true, "--passphrase-file": true, "--version": true,
+	"--kube-context": true, "--kubeconfig": true, "-n": true, "--namespace": true, "--registry-config": true, "--repository-cache": true, "--repository-config": true,
+}
+
+// Runs any Helm command, authenticated against the Artifactory Helm and OCI repositories of the configured servers.
+// The push command deploys a packaged chart, either to an OCI repository or to the Helm repository of the deployer.
+// If build-info collection was requested, the dependencies are collected from the chart lockfile, and the pushed charts are recorded as artifacts.
+type HelmCommand struct {
+	cmdName               string
+	args                  []string
+	configFilePath        string
+	executablePath        string
+	workingDirectory      string
+	repo                  string
+	deployRepo            string
+	skipLogin             bool
+	serverDetails         *config.ServerDetails
+	deployerServerDetails *config.ServerDetails
+	buildConfiguration    *build.BuildConfiguration
+}
+
+func NewHelmCommand(cmdName string) *HelmCommand {
+	return &HelmCommand{cmdName: cmdName}
+}
+
+func (hc *HelmCommand) SetConfigFilePath(configFilePath string) *HelmCommand {
+	hc.configFilePath = configFilePath
+	return hc
+}
+
+func (hc *HelmCommand) SetArgs(args []string) *HelmCommand {
+	hc.args = args
+	return hc
+}
+
+func (hc *HelmCommand) ServerDetails() (*config.ServerDetails, error) {
+	if hc.isPush() {
+		return hc.deployerServerDetails, nil
+	}
+	return hc.serverDetails, nil
+}
+
+func (hc *HelmCommand) CommandName() string {
+	return "rt_helm_" + hc.cmdName
+}
+
+func (hc *HelmCommand) isPush() bool {
+	return hc.cmdName == "push"
+}
+
+// The resolution repository is required by all commands but push, which requires the deployment repository.
+func (hc *HelmCommand) Init() (err error) {
+	log.Debug("Preparing to read the config file", hc.configFilePath)
+	vConfig, err := project.ReadConfigFile(hc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	if hc.isPush() {
+		deployerParams, err := project.GetRepoConfigByPrefix(hc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		hc.deployRepo = deployerParams.TargetRepo()
+		if hc.deployerServerDetails, err = deployerParams.ServerDetails(); err != nil {
+			return err
+		}
+	} else {
+		resolverParams, err := project.GetRepoConfigByPrefix(hc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		hc.repo = resolverParams.TargetRepo()
+		if hc.serverDetails, err = resolverParams.ServerDetails(); err != nil {
+			return err
+		}
+	}
+	if hc.args, hc.skipLogin, err = coreutils.ExtractSkipLoginFromArgs(hc.args); err != nil {
+		return err
+	}
+	hc.args, hc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(hc.args)
+	return err
+}
+
+func (hc *HelmCommand) Run() (err error) {
+	log.Info("Running helm " + hc.cmdName + "...")
+	if hc.executablePath, err = exec.LookPath("helm"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if hc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return err
+	}
+	// The credentials are stored in temporary copies of the Helm configuration files, so they don't remain on the machine.
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	if hc.isPush() {
+		err = hc.push(tempDir)
+	} else {
+		err = hc.runWithResolution(tempDir)
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("helm " + hc.cmdName + " finished successfully.")
+	return nil
+}
+
+func (hc *HelmCommand) runWithResolution(tempDir string) error {
+	env := os.Environ()
+	if !hc.skipLogin {
+		repositoryConfigPath, err := hc.createRepositoryConfig(tempDir)
+		if err != nil {
+			return err
+		}
+		env = append(env, "HELM_REPOSITORY_CONFIG="+repositoryConfigPath)
+		registryConfigPath, err := hc.copyRegistryConfig(tempDir)
+		if err != nil {
+			return err
+		}
+		env = append(env, "HELM_REGISTRY_CONFIG="+registryConfigPath)
+		// Charts may also be resolved from OCI repositories of the same server, so a failed login doesn't fail the command.
+		registry, insecure, err := getRegistryHost(hc.serverDetails)
+		if err == nil {
+			err = registryLogin(hc.executablePath, registry, insecure, hc.serverDetails, env)
+		}
+		if err != nil {
+			log.Warn("Charts can't be resolved from OCI repositories: " + err.Error())
+		}
+	}
+	collectBuildInfo, err := hc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	return projectconfig.RunWithResolution(func() error {
+		_, err := hc.runHelm(env, append([]string{hc.cmdName}, hc.args...))
+		return err
+	}, collectBuildInfo, hc.updatesDependencies(), hc.collectDependencies)
+}
+
+// The dependencies are recorded after they're downloaded by the dependency update/build commands, or when the chart is packaged.
+func (hc *HelmCommand) updatesDependencies() bool {
+	switch hc.cmdName {
+	case "package":
+		return true
+	case "dependency", "dep", "dependencies":
+		subcommand := getPositionalArg(hc.args, 0)
+		return subcommand == "update" || subcommand == "up" || subcommand == "build"
+	}
+	return false
+}
+
+// Runs Helm with the given arguments, and returns its output, which is also printed.
+func (hc *HelmCommand) runHelm(env, args []string) ([]byte, error) {
+	output := new(bytes.Buffer)
+	command := exec.Command(hc.executablePath, args...)
+	command.Dir = hc.workingDirectory
+	command.Env = env
+	command.Stdin = os.Stdin
+	command.Stdout = io.MultiWriter(os.Stdout, output)
+	command.Stderr = io.MultiWriter(os.Stderr, output)
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	if err := command.Run(); err != nil {
+		return nil, errorutils.CheckErrorf("helm %s failed: %s", hc.cmdName, err.Error())
+	}
+	return output.Bytes(), nil
+}
+
+func (hc *HelmCommand) collectDependencies() error {
+	chartPosition := 0
+	if hc.cmdName != "package" {
+		// The dependency command is followed by its subcommand.
+		chartPosition = 1
+	}
+	chartDir := getPositionalArg(hc.args, chartPosition)
+	if chartDir == "" {
+		chartDir = "."
+	}
+	if !filepath.IsAbs(chartDir) {
+		chartDir = filepath.Join(hc.workingDirectory, chartDir)
+	}
+	metadata, err := readChartMetadata(chartDir)
+	if err != nil {
+		return err
+	}
+	moduleId := hc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = metadata.id()
+	}
+	lockfilePath, err := getChartLockPath(chartDir)
+	if err != nil {
+		return err
+	}
+	if lockfilePath == "" {
+		log.Info("The chart has no lockfile, so no dependencies are added to the build-info.")
+		return nil
+	}
+	log.Info("Collecting dependencies from " + filepath.Base(lockfilePath) + "...")
+	dependencies, err := ParseChartLock(lockfilePath, moduleId)
+	if err != nil {
+		return err
+	}
+	var missingChecksums []string
+	for _, dependency := range dependencies {
+		if dependency.Sha1 == "" {
+			missingChecksums = append(missingChecksums, dependency.Id)
+		}
+	}
+	if len(missingChecksums) > 0 {
+		log.Warn("The following charts weren't found in the charts directory, so their checksums aren't added to the build-info. Run 'helm dependency build' to download them:\n" + strings.Join(missingChecksums, "\n"))
+	}
+	return projectconfig.SaveModule(hc.buildConfiguration, helmModuleType, moduleId, func(partial *buildinfo.Partial) {
+		partial.Dependencies = dependencies
+	})
+}
+
+// Pushes a packaged chart. If an OCI remote is given, the chart is pushed by Helm. Otherwise, it's uploaded to the Helm repository of the deployer.
+func (hc *HelmCommand) push(tempDir string) (err error) {
+	chartArchive := getPositionalArg(hc.args, 0)
+	if !strings.HasSuffix(chartArchive, ".tgz") {
+		return errorutils.CheckErrorf("the push command expects a packaged chart (.tgz). Use 'jf helm package' to package the chart")
+	}
+	if !filepath.IsAbs(chartArchive) {
+		chartArchive = filepath.Join(hc.workingDirectory, chartArchive)
+	}
+	metadata, err := readChartMetadataFromArchive(chartArchive)
+	if err != nil {
+		return err
+	}
+	collectBuildInfo, err := hc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	var artifacts []buildinfo.Artifact
+	if remote := getPositionalArg(hc.args, 1); remote != "" {
+		if !strings.HasPrefix(remote, ociScheme) {
+			return errorutils.CheckErrorf("charts can be pushed only to OCI remotes, which start with %s. To deploy the chart to the Helm repository configured by 'jf helm-config', omit the remote", ociScheme)
+		}
+		artifacts, err = hc.pushToOciRepo(tempDir, chartArchive, remote, metadata)
+	} else {
+		artifacts, err = hc.uploadToHelmRepo(chartArchive, metadata, collectBuildInfo)
+	}
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	moduleId := hc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = metadata.id()
+	}
+	return projectconfig.SaveModule(hc.buildConfiguration, helmModuleType, moduleId, func(partial *buildinfo.Partial) {
+		partial.Artifacts = artifacts
+	})
+}
+
+func (hc *HelmCommand) pushToOciRepo(tempDir, chartArchive, remote string, metadata *chartMetadata) ([]buildinfo.Artifact, error) {
+	registry, repoKey, namespace := parseOciRemote(remote)
+	env := os.Environ()
+	if !hc.skipLogin {
+		registryConfigPath, err := hc.copyRegistryConfig(tempDir)
+		if err != nil {
+			return nil, err
+		}
+		env = append(env, "HELM_REGISTRY_CONFIG="+registryConfigPath)
+		insecure := hasFlag(hc.args, "--plain-http") || hasFlag(hc.args, "--insecure-skip-tls-verify")
+		if err = registryLogin(hc.executablePath, registry, insecure, hc.deployerServerDetails, env); err != nil {
+			return nil, err
+		}
+	}
+	output, err := hc.runHelm(env, append([]string{hc.cmdName}, hc.args...))
+	if err != nil {
+		return nil, err
+	}
+
+	// Artifactory stores the chart under <namespace>/<chart>/<version>, as a manifest and a layer which is the chart archive.
+	artifactsPath := strings.TrimPrefix(namespace+"/"+metadata.Name+"/"+metadata.Version, "/")
+	layerArtifact, err := createChartArtifact(chartArchive)
+	if err != nil {
+		return nil, err
+	}
+	layerArtifact.Name = "sha256__" + layerArtifact.Sha256
+	layerArtifact.Path = artifactsPath + "/" + layerArtifact.Name
+	layerArtifact.OriginalDeploymentRepo = repoKey
+	artifacts := []buildinfo.Artifact{layerArtifact}
+	match := pushDigestRegExp.FindSubmatch(output)
+	if match == nil {
+		log.Warn("The digest of the pushed chart wasn't found in the output of helm push, so its manifest isn't added to the build-info.")
+		return artifacts, nil
+	}
+	servicesManager, err := utils.CreateServiceManager(hc.deployerServerDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	manifestDigest := strings.ToLower(string(match[1]))
+	found, err := checksums.Search(servicesManager, checksums.Sha256Field, []string{manifestDigest})
+	if err != nil {
+		return nil, err
+	}
+	manifestChecksum, exists := found[manifestDigest]
+	if !exists {
+		log.Warn("The manifest of the pushed chart wasn't found in Artifactory, so it isn't added to the build-info.")
+		return artifacts, nil
+	}
+	return append(artifacts, buildinfo.Artifact{
+		Name:                   "manifest.json",
+		Type:                   "json",
+		Path:                   artifactsPath + "/manifest.json",
+		OriginalDeploymentRepo: repoKey,
+		Checksum:               manifestChecksum,
+	}), nil
+}
+
+// Uploads the chart to the root of the Helm repository, which indexes it.
+func (hc *HelmCommand) uploadToHelmRepo(chartArchive string, metadata *chartMetadata, collectBuildInfo bool) ([]buildinfo.Artifact, error) {
+	log.Info("Uploading " + metadata.id() + " to " + hc.deployRepo + "...")
+	servicesManager, err := utils.CreateServiceManager(hc.deployerServerDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	uploadParams := services.NewUploadParams()
+	uploadParams.Pattern = chartArchive
+	uploadParams.Target = hc.deployRepo + "/"
+	uploadParams.Flat = true
+	if collectBuildInfo {
+		if uploadParams.BuildProps, err = build.CreateBuildPropsFromConfiguration(hc.buildConfiguration); err != nil {
+			return nil, err
+		}
+	}
+	uploaded, failed, err := servicesManager.UploadFiles(uploadParams)
+	if err != nil {
+		return nil, err
+	}
+	if failed > 0 || uploaded == 0 {
+		return nil, errorutils.CheckErrorf("failed uploading %s to %s", filepath.Base(chartArchive), hc.deployRepo)
+	}
+	artifact, err := createChartArtifact(chartArchive)
+	if err != nil {
+		return nil, err
+	}
+	artifact.OriginalDeploymentRepo = hc.deployRepo
+	return []buildinfo.Artifact{artifact}, nil
+}
+
+func createChartArtifact(chartArchive string) (buildinfo.Artifact, error) {
+	fileDetails, err := fileutils.GetFileDetails(chartArchive, true)
+	if err != nil {
+		return buildinfo.Artifact{}, err
+	}
+	fileName := filepath.Base(chartArchive)
+	return buildinfo.Artifact{
+		Name:     fileName,
+		Type:     chartFileType,
+		Path:     fileName,
+		Checksum: buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256},
+	}, nil
+}
+
+// Splits an OCI remote, such as oci://acme.jfrog.io/helm-oci/team, into its registry, repository key and namespace in the repository.
+func parseOciRemote(remote string) (registry, repoKey, namespace string) {
+	parts := strings.SplitN(strings.Trim(strings.TrimPrefix(remote, ociScheme), "/"), "/", 3)
+	registry = parts[0]
+	if len(parts) > 1 {
+		repoKey = parts[1]
+	}
+	if len(parts) > 2 {
+		namespace = parts[2]
+	}
+	return
+}
+
+// Returns the host of the OCI registry of the server, which is the host of the platform URL.
+func getRegistryHost(serverDetails *config.ServerDetails) (host string, insecure bool, err error) {
+	serverUrl := serverDetails.GetUrl()
+	if serverUrl == "" {
+		serverUrl = serverDetails.GetArtifactoryUrl()
+	}
+	parsedUrl, err := url.Parse(serverUrl)
+	if err != nil {
+		return "", false, errorutils.CheckError(err)
+	}
+	return parsedUrl.Host, parsedUrl.Scheme == "http", nil
+}
+
+// Returns the positional argument in the given position, skipping flags and their values.
+func getPositionalArg(args []string, position int) string {
+	for i := 0; i < len(args); i++ {
+		if strings.HasPrefix(args[i], "-") {
+			if flagsWithValue[args[i]] {
+				i++
+			}
+			continue
+		}
+		if position == 0 {
+			return args[i]
+		}
+		position--
+	}
+	return ""
+}
+
+func hasFlag(args []string, flagName string) bool {
+	for _, arg := range args {
+		if arg == flagName || strings.HasPrefix(arg, flagName+"=") {
+			return true
+		}
+	}
+	return false
+}
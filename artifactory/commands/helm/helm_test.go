@@ -0,0 +1,70 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestGetPositionalArg(t *testing.T) {
+	args := []string{"update", "--kube-context", "dev", "./my-chart", "--skip-refresh"}
+	assert.Equal(t, "update", getPositionalArg(args, 0))
+	assert.Equal(t, "./my-chart", getPositionalArg(args, 1))
+	assert.Empty(t, getPositionalArg(args, 2))
+
+	args = []string{"-d", "out", "--version=1.0.0", "my-chart"}
+	assert.Equal(t, "my-chart", getPositionalArg(args, 0))
+}
+
+func TestParseOciRemote(t *testing.T) {
+	testCases := []struct {
+		remote, registry, repoKey, namespace string
+	}{
+		{"oci://acme.jfrog.io/helm-oci", "acme.jfrog.io", "helm-oci", ""},
+		{"oci://acme.jfrog.io/helm-oci/", "acme.jfrog.io", "helm-oci", ""},
+		{"oci://localhost:8082/helm-oci/team/charts", "localhost:8082", "helm-oci", "team/charts"},
+	}
+	for _, testCase := range testCases {
+		registry, repoKey, namespace := parseOciRemote(testCase.remote)
+		assert.Equal(t, testCase.registry, registry)
+		assert.Equal(t, testCase.repoKey, repoKey)
+		assert.Equal(t, testCase.namespace, namespace)
+	}
+}
+
+func TestGetRegistryHost(t *testing.T) {
+	host, insecure, err := getRegistryHost(&config.ServerDetails{Url: "https://acme.jfrog.io/", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"})
+	assert.NoError(t, err)
+	assert.Equal(t, "acme.jfrog.io", host)
+	assert.False(t, insecure)
+
+	host, insecure, err = getRegistryHost(&config.ServerDetails{ArtifactoryUrl: "http://localhost:8082/artifactory/"})
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost:8082", host)
+	assert.True(t, insecure)
+}
+
+func TestCreateRepositoryEntry(t *testing.T) {
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "admin", Password: "password"}
+	entry := createRepositoryEntry(serverDetails, "helm-virtual")
+	assert.Equal(t, yaml.MapSlice{
+		{Key: "name", Value: ResolutionRepoName},
+		{Key: "url", Value: "https://acme.jfrog.io/artifactory/api/helm/helm-virtual"},
+		{Key: "username", Value: "admin"},
+		{Key: "password", Value: "password"},
+	}, entry)
+
+	// Anonymous access.
+	entry = createRepositoryEntry(&config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}, "helm-virtual")
+	assert.Len(t, entry, 2)
+}
+
+func TestSetRepository(t *testing.T) {
+	stable := yaml.MapSlice{{Key: "name", Value: "stable"}, {Key: "url", Value: "https://charts.example.com"}}
+	oldEntry := yaml.MapSlice{{Key: "name", Value: ResolutionRepoName}, {Key: "url", Value: "https://old.example.com"}}
+	newEntry := yaml.MapSlice{{Key: "name", Value: ResolutionRepoName}, {Key: "url", Value: "https://acme.jfrog.io"}}
+	assert.Equal(t, []yaml.MapSlice{newEntry, stable}, setRepository([]yaml.MapSlice{oldEntry, stable}, newEntry))
+	assert.Equal(t, []yaml.MapSlice{newEntry}, setRepository(nil, newEntry))
+}
@@ -0,0 +1,134 @@
+package helm
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+// The name of the Helm repository which is added for the resolution repository.
+// Charts can reference it in the dependencies of Chart.yaml as "@artifactory", or by its URL.
+const ResolutionRepoName = "artifactory"
+
+type repositoriesFile struct {
+	APIVersion   string          `yaml:"apiVersion"`
+	Generated    string          `yaml:"generated"`
+	Repositories []yaml.MapSlice `yaml:"repositories"`
+}
+
+// Logs in to the OCI registry. The password is passed through the standard input, to keep it out of the process list.
+func registryLogin(executablePath, registry string, insecure bool, serverDetails *config.ServerDetails, env []string) error {
+	username, password := projectconfig.GetBasicAuthCredentials(serverDetails)
+	if password == "" {
+		log.Debug("No credentials are configured for the server, so helm registry login is skipped.")
+		return nil
+	}
+	args := []string{"registry", "login", registry, "--username", username, "--password-stdin"}
+	if insecure {
+		args = append(args, "--insecure")
+	}
+	command := exec.Command(executablePath, args...)
+	command.Env = env
+	command.Stdin = strings.NewReader(password)
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	if output, err := command.CombinedOutput(); err != nil {
+		return errorutils.CheckErrorf("helm registry login to %s failed: %s", registry, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Creates a temporary copy of the Helm repositories file, with the resolution repository added.
+func (hc *HelmCommand) createRepositoryConfig(tempDir string) (string, error) {
+	repositories := new(repositoriesFile)
+	originalPath, err := hc.getHelmEnv("HELM_REPOSITORY_CONFIG")
+	if err != nil {
+		return "", err
+	}
+	if originalPath != "" {
+		content, err := os.ReadFile(originalPath)
+		if err != nil && !os.IsNotExist(err) {
+			return "", errorutils.CheckError(err)
+		}
+		if err = yaml.Unmarshal(content, repositories); err != nil {
+			return "", errorutils.CheckErrorf("failed parsing %s: %s", originalPath, err.Error())
+		}
+	}
+	repositories.Repositories = setRepository(repositories.Repositories, createRepositoryEntry(hc.serverDetails, hc.repo))
+	content, err := yaml.Marshal(repositories)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	repositoryConfigPath := filepath.Join(tempDir, "repositories.yaml")
+	return repositoryConfigPath, errorutils.CheckError(os.WriteFile(repositoryConfigPath, content, 0600))
+}
+
+func createRepositoryEntry(serverDetails *config.ServerDetails, repo string) yaml.MapSlice {
+	entry := yaml.MapSlice{
+		{Key: "name", Value: ResolutionRepoName},
+		{Key: "url", Value: GetHelmRepoUrl(serverDetails.GetArtifactoryUrl(), repo)},
+	}
+	if username, password := projectconfig.GetBasicAuthCredentials(serverDetails); password != "" {
+		entry = append(entry, yaml.MapItem{Key: "username", Value: username}, yaml.MapItem{Key: "password", Value: password})
+	}
+	return entry
+}
+
+// Replaces the repository with the same name, if it already exists.
+func setRepository(repositories []yaml.MapSlice, entry yaml.MapSlice) []yaml.MapSlice {
+	result := []yaml.MapSlice{entry}
+	for _, repository := range repositories {
+		isSameName := false
+		for _, item := range repository {
+			if item.Key == "name" && item.Value == ResolutionRepoName {
+				isSameName = true
+			}
+		}
+		if !isSameName {
+			result = append(result, repository)
+		}
+	}
+	return result
+}
+
+func GetHelmRepoUrl(artifactoryUrl, repo string) string {
+	return strings.TrimSuffix(artifactoryUrl, "/") + "/api/helm/" + repo
+}
+
+// Creates a temporary copy of the Helm registry configuration, so that the login doesn't change the original one.
+func (hc *HelmCommand) copyRegistryConfig(tempDir string) (string, error) {
+	registryConfigPath := filepath.Join(tempDir, "registry.json")
+	originalPath, err := hc.getHelmEnv("HELM_REGISTRY_CONFIG")
+	if err != nil {
+		return "", err
+	}
+	if originalPath == "" {
+		return registryConfigPath, nil
+	}
+	content, err := os.ReadFile(originalPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return registryConfigPath, nil
+		}
+		return "", errorutils.CheckError(err)
+	}
+	return registryConfigPath, errorutils.CheckError(os.WriteFile(registryConfigPath, content, 0600))
+}
+
+// Returns the value of a Helm environment variable, such as the path of a configuration file.
+func (hc *HelmCommand) getHelmEnv(name string) (string, error) {
+	if value := os.Getenv(name); value != "" {
+		return value, nil
+	}
+	output, err := exec.Command(hc.executablePath, "env", name).Output()
+	if err != nil {
+		return "", errorutils.CheckErrorf("failed running 'helm env %s': %s", name, err.Error())
+	}
+	return strings.TrimSpace(string(output)), nil
+}
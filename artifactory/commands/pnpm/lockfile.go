@@ -0,0 +1,296 @@
+package pnpm
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	PnpmLockFileName = "pnpm-lock.yaml"
+
+	prodScope = "prod"
+	devScope  = "dev"
+)
+
+// The parts of pnpm-lock.yaml which are needed for collecting the dependencies.
+// Lockfile versions 5.x, 6.x and 9.x are supported.
+type pnpmLockfile struct {
+	LockfileVersion interface{} `yaml:"lockfileVersion"`
+	// Lockfiles of projects without workspaces list the root project dependencies at the top level.
+	pnpmImporter `yaml:",inline"`
+	Importers    map[string]pnpmImporter `yaml:"importers"`
+	Packages     map[string]pnpmPackage  `yaml:"packages"`
+	// Since lockfile version 9, the dependencies of each package are listed in the snapshots section.
+	Snapshots map[string]pnpmPackage `yaml:"snapshots"`
+}
+
+type pnpmImporter struct {
+	Dependencies         map[string]pnpmDependencyRef `yaml:"dependencies"`
+	OptionalDependencies map[string]pnpmDependencyRef `yaml:"optionalDependencies"`
+	DevDependencies      map[string]pnpmDependencyRef `yaml:"devDependencies"`
+}
+
+type pnpmPackage struct {
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+// The resolved version of a dependency.
+// In lockfile version 5 it's a plain string, and since version 6 it's an object with the 'specifier' and 'version' fields.
+type pnpmDependencyRef string
+
+func (ref *pnpmDependencyRef) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var version string
+	if err := unmarshal(&version); err == nil {
+		*ref = pnpmDependencyRef(version)
+		return nil
+	}
+	var dependency struct {
+		Version string `yaml:"version"`
+	}
+	if err := unmarshal(&dependency); err != nil {
+		return err
+	}
+	*ref = pnpmDependencyRef(dependency.Version)
+	return nil
+}
+
+// A node in the dependency graph of the lockfile.
+type lockfileNode struct {
+	id           string
+	dependencies map[string]string
+	// The first path from this node to the root project, used for the 'requestedBy' field of its dependencies.
+	pathToRoot []string
+}
+
+// Reads the pnpm-lock.yaml file and returns the dependencies of the root project.
+// The dependencies are identified by 'name:version', the same way npm dependencies are identified in the build-info.
+func ParseLockfile(lockfilePath, rootModuleId string) ([]entities.Dependency, error) {
+	lockfile, err := readLockfile(lockfilePath)
+	if err != nil {
+		return nil, err
+	}
+	return lockfile.dependencies(rootModuleId)
+}
+
+// Reads the pnpm-lock.yaml file of a workspace and returns the dependencies of each of its importers, by their paths relative to the lockfile.
+// The build-info module ID of each importer, which requests its direct dependencies, is returned by moduleIdFunc.
+// Lockfiles without importers have a single importer, whose path is '.'.
+func ParseLockfileImporters(lockfilePath string, moduleIdFunc func(importerPath string) (string, error)) (map[string][]entities.Dependency, error) {
+	lockfile, err := readLockfile(lockfilePath)
+	if err != nil {
+		return nil, err
+	}
+	importers := lockfile.Importers
+	if len(importers) == 0 {
+		importers = map[string]pnpmImporter{".": lockfile.pnpmImporter}
+	}
+	dependenciesByImporter := make(map[string][]entities.Dependency, len(importers))
+	for importerPath, importer := range importers {
+		moduleId, err := moduleIdFunc(importerPath)
+		if err != nil {
+			return nil, err
+		}
+		if dependenciesByImporter[importerPath], err = lockfile.importerDependencies(importer, moduleId); err != nil {
+			return nil, err
+		}
+	}
+	return dependenciesByImporter, nil
+}
+
+func readLockfile(lockfilePath string) (*pnpmLockfile, error) {
+	content, err := os.ReadFile(lockfilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	lockfile := new(pnpmLockfile)
+	if err = yaml.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing %s: %s", lockfilePath, err.Error())
+	}
+	return lockfile, nil
+}
+
+func (lf *pnpmLockfile) majorVersion() (int, error) {
+	version := fmt.Sprint(lf.LockfileVersion)
+	var major int
+	if _, err := fmt.Sscanf(version, "%d", &major); err != nil {
+		return 0, errorutils.CheckErrorf("unsupported pnpm lockfile version: %s", version)
+	}
+	if major < 5 || major > 9 {
+		return 0, errorutils.CheckErrorf("unsupported pnpm lockfile version: %s. Lockfile versions 5 to 9 are supported", version)
+	}
+	return major, nil
+}
+
+func (lf *pnpmLockfile) dependencies(rootModuleId string) ([]entities.Dependency, error) {
+	root := lf.pnpmImporter
+	if rootImporter, exists := lf.Importers["."]; exists {
+		root = rootImporter
+	}
+	return lf.importerDependencies(root, rootModuleId)
+}
+
+func (lf *pnpmLockfile) importerDependencies(importer pnpmImporter, moduleId string) ([]entities.Dependency, error) {
+	major, err := lf.majorVersion()
+	if err != nil {
+		return nil, err
+	}
+	packages := lf.Packages
+	if major >= 9 {
+		packages = lf.Snapshots
+	}
+
+	dependencies := make(map[string]*entities.Dependency)
+	for _, scopedDeps := range []struct {
+		scope string
+		deps  []map[string]pnpmDependencyRef
+	}{
+		{prodScope, []map[string]pnpmDependencyRef{importer.Dependencies, importer.OptionalDependencies}},
+		{devScope, []map[string]pnpmDependencyRef{importer.DevDependencies}},
+	} {
+		rootNode := &lockfileNode{id: moduleId, dependencies: map[string]string{}}
+		for _, deps := range scopedDeps.deps {
+			for name, ref := range deps {
+				rootNode.dependencies[name] = string(ref)
+			}
+		}
+		traverseLockfile(rootNode, packages, major, scopedDeps.scope, dependencies)
+	}
+
+	result := make([]entities.Dependency, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		result = append(result, *dependency)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+	return result, nil
+}
+
+// Walks the dependency graph from the root node breadth-first, and adds the visited packages to the dependencies map.
+func traverseLockfile(root *lockfileNode, packages map[string]pnpmPackage, major int, scope string, dependencies map[string]*entities.Dependency) {
+	visited := map[string]bool{}
+	queue := []*lockfileNode{root}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		requestedBy := append([]string{parent.id}, parent.pathToRoot...)
+		for _, name := range sortedKeys(parent.dependencies) {
+			key, ok := packageKey(name, parent.dependencies[name], major)
+			if !ok {
+				// Local dependencies (link:, file: and workspace:) aren't resolved from Artifactory.
+				continue
+			}
+			depName, depVersion := parsePackageKey(key, major)
+			id := depName + ":" + depVersion
+			addDependency(dependencies, id, scope, requestedBy)
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+			pkg := packages[key]
+			child := &lockfileNode{id: id, dependencies: map[string]string{}, pathToRoot: requestedBy}
+			for _, deps := range []map[string]string{pkg.Dependencies, pkg.OptionalDependencies} {
+				for childName, childRef := range deps {
+					child.dependencies[childName] = childRef
+				}
+			}
+			queue = append(queue, child)
+		}
+	}
+}
+
+func addDependency(dependencies map[string]*entities.Dependency, id, scope string, requestedBy []string) {
+	dependency, exists := dependencies[id]
+	if !exists {
+		dependency = &entities.Dependency{Id: id}
+		dependencies[id] = dependency
+	}
+	if !containsString(dependency.Scopes, scope) {
+		dependency.Scopes = append(dependency.Scopes, scope)
+	}
+	for _, existing := range dependency.RequestedBy {
+		if strings.Join(existing, ",") == strings.Join(requestedBy, ",") {
+			return
+		}
+	}
+	dependency.RequestedBy = append(dependency.RequestedBy, requestedBy)
+}
+
+// Returns the key of the package in the packages (or snapshots) section of the lockfile, according to the dependency name and resolved version.
+// Returns false if the dependency isn't a registry package.
+func packageKey(name, ref string, major int) (string, bool) {
+	if ref == "" || strings.HasPrefix(ref, "link:") || strings.HasPrefix(ref, "file:") || strings.HasPrefix(ref, "workspace:") {
+		return "", false
+	}
+	switch {
+	case major == 5:
+		// For example: 17.0.2_react@17.0.2, or /string-width/4.2.3 for aliases.
+		if strings.HasPrefix(ref, "/") {
+			return ref, true
+		}
+		return "/" + name + "/" + ref, true
+	case major < 9:
+		// For example: 17.0.2(react@17.0.2), or /string-width@4.2.3 for aliases.
+		if strings.HasPrefix(ref, "/") {
+			return ref, true
+		}
+		return "/" + name + "@" + ref, true
+	default:
+		// For example: 17.0.2(react@17.0.2), or string-width@4.2.3 for aliases.
+		if version, _, _ := strings.Cut(ref, "("); strings.LastIndex(version, "@") > 0 {
+			return ref, true
+		}
+		return name + "@" + ref, true
+	}
+}
+
+// Returns the name and the version of the package from its key, without the peer dependencies suffix.
+func parsePackageKey(key string, major int) (name, version string) {
+	key = strings.TrimPrefix(key, "/")
+	if major == 5 {
+		// For example: @babel/core/7.0.0 or react-dom/17.0.2_react@17.0.2
+		parts := strings.Split(key, "/")
+		nameParts := 1
+		if strings.HasPrefix(key, "@") {
+			nameParts = 2
+		}
+		if len(parts) <= nameParts {
+			return key, ""
+		}
+		name = strings.Join(parts[:nameParts], "/")
+		version, _, _ = strings.Cut(strings.Join(parts[nameParts:], "/"), "_")
+		return
+	}
+	// For example: @babel/core@7.0.0 or react-dom@17.0.2(react@17.0.2)
+	key, _, _ = strings.Cut(key, "(")
+	separator := strings.LastIndex(key, "@")
+	if separator <= 0 {
+		return key, ""
+	}
+	return key[:separator], key[separator+1:]
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,255 @@
+package pnpm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lockfileV5 = `lockfileVersion: 5.4
+
+specifiers:
+  '@babel/code-frame': ^7.0.0
+  local-lib: link:../local-lib
+  react-dom: ^17.0.2
+  typescript: ^4.9.5
+
+dependencies:
+  '@babel/code-frame': 7.0.0
+  local-lib: link:../local-lib
+  react-dom: 17.0.2_react@17.0.2
+
+devDependencies:
+  typescript: 4.9.5
+
+packages:
+
+  /@babel/code-frame/7.0.0:
+    resolution: {integrity: sha512-a}
+    dependencies:
+      js-tokens: 4.0.0
+    dev: false
+
+  /js-tokens/4.0.0:
+    resolution: {integrity: sha512-b}
+
+  /react/17.0.2:
+    resolution: {integrity: sha512-c}
+    dependencies:
+      js-tokens: 4.0.0
+    dev: false
+
+  /react-dom/17.0.2_react@17.0.2:
+    resolution: {integrity: sha512-d}
+    peerDependencies:
+      react: 17.0.2
+    dependencies:
+      react: 17.0.2
+    dev: false
+
+  /typescript/4.9.5:
+    resolution: {integrity: sha512-e}
+    dev: true
+`
+
+const lockfileV6 = `lockfileVersion: '6.0'
+
+dependencies:
+  '@babel/code-frame':
+    specifier: ^7.0.0
+    version: 7.0.0
+  local-lib:
+    specifier: link:../local-lib
+    version: link:../local-lib
+  react-dom:
+    specifier: ^17.0.2
+    version: 17.0.2(react@17.0.2)
+
+devDependencies:
+  typescript:
+    specifier: ^4.9.5
+    version: 4.9.5
+
+packages:
+
+  /@babel/code-frame@7.0.0:
+    resolution: {integrity: sha512-a}
+    dependencies:
+      js-tokens: 4.0.0
+    dev: false
+
+  /js-tokens@4.0.0:
+    resolution: {integrity: sha512-b}
+
+  /react@17.0.2:
+    resolution: {integrity: sha512-c}
+    dependencies:
+      js-tokens: 4.0.0
+    dev: false
+
+  /react-dom@17.0.2(react@17.0.2):
+    resolution: {integrity: sha512-d}
+    peerDependencies:
+      react: 17.0.2
+    dependencies:
+      react: 17.0.2
+    dev: false
+
+  /typescript@4.9.5:
+    resolution: {integrity: sha512-e}
+    dev: true
+`
+
+const lockfileV9 = `lockfileVersion: '9.0'
+
+settings:
+  autoInstallPeers: true
+
+importers:
+
+  .:
+    dependencies:
+      '@babel/code-frame':
+        specifier: ^7.0.0
+        version: 7.0.0
+      local-lib:
+        specifier: link:../local-lib
+        version: link:../local-lib
+      react-dom:
+        specifier: ^17.0.2
+        version: 17.0.2(react@17.0.2)
+    devDependencies:
+      typescript:
+        specifier: ^4.9.5
+        version: 4.9.5
+
+  packages/other:
+    dependencies:
+      left-pad:
+        specifier: ^1.3.0
+        version: 1.3.0
+
+packages:
+
+  '@babel/code-frame@7.0.0':
+    resolution: {integrity: sha512-a}
+
+  js-tokens@4.0.0:
+    resolution: {integrity: sha512-b}
+
+  react@17.0.2:
+    resolution: {integrity: sha512-c}
+
+  react-dom@17.0.2:
+    resolution: {integrity: sha512-d}
+    peerDependencies:
+      react: 17.0.2
+
+  typescript@4.9.5:
+    resolution: {integrity: sha512-e}
+
+snapshots:
+
+  '@babel/code-frame@7.0.0':
+    dependencies:
+      js-tokens: 4.0.0
+
+  js-tokens@4.0.0: {}
+
+  react@17.0.2:
+    dependencies:
+      js-tokens: 4.0.0
+
+  react-dom@17.0.2(react@17.0.2):
+    dependencies:
+      react: 17.0.2
+
+  typescript@4.9.5: {}
+`
+
+func TestParseLockfile(t *testing.T) {
+	expected := []entities.Dependency{
+		{Id: "@babel/code-frame:7.0.0", Scopes: []string{"prod"}, RequestedBy: [][]string{{"my-app:1.0.0"}}},
+		{Id: "js-tokens:4.0.0", Scopes: []string{"prod"}, RequestedBy: [][]string{
+			{"@babel/code-frame:7.0.0", "my-app:1.0.0"},
+			{"react:17.0.2", "react-dom:17.0.2", "my-app:1.0.0"},
+		}},
+		{Id: "react-dom:17.0.2", Scopes: []string{"prod"}, RequestedBy: [][]string{{"my-app:1.0.0"}}},
+		{Id: "react:17.0.2", Scopes: []string{"prod"}, RequestedBy: [][]string{{"react-dom:17.0.2", "my-app:1.0.0"}}},
+		{Id: "typescript:4.9.5", Scopes: []string{"dev"}, RequestedBy: [][]string{{"my-app:1.0.0"}}},
+	}
+	for name, content := range map[string]string{"v5": lockfileV5, "v6": lockfileV6, "v9": lockfileV9} {
+		t.Run(name, func(t *testing.T) {
+			lockfilePath := filepath.Join(t.TempDir(), PnpmLockFileName)
+			require.NoError(t, os.WriteFile(lockfilePath, []byte(content), 0644))
+			dependencies, err := ParseLockfile(lockfilePath, "my-app:1.0.0")
+			require.NoError(t, err)
+			assert.Equal(t, expected, dependencies)
+		})
+	}
+}
+
+func TestParseLockfileImporters(t *testing.T) {
+	lockfilePath := filepath.Join(t.TempDir(), PnpmLockFileName)
+	require.NoError(t, os.WriteFile(lockfilePath, []byte(lockfileV9), 0644))
+	moduleIds := map[string]string{".": "my-app:1.0.0", "packages/other": "other:2.0.0"}
+	dependenciesByImporter, err := ParseLockfileImporters(lockfilePath, func(importerPath string) (string, error) {
+		return moduleIds[importerPath], nil
+	})
+	require.NoError(t, err)
+	require.Len(t, dependenciesByImporter, 2)
+	assert.Len(t, dependenciesByImporter["."], 5)
+	assert.Equal(t, []entities.Dependency{{Id: "left-pad:1.3.0", Scopes: []string{"prod"}, RequestedBy: [][]string{{"other:2.0.0"}}}},
+		dependenciesByImporter["packages/other"])
+
+	// A lockfile without importers has the root project as its only importer.
+	require.NoError(t, os.WriteFile(lockfilePath, []byte(lockfileV6), 0644))
+	dependenciesByImporter, err = ParseLockfileImporters(lockfilePath, func(importerPath string) (string, error) {
+		return moduleIds[importerPath], nil
+	})
+	require.NoError(t, err)
+	require.Len(t, dependenciesByImporter, 1)
+	assert.Len(t, dependenciesByImporter["."], 5)
+}
+
+func TestParseLockfileUnsupportedVersion(t *testing.T) {
+	lockfilePath := filepath.Join(t.TempDir(), PnpmLockFileName)
+	require.NoError(t, os.WriteFile(lockfilePath, []byte("lockfileVersion: 3\n"), 0644))
+	_, err := ParseLockfile(lockfilePath, "my-app:1.0.0")
+	assert.ErrorContains(t, err, "unsupported pnpm lockfile version")
+}
+
+func TestPackageKey(t *testing.T) {
+	testCases := []struct {
+		name, ref    string
+		major        int
+		expectedKey  string
+		expectedName string
+		expectedVer  string
+	}{
+		{"react-dom", "17.0.2_react@17.0.2", 5, "/react-dom/17.0.2_react@17.0.2", "react-dom", "17.0.2"},
+		{"@types/node", "20.1.0", 5, "/@types/node/20.1.0", "@types/node", "20.1.0"},
+		{"string-width-cjs", "/string-width/4.2.3", 5, "/string-width/4.2.3", "string-width", "4.2.3"},
+		{"@types/node", "20.1.0", 6, "/@types/node@20.1.0", "@types/node", "20.1.0"},
+		{"string-width-cjs", "/string-width@4.2.3", 6, "/string-width@4.2.3", "string-width", "4.2.3"},
+		{"react-dom", "17.0.2(react@17.0.2)", 9, "react-dom@17.0.2(react@17.0.2)", "react-dom", "17.0.2"},
+		{"string-width-cjs", "string-width@4.2.3", 9, "string-width@4.2.3", "string-width", "4.2.3"},
+		{"@types/node", "20.1.0", 9, "@types/node@20.1.0", "@types/node", "20.1.0"},
+	}
+	for _, testCase := range testCases {
+		key, ok := packageKey(testCase.name, testCase.ref, testCase.major)
+		assert.True(t, ok)
+		assert.Equal(t, testCase.expectedKey, key)
+		name, version := parsePackageKey(key, testCase.major)
+		assert.Equal(t, testCase.expectedName, name)
+		assert.Equal(t, testCase.expectedVer, version)
+	}
+	for _, ref := range []string{"link:../lib", "file:../lib.tgz", "workspace:*", ""} {
+		_, ok := packageKey("lib", ref, 9)
+		assert.False(t, ok)
+	}
+}
@@ -0,0 +1,370 @@
+package pnpm
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/yarn"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	NpmrcFileName       = ".npmrc"
+	NpmrcBackupFileName = "jfrog.npmrc.backup"
+)
+
+// Runs any pnpm command, with the dependencies resolved from Artifactory.
+// For the install command, the dependencies are collected from pnpm-lock.yaml into the build-info.
+type PnpmCommand struct {
+	cmdName            string
+	args               []string
+	configFilePath     string
+	executablePath     string
+	workingDirectory   string
+	registry           string
+	npmAuthIdent       string
+	npmAuthToken       string
+	repo               string
+	collectBuildInfo   bool
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewPnpmCommand(cmdName string) *PnpmCommand {
+	return &PnpmCommand{cmdName: cmdName}
+}
+
+func (pc *PnpmCommand) SetConfigFilePath(configFilePath string) *PnpmCommand {
+	pc.configFilePath = configFilePath
+	return pc
+}
+
+func (pc *PnpmCommand) SetArgs(args []string) *PnpmCommand {
+	pc.args = args
+	return pc
+}
+
+func (pc *PnpmCommand) ServerDetails() (*config.ServerDetails, error) {
+	return pc.serverDetails, nil
+}
+
+func (pc *PnpmCommand) CommandName() string {
+	return "rt_pnpm_" + pc.cmdName
+}
+
+func (pc *PnpmCommand) Init() (err error) {
+	log.Debug("Preparing to read the config file", pc.configFilePath)
+	vConfig, err := project.ReadConfigFile(pc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	resolverParams, err := project.GetRepoConfigByPrefix(pc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	pc.repo = resolverParams.TargetRepo()
+	if pc.serverDetails, err = resolverParams.ServerDetails(); err != nil {
+		return err
+	}
+	_, _, _, pc.args, pc.buildConfiguration, err = commandUtils.ExtractNpmOptionsFromArgs(pc.args)
+	return err
+}
+
+func (pc *PnpmCommand) Run() (err error) {
+	log.Info("Running pnpm " + pc.cmdName + "...")
+	if err = pc.preparePrerequisites(); err != nil {
+		return err
+	}
+	restoreNpmrcFunc, err := ioutils.BackupFile(filepath.Join(pc.workingDirectory, NpmrcFileName), NpmrcBackupFileName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, restoreNpmrcFunc())
+	}()
+	if err = pc.writeNpmrc(); err != nil {
+		return err
+	}
+
+	command := exec.Command(pc.executablePath, append([]string{pc.cmdName}, pc.args...)...)
+	command.Dir = pc.workingDirectory
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err = command.Run(); err != nil {
+		return errorutils.CheckErrorf("pnpm %s failed: %s", pc.cmdName, err.Error())
+	}
+	if pc.collectBuildInfo {
+		if err = pc.collectDependencies(); err != nil {
+			return err
+		}
+	}
+	log.Info("pnpm " + pc.cmdName + " finished successfully.")
+	return nil
+}
+
+func (pc *PnpmCommand) preparePrerequisites() (err error) {
+	if pc.executablePath, err = exec.LookPath("pnpm"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Debug("Found pnpm executable at:", pc.executablePath)
+	if pc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return err
+	}
+	log.Debug("Working directory set to:", pc.workingDirectory)
+	if isInstallCommand(pc.cmdName) {
+		if pc.collectBuildInfo, err = pc.buildConfiguration.IsCollectBuildInfo(); err != nil {
+			return err
+		}
+	}
+	pc.registry, pc.npmAuthIdent, pc.npmAuthToken, err = yarn.GetYarnAuthDetails(pc.serverDetails, pc.repo)
+	return err
+}
+
+func isInstallCommand(cmdName string) bool {
+	switch cmdName {
+	case "install", "i", "add":
+		return true
+	}
+	return false
+}
+
+// Writes an .npmrc file in the working directory, which points pnpm to the Artifactory registry.
+// The settings of the original .npmrc file are kept, except for its default registry and the credentials of the default registry.
+func (pc *PnpmCommand) writeNpmrc() error {
+	npmrcPath := filepath.Join(pc.workingDirectory, NpmrcFileName)
+	originalLines, err := readLines(npmrcPath)
+	if err != nil {
+		return err
+	}
+	npmrc := CreateNpmrc(originalLines, pc.registry, pc.npmAuthIdent, pc.npmAuthToken)
+	return errorutils.CheckError(os.WriteFile(npmrcPath, []byte(npmrc), 0600))
+}
+
+// Returns the content of an .npmrc file which resolves the packages from the registry, instead of the default registry of the original .npmrc file.
+// Scoped registries and the credentials of other registries are kept, since they may point to unrelated private registries.
+func CreateNpmrc(originalLines []string, registry, npmAuthIdent, npmAuthToken string) string {
+	registryPrefix := getRegistryAuthPrefix(registry)
+	// The credentials of the original default registry are replaced along with it.
+	removedPrefixes := []string{registryPrefix}
+	for _, line := range originalLines {
+		if key, value, _ := strings.Cut(strings.TrimSpace(line), "="); strings.TrimSpace(key) == "registry" {
+			removedPrefixes = append(removedPrefixes, getRegistryAuthPrefix(strings.TrimSpace(value)))
+		}
+	}
+	var npmrc strings.Builder
+	for _, line := range originalLines {
+		key, _, _ := strings.Cut(strings.TrimSpace(line), "=")
+		key = strings.TrimSpace(key)
+		if key == "" || key == "registry" || hasAnyPrefix(key, removedPrefixes) {
+			continue
+		}
+		npmrc.WriteString(strings.TrimSpace(line) + "\n")
+	}
+	npmrc.WriteString("registry=" + registry + "\n")
+	if npmAuthToken != "" {
+		npmrc.WriteString(registryPrefix + commandUtils.NpmConfigAuthTokenKey + "=" + npmAuthToken + "\n")
+	} else {
+		npmrc.WriteString(registryPrefix + commandUtils.NpmConfigAuthKey + "=" + npmAuthIdent + "\n")
+	}
+	npmrc.WriteString(registryPrefix + "always-auth=true\n")
+	return npmrc.String()
+}
+
+// Returns the prefix of the .npmrc keys, which hold the credentials of the registry. For example: //acme.jfrog.io/artifactory/api/npm/npm-virtual/:
+func getRegistryAuthPrefix(registry string) string {
+	_, registryHostAndPath, _ := strings.Cut(registry, "//")
+	return "//" + strings.TrimSuffix(registryHostAndPath, "/") + "/:"
+}
+
+func hasAnyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (pc *PnpmCommand) collectDependencies() error {
+	lockfilePath := filepath.Join(pc.workingDirectory, PnpmLockFileName)
+	if !projectconfig.LockfileExists(lockfilePath) {
+		return nil
+	}
+	modules, err := pc.getModulesDependencies(lockfilePath)
+	if err != nil {
+		return err
+	}
+
+	buildName, err := pc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := pc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := pc.buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	for _, module := range modules {
+		if err = pc.setChecksums(module.dependencies); err != nil {
+			return err
+		}
+		if err = build.SavePartialBuildInfo(buildName, buildNumber, projectKey, func(partial *buildinfo.Partial) {
+			partial.ModuleType = buildinfo.Npm
+			partial.ModuleId = module.id
+			partial.Dependencies = module.dependencies
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type moduleDependencies struct {
+	id           string
+	dependencies []buildinfo.Dependency
+}
+
+// In a workspace, each project (importer) of the lockfile is a module of its own, which is named after its package.json file.
+// The root project of the workspace is recorded only if it has dependencies of its own.
+// Without a workspace, or when the --module option is provided, the dependencies of the root project are recorded in a single module.
+func (pc *PnpmCommand) getModulesDependencies(lockfilePath string) ([]moduleDependencies, error) {
+	if pc.buildConfiguration.GetModule() == "" {
+		moduleIds := make(map[string]string)
+		dependenciesByImporter, err := ParseLockfileImporters(lockfilePath, func(importerPath string) (moduleId string, err error) {
+			moduleId, err = pc.getImporterModuleId(importerPath)
+			moduleIds[importerPath] = moduleId
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(dependenciesByImporter) > 1 {
+			importerPaths := make([]string, 0, len(dependenciesByImporter))
+			for importerPath := range dependenciesByImporter {
+				importerPaths = append(importerPaths, importerPath)
+			}
+			sort.Strings(importerPaths)
+			var modules []moduleDependencies
+			for _, importerPath := range importerPaths {
+				if importerPath == "." && len(dependenciesByImporter[importerPath]) == 0 {
+					continue
+				}
+				modules = append(modules, moduleDependencies{id: moduleIds[importerPath], dependencies: dependenciesByImporter[importerPath]})
+			}
+			return modules, nil
+		}
+	}
+	moduleId, err := pc.getModuleId()
+	if err != nil {
+		return nil, err
+	}
+	dependencies, err := ParseLockfile(lockfilePath, moduleId)
+	if err != nil {
+		return nil, err
+	}
+	return []moduleDependencies{{id: moduleId, dependencies: dependencies}}, nil
+}
+
+// Returns the module ID of the workspace project by the name and version in its package.json file, or by the name of its directory.
+func (pc *PnpmCommand) getImporterModuleId(importerPath string) (string, error) {
+	importerDir := filepath.Join(pc.workingDirectory, filepath.FromSlash(importerPath))
+	packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(importerDir, nil)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if moduleId := packageInfo.BuildInfoModuleId(); moduleId != "" {
+		return moduleId, nil
+	}
+	return filepath.Base(importerDir), nil
+}
+
+// The module ID is taken from the --module option, or from the name and version in package.json.
+func (pc *PnpmCommand) getModuleId() (string, error) {
+	if pc.buildConfiguration.GetModule() != "" {
+		return pc.buildConfiguration.GetModule(), nil
+	}
+	packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(pc.workingDirectory, nil)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if moduleId := packageInfo.BuildInfoModuleId(); moduleId != "" {
+		return moduleId, nil
+	}
+	return filepath.Base(pc.workingDirectory), nil
+}
+
+// Sets the checksums of the dependencies, using the dependencies of the latest build and Artifactory's npm properties.
+func (pc *PnpmCommand) setChecksums(dependencies []buildinfo.Dependency) error {
+	servicesManager, err := utils.CreateServiceManager(pc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	buildName, err := pc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	previousBuildDependencies, err := commandUtils.GetDependenciesFromLatestBuild(servicesManager, buildName)
+	if err != nil {
+		return err
+	}
+	missingDepsChan := make(chan string)
+	var missingDependencies []string
+	done := make(chan bool)
+	go func() {
+		for depId := range missingDepsChan {
+			missingDependencies = append(missingDependencies, depId)
+		}
+		done <- true
+	}()
+	collectChecksumsFunc := commandUtils.CreateCollectChecksumsFunc(previousBuildDependencies, servicesManager, missingDepsChan)
+	for i := range dependencies {
+		if _, err = collectChecksumsFunc(&dependencies[i]); err != nil {
+			break
+		}
+	}
+	close(missingDepsChan)
+	<-done
+	if err != nil {
+		return err
+	}
+	commandUtils.PrintMissingDependencies(missingDependencies)
+	return nil
+}
+
+// Reads the lines of a file, if it exists.
+func readLines(filePath string) ([]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, errorutils.CheckError(scanner.Err())
+}
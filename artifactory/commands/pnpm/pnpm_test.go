@@ -0,0 +1,39 @@
+package pnpm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateNpmrc(t *testing.T) {
+	originalLines := []string{
+		"registry=https://registry.npmjs.org/",
+		"@my-scope:registry=https://npm.example.com/",
+		"//npm.example.com/:_authToken=private",
+		"//registry.npmjs.org/:_authToken=old",
+		"strict-peer-dependencies=false",
+		"",
+	}
+	// Only the default registry and its credentials are replaced.
+	assert.Equal(t, "@my-scope:registry=https://npm.example.com/\n"+
+		"//npm.example.com/:_authToken=private\n"+
+		"strict-peer-dependencies=false\n"+
+		"registry=https://acme.jfrog.io/artifactory/api/npm/npm-virtual\n"+
+		"//acme.jfrog.io/artifactory/api/npm/npm-virtual/:_authToken=token\n"+
+		"//acme.jfrog.io/artifactory/api/npm/npm-virtual/:always-auth=true\n",
+		CreateNpmrc(originalLines, "https://acme.jfrog.io/artifactory/api/npm/npm-virtual", "", "token"))
+
+	assert.Contains(t, CreateNpmrc(nil, "https://acme.jfrog.io/artifactory/api/npm/npm-virtual/", "dXNlcjpwYXNz", ""),
+		"//acme.jfrog.io/artifactory/api/npm/npm-virtual/:_auth=dXNlcjpwYXNz\n")
+}
+
+func TestSplitPublishPath(t *testing.T) {
+	path, otherArgs := splitPublishPath([]string{"packages/lib", "--access", "public"})
+	assert.Equal(t, "packages/lib", path)
+	assert.Equal(t, []string{"--access", "public"}, otherArgs)
+
+	path, otherArgs = splitPublishPath([]string{"--access", "public"})
+	assert.Equal(t, ".", path)
+	assert.Equal(t, []string{"--access", "public"}, otherArgs)
+}
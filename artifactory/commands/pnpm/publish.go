@@ -0,0 +1,103 @@
+package pnpm
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/npm"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Publishes a package to Artifactory, the same way 'jf npm publish' does.
+// The package is packed by pnpm, to have the 'workspace:' and 'catalog:' versions in package.json replaced with the actual versions.
+type PnpmPublishCommand struct {
+	*npm.NpmPublishCommand
+	executablePath string
+	args           []string
+}
+
+func NewPnpmPublishCommand() *PnpmPublishCommand {
+	return &PnpmPublishCommand{NpmPublishCommand: npm.NewNpmPublishCommand()}
+}
+
+func (ppc *PnpmPublishCommand) SetConfigFilePath(configFilePath string) *PnpmPublishCommand {
+	ppc.NpmPublishCommand.SetConfigFilePath(configFilePath)
+	return ppc
+}
+
+func (ppc *PnpmPublishCommand) SetArgs(args []string) *PnpmPublishCommand {
+	ppc.NpmPublishCommand.SetArgs(args)
+	ppc.args = args
+	return ppc
+}
+
+func (ppc *PnpmPublishCommand) CommandName() string {
+	return "rt_pnpm_publish"
+}
+
+func (ppc *PnpmPublishCommand) Run() (err error) {
+	// Init removed the JFrog CLI options from the arguments of the embedded command, so they are filtered here the same way.
+	_, _, _, filteredArgs, _, err := commandUtils.ExtractNpmOptionsFromArgs(ppc.args)
+	if err != nil {
+		return err
+	}
+	if filteredArgs, _, err = coreutils.ExtractTagFromArgs(filteredArgs); err != nil {
+		return err
+	}
+	packageDir, otherArgs := splitPublishPath(filteredArgs)
+	if strings.HasSuffix(packageDir, ".tgz") {
+		// A packed tarball was provided.
+		return ppc.NpmPublishCommand.Run()
+	}
+	if ppc.executablePath, err = exec.LookPath("pnpm"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	tarballPath, err := ppc.pack(packageDir, tempDir)
+	if err != nil {
+		return err
+	}
+	ppc.SetNpmArgs(append([]string{tarballPath}, otherArgs...))
+	return ppc.NpmPublishCommand.Run()
+}
+
+// Packs the package in packageDir into destDir, and returns the path of the created tarball.
+func (ppc *PnpmPublishCommand) pack(packageDir, destDir string) (string, error) {
+	log.Info("Running pnpm pack...")
+	command := exec.Command(ppc.executablePath, "pack", "--pack-destination", destDir)
+	command.Dir = packageDir
+	command.Stderr = os.Stderr
+	if output, err := command.Output(); err != nil {
+		return "", errorutils.CheckErrorf("pnpm pack failed: %s %s", err.Error(), strings.TrimSpace(string(output)))
+	}
+	tarballs, err := filepath.Glob(filepath.Join(destDir, "*.tgz"))
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if len(tarballs) != 1 {
+		return "", errorutils.CheckErrorf("expected pnpm pack to create a single tarball, but %d were found", len(tarballs))
+	}
+	return tarballs[0], nil
+}
+
+// Returns the path of the package to publish and the rest of the arguments.
+// Like in 'jf npm publish', the path is the first argument, if it isn't a flag.
+func splitPublishPath(args []string) (publishPath string, otherArgs []string) {
+	if len(args) > 0 && !strings.HasPrefix(strings.TrimSpace(args[0]), "-") {
+		return strings.TrimSpace(args[0]), args[1:]
+	}
+	return ".", args
+}
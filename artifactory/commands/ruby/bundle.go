@@ -0,0 +1,135 @@
+package ruby
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The source which Bundler resolves from by default, and which is mirrored by the Artifactory repository.
+const rubygemsSource = "https://rubygems.org/"
+
+// The commands which resolve gems and update the lockfile, so their dependencies are collected.
+var bundleInstallCommands = map[string]bool{"install": true, "update": true, "lock": true}
+
+// Runs any Bundler command, with rubygems.org mirrored by the Artifactory RubyGems repository.
+// If build-info collection was requested, the dependencies are collected from the lockfile of the Gemfile.
+type BundleCommand struct {
+	RubyCommand
+}
+
+func NewBundleCommand() *BundleCommand {
+	return &BundleCommand{}
+}
+
+func (bc *BundleCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bc.serverDetails, nil
+}
+
+func (bc *BundleCommand) CommandName() string {
+	return "rt_bundle_" + bc.cmdName
+}
+
+func (bc *BundleCommand) Init() error {
+	return bc.init(false)
+}
+
+func (bc *BundleCommand) Run() (err error) {
+	log.Info("Running bundle " + bc.cmdName + "...")
+	if err = bc.prepare("bundle"); err != nil {
+		return err
+	}
+	env, err := createBundlerEnv(bc.serverDetails, bc.repo)
+	if err != nil {
+		return err
+	}
+	if err = bc.run(append(os.Environ(), env...), append([]string{bc.cmdName}, bc.args...)...); err != nil {
+		return err
+	}
+	collectBuildInfo, err := bc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if collectBuildInfo && bundleInstallCommands[bc.cmdName] {
+		if err = bc.collectDependencies(); err != nil {
+			return err
+		}
+	}
+	log.Info("bundle " + bc.cmdName + " finished successfully.")
+	return nil
+}
+
+// Bundler reads its configuration from BUNDLE_<KEY> environment variables, where dots in the key are replaced by double underscores.
+// The mirror of rubygems.org is set to the Artifactory repository, and the credentials are set for the Artifactory host.
+func createBundlerEnv(serverDetails *config.ServerDetails, repo string) ([]string, error) {
+	repoUrl := GetGemsRepoUrl(serverDetails.GetArtifactoryUrl(), repo)
+	env := []string{bundlerEnvName("mirror."+rubygemsSource) + "=" + repoUrl}
+	username, password := projectconfig.GetBasicAuthCredentials(serverDetails)
+	if password == "" {
+		return env, nil
+	}
+	parsedUrl, err := url.Parse(repoUrl)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return append(env, bundlerEnvName(parsedUrl.Hostname())+"="+url.UserPassword(username, password).String()), nil
+}
+
+func bundlerEnvName(key string) string {
+	return "BUNDLE_" + strings.ToUpper(strings.ReplaceAll(strings.ReplaceAll(key, ".", "__"), "-", "___"))
+}
+
+func (bc *BundleCommand) collectDependencies() error {
+	gemfilePath := os.Getenv("BUNDLE_GEMFILE")
+	if gemfilePath == "" {
+		gemfilePath = filepath.Join(bc.workingDirectory, GemfileName)
+	}
+	lockfilePath := gemfilePath + ".lock"
+	if !projectconfig.LockfileExists(lockfilePath) {
+		return nil
+	}
+	moduleId := bc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = filepath.Base(filepath.Dir(gemfilePath))
+	}
+	dependencies, err := ParseGemfileLock(lockfilePath, moduleId)
+	if err != nil {
+		return err
+	}
+	if err = bc.setChecksums(dependencies); err != nil {
+		return err
+	}
+	return projectconfig.SaveModule(bc.buildConfiguration, rubyModuleType, moduleId, func(partial *buildinfo.Partial) {
+		partial.Dependencies = dependencies
+	})
+}
+
+// The checksums of the gems are read from the resolution repository, where the gems are stored in the gems directory.
+func (bc *BundleCommand) setChecksums(dependencies []buildinfo.Dependency) error {
+	servicesManager, err := utils.CreateServiceManager(bc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	var missingDependencies []string
+	for i, dependency := range dependencies {
+		name, version, _ := strings.Cut(dependency.Id, ":")
+		fileInfo, err := servicesManager.FileInfo(bc.repo + "/" + getGemPath(name, version))
+		if err != nil {
+			log.Debug("Failed reading the checksums of " + dependency.Id + ": " + err.Error())
+			missingDependencies = append(missingDependencies, dependency.Id)
+			continue
+		}
+		dependencies[i].Checksum = buildinfo.Checksum{Sha1: fileInfo.Checksums.Sha1, Md5: fileInfo.Checksums.Md5, Sha256: fileInfo.Checksums.Sha256}
+	}
+	commandUtils.PrintMissingDependencies(missingDependencies)
+	return nil
+}
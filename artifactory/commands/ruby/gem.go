@@ -0,0 +1,150 @@
+package ruby
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Runs any gem command, with the gems resolved from the Artifactory RubyGems repository.
+// The push command pushes the gem to the deployment repository, and records it as a build-info artifact if build-info collection was requested.
+type GemCommand struct {
+	RubyCommand
+}
+
+func NewGemCommand() *GemCommand {
+	return &GemCommand{}
+}
+
+func (gc *GemCommand) ServerDetails() (*config.ServerDetails, error) {
+	if gc.isPush() {
+		return gc.deployerServerDetails, nil
+	}
+	return gc.serverDetails, nil
+}
+
+func (gc *GemCommand) CommandName() string {
+	return "rt_gem_" + gc.cmdName
+}
+
+func (gc *GemCommand) isPush() bool {
+	return gc.cmdName == "push"
+}
+
+func (gc *GemCommand) Init() error {
+	return gc.init(gc.isPush())
+}
+
+func (gc *GemCommand) Run() (err error) {
+	log.Info("Running gem " + gc.cmdName + "...")
+	if err = gc.prepare("gem"); err != nil {
+		return err
+	}
+	if gc.isPush() {
+		err = gc.push()
+	} else {
+		err = gc.runWithResolution()
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("gem " + gc.cmdName + " finished successfully.")
+	return nil
+}
+
+// The gem sources are replaced by the Artifactory repository, using a temporary configuration file which is set by the GEMRC environment variable.
+// RubyGems reads the credentials of a source only from its URL, so keeping them in the file keeps them out of the process list.
+func (gc *GemCommand) runWithResolution() (err error) {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	sourceUrl, err := getSourceUrlWithCredentials(gc.serverDetails, gc.repo)
+	if err != nil {
+		return err
+	}
+	gemrcPath := filepath.Join(tempDir, "gemrc")
+	if err = os.WriteFile(gemrcPath, []byte(fmt.Sprintf(":sources:\n- %q\n", sourceUrl)), 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return gc.run(append(os.Environ(), "GEMRC="+gemrcPath), append([]string{gc.cmdName}, gc.args...)...)
+}
+
+func getSourceUrlWithCredentials(serverDetails *config.ServerDetails, repo string) (string, error) {
+	sourceUrl, err := url.Parse(GetGemsRepoUrl(serverDetails.GetArtifactoryUrl(), repo))
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if username, password := projectconfig.GetBasicAuthCredentials(serverDetails); password != "" {
+		sourceUrl.User = url.UserPassword(username, password)
+	}
+	return sourceUrl.String(), nil
+}
+
+// Pushes the gem to the deployment repository. The API key which RubyGems sends to Artifactory is the basic authentication header value.
+func (gc *GemCommand) push() error {
+	gemFile := ""
+	for _, arg := range gc.args {
+		if strings.HasSuffix(arg, "."+gemFileType) {
+			gemFile = arg
+			break
+		}
+	}
+	if gemFile == "" {
+		return errorutils.CheckErrorf("the push command expects a gem file. Use 'gem build' to build the gem")
+	}
+	cmdArgs := append([]string{gc.cmdName}, gc.args...)
+	if !hasHostFlag(gc.args) {
+		cmdArgs = append(cmdArgs, "--host", strings.TrimSuffix(GetGemsRepoUrl(gc.deployerServerDetails.GetArtifactoryUrl(), gc.deployRepo), "/"))
+	}
+	env := os.Environ()
+	if username, password := projectconfig.GetBasicAuthCredentials(gc.deployerServerDetails); password != "" {
+		env = append(env, "GEM_HOST_API_KEY=Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	}
+	if err := gc.run(env, cmdArgs...); err != nil {
+		return err
+	}
+
+	collectBuildInfo, err := gc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	if !filepath.IsAbs(gemFile) {
+		gemFile = filepath.Join(gc.workingDirectory, gemFile)
+	}
+	artifact, err := createGemArtifact(gemFile, gc.deployRepo)
+	if err != nil {
+		return err
+	}
+	moduleId := gc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		name, version := splitGemFileName(artifact.Name)
+		moduleId = name + ":" + version
+	}
+	return projectconfig.SaveModule(gc.buildConfiguration, rubyModuleType, moduleId, func(partial *buildinfo.Partial) {
+		partial.Artifacts = []buildinfo.Artifact{artifact}
+	})
+}
+
+func hasHostFlag(args []string) bool {
+	for _, arg := range args {
+		if arg == "--host" || strings.HasPrefix(arg, "--host=") {
+			return true
+		}
+	}
+	return false
+}
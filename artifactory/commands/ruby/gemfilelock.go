@@ -0,0 +1,147 @@
+package ruby
+
+import (
+	"bufio"
+	"os"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	GemfileName    = "Gemfile"
+	gemFileType    = "gem"
+	gemSectionName = "GEM"
+)
+
+type gemSpec struct {
+	Name    string
+	Version string
+	// The section of the spec: GEM for gems from a registry, or GIT and PATH for gems from other sources.
+	Section      string
+	Dependencies []string
+}
+
+func (gs *gemSpec) id() string {
+	return gs.Name + ":" + gs.Version
+}
+
+type gemfileLock struct {
+	Specs        []*gemSpec
+	Dependencies []string
+}
+
+// Reads the lockfile of the Gemfile, and returns the gems from registries which the Gemfile requires, directly or transitively.
+func ParseGemfileLock(lockfilePath, rootModuleId string) ([]buildinfo.Dependency, error) {
+	file, err := os.Open(lockfilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	lockfile, err := parseGemfileLock(bufio.NewScanner(file))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing %s: %s", lockfilePath, err.Error())
+	}
+	return lockfile.dependencies(rootModuleId), nil
+}
+
+// The lockfile consists of sections, which start with an unindented header. For example:
+//
+//	GEM
+//	  remote: https://rubygems.org/
+//	  specs:
+//	    rack-test (2.1.0)
+//	      rack (>= 1.3)
+//
+//	DEPENDENCIES
+//	  rack-test (~> 2.1)
+func parseGemfileLock(scanner *bufio.Scanner) (*gemfileLock, error) {
+	lockfile := new(gemfileLock)
+	var section string
+	var currentSpec *gemSpec
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		indentation := len(line) - len(strings.TrimLeft(line, " "))
+		if indentation == 0 {
+			section = trimmed
+			currentSpec = nil
+			continue
+		}
+		name, version := splitNameAndRequirement(trimmed)
+		switch {
+		case section == "DEPENDENCIES" && indentation == 2:
+			lockfile.Dependencies = append(lockfile.Dependencies, strings.TrimSuffix(name, "!"))
+		case (section == gemSectionName || section == "GIT" || section == "PATH") && indentation == 4:
+			currentSpec = &gemSpec{Name: name, Version: version, Section: section}
+			lockfile.Specs = append(lockfile.Specs, currentSpec)
+		case currentSpec != nil && indentation == 6:
+			currentSpec.Dependencies = append(currentSpec.Dependencies, name)
+		}
+	}
+	return lockfile, scanner.Err()
+}
+
+// Splits a line such as "rack (>= 1.3)" or "nokogiri (1.15.0-x86_64-linux)" into the name and the parenthesized part.
+func splitNameAndRequirement(line string) (name, requirement string) {
+	name, requirement, _ = strings.Cut(line, " ")
+	return name, strings.TrimSuffix(strings.TrimPrefix(requirement, "("), ")")
+}
+
+func (gl *gemfileLock) dependencies(rootModuleId string) []buildinfo.Dependency {
+	specsByName := make(map[string][]*gemSpec)
+	for _, spec := range gl.Specs {
+		specsByName[spec.Name] = append(specsByName[spec.Name], spec)
+	}
+	type queuedSpec struct {
+		spec        *gemSpec
+		requestedBy []string
+	}
+	var queue []queuedSpec
+	for _, name := range gl.Dependencies {
+		for _, spec := range specsByName[name] {
+			queue = append(queue, queuedSpec{spec, []string{rootModuleId}})
+		}
+	}
+	dependencies := make(map[string]*buildinfo.Dependency)
+	visited := make(map[*gemSpec]bool)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		// Gems from Git and local paths aren't resolved from Artifactory, but their dependencies are.
+		if current.spec.Section == gemSectionName {
+			dependency, exists := dependencies[current.spec.id()]
+			if !exists {
+				dependency = &buildinfo.Dependency{Id: current.spec.id(), Type: gemFileType}
+				dependencies[current.spec.id()] = dependency
+			}
+			dependency.RequestedBy = append(dependency.RequestedBy, current.requestedBy)
+		}
+		if visited[current.spec] {
+			continue
+		}
+		visited[current.spec] = true
+		requestedBy := append([]string{current.spec.id()}, current.requestedBy...)
+		for _, name := range current.spec.Dependencies {
+			for _, spec := range specsByName[name] {
+				queue = append(queue, queuedSpec{spec, requestedBy})
+			}
+		}
+	}
+
+	result := make([]buildinfo.Dependency, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		result = append(result, *dependency)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
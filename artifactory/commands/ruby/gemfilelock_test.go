@@ -0,0 +1,61 @@
+package ruby
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gemfileLockContent = `GIT
+  remote: https://github.com/acme/internal-gem.git
+  revision: 0123456789abcdef
+  specs:
+    internal-gem (0.1.0)
+      rack (>= 2.0)
+
+GEM
+  remote: https://rubygems.org/
+  specs:
+    nokogiri (1.15.0-x86_64-linux)
+      racc (~> 1.4)
+    rack (3.0.8)
+    rack-test (2.1.0)
+      rack (>= 1.3)
+    racc (1.7.3)
+    unused (1.0.0)
+
+PLATFORMS
+  x86_64-linux
+
+DEPENDENCIES
+  internal-gem!
+  nokogiri (~> 1.15)
+  rack-test (~> 2.1)
+
+CHECKSUMS
+  rack (3.0.8) sha256=0123
+
+BUNDLED WITH
+   2.5.3
+`
+
+func TestParseGemfileLock(t *testing.T) {
+	lockfilePath := filepath.Join(t.TempDir(), "Gemfile.lock")
+	require.NoError(t, os.WriteFile(lockfilePath, []byte(gemfileLockContent), 0644))
+	dependencies, err := ParseGemfileLock(lockfilePath, "my-app")
+	require.NoError(t, err)
+	expected := []entities.Dependency{
+		{Id: "nokogiri:1.15.0-x86_64-linux", Type: "gem", RequestedBy: [][]string{{"my-app"}}},
+		{Id: "racc:1.7.3", Type: "gem", RequestedBy: [][]string{{"nokogiri:1.15.0-x86_64-linux", "my-app"}}},
+		{Id: "rack-test:2.1.0", Type: "gem", RequestedBy: [][]string{{"my-app"}}},
+		{Id: "rack:3.0.8", Type: "gem", RequestedBy: [][]string{
+			{"internal-gem:0.1.0", "my-app"},
+			{"rack-test:2.1.0", "my-app"},
+		}},
+	}
+	assert.Equal(t, expected, dependencies)
+}
@@ -0,0 +1,134 @@
+package ruby
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const rubyModuleType buildinfo.ModuleType = "ruby"
+
+// The common parts of the gem and bundle commands, which resolve gems from an Artifactory RubyGems repository.
+type RubyCommand struct {
+	cmdName               string
+	args                  []string
+	configFilePath        string
+	executablePath        string
+	workingDirectory      string
+	repo                  string
+	deployRepo            string
+	serverDetails         *config.ServerDetails
+	deployerServerDetails *config.ServerDetails
+	buildConfiguration    *build.BuildConfiguration
+}
+
+func (rc *RubyCommand) SetCommandName(cmdName string) *RubyCommand {
+	rc.cmdName = cmdName
+	return rc
+}
+
+func (rc *RubyCommand) SetConfigFilePath(configFilePath string) *RubyCommand {
+	rc.configFilePath = configFilePath
+	return rc
+}
+
+func (rc *RubyCommand) SetArgs(args []string) *RubyCommand {
+	rc.args = args
+	return rc
+}
+
+// Reads the deployment repository if isDeploy is true, and the resolution repository otherwise.
+func (rc *RubyCommand) init(isDeploy bool) (err error) {
+	log.Debug("Preparing to read the config file", rc.configFilePath)
+	vConfig, err := project.ReadConfigFile(rc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	if isDeploy {
+		deployerParams, err := project.GetRepoConfigByPrefix(rc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		rc.deployRepo = deployerParams.TargetRepo()
+		if rc.deployerServerDetails, err = deployerParams.ServerDetails(); err != nil {
+			return err
+		}
+	} else {
+		resolverParams, err := project.GetRepoConfigByPrefix(rc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		rc.repo = resolverParams.TargetRepo()
+		if rc.serverDetails, err = resolverParams.ServerDetails(); err != nil {
+			return err
+		}
+	}
+	rc.args, rc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(rc.args)
+	return err
+}
+
+func (rc *RubyCommand) prepare(executableName string) (err error) {
+	if rc.executablePath, err = exec.LookPath(executableName); err != nil {
+		return errorutils.CheckError(err)
+	}
+	rc.workingDirectory, err = coreutils.GetWorkingDirectory()
+	return err
+}
+
+func (rc *RubyCommand) run(env []string, args ...string) error {
+	command := exec.Command(rc.executablePath, args...)
+	command.Dir = rc.workingDirectory
+	command.Env = env
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	if err := command.Run(); err != nil {
+		return errorutils.CheckErrorf("%s %s failed: %s", filepath.Base(rc.executablePath), rc.cmdName, err.Error())
+	}
+	return nil
+}
+
+func GetGemsRepoUrl(artifactoryUrl, repo string) string {
+	return strings.TrimSuffix(artifactoryUrl, "/") + "/api/gems/" + repo + "/"
+}
+
+// Artifactory stores the gems in the gems directory of the repository, for example: gems/rack-3.0.8.gem.
+func getGemPath(name, version string) string {
+	return "gems/" + name + "-" + version + "." + gemFileType
+}
+
+// Returns the name and version of the gem from its file name, for example: my-gem-1.0.0.gem or nokogiri-1.15.0-x86_64-linux.gem.
+// The version starts after the first hyphen which is followed by a digit, since gem names may include hyphens, and versions may include a platform.
+func splitGemFileName(fileName string) (name, version string) {
+	baseName := strings.TrimSuffix(fileName, "."+gemFileType)
+	for i := 1; i < len(baseName); i++ {
+		if baseName[i-1] == '-' && baseName[i] >= '0' && baseName[i] <= '9' {
+			return baseName[:i-1], baseName[i:]
+		}
+	}
+	return baseName, ""
+}
+
+func createGemArtifact(gemFile, repo string) (buildinfo.Artifact, error) {
+	fileDetails, err := fileutils.GetFileDetails(gemFile, true)
+	if err != nil {
+		return buildinfo.Artifact{}, err
+	}
+	name, version := splitGemFileName(filepath.Base(gemFile))
+	return buildinfo.Artifact{
+		Name:                   filepath.Base(gemFile),
+		Type:                   gemFileType,
+		Path:                   getGemPath(name, version),
+		OriginalDeploymentRepo: repo,
+		Checksum:               buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256},
+	}, nil
+}
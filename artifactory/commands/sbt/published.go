@@ -0,0 +1,78 @@
+package sbt
+
+import (
+	"bytes"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	// The lines which sbt logs for each published file, such as: [info] 	published hello_2.13 to https://acme.jfrog.io/artifactory/sbt-local/com/example/hello_2.13/0.1.0/hello_2.13-0.1.0.jar
+	publishedLinePattern = regexp.MustCompile(`\bpublished\s+\S+\s+to\s+(\S+)`)
+	ansiEscapePattern    = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+)
+
+// Collects the URLs of the published files from the output of sbt, while the output is written to the console.
+type publishedUrlsCollector struct {
+	mutex sync.Mutex
+	// The last line, until it's complete.
+	partialLine []byte
+	urls        []string
+}
+
+func (puc *publishedUrlsCollector) Write(data []byte) (int, error) {
+	puc.mutex.Lock()
+	defer puc.mutex.Unlock()
+	puc.partialLine = append(puc.partialLine, data...)
+	for {
+		index := bytes.IndexByte(puc.partialLine, '\n')
+		if index < 0 {
+			break
+		}
+		puc.collect(string(puc.partialLine[:index]))
+		puc.partialLine = puc.partialLine[index+1:]
+	}
+	return len(data), nil
+}
+
+// Returns the URLs which were collected, including the one in the last line, if it isn't terminated by a newline.
+func (puc *publishedUrlsCollector) getUrls() []string {
+	puc.mutex.Lock()
+	defer puc.mutex.Unlock()
+	if len(puc.partialLine) > 0 {
+		puc.collect(string(puc.partialLine))
+		puc.partialLine = nil
+	}
+	return puc.urls
+}
+
+func (puc *publishedUrlsCollector) collect(line string) {
+	if match := publishedLinePattern.FindStringSubmatch(ansiEscapePattern.ReplaceAllString(line, "")); match != nil {
+		puc.urls = append(puc.urls, match[1])
+	}
+}
+
+// A file which sbt published to the deployment repository, in the Maven layout.
+type publishedFile struct {
+	// The path of the file in the repository.
+	Path     string
+	ModuleId string
+}
+
+// Returns the file which was published to the URL, if the URL is in the repository.
+// The module ID is groupId:artifactId:version, by the Maven layout of the path: <group path>/<artifactId>/<version>/<file>.
+func parsePublishedUrl(publishedUrl, repositoryUrl string) (*publishedFile, bool) {
+	relativePath, found := strings.CutPrefix(publishedUrl, strings.TrimSuffix(repositoryUrl, "/")+"/")
+	if !found {
+		return nil, false
+	}
+	segments := strings.Split(relativePath, "/")
+	if len(segments) < 4 {
+		return &publishedFile{Path: relativePath, ModuleId: path.Dir(relativePath)}, true
+	}
+	last := len(segments) - 1
+	groupId := strings.Join(segments[:last-2], ".")
+	return &publishedFile{Path: relativePath, ModuleId: groupId + ":" + segments[last-2] + ":" + segments[last-1]}, true
+}
@@ -0,0 +1,37 @@
+package sbt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sbtPublishOutput = "[info] welcome to sbt 1.10.0 (Eclipse Adoptium Java 17.0.11)\n" +
+	"[info] Wrote /home/dev/hello/target/scala-2.13/hello_2.13-0.1.0.pom\n" +
+	"[info] \tpublished hello_2.13 to https://acme.jfrog.io/artifactory/sbt-local/com/example/hello_2.13/0.1.0/hello_2.13-0.1.0.pom\n" +
+	"\x1b[0m[\x1b[0m\x1b[0minfo\x1b[0m] \x1b[0m\tpublished hello_2.13 to https://acme.jfrog.io/artifactory/sbt-local/com/example/hello_2.13/0.1.0/hello_2.13-0.1.0.jar\x1b[0m\n" +
+	"[info] \tpublished hello_2.13 to https://acme.jfrog.io/artifactory/sbt-local/com/example/hello_2.13/0.1.0/hello_2.13-0.1.0-sources.jar\n" +
+	"[success] Total time: 2 s"
+
+func TestPublishedUrlsCollector(t *testing.T) {
+	collector := new(publishedUrlsCollector)
+	// The output is written in chunks which split the lines.
+	for i := 0; i < len(sbtPublishOutput); i += 50 {
+		_, err := collector.Write([]byte(sbtPublishOutput[i:min(i+50, len(sbtPublishOutput))]))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{
+		"https://acme.jfrog.io/artifactory/sbt-local/com/example/hello_2.13/0.1.0/hello_2.13-0.1.0.pom",
+		"https://acme.jfrog.io/artifactory/sbt-local/com/example/hello_2.13/0.1.0/hello_2.13-0.1.0.jar",
+		"https://acme.jfrog.io/artifactory/sbt-local/com/example/hello_2.13/0.1.0/hello_2.13-0.1.0-sources.jar",
+	}, collector.getUrls())
+}
+
+func TestParsePublishedUrl(t *testing.T) {
+	file, found := parsePublishedUrl("https://acme.jfrog.io/artifactory/sbt-local/com/example/hello_2.13/0.1.0/hello_2.13-0.1.0.jar", "https://acme.jfrog.io/artifactory/sbt-local")
+	assert.True(t, found)
+	assert.Equal(t, &publishedFile{Path: "com/example/hello_2.13/0.1.0/hello_2.13-0.1.0.jar", ModuleId: "com.example:hello_2.13:0.1.0"}, file)
+
+	_, found = parsePublishedUrl("https://repo1.maven.org/maven2/com/example/hello_2.13/0.1.0/hello_2.13-0.1.0.jar", "https://acme.jfrog.io/artifactory/sbt-local")
+	assert.False(t, found)
+}
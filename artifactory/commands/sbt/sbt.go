@@ -0,0 +1,299 @@
+package sbt
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The name of the repository in the sbt repositories file.
+	resolutionRepositoryName = "artifactory"
+	// The realm of the basic authentication of Artifactory, which sbt requires for matching the publishing credentials.
+	artifactoryRealm = "Artifactory Realm"
+
+	sbtModuleType buildinfo.ModuleType = "sbt"
+)
+
+// The tasks which publish to the repository of publishTo. The tasks may be scoped to a project, such as core/publish, or cross-built, such as +publish.
+var publishTasks = map[string]bool{"publish": true, "publishSigned": true}
+
+// Runs sbt with the commands and tasks which were passed to it. The dependencies, including the sbt plugins, are resolved from the resolution repository,
+// which replaces the repositories of the build. The publish tasks publish to the deployment repository.
+// If build-info collection was requested, the files which were published are recorded as artifacts, in a module per published project.
+type SbtCommand struct {
+	args                  []string
+	configFilePath        string
+	executablePath        string
+	workingDirectory      string
+	repo                  string
+	deployRepo            string
+	serverDetails         *config.ServerDetails
+	deployerServerDetails *config.ServerDetails
+	buildConfiguration    *build.BuildConfiguration
+}
+
+func NewSbtCommand() *SbtCommand {
+	return &SbtCommand{}
+}
+
+func (sc *SbtCommand) SetConfigFilePath(configFilePath string) *SbtCommand {
+	sc.configFilePath = configFilePath
+	return sc
+}
+
+// The arguments are the options of sbt and the commands and tasks to run, such as: -v clean test publish.
+func (sc *SbtCommand) SetArgs(args []string) *SbtCommand {
+	sc.args = args
+	return sc
+}
+
+func (sc *SbtCommand) ServerDetails() (*config.ServerDetails, error) {
+	return sc.serverDetails, nil
+}
+
+func (sc *SbtCommand) CommandName() string {
+	return "rt_sbt"
+}
+
+// The resolution repository is required. The deployment repository is required only if a publish task is run.
+func (sc *SbtCommand) Init() (err error) {
+	log.Debug("Preparing to read the config file", sc.configFilePath)
+	vConfig, err := project.ReadConfigFile(sc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	resolverParams, err := project.GetRepoConfigByPrefix(sc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	sc.repo = resolverParams.TargetRepo()
+	if sc.serverDetails, err = resolverParams.ServerDetails(); err != nil {
+		return err
+	}
+	if sc.isPublish() {
+		deployerParams, err := project.GetRepoConfigByPrefix(sc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		sc.deployRepo = deployerParams.TargetRepo()
+		if sc.deployerServerDetails, err = deployerParams.ServerDetails(); err != nil {
+			return err
+		}
+	}
+	sc.args, sc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(sc.args)
+	return err
+}
+
+func (sc *SbtCommand) isPublish() bool {
+	for _, arg := range sc.args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		// Several commands may be passed in one argument, separated by semicolons.
+		for _, command := range strings.Split(arg, ";") {
+			task := strings.TrimPrefix(strings.TrimSpace(command), "+")
+			if publishTasks[path.Base(task)] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (sc *SbtCommand) Run() (err error) {
+	log.Info("Running sbt...")
+	if sc.executablePath, err = exec.LookPath("sbt"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if sc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return err
+	}
+	collectBuildInfo, err := sc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	repositoriesFilePath := filepath.Join(tempDir, "repositories")
+	if err = os.WriteFile(repositoriesFilePath, []byte(createRepositoriesFile(sc.serverDetails.GetArtifactoryUrl(), sc.repo)), 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	args := []string{"-Dsbt.override.build.repos=true", "-Dsbt.repository.config=" + filepath.ToSlash(repositoriesFilePath)}
+	if sc.deployRepo != "" {
+		publishSettings, err := sc.createPublishSettings(tempDir)
+		if err != nil {
+			return err
+		}
+		args = append(args, publishSettings...)
+	}
+	args = append(args, sc.args...)
+
+	collector := new(publishedUrlsCollector)
+	if err = sc.runSbt(args, collector); err != nil {
+		return err
+	}
+	if collectBuildInfo && sc.deployRepo != "" {
+		if err = sc.collectPublishedArtifacts(collector.getUrls()); err != nil {
+			return err
+		}
+	}
+	log.Info("sbt finished successfully.")
+	return nil
+}
+
+// Returns the sbt repositories file, which replaces the repositories of the build by the resolution repository, in addition to the local Ivy repository.
+func createRepositoriesFile(artifactoryUrl, repo string) string {
+	return "[repositories]\n" +
+		"  local\n" +
+		"  " + resolutionRepositoryName + ": " + GetRepositoryUrl(artifactoryUrl, repo) + "/\n"
+}
+
+func GetRepositoryUrl(artifactoryUrl, repo string) string {
+	return strings.TrimSuffix(artifactoryUrl, "/") + "/" + repo
+}
+
+// Returns the set commands, which publish to the deployment repository, with the credentials of the deployment server.
+// The credentials are written to a credentials file, so that they don't appear in the command line.
+func (sc *SbtCommand) createPublishSettings(tempDir string) ([]string, error) {
+	repositoryUrl := GetRepositoryUrl(sc.deployerServerDetails.GetArtifactoryUrl(), sc.deployRepo)
+	settings := []string{`set ThisBuild / publishTo := Some("` + resolutionRepositoryName + `" at "` + repositoryUrl + `")`}
+	username, password := projectconfig.GetBasicAuthCredentials(sc.deployerServerDetails)
+	if password == "" {
+		return settings, nil
+	}
+	host, err := getHost(repositoryUrl)
+	if err != nil {
+		return nil, err
+	}
+	credentialsFilePath := filepath.Join(tempDir, "credentials")
+	content := "realm=" + artifactoryRealm + "\nhost=" + host + "\nuser=" + username + "\npassword=" + password + "\n"
+	if err = os.WriteFile(credentialsFilePath, []byte(content), 0600); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return append(settings, `set ThisBuild / credentials += Credentials(file("`+filepath.ToSlash(credentialsFilePath)+`"))`), nil
+}
+
+// Returns the COURSIER_CREDENTIALS environment variable, with the credentials of the resolution server.
+// Coursier resolves the dependencies of sbt, including the plugins, which are resolved before the settings of the build are loaded.
+func createResolutionEnv(serverDetails *config.ServerDetails) ([]string, error) {
+	username, password := projectconfig.GetBasicAuthCredentials(serverDetails)
+	if password == "" {
+		return nil, nil
+	}
+	host, err := getHost(serverDetails.GetArtifactoryUrl())
+	if err != nil {
+		return nil, err
+	}
+	return []string{"COURSIER_CREDENTIALS=" + host + " " + username + ":" + password}, nil
+}
+
+func getHost(rawUrl string) (string, error) {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return parsedUrl.Hostname(), nil
+}
+
+// Runs sbt, while the output is written to the console and to the writer.
+func (sc *SbtCommand) runSbt(args []string, outputWriter io.Writer) error {
+	resolutionEnv, err := createResolutionEnv(sc.serverDetails)
+	if err != nil {
+		return err
+	}
+	command := exec.Command(sc.executablePath, args...)
+	command.Dir = sc.workingDirectory
+	command.Env = append(os.Environ(), resolutionEnv...)
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, io.MultiWriter(os.Stdout, outputWriter), os.Stderr
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	if err = command.Run(); err != nil {
+		return errorutils.CheckErrorf("sbt failed: %s", err.Error())
+	}
+	return nil
+}
+
+// Records the files which sbt published as artifacts, in a module per published project. The checksums are read from Artifactory.
+func (sc *SbtCommand) collectPublishedArtifacts(publishedUrls []string) error {
+	repositoryUrl := GetRepositoryUrl(sc.deployerServerDetails.GetArtifactoryUrl(), sc.deployRepo)
+	var moduleIds []string
+	artifactsByModule := make(map[string][]buildinfo.Artifact)
+	servicesManager, err := utils.CreateServiceManager(sc.deployerServerDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	for _, publishedUrl := range publishedUrls {
+		file, found := parsePublishedUrl(publishedUrl, repositoryUrl)
+		if !found {
+			log.Debug("The published file " + publishedUrl + " is not in the deployment repository, so it's not recorded.")
+			continue
+		}
+		artifact := buildinfo.Artifact{
+			Name:                   path.Base(file.Path),
+			Type:                   strings.TrimPrefix(path.Ext(file.Path), "."),
+			Path:                   file.Path,
+			OriginalDeploymentRepo: sc.deployRepo,
+		}
+		fileInfo, err := servicesManager.FileInfo(sc.deployRepo + "/" + file.Path)
+		if err != nil {
+			return err
+		}
+		artifact.Checksum = buildinfo.Checksum{Sha1: fileInfo.Checksums.Sha1, Md5: fileInfo.Checksums.Md5, Sha256: fileInfo.Checksums.Sha256}
+		moduleId := file.ModuleId
+		if sc.buildConfiguration.GetModule() != "" {
+			moduleId = sc.buildConfiguration.GetModule()
+		}
+		if _, exists := artifactsByModule[moduleId]; !exists {
+			moduleIds = append(moduleIds, moduleId)
+		}
+		artifactsByModule[moduleId] = append(artifactsByModule[moduleId], artifact)
+	}
+	if len(moduleIds) == 0 {
+		log.Warn("No published files were found in the output of sbt, so no artifacts are added to the build-info.")
+		return nil
+	}
+	buildName, err := sc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := sc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := sc.buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	for _, moduleId := range moduleIds {
+		artifacts := artifactsByModule[moduleId]
+		if err = build.SavePartialBuildInfo(buildName, buildNumber, projectKey, func(partial *buildinfo.Partial) {
+			partial.ModuleType = sbtModuleType
+			partial.ModuleId = moduleId
+			partial.Artifacts = artifacts
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
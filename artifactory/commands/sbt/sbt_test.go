@@ -0,0 +1,43 @@
+package sbt
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublish(t *testing.T) {
+	for _, args := range [][]string{{"publish"}, {"-v", "clean", "core/publish"}, {"+publishSigned"}, {"clean; test; publish"}} {
+		assert.True(t, NewSbtCommand().SetArgs(args).isPublish(), args)
+	}
+	for _, args := range [][]string{{"compile"}, {"publishLocal"}, {"core/publishM2", "test"}} {
+		assert.False(t, NewSbtCommand().SetArgs(args).isPublish(), args)
+	}
+}
+
+func TestCreateRepositoriesFile(t *testing.T) {
+	assert.Equal(t, "[repositories]\n  local\n  artifactory: https://acme.jfrog.io/artifactory/sbt-virtual/\n",
+		createRepositoriesFile("https://acme.jfrog.io/artifactory/", "sbt-virtual"))
+}
+
+func TestCreatePublishSettings(t *testing.T) {
+	sbtCommand := NewSbtCommand()
+	sbtCommand.deployRepo = "sbt-local"
+	sbtCommand.deployerServerDetails = &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "admin", Password: "password"}
+	tempDir := t.TempDir()
+	settings, err := sbtCommand.createPublishSettings(tempDir)
+	require.NoError(t, err)
+	require.Len(t, settings, 2)
+	assert.Equal(t, `set ThisBuild / publishTo := Some("artifactory" at "https://acme.jfrog.io/artifactory/sbt-local")`, settings[0])
+	assert.Contains(t, settings[1], `set ThisBuild / credentials += Credentials(file("`)
+	content, err := os.ReadFile(tempDir + "/credentials")
+	require.NoError(t, err)
+	assert.Equal(t, "realm=Artifactory Realm\nhost=acme.jfrog.io\nuser=admin\npassword=password\n", string(content))
+
+	env, err := createResolutionEnv(sbtCommand.deployerServerDetails)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"COURSIER_CREDENTIALS=acme.jfrog.io admin:password"}, env)
+}
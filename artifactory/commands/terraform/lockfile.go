@@ -0,0 +1,169 @@
+package terraform
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	lockFileName = ".terraform.lock.hcl"
+	// The manifest of the modules which 'terraform init' installed.
+	modulesManifestPath = ".terraform/modules/modules.json"
+
+	// The hash of the zip archive of a provider package, for a single platform: zh:<hex sha256>.
+	zipHashPrefix = "zh:"
+	// The hash of the content of a provider package, for a single platform, in the format of the Go checksum database: h1:<base64 sha256>.
+	contentHashPrefix = "h1:"
+)
+
+var (
+	providerBlockPattern = regexp.MustCompile(`^provider\s+"([^"]+)"\s*\{$`)
+	attributePattern     = regexp.MustCompile(`^(\w+)\s*=\s*"([^"]*)"$`)
+	quotedStringPattern  = regexp.MustCompile(`"([^"]*)"`)
+)
+
+// A provider which is locked in the dependency lock file.
+type lockedProvider struct {
+	// The source address of the provider, such as registry.terraform.io/hashicorp/aws.
+	Address     string
+	Version     string
+	Constraints string
+	Hashes      []string
+}
+
+// Returns the hostname, namespace and type of the source address of the provider.
+func (lp *lockedProvider) splitAddress() (hostname, namespace, providerType string, err error) {
+	parts := strings.Split(lp.Address, "/")
+	if len(parts) != 3 {
+		return "", "", "", errorutils.CheckErrorf("unexpected provider source address in %s: %s", lockFileName, lp.Address)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// Returns the hashes of the zip archives of the provider, without their prefix.
+func (lp *lockedProvider) zipHashes() []string {
+	var zipHashes []string
+	for _, hash := range lp.Hashes {
+		if zipHash, found := strings.CutPrefix(hash, zipHashPrefix); found {
+			zipHashes = append(zipHashes, zipHash)
+		}
+	}
+	return zipHashes
+}
+
+// Reads the providers of the dependency lock file. The lock file is generated by 'terraform init' in a fixed format:
+//
+//	provider "registry.terraform.io/hashicorp/aws" {
+//	  version     = "5.31.0"
+//	  constraints = "~> 5.0"
+//	  hashes = [
+//	    "h1:...",
+//	    "zh:...",
+//	  ]
+//	}
+func parseLockFile(lockFilePath string) (providers []*lockedProvider, err error) {
+	file, err := os.Open(lockFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	var current *lockedProvider
+	inHashes := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if current == nil {
+			if match := providerBlockPattern.FindStringSubmatch(line); match != nil {
+				current = &lockedProvider{Address: match[1]}
+			}
+			continue
+		}
+		switch {
+		case inHashes:
+			for _, match := range quotedStringPattern.FindAllStringSubmatch(line, -1) {
+				current.Hashes = append(current.Hashes, match[1])
+			}
+			inHashes = !strings.HasSuffix(line, "]")
+		case strings.HasPrefix(line, "hashes"):
+			// The hashes may also be written in a single line: hashes = ["h1:...", "zh:..."]
+			_, values, _ := strings.Cut(line, "[")
+			for _, match := range quotedStringPattern.FindAllStringSubmatch(values, -1) {
+				current.Hashes = append(current.Hashes, match[1])
+			}
+			inHashes = !strings.HasSuffix(line, "]")
+		case line == "}":
+			if current.Version == "" {
+				return nil, errorutils.CheckErrorf("the version of the provider %s is missing from %s", current.Address, lockFilePath)
+			}
+			providers = append(providers, current)
+			current = nil
+		default:
+			if match := attributePattern.FindStringSubmatch(line); match != nil {
+				switch match[1] {
+				case "version":
+					current.Version = match[2]
+				case "constraints":
+					current.Constraints = match[2]
+				}
+			}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if current != nil {
+		return nil, errorutils.CheckErrorf("the block of the provider %s isn't closed in %s", current.Address, lockFilePath)
+	}
+	return providers, nil
+}
+
+// A module which 'terraform init' installed, as recorded in the modules manifest.
+type installedModule struct {
+	// The address of the module call in the configuration, such as vpc or vpc.subnets. The root module has an empty key.
+	Key    string `json:"Key"`
+	Source string `json:"Source"`
+	// The version is set only for the modules which were installed from a module registry.
+	Version string `json:"Version,omitempty"`
+	Dir     string `json:"Dir"`
+}
+
+type modulesManifest struct {
+	Modules []installedModule `json:"Modules"`
+}
+
+// Returns the remote modules which were installed in the working directory. The root module and the local modules, which are sourced
+// from a relative path, are not returned. If the configuration has no module calls, there's no manifest and no modules are returned.
+func readInstalledModules(workingDirectory string) ([]installedModule, error) {
+	content, err := os.ReadFile(filepath.Join(workingDirectory, filepath.FromSlash(modulesManifestPath)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	var manifest modulesManifest
+	if err = json.Unmarshal(content, &manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing %s: %s", modulesManifestPath, err.Error())
+	}
+	var modules []installedModule
+	for _, module := range manifest.Modules {
+		if module.Key == "" || module.Source == "" || strings.HasPrefix(module.Source, "./") || strings.HasPrefix(module.Source, "../") {
+			continue
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
@@ -0,0 +1,58 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLockFile(t *testing.T) {
+	providers, err := parseLockFile(filepath.Join("testdata", lockFileName))
+	require.NoError(t, err)
+	require.Len(t, providers, 2)
+
+	assert.Equal(t, "registry.terraform.io/hashicorp/aws", providers[0].Address)
+	assert.Equal(t, "5.31.0", providers[0].Version)
+	assert.Equal(t, "~> 5.0", providers[0].Constraints)
+	assert.Len(t, providers[0].Hashes, 3)
+	assert.Equal(t, []string{"0cdb9c2083bf0902442384f7309367791e4640581652dda456f2d6d7abf0de8d", "2fe4884cb9642f48a5889f8dff8f5f511418a18537a9dfa77ada3bcdad391e4e"},
+		providers[0].zipHashes())
+	hostname, namespace, providerType, err := providers[0].splitAddress()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"registry.terraform.io", "hashicorp", "aws"}, []string{hostname, namespace, providerType})
+
+	assert.Equal(t, "registry.terraform.io/hashicorp/random", providers[1].Address)
+	assert.Equal(t, "3.6.0", providers[1].Version)
+	assert.Equal(t, []string{"h1:I8MBeauYA8J8yheLJ8oSMWqB0kovn16dF/wKZ1QTdkk="}, providers[1].Hashes)
+	assert.Empty(t, providers[1].zipHashes())
+}
+
+func TestParseLockFileErrors(t *testing.T) {
+	lockFilePath := filepath.Join(t.TempDir(), lockFileName)
+	require.NoError(t, os.WriteFile(lockFilePath, []byte("provider \"registry.terraform.io/hashicorp/aws\" {\n  hashes = []\n}\n"), 0644))
+	_, err := parseLockFile(lockFilePath)
+	assert.ErrorContains(t, err, "the version of the provider registry.terraform.io/hashicorp/aws is missing")
+
+	require.NoError(t, os.WriteFile(lockFilePath, []byte("provider \"registry.terraform.io/hashicorp/aws\" {\n  version = \"5.31.0\"\n"), 0644))
+	_, err = parseLockFile(lockFilePath)
+	assert.ErrorContains(t, err, "isn't closed")
+}
+
+func TestReadInstalledModules(t *testing.T) {
+	modules, err := readInstalledModules("testdata")
+	require.NoError(t, err)
+	require.Len(t, modules, 3)
+	assert.Equal(t, "vpc", modules[0].Key)
+	assert.Equal(t, "registry.terraform.io/terraform-aws-modules/vpc/aws", modules[0].Source)
+	assert.Equal(t, "5.1.0", modules[0].Version)
+	assert.Equal(t, "labels", modules[2].Key)
+	assert.Empty(t, modules[2].Version)
+
+	// A configuration without module calls has no modules manifest.
+	modules, err = readInstalledModules(t.TempDir())
+	assert.NoError(t, err)
+	assert.Empty(t, modules)
+}
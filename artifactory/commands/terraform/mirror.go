@@ -0,0 +1,396 @@
+package terraform
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	commandutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+const (
+	// The service discovery document of a Terraform registry host.
+	serviceDiscoveryPath = "/.well-known/terraform.json"
+	providersServiceId   = "providers.v1"
+	mirrorIndexFileName  = "index.json"
+
+	registryRequestTimeout = 10 * time.Minute
+)
+
+// Populates a repository with the providers of the dependency lock file, in the layout of the Terraform provider network mirror protocol:
+// <hostname>/<namespace>/<type>/index.json, <hostname>/<namespace>/<type>/<version>.json, and the zip archives of the packages, next to them.
+// The repository, which should be a generic repository, can then serve as a network mirror of the providers, by its URL.
+// The packages are downloaded from the origin registries of the providers, for each of the platforms, and are verified by the hashes of the lock file.
+// The versions and the platforms which are already in the repository are kept in the index files.
+type ProvidersMirrorCommand struct {
+	configFilePath   string
+	targetRepo       string
+	platforms        []string
+	workingDirectory string
+	serverDetails    *config.ServerDetails
+	httpClient       *http.Client
+	result           *commandutils.Result
+}
+
+func NewProvidersMirrorCommand() *ProvidersMirrorCommand {
+	return &ProvidersMirrorCommand{httpClient: &http.Client{Timeout: registryRequestTimeout}, result: new(commandutils.Result)}
+}
+
+func (pmc *ProvidersMirrorCommand) SetConfigFilePath(configFilePath string) *ProvidersMirrorCommand {
+	pmc.configFilePath = configFilePath
+	return pmc
+}
+
+func (pmc *ProvidersMirrorCommand) SetTargetRepo(targetRepo string) *ProvidersMirrorCommand {
+	pmc.targetRepo = targetRepo
+	return pmc
+}
+
+// The platforms are in the format <os>_<arch>, such as linux_amd64. The platform of the current machine is mirrored if no platforms are set.
+func (pmc *ProvidersMirrorCommand) SetPlatforms(platforms []string) *ProvidersMirrorCommand {
+	pmc.platforms = platforms
+	return pmc
+}
+
+func (pmc *ProvidersMirrorCommand) Result() *commandutils.Result {
+	return pmc.result
+}
+
+func (pmc *ProvidersMirrorCommand) ServerDetails() (*config.ServerDetails, error) {
+	return pmc.serverDetails, nil
+}
+
+func (pmc *ProvidersMirrorCommand) CommandName() string {
+	return "rt_terraform_providers_mirror"
+}
+
+// Returns the arguments of the providers-mirror command: the target repository, and the platforms of the --platform options, which may repeat.
+func ParseProvidersMirrorArgs(args []string) (targetRepo string, platforms []string, err error) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			if targetRepo != "" {
+				return "", nil, errorutils.CheckErrorf("unexpected argument: %s. The providers-mirror command expects a single target repository", arg)
+			}
+			targetRepo = arg
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if name != "platform" {
+			return "", nil, errorutils.CheckErrorf("unknown option of the providers-mirror command: %s", arg)
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				return "", nil, errorutils.CheckErrorf("the --platform option requires a value, such as linux_amd64")
+			}
+			i++
+			value = args[i]
+		}
+		if goos, arch, found := strings.Cut(value, "_"); !found || goos == "" || arch == "" {
+			return "", nil, errorutils.CheckErrorf("invalid platform: %s. The platform should be in the format <os>_<arch>, such as linux_amd64", value)
+		}
+		platforms = append(platforms, value)
+	}
+	if targetRepo == "" {
+		return "", nil, errorutils.CheckErrorf("the target repository is missing")
+	}
+	return targetRepo, platforms, nil
+}
+
+func (pmc *ProvidersMirrorCommand) Run() (err error) {
+	vConfig, err := project.ReadConfigFile(pmc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	deployerParams, err := project.GetRepoConfigByPrefix(pmc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	if pmc.serverDetails, err = deployerParams.ServerDetails(); err != nil {
+		return err
+	}
+	if pmc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return err
+	}
+	if len(pmc.platforms) == 0 {
+		pmc.platforms = []string{runtime.GOOS + "_" + runtime.GOARCH}
+	}
+	providers, err := parseLockFile(filepath.Join(pmc.workingDirectory, lockFileName))
+	if err != nil {
+		return err
+	}
+	if len(providers) == 0 {
+		log.Warn("No providers were found in " + lockFileName + ", so nothing is mirrored.")
+		return nil
+	}
+	servicesManager, err := utils.CreateServiceManager(pmc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	for _, provider := range providers {
+		if err = pmc.mirrorProvider(servicesManager, provider, tempDir); err != nil {
+			return err
+		}
+	}
+	log.Info("The providers of " + lockFileName + " were mirrored to " + pmc.targetRepo + " successfully.")
+	return nil
+}
+
+// The archive of a provider package in the network mirror protocol.
+type mirrorArchive struct {
+	// The URL of the archive, relative to the version file.
+	Url    string   `json:"url"`
+	Hashes []string `json:"hashes,omitempty"`
+}
+
+type mirrorVersion struct {
+	// The archives by platform.
+	Archives map[string]mirrorArchive `json:"archives"`
+}
+
+type mirrorIndex struct {
+	Versions map[string]struct{} `json:"versions"`
+}
+
+// Mirrors the package of the provider version for each platform, and then updates the version file and the index file.
+func (pmc *ProvidersMirrorCommand) mirrorProvider(servicesManager artifactory.ArtifactoryServicesManager, provider *lockedProvider, tempDir string) error {
+	hostname, namespace, providerType, err := provider.splitAddress()
+	if err != nil {
+		return err
+	}
+	providerPath := path.Join(hostname, namespace, providerType)
+	providersUrl, err := pmc.discoverProvidersService(hostname)
+	if err != nil {
+		return err
+	}
+	versionFilePath := path.Join(providerPath, provider.Version+".json")
+	version := &mirrorVersion{}
+	if err = pmc.readMirrorFile(servicesManager, versionFilePath, version); err != nil {
+		return err
+	}
+	if version.Archives == nil {
+		version.Archives = make(map[string]mirrorArchive)
+	}
+	for _, platform := range pmc.platforms {
+		log.Info("Mirroring " + provider.Address + " " + provider.Version + " for " + platform + "...")
+		archive, err := pmc.mirrorPackage(servicesManager, providersUrl, providerPath, provider, platform, tempDir)
+		if err != nil {
+			pmc.result.SetFailCount(pmc.result.FailCount() + 1)
+			return err
+		}
+		pmc.result.SetSuccessCount(pmc.result.SuccessCount() + 1)
+		version.Archives[platform] = *archive
+	}
+	if err = pmc.writeMirrorFile(servicesManager, versionFilePath, version, tempDir); err != nil {
+		return err
+	}
+	indexFilePath := path.Join(providerPath, mirrorIndexFileName)
+	index := &mirrorIndex{}
+	if err = pmc.readMirrorFile(servicesManager, indexFilePath, index); err != nil {
+		return err
+	}
+	if index.Versions == nil {
+		index.Versions = make(map[string]struct{})
+	}
+	index.Versions[provider.Version] = struct{}{}
+	return pmc.writeMirrorFile(servicesManager, indexFilePath, index, tempDir)
+}
+
+// Returns the base URL of the provider registry API of the host, by its service discovery document.
+func (pmc *ProvidersMirrorCommand) discoverProvidersService(hostname string) (*url.URL, error) {
+	discoveryUrl := &url.URL{Scheme: "https", Host: hostname, Path: serviceDiscoveryPath}
+	services := make(map[string]interface{})
+	if err := pmc.getJson(discoveryUrl.String(), &services); err != nil {
+		return nil, err
+	}
+	providersService, ok := services[providersServiceId].(string)
+	if !ok {
+		return nil, errorutils.CheckErrorf("%s isn't a provider registry: %s is missing from %s", hostname, providersServiceId, discoveryUrl)
+	}
+	providersUrl, err := discoveryUrl.Parse(providersService)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if !strings.HasSuffix(providersUrl.Path, "/") {
+		providersUrl.Path += "/"
+	}
+	return providersUrl, nil
+}
+
+// Downloads the package of the provider for the platform from its registry, verifies it by the hashes of the lock file, and uploads it to the repository.
+func (pmc *ProvidersMirrorCommand) mirrorPackage(servicesManager artifactory.ArtifactoryServicesManager, providersUrl *url.URL, providerPath string,
+	provider *lockedProvider, platform, tempDir string) (*mirrorArchive, error) {
+	_, namespace, providerType, err := provider.splitAddress()
+	if err != nil {
+		return nil, err
+	}
+	goos, arch, _ := strings.Cut(platform, "_")
+	downloadInfoUrl, err := providersUrl.Parse(path.Join(namespace, providerType, provider.Version, "download", goos, arch))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var downloadInfo struct {
+		Filename    string `json:"filename"`
+		DownloadUrl string `json:"download_url"`
+		Shasum      string `json:"shasum"`
+	}
+	if err = pmc.getJson(downloadInfoUrl.String(), &downloadInfo); err != nil {
+		return nil, err
+	}
+	packageUrl, err := downloadInfoUrl.Parse(downloadInfo.DownloadUrl)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	fileName := path.Base(downloadInfo.Filename)
+	if downloadInfo.Filename == "" {
+		fileName = path.Base(packageUrl.Path)
+	}
+	packageFilePath := filepath.Join(tempDir, fileName)
+	zipHash, err := pmc.download(packageUrl.String(), packageFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if downloadInfo.Shasum != "" && downloadInfo.Shasum != zipHash {
+		return nil, errorutils.CheckErrorf("the checksum of %s doesn't match the checksum which the registry reported", fileName)
+	}
+	contentHash, err := dirhash.HashZip(packageFilePath, dirhash.Hash1)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if err = verifyLockedHashes(provider, zipHash, contentHash); err != nil {
+		return nil, err
+	}
+	if err = pmc.upload(servicesManager, packageFilePath, path.Join(providerPath, fileName)); err != nil {
+		return nil, err
+	}
+	return &mirrorArchive{Url: fileName, Hashes: []string{contentHash}}, nil
+}
+
+// The package should match one of the hashes of the lock file. The lock file may hold only the content hashes of some of the platforms,
+// in which case the packages of the other platforms are verified by the checksum which the registry reported.
+func verifyLockedHashes(provider *lockedProvider, zipHash, contentHash string) error {
+	if len(provider.Hashes) == 0 || slices.Contains(provider.Hashes, zipHashPrefix+zipHash) || slices.Contains(provider.Hashes, contentHash) {
+		return nil
+	}
+	if len(provider.zipHashes()) == 0 {
+		log.Warn("The package of " + provider.Address + " " + provider.Version + " isn't one of the packages of " + lockFileName + ", which holds no hashes of the packages of the other platforms.")
+		return nil
+	}
+	return errorutils.CheckErrorf("the package of %s %s doesn't match any of the hashes of %s", provider.Address, provider.Version, lockFileName)
+}
+
+func (pmc *ProvidersMirrorCommand) getJson(requestUrl string, value interface{}) error {
+	log.Debug("Sending GET request to", requestUrl)
+	response, err := pmc.httpClient.Get(requestUrl)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		return errorutils.CheckErrorf("GET %s failed with status %s", requestUrl, response.Status)
+	}
+	if err = json.NewDecoder(response.Body).Decode(value); err != nil {
+		return errorutils.CheckErrorf("failed parsing the response of %s: %s", requestUrl, err.Error())
+	}
+	return nil
+}
+
+// Downloads the file and returns its SHA256 checksum.
+func (pmc *ProvidersMirrorCommand) download(requestUrl, filePath string) (sha256Checksum string, err error) {
+	log.Debug("Downloading", requestUrl)
+	response, err := pmc.httpClient.Get(requestUrl)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(response.Body.Close()))
+	}()
+	if response.StatusCode != http.StatusOK {
+		return "", errorutils.CheckErrorf("downloading %s failed with status %s", requestUrl, response.Status)
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	hash := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(file, hash), response.Body); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Reads the JSON file of the mirror from the repository, if it exists.
+func (pmc *ProvidersMirrorCommand) readMirrorFile(servicesManager artifactory.ArtifactoryServicesManager, filePath string, value interface{}) error {
+	if _, err := servicesManager.FileInfo(pmc.targetRepo + "/" + filePath); err != nil {
+		log.Debug(filePath + " doesn't exist in " + pmc.targetRepo + ": " + err.Error())
+		return nil
+	}
+	reader, err := servicesManager.ReadRemoteFile(pmc.targetRepo + "/" + filePath)
+	if err != nil {
+		return err
+	}
+	content, err := io.ReadAll(reader)
+	if err = errors.Join(errorutils.CheckError(err), errorutils.CheckError(reader.Close())); err != nil {
+		return err
+	}
+	return errorutils.CheckError(json.Unmarshal(content, value))
+}
+
+func (pmc *ProvidersMirrorCommand) writeMirrorFile(servicesManager artifactory.ArtifactoryServicesManager, filePath string, value interface{}, tempDir string) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	localPath := filepath.Join(tempDir, path.Base(filePath))
+	if err = os.WriteFile(localPath, content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return pmc.upload(servicesManager, localPath, filePath)
+}
+
+func (pmc *ProvidersMirrorCommand) upload(servicesManager artifactory.ArtifactoryServicesManager, localPath, targetPath string) error {
+	uploadParams := services.NewUploadParams()
+	uploadParams.Pattern = localPath
+	uploadParams.Target = pmc.targetRepo + "/" + targetPath
+	uploaded, failed, err := servicesManager.UploadFiles(uploadParams)
+	if err != nil {
+		return err
+	}
+	if failed > 0 || uploaded == 0 {
+		return errorutils.CheckErrorf("failed uploading %s to %s", targetPath, pmc.targetRepo)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package terraform
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProvidersMirrorArgs(t *testing.T) {
+	targetRepo, platforms, err := ParseProvidersMirrorArgs([]string{"terraform-mirror", "--platform=linux_amd64", "--platform", "darwin_arm64"})
+	require.NoError(t, err)
+	assert.Equal(t, "terraform-mirror", targetRepo)
+	assert.Equal(t, []string{"linux_amd64", "darwin_arm64"}, platforms)
+
+	for _, args := range [][]string{{}, {"repo1", "repo2"}, {"repo", "--platform=linux"}, {"repo", "--platform"}, {"repo", "--arch=amd64"}} {
+		_, _, err = ParseProvidersMirrorArgs(args)
+		assert.Error(t, err, args)
+	}
+}
+
+func TestVerifyLockedHashes(t *testing.T) {
+	provider := &lockedProvider{Address: "registry.terraform.io/hashicorp/aws", Version: "5.31.0", Hashes: []string{"h1:content", "zh:zip"}}
+	assert.NoError(t, verifyLockedHashes(provider, "zip", "h1:other"))
+	assert.NoError(t, verifyLockedHashes(provider, "other", "h1:content"))
+	assert.Error(t, verifyLockedHashes(provider, "other", "h1:other"))
+	// A lock file without the hashes of the zip archives can't verify the packages of other platforms.
+	provider.Hashes = []string{"h1:content"}
+	assert.NoError(t, verifyLockedHashes(provider, "other", "h1:other"))
+}
+
+func TestDiscoverProvidersServiceAndDownload(t *testing.T) {
+	packageContent := []byte("package")
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case serviceDiscoveryPath:
+			_, _ = w.Write([]byte(`{"providers.v1":"/v1/providers"}`))
+		case "/v1/providers/hashicorp/aws/5.31.0/download/linux/amd64":
+			_, _ = w.Write([]byte(`{"filename":"terraform-provider-aws_5.31.0_linux_amd64.zip","download_url":"/files/aws.zip"}`))
+		case "/files/aws.zip":
+			_, _ = w.Write(packageContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	mirrorCommand := NewProvidersMirrorCommand()
+	mirrorCommand.httpClient = server.Client()
+	providersUrl, err := mirrorCommand.discoverProvidersService(serverUrl.Host)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/v1/providers/", providersUrl.String())
+
+	filePath := filepath.Join(t.TempDir(), "aws.zip")
+	checksum, err := mirrorCommand.download(server.URL+"/files/aws.zip", filePath)
+	require.NoError(t, err)
+	expectedChecksum := sha256.Sum256(packageContent)
+	assert.Equal(t, hex.EncodeToString(expectedChecksum[:]), checksum)
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, packageContent, content)
+}
@@ -0,0 +1,370 @@
+package terraform
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	cliConfigFileEnv = "TF_CLI_CONFIG_FILE"
+	chdirOption      = "-chdir"
+	// The public registry, whose modules are installed from the Artifactory repository.
+	publicRegistryHostname = "registry.terraform.io"
+
+	providerDependencyType = "provider"
+	moduleDependencyType   = "module"
+)
+
+// Runs any terraform command, other than publish, with a temporary CLI configuration file, which installs the providers through
+// the network mirror of the Artifactory Terraform repository, installs the modules of the public registry from the repository,
+// and holds the credentials of Artifactory. The CLI configuration file replaces the CLI configuration of the user while terraform runs.
+// The repository is the resolution repository, or the deployment repository if no resolution repository is configured.
+// If build-info collection was requested, the providers of the dependency lock file and the installed remote modules are recorded
+// as the dependencies of the module, which is named after the working directory.
+type TerraformCommand struct {
+	cmdName string
+	// The global options of terraform, which precede the command, such as -chdir.
+	globalOptions      []string
+	args               []string
+	configFilePath     string
+	executablePath     string
+	workingDirectory   string
+	repo               string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewTerraformCommand() *TerraformCommand {
+	return &TerraformCommand{}
+}
+
+func (tc *TerraformCommand) SetConfigFilePath(configFilePath string) *TerraformCommand {
+	tc.configFilePath = configFilePath
+	return tc
+}
+
+// The arguments include the global options and the command, such as: -chdir=infra init -upgrade.
+func (tc *TerraformCommand) SetArgs(args []string) *TerraformCommand {
+	tc.args = args
+	return tc
+}
+
+func (tc *TerraformCommand) ServerDetails() (*config.ServerDetails, error) {
+	return tc.serverDetails, nil
+}
+
+func (tc *TerraformCommand) CommandName() string {
+	return "rt_terraform_" + tc.cmdName
+}
+
+// Splits the arguments of the terraform command into the global options, the command name and the command arguments.
+func SplitArgs(args []string) (globalOptions []string, cmdName string, cmdArgs []string) {
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			return args[:i], arg, args[i+1:]
+		}
+	}
+	return args, "", nil
+}
+
+func (tc *TerraformCommand) Init() (err error) {
+	log.Debug("Preparing to read the config file", tc.configFilePath)
+	vConfig, err := project.ReadConfigFile(tc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	// The terraform config was created with a deployment repository only, before resolution was supported,
+	// so the deployment repository is used if no resolution repository is configured.
+	prefix := project.ProjectConfigResolverPrefix
+	if !vConfig.IsSet(prefix) {
+		if !vConfig.IsSet(project.ProjectConfigDeployerPrefix) {
+			return errorutils.CheckErrorf("neither a resolution nor a deployment repository is set in %s. Please run 'jf terraform-config'", tc.configFilePath)
+		}
+		prefix = project.ProjectConfigDeployerPrefix
+	}
+	repoConfig, err := project.GetRepoConfigByPrefix(tc.configFilePath, prefix, vConfig)
+	if err != nil {
+		return err
+	}
+	tc.repo = repoConfig.TargetRepo()
+	if tc.serverDetails, err = repoConfig.ServerDetails(); err != nil {
+		return err
+	}
+	var args []string
+	if args, tc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(tc.args); err != nil {
+		return err
+	}
+	tc.globalOptions, tc.cmdName, tc.args = SplitArgs(args)
+	return nil
+}
+
+func (tc *TerraformCommand) Run() (err error) {
+	log.Info("Running terraform " + tc.cmdName + "...")
+	if tc.executablePath, err = exec.LookPath("terraform"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if tc.workingDirectory, err = tc.getWorkingDirectory(); err != nil {
+		return err
+	}
+	collectBuildInfo, err := tc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	cliConfig, err := createCliConfig(tc.serverDetails, tc.repo)
+	if err != nil {
+		return err
+	}
+	cliConfigPath := filepath.Join(tempDir, "jfrog.tfrc")
+	if err = os.WriteFile(cliConfigPath, []byte(cliConfig), 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = tc.runTerraform(cliConfigPath); err != nil {
+		return err
+	}
+	if collectBuildInfo {
+		if err = tc.collectBuildInfo(); err != nil {
+			return err
+		}
+	}
+	log.Info("terraform " + tc.cmdName + " finished successfully.")
+	return nil
+}
+
+// Returns the directory of the terraform configuration, which is changed by the -chdir global option.
+func (tc *TerraformCommand) getWorkingDirectory() (string, error) {
+	workingDirectory, err := coreutils.GetWorkingDirectory()
+	if err != nil {
+		return "", err
+	}
+	for _, option := range tc.globalOptions {
+		chdir, found := strings.CutPrefix(strings.TrimPrefix(option, "-"), chdirOption+"=")
+		if !found {
+			continue
+		}
+		if filepath.IsAbs(chdir) {
+			return chdir, nil
+		}
+		return filepath.Join(workingDirectory, chdir), nil
+	}
+	return workingDirectory, nil
+}
+
+// Returns the CLI configuration, which installs all the providers through the network mirror of the repository,
+// and installs the modules of the public registry from the repository.
+// Terraform authenticates against the mirror and the module registry of Artifactory by the access token of the credentials block of its host.
+func createCliConfig(serverDetails *config.ServerDetails, repo string) (string, error) {
+	terraformApiUrl := strings.TrimSuffix(serverDetails.GetArtifactoryUrl(), "/") + "/api/terraform/" + repo
+	parsedUrl, err := url.Parse(terraformApiUrl)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	var builder strings.Builder
+	builder.WriteString("provider_installation {\n")
+	builder.WriteString("  network_mirror {\n")
+	builder.WriteString("    url = " + quote(terraformApiUrl+"/providers/") + "\n")
+	builder.WriteString("  }\n")
+	builder.WriteString("}\n\n")
+	builder.WriteString("host " + quote(publicRegistryHostname) + " {\n")
+	builder.WriteString("  services = {\n")
+	builder.WriteString("    \"modules.v1\" = " + quote(terraformApiUrl+"/v1/modules/") + "\n")
+	builder.WriteString("  }\n")
+	builder.WriteString("}\n")
+	// Artifactory accepts only access tokens as bearer tokens, so a password isn't written to the credentials block.
+	if token := serverDetails.GetAccessToken(); token != "" {
+		builder.WriteString("\ncredentials " + quote(parsedUrl.Host) + " {\n")
+		builder.WriteString("  token = " + quote(token) + "\n")
+		builder.WriteString("}\n")
+	} else {
+		log.Warn("No access token is configured for " + serverDetails.GetArtifactoryUrl() + ", so terraform isn't authenticated against Artifactory. " +
+			"Configure the server with an access token, or run 'terraform login " + parsedUrl.Host + "'.")
+	}
+	return builder.String(), nil
+}
+
+// Quotes an HCL string. The JSON escaping of a string is valid in HCL, except for the template sequences, which are escaped too.
+func quote(value string) string {
+	quoted, _ := json.Marshal(value)
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(string(quoted))
+}
+
+func (tc *TerraformCommand) runTerraform(cliConfigPath string) error {
+	if os.Getenv(cliConfigFileEnv) != "" {
+		log.Debug("The CLI configuration file of " + cliConfigFileEnv + " is replaced while terraform runs.")
+	}
+	command := exec.Command(tc.executablePath, append(append(append([]string(nil), tc.globalOptions...), tc.cmdName), tc.args...)...)
+	command.Env = append(os.Environ(), cliConfigFileEnv+"="+cliConfigPath)
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	if err := command.Run(); err != nil {
+		return errorutils.CheckErrorf("terraform %s failed: %s", tc.cmdName, err.Error())
+	}
+	return nil
+}
+
+func (tc *TerraformCommand) collectBuildInfo() error {
+	lockFilePath := filepath.Join(tc.workingDirectory, lockFileName)
+	exists, err := fileutils.IsFileExists(lockFilePath, false)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Warn("No " + lockFileName + " file was found in " + tc.workingDirectory + ", so no dependencies are added to the build-info. Run 'jf terraform init' first.")
+		return nil
+	}
+	log.Info("Collecting build-info from " + lockFilePath + "...")
+	providers, err := parseLockFile(lockFilePath)
+	if err != nil {
+		return err
+	}
+	modules, err := readInstalledModules(tc.workingDirectory)
+	if err != nil {
+		return err
+	}
+	dependencies, err := tc.getProviderDependencies(providers)
+	if err != nil {
+		return err
+	}
+	dependencies = append(dependencies, getModuleDependencies(modules)...)
+	if len(dependencies) == 0 {
+		log.Warn("No providers or remote modules were found, so no dependencies are added to the build-info.")
+		return nil
+	}
+	buildName, err := tc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := tc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := tc.buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	moduleId := tc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = filepath.Base(tc.workingDirectory)
+	}
+	return build.SavePartialBuildInfo(buildName, buildNumber, projectKey, func(partial *buildinfo.Partial) {
+		partial.ModuleType = buildinfo.Terraform
+		partial.ModuleId = moduleId
+		partial.Dependencies = dependencies
+	})
+}
+
+// The lock file holds the SHA256 checksums of the zip archives of the providers, for all the platforms which were locked.
+// The other checksums are read from Artifactory, by the archive which was installed through the mirror.
+func (tc *TerraformCommand) getProviderDependencies(providers []*lockedProvider) ([]buildinfo.Dependency, error) {
+	if len(providers) == 0 {
+		return nil, nil
+	}
+	var zipHashes []string
+	for _, provider := range providers {
+		zipHashes = append(zipHashes, provider.zipHashes()...)
+	}
+	checksumsBySha256 := make(map[string]buildinfo.Checksum)
+	if len(zipHashes) > 0 {
+		servicesManager, err := utils.CreateServiceManager(tc.serverDetails, -1, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		reader, err := servicesManager.Aql(createChecksumsQuery(zipHashes))
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(reader)
+		err = errors.Join(errorutils.CheckError(err), errorutils.CheckError(reader.Close()))
+		if err != nil {
+			return nil, err
+		}
+		if checksumsBySha256, err = parseChecksumsQueryResult(content); err != nil {
+			return nil, err
+		}
+	}
+	dependencies := make([]buildinfo.Dependency, 0, len(providers))
+	var missingDependencies []string
+	for _, provider := range providers {
+		dependency := buildinfo.Dependency{Id: provider.Address + ":" + provider.Version, Type: providerDependencyType}
+		for _, zipHash := range provider.zipHashes() {
+			if checksum, found := checksumsBySha256[zipHash]; found {
+				dependency.Checksum = checksum
+				break
+			}
+		}
+		if dependency.Checksum.Sha1 == "" {
+			missingDependencies = append(missingDependencies, dependency.Id)
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	commandUtils.PrintMissingDependencies(missingDependencies)
+	return dependencies, nil
+}
+
+func createChecksumsQuery(sha256Checksums []string) string {
+	conditions := make([]string, 0, len(sha256Checksums))
+	for _, sha256 := range sha256Checksums {
+		conditions = append(conditions, `{"sha256":"`+sha256+`"}`)
+	}
+	return `items.find({"$or":[` + strings.Join(conditions, ",") + `]}).include("sha256","actual_sha1","actual_md5")`
+}
+
+func parseChecksumsQueryResult(content []byte) (map[string]buildinfo.Checksum, error) {
+	var result struct {
+		Results []struct {
+			Sha256 string `json:"sha256"`
+			Sha1   string `json:"actual_sha1"`
+			Md5    string `json:"actual_md5"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(content, &result); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	checksumsBySha256 := make(map[string]buildinfo.Checksum, len(result.Results))
+	for _, item := range result.Results {
+		checksumsBySha256[item.Sha256] = buildinfo.Checksum{Sha1: item.Sha1, Md5: item.Md5, Sha256: item.Sha256}
+	}
+	return checksumsBySha256, nil
+}
+
+// The modules are recorded by their source address and version. The modules which weren't installed from a registry, such as the modules
+// of git repositories, have no version, and their source address includes the revision. The checksums of the modules aren't recorded.
+func getModuleDependencies(modules []installedModule) []buildinfo.Dependency {
+	var dependencies []buildinfo.Dependency
+	recorded := make(map[string]bool)
+	for _, module := range modules {
+		id := module.Source
+		if module.Version != "" {
+			id += ":" + module.Version
+		}
+		if recorded[id] {
+			continue
+		}
+		recorded[id] = true
+		dependencies = append(dependencies, buildinfo.Dependency{Id: id, Type: moduleDependencyType})
+	}
+	return dependencies
+}
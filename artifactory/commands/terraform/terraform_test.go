@@ -0,0 +1,74 @@
+package terraform
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitArgs(t *testing.T) {
+	globalOptions, cmdName, args := SplitArgs([]string{"-chdir=infra", "plan", "-out=plan.tfplan"})
+	assert.Equal(t, []string{"-chdir=infra"}, globalOptions)
+	assert.Equal(t, "plan", cmdName)
+	assert.Equal(t, []string{"-out=plan.tfplan"}, args)
+
+	globalOptions, cmdName, args = SplitArgs([]string{"-version"})
+	assert.Equal(t, []string{"-version"}, globalOptions)
+	assert.Empty(t, cmdName)
+	assert.Empty(t, args)
+}
+
+func TestCreateCliConfig(t *testing.T) {
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "admin", AccessToken: "token"}
+	cliConfig, err := createCliConfig(serverDetails, "terraform-virtual")
+	require.NoError(t, err)
+	assert.Equal(t, `provider_installation {
+  network_mirror {
+    url = "https://acme.jfrog.io/artifactory/api/terraform/terraform-virtual/providers/"
+  }
+}
+
+host "registry.terraform.io" {
+  services = {
+    "modules.v1" = "https://acme.jfrog.io/artifactory/api/terraform/terraform-virtual/v1/modules/"
+  }
+}
+
+credentials "acme.jfrog.io" {
+  token = "token"
+}
+`, cliConfig)
+
+	// Without an access token, there's no credentials block, and the password isn't used as a token.
+	cliConfig, err = createCliConfig(&config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "admin", Password: "password"}, "terraform-virtual")
+	require.NoError(t, err)
+	assert.NotContains(t, cliConfig, "credentials")
+	assert.NotContains(t, cliConfig, "password")
+}
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, `"a\"b\\c$${d}%%{e}"`, quote(`a"b\c${d}%{e}`))
+}
+
+func TestCreateChecksumsQuery(t *testing.T) {
+	assert.Equal(t, `items.find({"$or":[{"sha256":"abc"},{"sha256":"def"}]}).include("sha256","actual_sha1","actual_md5")`,
+		createChecksumsQuery([]string{"abc", "def"}))
+}
+
+func TestParseChecksumsQueryResult(t *testing.T) {
+	checksums, err := parseChecksumsQueryResult([]byte(`{"results":[{"sha256":"abc","actual_sha1":"sha1","actual_md5":"md5"}],"range":{"total":1}}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]buildinfo.Checksum{"abc": {Sha1: "sha1", Md5: "md5", Sha256: "abc"}}, checksums)
+}
+
+func TestGetModuleDependencies(t *testing.T) {
+	modules, err := readInstalledModules("testdata")
+	require.NoError(t, err)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "registry.terraform.io/terraform-aws-modules/vpc/aws:5.1.0", Type: moduleDependencyType},
+		{Id: "git::https://github.com/example/labels.git?ref=v1.0.0", Type: moduleDependencyType},
+	}, getModuleDependencies(modules))
+}
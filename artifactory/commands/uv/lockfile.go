@@ -0,0 +1,226 @@
+package uv
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const UvLockFileName = "uv.lock"
+
+type uvLockfile struct {
+	Packages []uvPackage `toml:"package"`
+}
+
+type uvPackage struct {
+	Name                 string                       `toml:"name"`
+	Version              string                       `toml:"version"`
+	Source               map[string]any               `toml:"source"`
+	Dependencies         []uvDependencyRef            `toml:"dependencies"`
+	OptionalDependencies map[string][]uvDependencyRef `toml:"optional-dependencies"`
+	DevDependencies      map[string][]uvDependencyRef `toml:"dev-dependencies"`
+	Sdist                *uvFile                      `toml:"sdist"`
+	Wheels               []uvFile                     `toml:"wheels"`
+}
+
+// A dependency of a package. The version and source are listed only if the lockfile includes several packages with the same name.
+type uvDependencyRef struct {
+	Name    string         `toml:"name"`
+	Version string         `toml:"version"`
+	Source  map[string]any `toml:"source"`
+}
+
+type uvFile struct {
+	Url  string `toml:"url"`
+	Hash string `toml:"hash"`
+}
+
+// The file name and SHA-256 checksum of a distribution of a package.
+type distribution struct {
+	FileName string
+	Sha256   string
+}
+
+// A dependency with the distributions which may have been installed, since uv.lock lists the distributions of all platforms.
+type lockedDependency struct {
+	buildinfo.Dependency
+	Distributions []distribution
+}
+
+func (up *uvPackage) id() string {
+	return normalizeName(up.Name) + ":" + up.Version
+}
+
+// Workspace members and path dependencies have a virtual, editable or directory source. Packages from Git aren't resolved from Artifactory either.
+func (up *uvPackage) isFromRegistry() bool {
+	_, exists := up.Source["registry"]
+	return exists
+}
+
+func (up *uvPackage) isLocal() bool {
+	for _, sourceType := range []string{"virtual", "editable", "directory"} {
+		if _, exists := up.Source[sourceType]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+func (up *uvPackage) distributions() []distribution {
+	var distributions []distribution
+	for _, file := range append(append([]uvFile(nil), up.Wheels...), derefFile(up.Sdist)...) {
+		if sha256, found := strings.CutPrefix(file.Hash, "sha256:"); found {
+			distributions = append(distributions, distribution{FileName: file.Url[strings.LastIndex(file.Url, "/")+1:], Sha256: sha256})
+		}
+	}
+	return distributions
+}
+
+func derefFile(file *uvFile) []uvFile {
+	if file == nil {
+		return nil
+	}
+	return []uvFile{*file}
+}
+
+// Package names are normalized as defined by PEP 503.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(name))
+}
+
+// Reads uv.lock, and returns the registry dependencies of the workspace members, which are required directly or transitively.
+// Dependencies which are required only through the dev-dependencies of the members have the dev scope.
+func ParseLockfile(lockfilePath string) ([]lockedDependency, error) {
+	lockfile := new(uvLockfile)
+	if _, err := toml.DecodeFile(lockfilePath, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing %s: %s", lockfilePath, err.Error())
+	}
+	return lockfile.dependencies(), nil
+}
+
+func (ul *uvLockfile) dependencies() []lockedDependency {
+	packagesByName := make(map[string][]*uvPackage)
+	for i := range ul.Packages {
+		pkg := &ul.Packages[i]
+		packagesByName[normalizeName(pkg.Name)] = append(packagesByName[normalizeName(pkg.Name)], pkg)
+	}
+	type queuedPackage struct {
+		pkg         *uvPackage
+		scope       string
+		requestedBy []string
+	}
+	// The roots are the local packages which aren't required by other packages, such as the workspace members.
+	// Path dependencies are traversed like other packages, but aren't recorded as dependencies, since they aren't resolved from Artifactory.
+	requiredPackages := make(map[*uvPackage]bool)
+	for i := range ul.Packages {
+		for _, ref := range append(ul.Packages[i].allDependencies(false), ul.Packages[i].allDependencies(true)...) {
+			requiredPackages[resolveDependencyRef(ref, packagesByName)] = true
+		}
+	}
+	var queue []queuedPackage
+	for i := range ul.Packages {
+		pkg := &ul.Packages[i]
+		if !pkg.isLocal() || requiredPackages[pkg] {
+			continue
+		}
+		requestedBy := []string{pkg.id()}
+		for _, ref := range pkg.allDependencies(false) {
+			if dependency := resolveDependencyRef(ref, packagesByName); dependency != nil {
+				queue = append(queue, queuedPackage{dependency, "prod", requestedBy})
+			}
+		}
+		for _, ref := range pkg.allDependencies(true) {
+			if dependency := resolveDependencyRef(ref, packagesByName); dependency != nil {
+				queue = append(queue, queuedPackage{dependency, "dev", requestedBy})
+			}
+		}
+	}
+
+	dependencies := make(map[*uvPackage]*lockedDependency)
+	visited := make(map[*uvPackage]map[string]bool)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current.pkg.isFromRegistry() {
+			dependency, exists := dependencies[current.pkg]
+			if !exists {
+				dependency = &lockedDependency{Dependency: buildinfo.Dependency{Id: current.pkg.id()}, Distributions: current.pkg.distributions()}
+				dependencies[current.pkg] = dependency
+			}
+			if !containsString(dependency.Scopes, current.scope) {
+				dependency.Scopes = append(dependency.Scopes, current.scope)
+			}
+			dependency.RequestedBy = append(dependency.RequestedBy, current.requestedBy)
+		}
+		// Packages are traversed once per scope, so that the production dependencies of dev dependencies get the dev scope too.
+		if visited[current.pkg] == nil {
+			visited[current.pkg] = make(map[string]bool)
+		}
+		if visited[current.pkg][current.scope] {
+			continue
+		}
+		visited[current.pkg][current.scope] = true
+		requestedBy := append([]string{current.pkg.id()}, current.requestedBy...)
+		for _, ref := range current.pkg.allDependencies(false) {
+			if dependency := resolveDependencyRef(ref, packagesByName); dependency != nil {
+				queue = append(queue, queuedPackage{dependency, current.scope, requestedBy})
+			}
+		}
+	}
+
+	result := make([]lockedDependency, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		sort.Strings(dependency.Scopes)
+		result = append(result, *dependency)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
+
+// Returns the dependencies of the package, including the optional dependencies (extras), or only its dev dependencies if dev is true.
+func (up *uvPackage) allDependencies(dev bool) []uvDependencyRef {
+	if dev {
+		var refs []uvDependencyRef
+		for _, group := range sortedKeys(up.DevDependencies) {
+			refs = append(refs, up.DevDependencies[group]...)
+		}
+		return refs
+	}
+	refs := append([]uvDependencyRef(nil), up.Dependencies...)
+	for _, extra := range sortedKeys(up.OptionalDependencies) {
+		refs = append(refs, up.OptionalDependencies[extra]...)
+	}
+	return refs
+}
+
+func resolveDependencyRef(ref uvDependencyRef, packagesByName map[string][]*uvPackage) *uvPackage {
+	for _, candidate := range packagesByName[normalizeName(ref.Name)] {
+		if ref.Version == "" || candidate.Version == ref.Version {
+			return candidate
+		}
+	}
+	return nil
+}
+
+func sortedKeys(groups map[string][]uvDependencyRef) []string {
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(values []string, value string) bool {
+	for _, existing := range values {
+		if existing == value {
+			return true
+		}
+	}
+	return false
+}
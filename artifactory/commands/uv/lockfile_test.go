@@ -0,0 +1,131 @@
+package uv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const uvLockContent = `version = 1
+requires-python = ">=3.12"
+
+[[package]]
+name = "my-app"
+version = "1.0.0"
+source = { editable = "." }
+dependencies = [
+    { name = "requests" },
+    { name = "local-lib" },
+]
+
+[package.optional-dependencies]
+socks = [
+    { name = "pysocks" },
+]
+
+[package.dev-dependencies]
+dev = [
+    { name = "pytest" },
+]
+
+[[package]]
+name = "local-lib"
+version = "0.1.0"
+source = { directory = "libs/local-lib" }
+dependencies = [
+    { name = "certifi" },
+]
+
+[[package]]
+name = "requests"
+version = "2.32.3"
+source = { registry = "https://pypi.org/simple" }
+dependencies = [
+    { name = "certifi" },
+]
+sdist = { url = "https://files.pythonhosted.org/packages/requests-2.32.3.tar.gz", hash = "sha256:55365417734eb18255590a9ff9eb97e9e1da868d4ccd6402399eaf68af20a760", size = 131218 }
+wheels = [
+    { url = "https://files.pythonhosted.org/packages/requests-2.32.3-py3-none-any.whl", hash = "sha256:70761cfe03c773ceb22aa2f671b4757976145175cdfca038c02654d061d6dcc6", size = 64928 },
+]
+
+[[package]]
+name = "certifi"
+version = "2024.8.30"
+source = { registry = "https://pypi.org/simple" }
+wheels = [
+    { url = "https://files.pythonhosted.org/packages/certifi-2024.8.30-py3-none-any.whl", hash = "sha256:922820b53db7a7257ffbda3f597266d435245903d80737e34f8a45ff3e3230d8", size = 167321 },
+]
+
+[[package]]
+name = "PySocks"
+version = "1.7.1"
+source = { registry = "https://pypi.org/simple" }
+wheels = [
+    { url = "https://files.pythonhosted.org/packages/PySocks-1.7.1-py3-none-any.whl", hash = "sha256:2725bd0a9925919b9b51739eea5f9e2bae91e83288108a9ad338b2e3a4435ee5", size = 16725 },
+]
+
+[[package]]
+name = "pytest"
+version = "8.3.3"
+source = { registry = "https://pypi.org/simple" }
+dependencies = [
+    { name = "certifi" },
+]
+wheels = [
+    { url = "https://files.pythonhosted.org/packages/pytest-8.3.3-py3-none-any.whl", hash = "sha256:a6853c7375b2663155079443d2e45de913a911a11d669df02a50814944db57b2", size = 342341 },
+]
+`
+
+func TestParseLockfile(t *testing.T) {
+	lockfilePath := filepath.Join(t.TempDir(), UvLockFileName)
+	require.NoError(t, os.WriteFile(lockfilePath, []byte(uvLockContent), 0644))
+
+	dependencies, err := ParseLockfile(lockfilePath)
+	require.NoError(t, err)
+	expected := []lockedDependency{
+		{
+			Dependency: entities.Dependency{
+				Id:     "certifi:2024.8.30",
+				Scopes: []string{"dev", "prod"},
+				RequestedBy: [][]string{
+					{"requests:2.32.3", "my-app:1.0.0"},
+					{"local-lib:0.1.0", "my-app:1.0.0"},
+					{"pytest:8.3.3", "my-app:1.0.0"},
+				},
+			},
+			Distributions: []distribution{{FileName: "certifi-2024.8.30-py3-none-any.whl", Sha256: "922820b53db7a7257ffbda3f597266d435245903d80737e34f8a45ff3e3230d8"}},
+		},
+		{
+			Dependency: entities.Dependency{
+				Id:          "pysocks:1.7.1",
+				Scopes:      []string{"prod"},
+				RequestedBy: [][]string{{"my-app:1.0.0"}},
+			},
+			Distributions: []distribution{{FileName: "PySocks-1.7.1-py3-none-any.whl", Sha256: "2725bd0a9925919b9b51739eea5f9e2bae91e83288108a9ad338b2e3a4435ee5"}},
+		},
+		{
+			Dependency: entities.Dependency{
+				Id:          "pytest:8.3.3",
+				Scopes:      []string{"dev"},
+				RequestedBy: [][]string{{"my-app:1.0.0"}},
+			},
+			Distributions: []distribution{{FileName: "pytest-8.3.3-py3-none-any.whl", Sha256: "a6853c7375b2663155079443d2e45de913a911a11d669df02a50814944db57b2"}},
+		},
+		{
+			Dependency: entities.Dependency{
+				Id:          "requests:2.32.3",
+				Scopes:      []string{"prod"},
+				RequestedBy: [][]string{{"my-app:1.0.0"}},
+			},
+			Distributions: []distribution{
+				{FileName: "requests-2.32.3-py3-none-any.whl", Sha256: "70761cfe03c773ceb22aa2f671b4757976145175cdfca038c02654d061d6dcc6"},
+				{FileName: "requests-2.32.3.tar.gz", Sha256: "55365417734eb18255590a9ff9eb97e9e1da868d4ccd6402399eaf68af20a760"},
+			},
+		},
+	}
+	assert.Equal(t, expected, dependencies)
+}
@@ -0,0 +1,367 @@
+package uv
+
+import (
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/checksums"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	PyprojectFileName = "pyproject.toml"
+	// The files which 'uv publish' uploads, if no files are passed to it.
+	defaultPublishPattern = "dist/*"
+)
+
+// The commands which install packages into the project environment, so their dependencies are collected.
+var syncCommands = map[string]bool{"sync": true, "add": true, "remove": true}
+
+// The options of 'uv publish' which are followed by a value.
+var publishFlagsWithValue = map[string]bool{
+	"--publish-url": true, "--check-url": true, "--index": true, "-u": true, "--username": true, "-p": true, "--password": true,
+	"-t": true, "--token": true, "--trusted-publishing": true, "--keyring-provider": true, "--directory": true, "--project": true,
+	"--cache-dir": true, "--config-file": true, "--color": true, "--python": true, "--allow-insecure-host": true,
+}
+
+// Runs any uv command, with the packages resolved from an Artifactory PyPI repository, which replaces the default index.
+// The publish command uploads the distributions to the deployment repository.
+// If build-info collection was requested, the dependencies are collected from uv.lock, and the published distributions are recorded as artifacts.
+type UvCommand struct {
+	cmdName               string
+	args                  []string
+	configFilePath        string
+	executablePath        string
+	workingDirectory      string
+	repo                  string
+	deployRepo            string
+	serverDetails         *config.ServerDetails
+	deployerServerDetails *config.ServerDetails
+	buildConfiguration    *build.BuildConfiguration
+}
+
+func NewUvCommand(cmdName string) *UvCommand {
+	return &UvCommand{cmdName: cmdName}
+}
+
+func (uc *UvCommand) SetConfigFilePath(configFilePath string) *UvCommand {
+	uc.configFilePath = configFilePath
+	return uc
+}
+
+func (uc *UvCommand) SetArgs(args []string) *UvCommand {
+	uc.args = args
+	return uc
+}
+
+func (uc *UvCommand) ServerDetails() (*config.ServerDetails, error) {
+	if uc.isPublish() {
+		return uc.deployerServerDetails, nil
+	}
+	return uc.serverDetails, nil
+}
+
+func (uc *UvCommand) CommandName() string {
+	return "rt_uv_" + uc.cmdName
+}
+
+func (uc *UvCommand) isPublish() bool {
+	return uc.cmdName == "publish"
+}
+
+// The resolution repository is required by all commands but publish, which requires the deployment repository.
+func (uc *UvCommand) Init() (err error) {
+	log.Debug("Preparing to read the config file", uc.configFilePath)
+	vConfig, err := project.ReadConfigFile(uc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	if uc.isPublish() {
+		deployerParams, err := project.GetRepoConfigByPrefix(uc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		uc.deployRepo = deployerParams.TargetRepo()
+		if uc.deployerServerDetails, err = deployerParams.ServerDetails(); err != nil {
+			return err
+		}
+	} else {
+		resolverParams, err := project.GetRepoConfigByPrefix(uc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		uc.repo = resolverParams.TargetRepo()
+		if uc.serverDetails, err = resolverParams.ServerDetails(); err != nil {
+			return err
+		}
+	}
+	uc.args, uc.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(uc.args)
+	return err
+}
+
+func (uc *UvCommand) Run() (err error) {
+	log.Info("Running uv " + uc.cmdName + "...")
+	if uc.executablePath, err = exec.LookPath("uv"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if uc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return err
+	}
+	collectBuildInfo, err := uc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if uc.isPublish() {
+		err = uc.publish(collectBuildInfo)
+	} else {
+		err = uc.runWithResolution(collectBuildInfo)
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("uv " + uc.cmdName + " finished successfully.")
+	return nil
+}
+
+// The default index is replaced by the Artifactory repository. uv reads the credentials of the index from its URL.
+// UV_DEFAULT_INDEX replaces UV_INDEX_URL in recent versions of uv, so both are set.
+func (uc *UvCommand) runWithResolution(collectBuildInfo bool) error {
+	indexUrl, err := getIndexUrlWithCredentials(uc.serverDetails, uc.repo)
+	if err != nil {
+		return err
+	}
+	env := append(os.Environ(), "UV_INDEX_URL="+indexUrl, "UV_DEFAULT_INDEX="+indexUrl)
+	return projectconfig.RunWithResolution(func() error {
+		return uc.runUv(env, append([]string{uc.cmdName}, uc.args...)...)
+	}, collectBuildInfo, syncCommands[uc.cmdName], uc.collectDependencies)
+}
+
+func GetIndexUrl(artifactoryUrl, repo string) string {
+	return strings.TrimSuffix(artifactoryUrl, "/") + "/api/pypi/" + repo + "/simple"
+}
+
+func getIndexUrlWithCredentials(serverDetails *config.ServerDetails, repo string) (string, error) {
+	indexUrl, err := url.Parse(GetIndexUrl(serverDetails.GetArtifactoryUrl(), repo))
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if username, password := projectconfig.GetBasicAuthCredentials(serverDetails); password != "" {
+		indexUrl.User = url.UserPassword(username, password)
+	}
+	return indexUrl.String(), nil
+}
+
+func (uc *UvCommand) runUv(env []string, args ...string) error {
+	command := exec.Command(uc.executablePath, args...)
+	command.Dir = uc.workingDirectory
+	command.Env = env
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	if err := command.Run(); err != nil {
+		return errorutils.CheckErrorf("uv %s failed: %s", uc.cmdName, err.Error())
+	}
+	return nil
+}
+
+func (uc *UvCommand) collectDependencies() error {
+	lockfilePath := filepath.Join(uc.workingDirectory, UvLockFileName)
+	if !projectconfig.LockfileExists(lockfilePath) {
+		return nil
+	}
+	lockedDependencies, err := ParseLockfile(lockfilePath)
+	if err != nil {
+		return err
+	}
+	dependencies, err := uc.getDependenciesWithChecksums(lockedDependencies)
+	if err != nil {
+		return err
+	}
+	moduleId, err := uc.getModuleId()
+	if err != nil {
+		return err
+	}
+	return projectconfig.SaveModule(uc.buildConfiguration, buildinfo.Python, moduleId, func(partial *buildinfo.Partial) {
+		partial.Dependencies = dependencies
+	})
+}
+
+// uv.lock lists the distributions of all platforms. The distribution of a dependency is the first one which is found in Artifactory by its SHA-256 checksum.
+func (uc *UvCommand) getDependenciesWithChecksums(lockedDependencies []lockedDependency) ([]buildinfo.Dependency, error) {
+	servicesManager, err := utils.CreateServiceManager(uc.serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	var sha256s []string
+	for _, dependency := range lockedDependencies {
+		for _, dist := range dependency.Distributions {
+			sha256s = append(sha256s, dist.Sha256)
+		}
+	}
+	found, err := checksums.Search(servicesManager, checksums.Sha256Field, sha256s)
+	if err != nil {
+		return nil, err
+	}
+	dependencies := make([]buildinfo.Dependency, 0, len(lockedDependencies))
+	var missingDependencies []string
+	for _, locked := range lockedDependencies {
+		dependency := locked.Dependency
+		for _, dist := range locked.Distributions {
+			if checksum, exists := found[strings.ToLower(dist.Sha256)]; exists {
+				dependency.Checksum = checksum
+				dependency.Type = getDistributionType(dist.FileName)
+				break
+			}
+		}
+		if dependency.Sha256 == "" {
+			missingDependencies = append(missingDependencies, dependency.Id)
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	commandUtils.PrintMissingDependencies(missingDependencies)
+	return dependencies, nil
+}
+
+// Returns the type of the distribution from its file name: whl for wheels, and the archive extension for source distributions.
+func getDistributionType(fileName string) string {
+	for _, extension := range []string{".whl", ".tar.gz", ".zip", ".tar.bz2"} {
+		if strings.HasSuffix(fileName, extension) {
+			return strings.TrimPrefix(extension, ".")
+		}
+	}
+	return filepath.Ext(fileName)
+}
+
+// Runs 'uv publish' against the deployment repository, with the credentials passed through the environment.
+func (uc *UvCommand) publish(collectBuildInfo bool) error {
+	env := append(os.Environ(), "UV_PUBLISH_URL="+GetPublishUrl(uc.deployerServerDetails.GetArtifactoryUrl(), uc.deployRepo))
+	if username, password := projectconfig.GetBasicAuthCredentials(uc.deployerServerDetails); password != "" {
+		env = append(env, "UV_PUBLISH_USERNAME="+username, "UV_PUBLISH_PASSWORD="+password)
+	}
+	if err := uc.runUv(env, append([]string{uc.cmdName}, uc.args...)...); err != nil {
+		return err
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	distributionFiles, err := getPublishedFiles(uc.workingDirectory, uc.args)
+	if err != nil {
+		return err
+	}
+	var artifacts []buildinfo.Artifact
+	for _, distributionFile := range distributionFiles {
+		fileDetails, err := fileutils.GetFileDetails(distributionFile, true)
+		if err != nil {
+			return err
+		}
+		fileName := filepath.Base(distributionFile)
+		name, version := splitDistributionFileName(fileName)
+		artifacts = append(artifacts, buildinfo.Artifact{
+			Name:                   fileName,
+			Type:                   getDistributionType(fileName),
+			Path:                   name + "/" + version + "/" + fileName,
+			OriginalDeploymentRepo: uc.deployRepo,
+			Checksum:               buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256},
+		})
+	}
+	moduleId := uc.buildConfiguration.GetModule()
+	if moduleId == "" && len(artifacts) > 0 {
+		name, version := splitDistributionFileName(artifacts[0].Name)
+		moduleId = name + ":" + version
+	}
+	return projectconfig.SaveModule(uc.buildConfiguration, buildinfo.Python, moduleId, func(partial *buildinfo.Partial) {
+		partial.Artifacts = artifacts
+	})
+}
+
+func GetPublishUrl(artifactoryUrl, repo string) string {
+	return strings.TrimSuffix(artifactoryUrl, "/") + "/api/pypi/" + repo
+}
+
+// Returns the distributions which 'uv publish' uploaded: the files which match the patterns passed to it, or dist/* by default.
+func getPublishedFiles(workingDirectory string, args []string) ([]string, error) {
+	var patterns []string
+	for i := 0; i < len(args); i++ {
+		if strings.HasPrefix(args[i], "-") {
+			if publishFlagsWithValue[args[i]] {
+				i++
+			}
+			continue
+		}
+		patterns = append(patterns, args[i])
+	}
+	if len(patterns) == 0 {
+		patterns = []string{defaultPublishPattern}
+	}
+	var files []string
+	for _, pattern := range patterns {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(workingDirectory, pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		for _, match := range matches {
+			if distributionType := getDistributionType(match); distributionType == "whl" || distributionType == "tar.gz" || distributionType == "zip" {
+				files = append(files, match)
+			}
+		}
+	}
+	return files, nil
+}
+
+// Returns the normalized name and the version of the distribution from its file name.
+// Wheels are named <name>-<version>(-<build>)?-<python>-<abi>-<platform>.whl, and source distributions are named <name>-<version>.tar.gz.
+func splitDistributionFileName(fileName string) (name, version string) {
+	if strings.HasSuffix(fileName, ".whl") {
+		parts := strings.Split(strings.TrimSuffix(fileName, ".whl"), "-")
+		if len(parts) < 2 {
+			return normalizeName(parts[0]), ""
+		}
+		return normalizeName(parts[0]), parts[1]
+	}
+	baseName := strings.TrimSuffix(fileName, "."+getDistributionType(fileName))
+	separatorIndex := strings.LastIndex(baseName, "-")
+	if separatorIndex < 0 {
+		return normalizeName(baseName), ""
+	}
+	return normalizeName(baseName[:separatorIndex]), baseName[separatorIndex+1:]
+}
+
+// The module ID is taken from the --module option, or from the project name and version in pyproject.toml.
+func (uc *UvCommand) getModuleId() (string, error) {
+	if uc.buildConfiguration.GetModule() != "" {
+		return uc.buildConfiguration.GetModule(), nil
+	}
+	pyprojectPath := filepath.Join(uc.workingDirectory, PyprojectFileName)
+	var pyproject struct {
+		Project struct {
+			Name    string `toml:"name"`
+			Version string `toml:"version"`
+		} `toml:"project"`
+	}
+	if _, err := toml.DecodeFile(pyprojectPath, &pyproject); err != nil && !os.IsNotExist(err) {
+		return "", errorutils.CheckErrorf("failed parsing %s: %s", pyprojectPath, err.Error())
+	}
+	if pyproject.Project.Name == "" {
+		return filepath.Base(uc.workingDirectory), nil
+	}
+	if pyproject.Project.Version == "" {
+		return normalizeName(pyproject.Project.Name), nil
+	}
+	return normalizeName(pyproject.Project.Name) + ":" + pyproject.Project.Version, nil
+}
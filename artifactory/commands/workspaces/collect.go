@@ -0,0 +1,248 @@
+package workspaces
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	prodScope = "prod"
+	devScope  = "dev"
+)
+
+// A package which a dependency is resolved to.
+type resolvedPackage struct {
+	// Identifies the package in the lockfile.
+	key string
+	// The build-info ID of the package: 'name:version'.
+	id string
+	// The version ranges of the dependencies of the package, by their names.
+	dependencies map[string]string
+}
+
+// A lockfile which resolves the dependencies of the packages.
+// Returns false if the dependency isn't a registry package, or if it isn't found in the lockfile.
+type lockfile interface {
+	resolve(parentKey, name, versionRange string) (*resolvedPackage, bool)
+}
+
+// Returns the dependencies of the package, by traversing the lockfile from its dependencies in package.json.
+// The dependencies are identified by 'name:version', the same way npm dependencies are identified in the build-info.
+func getWorkspaceDependencies(lock lockfile, workspace *Workspace) []buildinfo.Dependency {
+	dependencies := make(map[string]*buildinfo.Dependency)
+	for _, scopedDeps := range []struct {
+		scope string
+		deps  []map[string]string
+	}{
+		{prodScope, []map[string]string{workspace.Dependencies, workspace.OptionalDependencies}},
+		{devScope, []map[string]string{workspace.DevDependencies}},
+	} {
+		root := &resolvedPackage{key: workspace.RelPath, id: workspace.ModuleId(), dependencies: map[string]string{}}
+		for _, deps := range scopedDeps.deps {
+			for name, versionRange := range deps {
+				root.dependencies[name] = versionRange
+			}
+		}
+		traverse(lock, root, scopedDeps.scope, dependencies)
+	}
+	result := make([]buildinfo.Dependency, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		result = append(result, *dependency)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
+
+type traversedPackage struct {
+	*resolvedPackage
+	// The first path from this package to the root package, used for the 'requestedBy' field of its dependencies.
+	pathToRoot []string
+}
+
+// Walks the dependency graph from the root package breadth-first, and adds the visited packages to the dependencies map.
+func traverse(lock lockfile, root *resolvedPackage, scope string, dependencies map[string]*buildinfo.Dependency) {
+	visited := map[string]bool{}
+	queue := []*traversedPackage{{resolvedPackage: root}}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		requestedBy := append([]string{parent.id}, parent.pathToRoot...)
+		names := make([]string, 0, len(parent.dependencies))
+		for name := range parent.dependencies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			child, ok := lock.resolve(parent.key, name, parent.dependencies[name])
+			if !ok {
+				continue
+			}
+			addDependency(dependencies, child.id, scope, requestedBy)
+			if visited[child.key] {
+				continue
+			}
+			visited[child.key] = true
+			queue = append(queue, &traversedPackage{resolvedPackage: child, pathToRoot: requestedBy})
+		}
+	}
+}
+
+func addDependency(dependencies map[string]*buildinfo.Dependency, id, scope string, requestedBy []string) {
+	dependency, exists := dependencies[id]
+	if !exists {
+		dependency = &buildinfo.Dependency{Id: id}
+		dependencies[id] = dependency
+	}
+	if !slices.Contains(dependency.Scopes, scope) {
+		dependency.Scopes = append(dependency.Scopes, scope)
+	}
+	for _, existing := range dependency.RequestedBy {
+		if strings.Join(existing, ",") == strings.Join(requestedBy, ",") {
+			return
+		}
+	}
+	dependency.RequestedBy = append(dependency.RequestedBy, requestedBy)
+}
+
+// Reads package-lock.json or yarn.lock from the root directory of the workspaces.
+func readLockfile(rootDir string) (lockfile, error) {
+	packageLockPath := filepath.Join(rootDir, PackageLockFileName)
+	if _, err := os.Stat(packageLockPath); err == nil {
+		return readPackageLock(packageLockPath)
+	}
+	yarnLockPath := filepath.Join(rootDir, YarnLockFileName)
+	if _, err := os.Stat(yarnLockPath); err == nil {
+		return readYarnLock(yarnLockPath)
+	}
+	return nil, errorutils.CheckErrorf("neither %s nor %s could be found in %s", PackageLockFileName, YarnLockFileName, rootDir)
+}
+
+// Records the dependencies of the root project and of each of its workspaces, in a build-info module per package.
+// The root project is recorded only if it has dependencies of its own. The dependencies are read from package-lock.json or yarn.lock.
+func CollectDependencies(rootDir string, serverDetails *config.ServerDetails, buildConfiguration *build.BuildConfiguration) error {
+	root, workspaces, err := ReadWorkspaces(rootDir)
+	if err != nil {
+		return err
+	}
+	lock, err := readLockfile(rootDir)
+	if err != nil {
+		return err
+	}
+	log.Info("Collecting the dependencies of", len(workspaces), "workspaces...")
+	dependenciesByModule := make(map[*Workspace][]buildinfo.Dependency)
+	packages := workspaces
+	if rootDependencies := getWorkspaceDependencies(lock, root); len(rootDependencies) > 0 {
+		dependenciesByModule[root] = rootDependencies
+		packages = append([]*Workspace{root}, workspaces...)
+	}
+	for _, workspace := range workspaces {
+		dependenciesByModule[workspace] = getWorkspaceDependencies(lock, workspace)
+	}
+
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	if err = setChecksums(serverDetails, buildName, dependenciesByModule); err != nil {
+		return err
+	}
+	projectKey := buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	for _, workspace := range packages {
+		log.Debug("Recording the module", workspace.ModuleId(), "with", len(dependenciesByModule[workspace]), "dependencies.")
+		if err = build.SavePartialBuildInfo(buildName, buildNumber, projectKey, func(partial *buildinfo.Partial) {
+			partial.ModuleType = buildinfo.Npm
+			partial.ModuleId = workspace.ModuleId()
+			partial.Dependencies = dependenciesByModule[workspace]
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sets the checksums of the dependencies of all the modules, using the dependencies of the latest build and Artifactory's npm properties.
+// Each dependency is looked up once, even if several workspaces depend on it.
+func setChecksums(serverDetails *config.ServerDetails, buildName string, dependenciesByModule map[*Workspace][]buildinfo.Dependency) error {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	previousBuildDependencies, err := commandUtils.GetDependenciesFromLatestBuild(servicesManager, buildName)
+	if err != nil {
+		return err
+	}
+	missingDepsChan := make(chan string)
+	var missingDependencies []string
+	done := make(chan bool)
+	go func() {
+		for depId := range missingDepsChan {
+			missingDependencies = append(missingDependencies, depId)
+		}
+		done <- true
+	}()
+	collectChecksumsFunc := commandUtils.CreateCollectChecksumsFunc(previousBuildDependencies, servicesManager, missingDepsChan)
+	checksums := make(map[string]*buildinfo.Checksum)
+	for _, dependencies := range dependenciesByModule {
+		for i := range dependencies {
+			checksum, collected := checksums[dependencies[i].Id]
+			if !collected {
+				if _, err = collectChecksumsFunc(&dependencies[i]); err != nil {
+					break
+				}
+				checksum = &dependencies[i].Checksum
+				checksums[dependencies[i].Id] = checksum
+			}
+			dependencies[i].Checksum = *checksum
+		}
+		if err != nil {
+			break
+		}
+	}
+	close(missingDepsChan)
+	<-done
+	if err != nil {
+		return err
+	}
+	commandUtils.PrintMissingDependencies(missingDependencies)
+	return nil
+}
+
+// Returns true if the dependencies should be collected into a build-info module per workspace: when the build-info is collected
+// for all the dependencies of a workspaces project, rather than for specific packages, and the --module option isn't provided.
+// The installation of specific packages is recognized by the arguments of the install command which aren't flags.
+func IsWorkspacesBuildInfo(rootDir string, buildConfiguration *build.BuildConfiguration, installArgs []string) (bool, error) {
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo || buildConfiguration.GetModule() != "" {
+		return false, err
+	}
+	for _, arg := range installArgs {
+		if !strings.HasPrefix(arg, "-") {
+			return false, nil
+		}
+	}
+	if _, err = os.Stat(filepath.Join(rootDir, packageJsonFileName)); err != nil {
+		return false, nil
+	}
+	_, workspaces, err := ReadWorkspaces(rootDir)
+	return len(workspaces) > 0, err
+}
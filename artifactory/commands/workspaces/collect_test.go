@@ -0,0 +1,47 @@
+package workspaces
+
+import (
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWorkspaceDependenciesFromPackageLock(t *testing.T) {
+	rootDir, err := filepath.Abs(filepath.Join("testdata", "npm"))
+	require.NoError(t, err)
+	root, workspaces, err := ReadWorkspaces(rootDir)
+	require.NoError(t, err)
+	lock, err := readLockfile(rootDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "typescript:5.4.5", Scopes: []string{devScope}, RequestedBy: [][]string{{"monorepo:1.0.0"}}},
+	}, getWorkspaceDependencies(lock, root))
+	// The workspace dependency @acme/b is a module of its own.
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "debug:4.3.4", Scopes: []string{prodScope}, RequestedBy: [][]string{{"acme:a:1.2.0"}}},
+		{Id: "lodash:4.17.21", Scopes: []string{prodScope}, RequestedBy: [][]string{{"acme:a:1.2.0"}}},
+		{Id: "ms:2.1.2", Scopes: []string{prodScope}, RequestedBy: [][]string{{"debug:4.3.4", "acme:a:1.2.0"}}},
+	}, getWorkspaceDependencies(lock, workspaces[0]))
+	// The nested lodash of the workspace is resolved before the hoisted one.
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "lodash:3.10.1", Scopes: []string{devScope}, RequestedBy: [][]string{{"acme:b:2.0.0"}}},
+		{Id: "ms:2.1.3", Scopes: []string{prodScope}, RequestedBy: [][]string{{"acme:b:2.0.0"}}},
+	}, getWorkspaceDependencies(lock, workspaces[1]))
+}
+
+func TestReadPackageLockUnsupportedVersion(t *testing.T) {
+	lockFilePath := filepath.Join(t.TempDir(), PackageLockFileName)
+	writeFile(t, lockFilePath, `{"lockfileVersion": 1, "dependencies": {}}`)
+	_, err := readPackageLock(lockFilePath)
+	assert.ErrorContains(t, err, "isn't supported for workspaces")
+}
+
+func TestParentDir(t *testing.T) {
+	assert.Equal(t, "packages/a/node_modules", parentDir("packages/a/node_modules/debug"))
+	assert.Equal(t, "packages", parentDir("packages/a"))
+	assert.Empty(t, parentDir("packages"))
+}
@@ -0,0 +1,78 @@
+package workspaces
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const PackageLockFileName = "package-lock.json"
+
+// The parts of package-lock.json which are needed for collecting the dependencies.
+// Only lockfile versions 2 and 3 are supported, since they list the installed packages by their location in the node_modules tree.
+type packageLock struct {
+	LockfileVersion int `json:"lockfileVersion"`
+	// The packages by their location, relative to the root project. The root project is listed with an empty location.
+	Packages map[string]packageLockEntry `json:"packages"`
+}
+
+type packageLockEntry struct {
+	// The name is only set for aliased packages, whose location doesn't end with the actual name.
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Link                 bool              `json:"link"`
+	Dependencies         map[string]string `json:"dependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+func readPackageLock(lockFilePath string) (*packageLock, error) {
+	content, err := os.ReadFile(lockFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	lock := new(packageLock)
+	if err = json.Unmarshal(content, lock); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing %s: %s", lockFilePath, err.Error())
+	}
+	if lock.LockfileVersion < 2 {
+		return nil, errorutils.CheckErrorf("%s version %d isn't supported for workspaces. Run the install command with npm 7 or above to upgrade it", PackageLockFileName, lock.LockfileVersion)
+	}
+	return lock, nil
+}
+
+// Resolves the dependency the way Node.js does, from the node_modules directory of the requesting package up to the node_modules directory of the root project.
+// The workspaces, which are linked into node_modules, aren't resolved, since each of them is a module of its own.
+func (lock *packageLock) resolve(parentKey, name, _ string) (*resolvedPackage, bool) {
+	for dir := parentKey; ; dir = parentDir(dir) {
+		location := path.Join(dir, "node_modules", name)
+		if entry, exists := lock.Packages[location]; exists {
+			if entry.Link || entry.Version == "" {
+				return nil, false
+			}
+			actualName := entry.Name
+			if actualName == "" {
+				actualName = name
+			}
+			dependencies := make(map[string]string)
+			for _, deps := range []map[string]string{entry.Dependencies, entry.OptionalDependencies, entry.PeerDependencies} {
+				for depName, depRange := range deps {
+					dependencies[depName] = depRange
+				}
+			}
+			return &resolvedPackage{key: location, id: actualName + ":" + entry.Version, dependencies: dependencies}, true
+		}
+		if dir == "" {
+			return nil, false
+		}
+	}
+}
+
+func parentDir(dir string) string {
+	if parent := path.Dir(dir); parent != "." {
+		return parent
+	}
+	return ""
+}
@@ -0,0 +1,287 @@
+package workspaces
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/npm"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Publishes the packages of the workspaces of the current directory, whose versions aren't in the deployment repository yet.
+// The private packages are skipped. Each package is published by the 'jf npm publish' command in its directory,
+// so it's recorded as an artifact of its own build-info module.
+type NpmPublishCommand struct {
+	configFilePath string
+	// The npm publish arguments, without the workspaces options.
+	args []string
+	// The names or paths of the workspaces to publish, from the --workspace options. All the workspaces are published if empty.
+	selectedWorkspaces []string
+	detailedSummary    bool
+	xrayScan           bool
+	repo               string
+	serverDetails      *config.ServerDetails
+	result             *commandUtils.Result
+}
+
+func NewNpmPublishCommand() *NpmPublishCommand {
+	return &NpmPublishCommand{result: new(commandUtils.Result)}
+}
+
+func (npc *NpmPublishCommand) SetConfigFilePath(configFilePath string) *NpmPublishCommand {
+	npc.configFilePath = configFilePath
+	return npc
+}
+
+func (npc *NpmPublishCommand) SetArgs(args []string) *NpmPublishCommand {
+	npc.args = args
+	return npc
+}
+
+func (npc *NpmPublishCommand) SetSelectedWorkspaces(selectedWorkspaces []string) *NpmPublishCommand {
+	npc.selectedWorkspaces = selectedWorkspaces
+	return npc
+}
+
+func (npc *NpmPublishCommand) SetDetailedSummary(detailedSummary bool) *NpmPublishCommand {
+	npc.detailedSummary = detailedSummary
+	return npc
+}
+
+func (npc *NpmPublishCommand) IsDetailedSummary() bool {
+	return npc.detailedSummary
+}
+
+func (npc *NpmPublishCommand) GetXrayScan() bool {
+	return npc.xrayScan
+}
+
+func (npc *NpmPublishCommand) Result() *commandUtils.Result {
+	return npc.result
+}
+
+func (npc *NpmPublishCommand) ServerDetails() (*config.ServerDetails, error) {
+	return npc.serverDetails, nil
+}
+
+func (npc *NpmPublishCommand) CommandName() string {
+	return "rt_npm_publish_workspaces"
+}
+
+// Returns true if the arguments include the --workspaces or --workspace options of npm publish, and returns the arguments without them,
+// and the workspaces of the --workspace options.
+func ExtractWorkspacesOptions(args []string) (publishWorkspaces bool, selectedWorkspaces, otherArgs []string, err error) {
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		switch name {
+		case "--workspaces", "--ws":
+			if hasValue && value != "true" {
+				if value != "false" {
+					return false, nil, nil, errorutils.CheckErrorf("invalid value for %s: %s", name, value)
+				}
+				continue
+			}
+			publishWorkspaces = true
+		case "--workspace", "-w":
+			if !hasValue {
+				if i+1 == len(args) || strings.HasPrefix(args[i+1], "-") {
+					return false, nil, nil, errorutils.CheckErrorf("the %s option requires a workspace name or path", name)
+				}
+				i++
+				value = args[i]
+			}
+			publishWorkspaces = true
+			selectedWorkspaces = append(selectedWorkspaces, value)
+		default:
+			otherArgs = append(otherArgs, args[i])
+		}
+	}
+	return
+}
+
+func (npc *NpmPublishCommand) Init() error {
+	detailedSummary, xrayScan, _, filteredArgs, _, err := commandUtils.ExtractNpmOptionsFromArgs(npc.args)
+	if err != nil {
+		return err
+	}
+	if len(filteredArgs) > 0 && !strings.HasPrefix(filteredArgs[0], "-") {
+		return errorutils.CheckErrorf("a package path can't be provided when publishing the workspaces, since the packages are published from the directories of the workspaces")
+	}
+	npc.detailedSummary, npc.xrayScan = detailedSummary, xrayScan
+	vConfig, err := project.ReadConfigFile(npc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	deployerParams, err := project.GetRepoConfigByPrefix(npc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	npc.repo = deployerParams.TargetRepo()
+	npc.serverDetails, err = deployerParams.ServerDetails()
+	return err
+}
+
+func (npc *NpmPublishCommand) Run() (err error) {
+	rootDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, workspaces, err := ReadWorkspaces(rootDir)
+	if err != nil {
+		return err
+	}
+	if len(workspaces) == 0 {
+		return errorutils.CheckErrorf("no workspaces were found in the package.json file of %s", rootDir)
+	}
+	if workspaces, err = filterWorkspaces(workspaces, npc.selectedWorkspaces); err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(npc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	changedWorkspaces, err := getUnpublishedWorkspaces(servicesManager, npc.repo, workspaces)
+	if err != nil {
+		return err
+	}
+	if len(changedWorkspaces) == 0 {
+		log.Info("The versions of all the workspace packages are already published. There is nothing to publish.")
+		return nil
+	}
+	var plan []string
+	for _, workspace := range changedWorkspaces {
+		plan = append(plan, workspace.FullName()+"@"+workspace.Version)
+	}
+	log.Info("Publishing", len(changedWorkspaces), "workspace packages:\n  "+strings.Join(plan, "\n  "))
+
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(os.Chdir(rootDir)))
+	}()
+	var readers []*content.ContentReader
+	defer func() {
+		if len(readers) > 0 {
+			npc.setResultReader(readers)
+		}
+	}()
+	for _, workspace := range changedWorkspaces {
+		// The module of the published package is named after the package.json file of the current directory.
+		if err = os.Chdir(workspace.Dir); err != nil {
+			return errorutils.CheckError(err)
+		}
+		publishCmd := npm.NewNpmPublishCommand()
+		publishCmd.SetConfigFilePath(npc.configFilePath).SetArgs(npc.args)
+		if err = publishCmd.Init(); err != nil {
+			return err
+		}
+		publishCmd.SetDetailedSummary(npc.detailedSummary)
+		err = publishCmd.Run()
+		result := publishCmd.Result()
+		npc.result.SetSuccessCount(npc.result.SuccessCount() + result.SuccessCount())
+		npc.result.SetFailCount(npc.result.FailCount() + result.FailCount())
+		if result.Reader() != nil {
+			readers = append(readers, result.Reader())
+		}
+		if err != nil {
+			return errors.Join(errorutils.CheckErrorf("failed publishing %s@%s", workspace.FullName(), workspace.Version), err)
+		}
+	}
+	return nil
+}
+
+// Returns the workspaces which are selected by their names or by their paths. All the workspaces are returned if none is selected.
+func filterWorkspaces(workspaces []*Workspace, selected []string) ([]*Workspace, error) {
+	if len(selected) == 0 {
+		return workspaces, nil
+	}
+	var filtered []*Workspace
+	for _, selection := range selected {
+		relPath := strings.TrimPrefix(path.Clean(strings.ReplaceAll(selection, "\\", "/")), "./")
+		found := false
+		for _, workspace := range workspaces {
+			if workspace.FullName() == selection || workspace.RelPath == relPath {
+				filtered = append(filtered, workspace)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errorutils.CheckErrorf("no workspace named %s was found", selection)
+		}
+	}
+	return filtered, nil
+}
+
+// Returns the public workspace packages, whose versions aren't in the repository.
+func getUnpublishedWorkspaces(servicesManager artifactory.ArtifactoryServicesManager, repo string, workspaces []*Workspace) ([]*Workspace, error) {
+	var unpublished []*Workspace
+	for _, workspace := range workspaces {
+		if workspace.Private {
+			log.Debug("Skipping the private package", workspace.FullName())
+			continue
+		}
+		if workspace.Name == "" || workspace.Version == "" {
+			log.Warn("Skipping the workspace " + workspace.RelPath + ", since the name or the version is missing from its package.json file.")
+			continue
+		}
+		published, err := isPublished(servicesManager, repo, workspace)
+		if err != nil {
+			return nil, err
+		}
+		if published {
+			log.Info(fmt.Sprintf("%s@%s is already published. Skipping it.", workspace.FullName(), workspace.Version))
+			continue
+		}
+		unpublished = append(unpublished, workspace)
+	}
+	return unpublished, nil
+}
+
+func isPublished(servicesManager artifactory.ArtifactoryServicesManager, repo string, workspace *Workspace) (bool, error) {
+	stream, err := servicesManager.Aql(createPublishedPackageQuery(repo, workspace.GetDeployPath()))
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+	var result struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	if err = json.NewDecoder(stream).Decode(&result); err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	return len(result.Results) > 0, nil
+}
+
+func createPublishedPackageQuery(repo, deployPath string) string {
+	itemPath, itemName := path.Split(deployPath)
+	query := map[string]string{"repo": repo, "path": strings.TrimSuffix(itemPath, "/"), "name": itemName}
+	queryJson, _ := json.Marshal(query)
+	return fmt.Sprintf(`items.find(%s).include("name")`, queryJson)
+}
+
+// The transfer details of all the packages are merged into one reader, for the detailed summary.
+func (npc *NpmPublishCommand) setResultReader(readers []*content.ContentReader) {
+	mergedReader, err := content.MergeReaders(readers, content.DefaultKey)
+	if err != nil {
+		log.Warn("Failed merging the transfer details of the packages: " + err.Error())
+		return
+	}
+	for _, reader := range readers {
+		if closeErr := reader.Close(); closeErr != nil {
+			log.Debug("Failed closing the transfer details reader: " + closeErr.Error())
+		}
+	}
+	npc.result.SetReader(mergedReader)
+}
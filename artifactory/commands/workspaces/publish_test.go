@@ -0,0 +1,54 @@
+package workspaces
+
+import (
+	"testing"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractWorkspacesOptions(t *testing.T) {
+	publishWorkspaces, selected, otherArgs, err := ExtractWorkspacesOptions([]string{"--workspaces", "--tag=next", "--build-name=b"})
+	require.NoError(t, err)
+	assert.True(t, publishWorkspaces)
+	assert.Empty(t, selected)
+	assert.Equal(t, []string{"--tag=next", "--build-name=b"}, otherArgs)
+
+	publishWorkspaces, selected, otherArgs, err = ExtractWorkspacesOptions([]string{"-w", "@acme/a", "--workspace=packages/b"})
+	require.NoError(t, err)
+	assert.True(t, publishWorkspaces)
+	assert.Equal(t, []string{"@acme/a", "packages/b"}, selected)
+	assert.Empty(t, otherArgs)
+
+	publishWorkspaces, _, otherArgs, err = ExtractWorkspacesOptions([]string{"--ws=false", "--access", "public"})
+	require.NoError(t, err)
+	assert.False(t, publishWorkspaces)
+	assert.Equal(t, []string{"--access", "public"}, otherArgs)
+
+	_, _, _, err = ExtractWorkspacesOptions([]string{"--workspace"})
+	assert.Error(t, err)
+}
+
+func TestFilterWorkspaces(t *testing.T) {
+	workspaces := []*Workspace{
+		{PackageInfo: &biutils.PackageInfo{Name: "a", Scope: "@acme"}, RelPath: "packages/a"},
+		{PackageInfo: &biutils.PackageInfo{Name: "b"}, RelPath: "packages/b"},
+	}
+	filtered, err := filterWorkspaces(workspaces, nil)
+	require.NoError(t, err)
+	assert.Equal(t, workspaces, filtered)
+
+	filtered, err = filterWorkspaces(workspaces, []string{"./packages/b", "@acme/a"})
+	require.NoError(t, err)
+	assert.Equal(t, []*Workspace{workspaces[1], workspaces[0]}, filtered)
+
+	_, err = filterWorkspaces(workspaces, []string{"c"})
+	assert.ErrorContains(t, err, "no workspace named c")
+}
+
+func TestCreatePublishedPackageQuery(t *testing.T) {
+	workspace := &Workspace{PackageInfo: &biutils.PackageInfo{Name: "a", Scope: "@acme", Version: "1.2.0"}}
+	assert.Equal(t, `items.find({"name":"a-1.2.0.tgz","path":"@acme/a/-","repo":"npm-local"}).include("name")`,
+		createPublishedPackageQuery("npm-local", workspace.GetDeployPath()))
+}
@@ -0,0 +1,177 @@
+package workspaces
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const packageJsonFileName = "package.json"
+
+// A package of an npm or yarn workspaces project. The root project is a package too, which has an empty relative path.
+type Workspace struct {
+	*biutils.PackageInfo
+	// The absolute path of the directory of package.json.
+	Dir string
+	// The slash-separated path of the directory, relative to the root project.
+	RelPath string
+	Private bool
+}
+
+// Returns the name of the package, including its scope.
+func (ws *Workspace) FullName() string {
+	if ws.Scope == "" {
+		return ws.Name
+	}
+	return ws.Scope + "/" + ws.Name
+}
+
+// Returns the ID of the build-info module of the package. Packages without a name or a version are named after their directory.
+func (ws *Workspace) ModuleId() string {
+	if moduleId := ws.BuildInfoModuleId(); moduleId != "" {
+		return moduleId
+	}
+	return filepath.Base(ws.Dir)
+}
+
+// The fields of package.json which aren't read by biutils.PackageInfo.
+type workspacesPackageJson struct {
+	Private bool `json:"private"`
+	// Either a list of patterns, or an object with the patterns in its 'packages' field, as yarn accepts.
+	Workspaces json.RawMessage `json:"workspaces"`
+}
+
+func readWorkspace(rootDir, dir string) (*Workspace, *workspacesPackageJson, error) {
+	content, err := os.ReadFile(filepath.Join(dir, packageJsonFileName))
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	packageInfo, err := biutils.ReadPackageInfo(content, nil)
+	if err != nil {
+		return nil, nil, errorutils.CheckErrorf("failed parsing %s: %s", filepath.Join(dir, packageJsonFileName), err.Error())
+	}
+	packageJson := new(workspacesPackageJson)
+	if err = json.Unmarshal(content, packageJson); err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	relPath, err := filepath.Rel(rootDir, dir)
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	if relPath == "." {
+		relPath = ""
+	}
+	return &Workspace{PackageInfo: packageInfo, Dir: dir, RelPath: filepath.ToSlash(relPath), Private: packageJson.Private}, packageJson, nil
+}
+
+func (pj *workspacesPackageJson) patterns() ([]string, error) {
+	if len(pj.Workspaces) == 0 {
+		return nil, nil
+	}
+	var patterns []string
+	if err := json.Unmarshal(pj.Workspaces, &patterns); err == nil {
+		return patterns, nil
+	}
+	var workspacesObject struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(pj.Workspaces, &workspacesObject); err != nil {
+		return nil, errorutils.CheckErrorf("the workspaces field of package.json should be a list of patterns or an object with a packages list: %s", err.Error())
+	}
+	return workspacesObject.Packages, nil
+}
+
+// Returns the root project of the directory and the packages of its workspaces, sorted by their paths.
+// The packages are matched by the patterns of the 'workspaces' field of package.json, including the '**' patterns and the '!' exclusions.
+// If the root project has no workspaces, nil is returned.
+func ReadWorkspaces(rootDir string) (root *Workspace, workspaces []*Workspace, err error) {
+	root, packageJson, err := readWorkspace(rootDir, rootDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	patterns, err := packageJson.patterns()
+	if err != nil || len(patterns) == 0 {
+		return root, nil, err
+	}
+	err = filepath.WalkDir(rootDir, func(dirPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() || dirPath == rootDir {
+			return nil
+		}
+		if name := entry.Name(); name == "node_modules" || strings.HasPrefix(name, ".") {
+			return filepath.SkipDir
+		}
+		relPath, err := filepath.Rel(rootDir, dirPath)
+		if err != nil || !matchWorkspacePatterns(patterns, filepath.ToSlash(relPath)) {
+			return err
+		}
+		if _, err = os.Stat(filepath.Join(dirPath, packageJsonFileName)); err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		workspace, _, err := readWorkspace(rootDir, dirPath)
+		if err != nil {
+			return err
+		}
+		workspaces = append(workspaces, workspace)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	sort.Slice(workspaces, func(i, j int) bool {
+		return workspaces[i].RelPath < workspaces[j].RelPath
+	})
+	return root, workspaces, nil
+}
+
+// Returns true if the path matches any of the patterns, and doesn't match any of the exclusion patterns which follow it.
+func matchWorkspacePatterns(patterns []string, relPath string) bool {
+	matched := false
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(strings.TrimPrefix(path.Clean(filepath.ToSlash(pattern)), "./"), "/")
+		if exclusion := strings.TrimPrefix(pattern, "!"); exclusion != pattern {
+			if matched && matchGlob(strings.Split(exclusion, "/"), strings.Split(relPath, "/")) {
+				matched = false
+			}
+			continue
+		}
+		if !matched {
+			matched = matchGlob(strings.Split(pattern, "/"), strings.Split(relPath, "/"))
+		}
+	}
+	return matched
+}
+
+// Matches the path segments against the pattern segments, where '**' matches any number of segments.
+func matchGlob(patternSegments, pathSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(pathSegments) == 0
+	}
+	if patternSegments[0] == "**" {
+		for i := 0; i <= len(pathSegments); i++ {
+			if matchGlob(patternSegments[1:], pathSegments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(pathSegments) == 0 {
+		return false
+	}
+	if matched, err := path.Match(patternSegments[0], pathSegments[0]); err != nil || !matched {
+		return false
+	}
+	return matchGlob(patternSegments[1:], pathSegments[1:])
+}
@@ -0,0 +1,64 @@
+package workspaces
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadWorkspaces(t *testing.T) {
+	rootDir, err := filepath.Abs(filepath.Join("testdata", "npm"))
+	require.NoError(t, err)
+	root, workspaces, err := ReadWorkspaces(rootDir)
+	require.NoError(t, err)
+	assert.Equal(t, "monorepo:1.0.0", root.ModuleId())
+	assert.Empty(t, root.RelPath)
+	assert.True(t, root.Private)
+
+	require.Len(t, workspaces, 2)
+	assert.Equal(t, "packages/a", workspaces[0].RelPath)
+	assert.Equal(t, "@acme/a", workspaces[0].FullName())
+	assert.Equal(t, "acme:a:1.2.0", workspaces[0].ModuleId())
+	assert.Equal(t, filepath.Join(rootDir, "packages", "a"), workspaces[0].Dir)
+	assert.Equal(t, "packages/b", workspaces[1].RelPath)
+
+	// A project without workspaces.
+	_, workspaces, err = ReadWorkspaces(filepath.Join(rootDir, "packages", "a"))
+	require.NoError(t, err)
+	assert.Empty(t, workspaces)
+}
+
+func TestMatchWorkspacePatterns(t *testing.T) {
+	testCases := []struct {
+		patterns []string
+		relPath  string
+		expected bool
+	}{
+		{[]string{"packages/*"}, "packages/a", true},
+		{[]string{"packages/*"}, "packages/a/b", false},
+		{[]string{"./packages/*/"}, "packages/a", true},
+		{[]string{"packages/**"}, "packages/a/b", true},
+		{[]string{"apps/**/web"}, "apps/web", true},
+		{[]string{"apps/**/web"}, "apps/shop/web", true},
+		{[]string{"apps/**/web"}, "apps/shop/api", false},
+		{[]string{"packages/*", "!packages/internal"}, "packages/internal", false},
+		{[]string{"packages/*", "!packages/internal", "packages/internal"}, "packages/internal", true},
+		{[]string{"tools"}, "tools", true},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, matchWorkspacePatterns(testCase.patterns, testCase.relPath), testCase)
+	}
+}
+
+func TestWorkspacesPatterns(t *testing.T) {
+	packageJson := &workspacesPackageJson{Workspaces: []byte(`{"packages":["packages/*"],"nohoist":["**/react"]}`)}
+	patterns, err := packageJson.patterns()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"packages/*"}, patterns)
+
+	packageJson.Workspaces = []byte(`"packages/*"`)
+	_, err = packageJson.patterns()
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package workspaces
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/yarn"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Runs yarn in the root of a workspaces project, with the dependencies resolved from Artifactory, the same way 'jf yarn' does.
+// The dependencies are collected from yarn.lock into a build-info module per workspace, rather than into a single module of the root project.
+type YarnCommand struct {
+	configFilePath     string
+	args               []string
+	repo               string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewYarnCommand() *YarnCommand {
+	return &YarnCommand{}
+}
+
+func (yc *YarnCommand) SetConfigFilePath(configFilePath string) *YarnCommand {
+	yc.configFilePath = configFilePath
+	return yc
+}
+
+func (yc *YarnCommand) SetArgs(args []string) *YarnCommand {
+	yc.args = args
+	return yc
+}
+
+func (yc *YarnCommand) ServerDetails() (*config.ServerDetails, error) {
+	return yc.serverDetails, nil
+}
+
+func (yc *YarnCommand) CommandName() string {
+	return "rt_yarn_workspaces"
+}
+
+func (yc *YarnCommand) Init() (err error) {
+	log.Debug("Preparing to read the config file", yc.configFilePath)
+	vConfig, err := project.ReadConfigFile(yc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	resolverParams, err := project.GetRepoConfigByPrefix(yc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	yc.repo = resolverParams.TargetRepo()
+	if yc.serverDetails, err = resolverParams.ServerDetails(); err != nil {
+		return err
+	}
+	_, _, _, _, yc.args, yc.buildConfiguration, err = commandUtils.ExtractYarnOptionsFromArgs(yc.args)
+	return err
+}
+
+func (yc *YarnCommand) Run() (err error) {
+	log.Info("Running Yarn...")
+	executablePath, err := exec.LookPath("yarn")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	workingDirectory, err := coreutils.GetWorkingDirectory()
+	if err != nil {
+		return err
+	}
+	registry, npmAuthIdent, npmAuthToken, err := yarn.GetYarnAuthDetails(yc.serverDetails, yc.repo)
+	if err != nil {
+		return err
+	}
+	restoreYarnrcFunc, err := ioutils.BackupFile(filepath.Join(workingDirectory, yarn.YarnrcFileName), yarn.YarnrcBackupFileName)
+	if err != nil {
+		return err
+	}
+	backupEnvMap, err := yarn.ModifyYarnConfigurations(executablePath, registry, npmAuthIdent, npmAuthToken)
+	if err != nil {
+		return errors.Join(err, restoreYarnrcFunc())
+	}
+	defer func() {
+		err = errors.Join(err, yarn.RestoreConfigurationsFromBackup(backupEnvMap, restoreYarnrcFunc))
+	}()
+
+	command := exec.Command(executablePath, yc.args...)
+	command.Dir = workingDirectory
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err = command.Run(); err != nil {
+		return errorutils.CheckErrorf("yarn failed: %s", err.Error())
+	}
+	collectBuildInfo, err := yc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if collectBuildInfo {
+		if err = CollectDependencies(workingDirectory, yc.serverDetails, yc.buildConfiguration); err != nil {
+			return err
+		}
+	}
+	log.Info("Yarn finished successfully.")
+	return nil
+}
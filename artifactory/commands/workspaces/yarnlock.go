@@ -0,0 +1,129 @@
+package workspaces
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const YarnLockFileName = "yarn.lock"
+
+// A package of yarn.lock. The lockfile of yarn v1 and the YAML lockfile of yarn v2 and above are both supported.
+type yarnLockEntry struct {
+	Version string
+	// The locator of the package, which is only written by yarn v2 and above. For example: lodash@npm:4.17.21 or app@workspace:packages/app
+	Resolution   string
+	Dependencies map[string]string
+}
+
+// The packages of yarn.lock, by each of their descriptors. For example: lodash@^4.17.21 in yarn v1, or lodash@npm:^4.17.21 in yarn v2 and above.
+type yarnLock map[string]*yarnLockEntry
+
+func readYarnLock(lockFilePath string) (yarnLock, error) {
+	file, err := os.Open(lockFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	lock := make(yarnLock)
+	var entry *yarnLockEntry
+	var section string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		switch indent := len(line) - len(strings.TrimLeft(line, " ")); {
+		case indent == 0:
+			entry = parseYarnLockDescriptors(lock, strings.TrimSuffix(trimmed, ":"))
+		case entry == nil:
+			continue
+		case indent == 2:
+			key, value := parseYarnLockKeyValue(trimmed)
+			section = ""
+			switch key {
+			case "version":
+				entry.Version = value
+			case "resolution":
+				entry.Resolution = value
+			case "dependencies", "optionalDependencies":
+				section = key
+			}
+		case indent == 4 && section != "":
+			name, versionRange := parseYarnLockKeyValue(trimmed)
+			entry.Dependencies[name] = versionRange
+		}
+	}
+	return lock, errorutils.CheckError(scanner.Err())
+}
+
+// Adds an entry to the lock for all the descriptors of the line, and returns it.
+// Yarn v1 quotes each descriptor separately, while yarn v2 and above quote the whole list of descriptors.
+func parseYarnLockDescriptors(lock yarnLock, line string) *yarnLockEntry {
+	if line == "__metadata" {
+		return nil
+	}
+	entry := &yarnLockEntry{Dependencies: map[string]string{}}
+	for _, descriptor := range strings.Split(line, ",") {
+		lock[strings.Trim(strings.TrimSpace(descriptor), `"`)] = entry
+	}
+	return entry
+}
+
+// Parses the key and the value of a property line, which are separated by a space in yarn v1 and by a colon in yarn v2 and above.
+func parseYarnLockKeyValue(line string) (key, value string) {
+	rest := line
+	if strings.HasPrefix(line, `"`) {
+		if end := strings.Index(line[1:], `"`); end >= 0 {
+			key, rest = line[1:end+1], line[end+2:]
+		}
+	} else {
+		key, rest, _ = strings.Cut(line, " ")
+		key = strings.TrimSuffix(key, ":")
+	}
+	value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rest), ":"))
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	return
+}
+
+// Resolves the dependency by its descriptor. The workspaces and the local packages aren't resolved.
+func (lock yarnLock) resolve(_, name, versionRange string) (*resolvedPackage, bool) {
+	for _, protocol := range []string{"workspace:", "link:", "portal:", "file:"} {
+		if strings.HasPrefix(versionRange, protocol) {
+			return nil, false
+		}
+	}
+	entry, exists := lock[name+"@"+versionRange]
+	if !exists {
+		// Yarn v2 and above add the npm protocol to the descriptors of registry packages.
+		if entry, exists = lock[name+"@npm:"+versionRange]; !exists {
+			return nil, false
+		}
+	}
+	if strings.Contains(entry.Resolution, "@workspace:") || entry.Version == "" {
+		return nil, false
+	}
+	actualName := name
+	if entry.Resolution != "" {
+		// The name in the locator is the actual name of an aliased package. For example: string-width@npm:4.2.3
+		if separator := strings.Index(entry.Resolution[1:], "@"); separator >= 0 {
+			actualName = entry.Resolution[:separator+1]
+		}
+	} else if alias := strings.TrimPrefix(versionRange, "npm:"); alias != versionRange {
+		// For example: npm:string-width@^4.2.0
+		if separator := strings.LastIndex(alias, "@"); separator > 0 {
+			actualName = alias[:separator]
+		}
+	}
+	id := actualName + ":" + entry.Version
+	return &resolvedPackage{key: id, id: id, dependencies: entry.Dependencies}, true
+}
@@ -0,0 +1,125 @@
+package workspaces
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const yarnLockV1 = `# THIS IS AN AUTOGENERATED FILE. DO NOT EDIT THIS FILE DIRECTLY.
+# yarn lockfile v1
+
+
+"@babel/code-frame@^7.0.0":
+  version "7.24.2"
+  resolved "https://registry.yarnpkg.com/@babel/code-frame/-/code-frame-7.24.2.tgz#5a"
+  integrity sha512-a
+  dependencies:
+    js-tokens "^4.0.0"
+
+js-tokens@^4.0.0, "js-tokens@^3.0.0 || ^4.0.0":
+  version "4.0.0"
+  resolved "https://registry.yarnpkg.com/js-tokens/-/js-tokens-4.0.0.tgz#19"
+  integrity sha512-b
+
+"string-width-cjs@npm:string-width@^4.2.0":
+  version "4.2.3"
+  resolved "https://registry.yarnpkg.com/string-width/-/string-width-4.2.3.tgz#26"
+  integrity sha512-c
+`
+
+const yarnLockBerry = `# This file is generated by running "yarn install" inside your project.
+# Manual changes might be lost - proceed with caution!
+
+__metadata:
+  version: 8
+  cacheKey: 10c0
+
+"@acme/a@workspace:packages/a":
+  version: 0.0.0-use.local
+  resolution: "@acme/a@workspace:packages/a"
+  dependencies:
+    "@acme/b": "workspace:^"
+    "@babel/code-frame": "npm:^7.0.0"
+    string-width-cjs: "npm:string-width@^4.2.0"
+  languageName: unknown
+  linkType: soft
+
+"@acme/b@workspace:^, @acme/b@workspace:packages/b":
+  version: 0.0.0-use.local
+  resolution: "@acme/b@workspace:packages/b"
+  languageName: unknown
+  linkType: soft
+
+"@babel/code-frame@npm:^7.0.0":
+  version: 7.24.2
+  resolution: "@babel/code-frame@npm:7.24.2"
+  dependencies:
+    js-tokens: "npm:^4.0.0"
+  dependenciesMeta:
+    js-tokens:
+      optional: true
+  checksum: 10c0/d1d4
+  languageName: node
+  linkType: hard
+
+"js-tokens@npm:^3.0.0 || ^4.0.0, js-tokens@npm:^4.0.0":
+  version: 4.0.0
+  resolution: "js-tokens@npm:4.0.0"
+  checksum: 10c0/e248
+  languageName: node
+  linkType: hard
+
+"string-width-cjs@npm:string-width@^4.2.0":
+  version: 4.2.3
+  resolution: "string-width@npm:4.2.3"
+  checksum: 10c0/1e52
+  languageName: node
+  linkType: hard
+`
+
+func TestReadYarnLock(t *testing.T) {
+	workspace := &Workspace{PackageInfo: &biutils.PackageInfo{Name: "a", Scope: "@acme", Version: "1.0.0",
+		Dependencies: map[string]string{"@acme/b": "workspace:^", "@babel/code-frame": "^7.0.0", "string-width-cjs": "npm:string-width@^4.2.0"}}}
+	expected := []buildinfo.Dependency{
+		{Id: "@babel/code-frame:7.24.2", Scopes: []string{prodScope}, RequestedBy: [][]string{{"acme:a:1.0.0"}}},
+		{Id: "js-tokens:4.0.0", Scopes: []string{prodScope}, RequestedBy: [][]string{{"@babel/code-frame:7.24.2", "acme:a:1.0.0"}}},
+		{Id: "string-width:4.2.3", Scopes: []string{prodScope}, RequestedBy: [][]string{{"acme:a:1.0.0"}}},
+	}
+	for name, content := range map[string]string{"v1": yarnLockV1, "berry": yarnLockBerry} {
+		t.Run(name, func(t *testing.T) {
+			lockFilePath := filepath.Join(t.TempDir(), YarnLockFileName)
+			writeFile(t, lockFilePath, content)
+			lock, err := readYarnLock(lockFilePath)
+			require.NoError(t, err)
+			assert.Equal(t, expected, getWorkspaceDependencies(lock, workspace))
+		})
+	}
+}
+
+func TestParseYarnLockKeyValue(t *testing.T) {
+	testCases := []struct {
+		line, expectedKey, expectedValue string
+	}{
+		{`version "4.0.0"`, "version", "4.0.0"},
+		{`"@babel/code-frame" "^7.0.0"`, "@babel/code-frame", "^7.0.0"},
+		{`version: 4.0.0`, "version", "4.0.0"},
+		{`"@babel/code-frame": "npm:^7.0.0"`, "@babel/code-frame", "npm:^7.0.0"},
+		{`js-tokens: "npm:^3.0.0 || ^4.0.0"`, "js-tokens", "npm:^3.0.0 || ^4.0.0"},
+		{`dependencies:`, "dependencies", ""},
+	}
+	for _, testCase := range testCases {
+		key, value := parseYarnLockKeyValue(testCase.line)
+		assert.Equal(t, testCase.expectedKey, key, testCase.line)
+		assert.Equal(t, testCase.expectedValue, value, testCase.line)
+	}
+}
+
+func writeFile(t *testing.T, filePath, content string) {
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+}
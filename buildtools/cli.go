@@ -30,33 +30,72 @@ import (
 	securityCLI "github.com/jfrog/jfrog-cli-security/cli"
 	securityDocs "github.com/jfrog/jfrog-cli-security/cli/docs"
 	"github.com/jfrog/jfrog-cli-security/commands/scan"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/bazel"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/cargo"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/composer"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/conan"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/conda"
+	containercmd "github.com/jfrog/jfrog-cli/artifactory/commands/container"
+	dotnetcmd "github.com/jfrog/jfrog-cli/artifactory/commands/dotnet"
+	gopublishcmd "github.com/jfrog/jfrog-cli/artifactory/commands/gopublish"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/helm"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/pnpm"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/ruby"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/sbt"
+	terraformcmd "github.com/jfrog/jfrog-cli/artifactory/commands/terraform"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/uv"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/workspaces"
 	terraformdocs "github.com/jfrog/jfrog-cli/docs/artifactory/terraform"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/terraformconfig"
+	bazeldocs "github.com/jfrog/jfrog-cli/docs/buildtools/bazel"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/bazelconfig"
+	bundledocs "github.com/jfrog/jfrog-cli/docs/buildtools/bundle"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/bundleconfig"
+	cargodocs "github.com/jfrog/jfrog-cli/docs/buildtools/cargo"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/cargoconfig"
+	composerdocs "github.com/jfrog/jfrog-cli/docs/buildtools/composer"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/composerconfig"
+	conandocs "github.com/jfrog/jfrog-cli/docs/buildtools/conan"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/conanconfig"
+	condadocs "github.com/jfrog/jfrog-cli/docs/buildtools/conda"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/condaconfig"
+	containerdoc "github.com/jfrog/jfrog-cli/docs/buildtools/container"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/docker"
 	dotnetdocs "github.com/jfrog/jfrog-cli/docs/buildtools/dotnet"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/dotnetconfig"
+	gemdocs "github.com/jfrog/jfrog-cli/docs/buildtools/gem"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/gemconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/gocommand"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/goconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/gopublish"
 	gradledoc "github.com/jfrog/jfrog-cli/docs/buildtools/gradle"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/gradleconfig"
+	helmdocs "github.com/jfrog/jfrog-cli/docs/buildtools/helm"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/helmconfig"
 	mvndoc "github.com/jfrog/jfrog-cli/docs/buildtools/mvn"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/mvnconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/npmcommand"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/npmconfig"
 	nugetdocs "github.com/jfrog/jfrog-cli/docs/buildtools/nuget"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/nugetconfig"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/oci"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/pipconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/pipenvconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/pipenvinstall"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/pipinstall"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/pnpmcommand"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/pnpmconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/poetry"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/poetryconfig"
+	sbtdocs "github.com/jfrog/jfrog-cli/docs/buildtools/sbt"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/sbtconfig"
+	uvdocs "github.com/jfrog/jfrog-cli/docs/buildtools/uv"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/uvconfig"
 	yarndocs "github.com/jfrog/jfrog-cli/docs/buildtools/yarn"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/yarnconfig"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/urfave/cli"
@@ -136,6 +175,7 @@ func GetCommands() []cli.Command {
 			Flags:           cliutils.GetCommandFlags(cliutils.Yarn),
 			Usage:           yarndocs.GetDescription(),
 			HelpName:        corecommon.CreateUsage("yarn", yarndocs.GetDescription(), yarndocs.Usage),
+			UsageText:       yarndocs.GetArguments(),
 			ArgsUsage:       common.CreateEnvVars(),
 			SkipFlagParsing: true,
 			BashComplete:    corecommon.CreateBashCompletionFunc(),
@@ -343,6 +383,246 @@ func GetCommands() []cli.Command {
 				return cliutils.CreateConfigCmd(c, project.Pnpm)
 			},
 		},
+		{
+			Name:            "pnpm",
+			Usage:           pnpmcommand.GetDescription(),
+			HelpName:        corecommon.CreateUsage("pnpm", pnpmcommand.GetDescription(), pnpmcommand.Usage),
+			UsageText:       pnpmcommand.GetArguments(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("install", "i", "add", "publish", "p"),
+			Category:        buildToolsCategory,
+			Action:          PnpmCmd,
+		},
+		{
+			Name:         "cargo-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.CargoConfig),
+			Aliases:      []string{"cargoc"},
+			Usage:        cargoconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("cargo-config", cargoconfig.GetDescription(), cargoconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Cargo)
+			},
+		},
+		{
+			Name:            "cargo",
+			Usage:           cargodocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("cargo", cargodocs.GetDescription(), cargodocs.Usage),
+			UsageText:       cargodocs.GetArguments(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("build", "fetch", "publish"),
+			Category:        buildToolsCategory,
+			Action:          CargoCmd,
+		},
+		{
+			Name:         "helm-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.HelmConfig),
+			Aliases:      []string{"helmc"},
+			Usage:        helmconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("helm-config", helmconfig.GetDescription(), helmconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Helm)
+			},
+		},
+		{
+			Name:            "helm",
+			Usage:           helmdocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("helm", helmdocs.GetDescription(), helmdocs.Usage),
+			UsageText:       helmdocs.GetArguments(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("dependency", "package", "push"),
+			Category:        buildToolsCategory,
+			Action:          HelmCmd,
+		},
+		{
+			Name:         "conan-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.ConanConfig),
+			Aliases:      []string{"conanc"},
+			Usage:        conanconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("conan-config", conanconfig.GetDescription(), conanconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Conan)
+			},
+		},
+		{
+			Name:            "conan",
+			Usage:           conandocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("conan", conandocs.GetDescription(), conandocs.Usage),
+			UsageText:       conandocs.GetArguments(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("install", "create", "upload"),
+			Category:        buildToolsCategory,
+			Action:          ConanCmd,
+		},
+		{
+			Name:         "composer-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.ComposerConfig),
+			Aliases:      []string{"composerc"},
+			Usage:        composerconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("composer-config", composerconfig.GetDescription(), composerconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Composer)
+			},
+		},
+		{
+			Name:            "composer",
+			Usage:           composerdocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("composer", composerdocs.GetDescription(), composerdocs.Usage),
+			UsageText:       composerdocs.GetArguments(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("install", "update", "publish"),
+			Category:        buildToolsCategory,
+			Action:          ComposerCmd,
+		},
+		{
+			Name:         "gem-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.GemConfig),
+			Aliases:      []string{"gemc"},
+			Usage:        gemconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("gem-config", gemconfig.GetDescription(), gemconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Gem)
+			},
+		},
+		{
+			Name:            "gem",
+			Usage:           gemdocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("gem", gemdocs.GetDescription(), gemdocs.Usage),
+			UsageText:       gemdocs.GetArguments(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("install", "push"),
+			Category:        buildToolsCategory,
+			Action:          GemCmd,
+		},
+		{
+			Name:         "bundle-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.BundleConfig),
+			Aliases:      []string{"bundlec"},
+			Usage:        bundleconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("bundle-config", bundleconfig.GetDescription(), bundleconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Bundle)
+			},
+		},
+		{
+			Name:            "bundle",
+			Usage:           bundledocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("bundle", bundledocs.GetDescription(), bundledocs.Usage),
+			UsageText:       bundledocs.GetArguments(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("install", "update"),
+			Category:        buildToolsCategory,
+			Action:          BundleCmd,
+		},
+		{
+			Name:         "uv-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.UvConfig),
+			Aliases:      []string{"uvc"},
+			Usage:        uvconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("uv-config", uvconfig.GetDescription(), uvconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Uv)
+			},
+		},
+		{
+			Name:            "uv",
+			Usage:           uvdocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("uv", uvdocs.GetDescription(), uvdocs.Usage),
+			UsageText:       uvdocs.GetArguments(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("sync", "add", "lock", "publish"),
+			Category:        buildToolsCategory,
+			Action:          UvCmd,
+		},
+		{
+			Name:         "conda-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.CondaConfig),
+			Aliases:      []string{"condac"},
+			Usage:        condaconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("conda-config", condaconfig.GetDescription(), condaconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Conda)
+			},
+		},
+		{
+			Name:            "conda",
+			Usage:           condadocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("conda", condadocs.GetDescription(), condadocs.Usage),
+			UsageText:       condadocs.GetArguments(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("create", "install", "update", "publish"),
+			Category:        buildToolsCategory,
+			Action:          CondaCmd,
+		},
+		{
+			Name:         "bazel-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.BazelConfig),
+			Aliases:      []string{"bazelc"},
+			Usage:        bazelconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("bazel-config", bazelconfig.GetDescription(), bazelconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Bazel)
+			},
+		},
+		{
+			Name:            "bazel",
+			Usage:           bazeldocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("bazel", bazeldocs.GetDescription(), bazeldocs.Usage),
+			UsageText:       bazeldocs.GetArguments(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("build", "test", "run", "coverage"),
+			Category:        buildToolsCategory,
+			Action:          BazelCmd,
+		},
+		{
+			Name:         "sbt-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.SbtConfig),
+			Aliases:      []string{"sbtc"},
+			Usage:        sbtconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("sbt-config", sbtconfig.GetDescription(), sbtconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Sbt)
+			},
+		},
+		{
+			Name:            "sbt",
+			Usage:           sbtdocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("sbt", sbtdocs.GetDescription(), sbtdocs.Usage),
+			UsageText:       sbtdocs.GetArguments(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("compile", "test", "publish"),
+			Category:        buildToolsCategory,
+			Action:          SbtCmd,
+		},
 		{
 			Name:      "docker",
 			Flags:     cliutils.GetCommandFlags(cliutils.Docker),
@@ -362,6 +642,30 @@ func GetCommands() []cli.Command {
 			Category:     buildToolsCategory,
 			Action:       dockerCmd,
 		},
+		{
+			Name:            "oci",
+			Flags:           cliutils.GetCommandFlags(cliutils.Oci),
+			Usage:           oci.GetDescription(),
+			HelpName:        corecommon.CreateUsage("oci", oci.GetDescription(), oci.Usage),
+			UsageText:       oci.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("push", "pull", "discover"),
+			Category:        buildToolsCategory,
+			Action:          ociCmd,
+		},
+		{
+			Name:            "container",
+			Flags:           cliutils.GetCommandFlags(cliutils.Container),
+			Usage:           containerdoc.GetDescription(),
+			HelpName:        corecommon.CreateUsage("container", containerdoc.GetDescription(), containerdoc.Usage),
+			UsageText:       containerdoc.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc("build-info"),
+			Category:        buildToolsCategory,
+			Action:          containerCmd,
+		},
 		{
 			Name:         "terraform-config",
 			Flags:        cliutils.GetCommandFlags(cliutils.TerraformConfig),
@@ -519,6 +823,32 @@ func YarnCmd(c *cli.Context) error {
 		return fmt.Errorf("no config file was found! Before running the yarn command on a project for the first time, the project should be configured using the yarn-config command")
 	}
 
+	_, _, _, _, filteredArgs, buildConfiguration, err := commandsUtils.ExtractYarnOptionsFromArgs(c.Args())
+	if err != nil {
+		return err
+	}
+	if len(filteredArgs) == 0 || filteredArgs[0] == "install" {
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		var installArgs []string
+		if len(filteredArgs) > 0 {
+			installArgs = filteredArgs[1:]
+		}
+		isWorkspacesBuildInfo, err := workspaces.IsWorkspacesBuildInfo(workingDir, buildConfiguration, installArgs)
+		if err != nil {
+			return err
+		}
+		if isWorkspacesBuildInfo {
+			yarnCmd := workspaces.NewYarnCommand().SetConfigFilePath(configFilePath).SetArgs(c.Args())
+			if err = yarnCmd.Init(); err != nil {
+				return err
+			}
+			return commands.Exec(yarnCmd)
+		}
+	}
+
 	yarnCmd := yarn.NewYarnCommand().SetConfigFilePath(configFilePath).SetArgs(c.Args())
 	return commands.Exec(yarnCmd)
 }
@@ -589,6 +919,32 @@ func DotnetCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if len(filteredDotnetArgs) > 1 && filteredDotnetArgs[0] == "nuget" && filteredDotnetArgs[1] == "push" {
+		return dotnetNugetPushCmd(configFilePath, args)
+	}
+
+	// The dependencies of the projects which restore their packages with lock files are collected from the lock files,
+	// which hold the exact versions and the content hashes of the packages, rather than from the assets files of the projects.
+	// If there are lock files, the dependencies of all the restored projects are collected after the command runs,
+	// from the lock files of the projects which have them, and from the assets files of the others.
+	var lockFilePaths []string
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if collectBuildInfo {
+		workingDirectory, err := os.Getwd()
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if lockFilePaths, err = dotnetcmd.FindLockFiles(workingDirectory); err != nil {
+			return err
+		}
+	}
+	dependenciesBuildConfiguration := buildConfiguration
+	if len(lockFilePaths) > 0 {
+		buildConfiguration = build.NewBuildConfiguration("", "", "", "")
+	}
 
 	// Run command.
 	dotnetCmd := dotnet.NewDotnetCoreCliCommand()
@@ -599,7 +955,28 @@ func DotnetCmd(c *cli.Context) error {
 	if len(filteredDotnetArgs) > 1 {
 		dotnetCmd.SetArgAndFlags(filteredDotnetArgs[1:])
 	}
-	return commands.Exec(dotnetCmd)
+	if err = commands.Exec(dotnetCmd); err != nil || len(lockFilePaths) == 0 {
+		return err
+	}
+	return dotnetcmd.CollectDependencies(filteredDotnetArgs[1:], dependenciesBuildConfiguration)
+}
+
+func dotnetNugetPushCmd(configFilePath string, args []string) error {
+	// The args start with the nuget and push commands, which may be preceded by the build-info options.
+	var pushArgs []string
+	commandsCount := 0
+	for _, arg := range args {
+		if commandsCount < 2 && (arg == "nuget" || arg == "push") {
+			commandsCount++
+			continue
+		}
+		pushArgs = append(pushArgs, arg)
+	}
+	pushCmd := dotnetcmd.NewNugetPushCommand().SetConfigFilePath(configFilePath).SetArgs(pushArgs)
+	if err := pushCmd.Init(); err != nil {
+		return err
+	}
+	return commands.Exec(pushCmd)
 }
 
 func getNugetAndDotnetConfigFields(configFilePath string) (rtDetails *coreConfig.ServerDetails, targetRepo string, useNugetV2 bool, err error) {
@@ -650,6 +1027,9 @@ func GoCmd(c *cli.Context) error {
 }
 
 func GoPublishCmd(c *cli.Context) (err error) {
+	if c.Bool("all") {
+		return goPublishAllCmd(c)
+	}
 	configFilePath, err := goCmdVerification(c)
 	if err != nil {
 		return err
@@ -669,6 +1049,35 @@ func GoPublishCmd(c *cli.Context) (err error) {
 	return
 }
 
+// Publishes all the modules of a multi-module repository, with the versions of their git tags, so no version argument is expected.
+func goPublishAllCmd(c *cli.Context) (err error) {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() > 0 {
+		return cliutils.PrintHelpAndReturnError("The --all option can't be used with a version argument, since the version of each module is resolved from its git tags.", c)
+	}
+	configFilePath, exists, err := project.GetProjectConfFilePath(project.Go)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("no config file was found! Before running the go command on a project for the first time, the project should be configured using the go-config command")
+	}
+	buildConfiguration, err := cliutils.CreateBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	printDeploymentView, detailedSummary := log.IsStdErrTerminal(), c.Bool("detailed-summary")
+	publishAllCmd := gopublishcmd.NewGoPublishAllCommand().SetConfigFilePath(configFilePath).SetBuildConfiguration(buildConfiguration).
+		SetDetailedSummary(detailedSummary || printDeploymentView).SetExcludedPatterns(cliutils.GetStringsArrFlagValue(c, "exclusions"))
+	err = commands.Exec(publishAllCmd)
+	result := publishAllCmd.Result()
+	defer cliutils.CleanupResult(result, &err)
+	err = cliutils.PrintCommandSummary(result, detailedSummary, printDeploymentView, false, err)
+	return
+}
+
 func goCmdVerification(c *cli.Context) (string, error) {
 	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
 		return "", err
@@ -708,6 +1117,8 @@ func dockerCmd(c *cli.Context) error {
 		err = pullCmd(c, image)
 	case "push":
 		err = pushCmd(c, image)
+	case "buildx":
+		err = buildxCmd(c)
 	case "scan":
 		return dockerScanCmd(c, image)
 	default:
@@ -727,6 +1138,10 @@ func pullCmd(c *cli.Context, image string) error {
 	if err != nil {
 		return err
 	}
+	filteredDockerArgs, verifyKey, err := containercmd.ExtractKeyFromArgs(filteredDockerArgs, containercmd.VerifyKeyFlag)
+	if err != nil {
+		return err
+	}
 	PullCommand := container.NewPullCommand(containerutils.DockerClient)
 	PullCommand.SetCmdParams(filteredDockerArgs).SetSkipLogin(skipLogin).SetImageTag(image).SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration)
 	supported, err := PullCommand.IsGetRepoSupported()
@@ -736,6 +1151,14 @@ func pullCmd(c *cli.Context, image string) error {
 	if !supported {
 		return cliutils.NotSupportedNativeDockerCommand("docker-pull")
 	}
+	if verifyKey != "" {
+		// The image isn't pulled unless its signature is verified.
+		digest, err := containercmd.VerifyImage(image, rtDetails, skipLogin, verifyKey)
+		if err != nil {
+			return err
+		}
+		return containercmd.PullVerifiedImage(PullCommand, filteredDockerArgs, image, digest)
+	}
 	return commands.Exec(PullCommand)
 }
 
@@ -750,9 +1173,33 @@ func pushCmd(c *cli.Context, image string) (err error) {
 	if err != nil {
 		return
 	}
+	filteredDockerArgs, signKey, err := containercmd.ExtractKeyFromArgs(filteredDockerArgs, containercmd.SignKeyFlag)
+	if err != nil {
+		return
+	}
+	filteredDockerArgs, baseImage, err := containercmd.ExtractBaseImageFromArgs(filteredDockerArgs)
+	if err != nil {
+		return
+	}
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return
+	}
+	if baseImage == "" && collectBuildInfo {
+		// The base image is identified by the Dockerfile in the working directory, unless it's provided.
+		var detectErr error
+		if baseImage, detectErr = containercmd.DetectBaseImage(containercmd.DefaultDockerfile); detectErr != nil {
+			log.Warn("The base image couldn't be identified by the Dockerfile: " + detectErr.Error())
+		}
+	}
+	pushBuildConfiguration := buildConfiguration
+	if baseImage != "" && collectBuildInfo {
+		// The build-info is recorded after the push, with the layers of the base image as dependencies.
+		pushBuildConfiguration = build.NewBuildConfiguration("", "", "", "")
+	}
 	printDeploymentView := log.IsStdErrTerminal()
 	PushCommand := container.NewPushCommand(containerutils.DockerClient)
-	PushCommand.SetThreads(threads).SetDetailedSummary(detailedSummary || printDeploymentView).SetCmdParams(filteredDockerArgs).SetSkipLogin(skipLogin).SetBuildConfiguration(buildConfiguration).SetServerDetails(rtDetails).SetImageTag(image)
+	PushCommand.SetThreads(threads).SetDetailedSummary(detailedSummary || printDeploymentView).SetCmdParams(filteredDockerArgs).SetSkipLogin(skipLogin).SetBuildConfiguration(pushBuildConfiguration).SetServerDetails(rtDetails).SetImageTag(image)
 	supported, err := PushCommand.IsGetRepoSupported()
 	if err != nil {
 		return err
@@ -761,12 +1208,107 @@ func pushCmd(c *cli.Context, image string) (err error) {
 		return cliutils.NotSupportedNativeDockerCommand("docker-push")
 	}
 	err = commands.Exec(PushCommand)
+	if err == nil && baseImage != "" {
+		if collectBuildInfo {
+			err = containercmd.SaveImageBuildInfoWithBaseImage(image, baseImage, rtDetails, skipLogin, buildConfiguration)
+		} else {
+			log.Warn("The --base-image option is ignored, since the build-info isn't collected.")
+		}
+	}
+	if err == nil && signKey != "" {
+		var digest string
+		if digest, err = containercmd.GetPushedDigest(image); err == nil {
+			err = containercmd.SignImage(image, digest, rtDetails, skipLogin, signKey)
+		}
+	}
 	result := PushCommand.Result()
 	defer cliutils.CleanupResult(result, &err)
 	err = cliutils.PrintCommandSummary(PushCommand.Result(), detailedSummary, printDeploymentView, false, err)
 	return
 }
 
+// Runs 'docker buildx build', and records the pushed image in the build-info. Other buildx commands run as native docker commands.
+func buildxCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	_, rtDetails, _, skipLogin, filteredDockerArgs, buildConfiguration, err := commandsUtils.ExtractDockerOptionsFromArgs(c.Args())
+	if err != nil {
+		return err
+	}
+	globalArgs, buildxArgs := containercmd.SplitBuildxArgs(filteredDockerArgs)
+	if !containercmd.IsBuildxBuild(buildxArgs) {
+		return dockerNativeCmd(c)
+	}
+	buildxCommand := containercmd.NewBuildxCommand().SetGlobalArgs(globalArgs).SetArgs(buildxArgs)
+	buildxCommand.SetSkipLogin(skipLogin).SetBuildConfiguration(buildConfiguration).SetServerDetails(rtDetails)
+	return commands.Exec(buildxCommand)
+}
+
+// Runs 'jf oci push', 'jf oci pull' or 'jf oci discover', with the server, build and login options of 'jf docker'.
+func ociCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	_, rtDetails, _, skipLogin, filteredArgs, buildConfiguration, err := commandsUtils.ExtractDockerOptionsFromArgs(c.Args())
+	if err != nil {
+		return err
+	}
+	cmdName, ociArgs := getCommandName(filteredArgs)
+	var ociCommand interface {
+		commands.Command
+		Init() error
+	}
+	switch cmdName {
+	case "push":
+		pushCommand := containercmd.NewOciPushCommand()
+		pushCommand.SetArgs(ociArgs)
+		pushCommand.SetSkipLogin(skipLogin)
+		pushCommand.SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration)
+		ociCommand = pushCommand
+	case "pull":
+		pullCommand := containercmd.NewOciPullCommand()
+		pullCommand.SetArgs(ociArgs)
+		pullCommand.SetSkipLogin(skipLogin)
+		pullCommand.SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration)
+		ociCommand = pullCommand
+	case "discover":
+		discoverCommand := containercmd.NewOciDiscoverCommand()
+		discoverCommand.SetArgs(ociArgs)
+		discoverCommand.SetSkipLogin(skipLogin)
+		discoverCommand.SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration)
+		ociCommand = discoverCommand
+	default:
+		return cliutils.PrintHelpAndReturnError("Unknown oci command '"+cmdName+"'. Expected: push, pull or discover.", c)
+	}
+	if err = ociCommand.Init(); err != nil {
+		return err
+	}
+	return commands.Exec(ociCommand)
+}
+
+func containerCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	_, rtDetails, _, skipLogin, filteredArgs, buildConfiguration, err := commandsUtils.ExtractDockerOptionsFromArgs(c.Args())
+	if err != nil {
+		return err
+	}
+	cmdName, containerArgs := getCommandName(filteredArgs)
+	if cmdName != "build-info" {
+		return cliutils.PrintHelpAndReturnError("Unknown container command '"+cmdName+"'. Expected: build-info.", c)
+	}
+	buildInfoCommand := containercmd.NewContainerBuildInfoCommand()
+	buildInfoCommand.SetArgs(containerArgs)
+	buildInfoCommand.SetSkipLogin(skipLogin)
+	buildInfoCommand.SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration)
+	if err = buildInfoCommand.Init(); err != nil {
+		return err
+	}
+	return commands.Exec(buildInfoCommand)
+}
+
 func dockerScanCmd(c *cli.Context, imageTag string) error {
 	convertedCtx, err := components.ConvertContext(c, securityDocs.GetCommandFlags(securityDocs.DockerScan)...)
 	if err != nil {
@@ -829,12 +1371,30 @@ func npmGenericCmd(c *cli.Context, cmdName string, collectBuildInfoIfRequested b
 		return NpmPublishCmd(c)
 	}
 
-	// Run generic npm command.
-	npmCmd := npm.NewNpmCommand(cmdName, collectBuildInfoIfRequested)
 	configFilePath, args, err := GetNpmConfigAndArgs(c)
 	if err != nil {
 		return err
 	}
+	if collectBuildInfoIfRequested {
+		_, _, _, filteredArgs, buildConfiguration, err := commandsUtils.ExtractNpmOptionsFromArgs(args)
+		if err != nil {
+			return err
+		}
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		isWorkspacesBuildInfo, err := workspaces.IsWorkspacesBuildInfo(workingDir, buildConfiguration, filteredArgs)
+		if err != nil {
+			return err
+		}
+		if isWorkspacesBuildInfo {
+			return npmWorkspacesInstallCmd(cmdName, configFilePath, args, workingDir, buildConfiguration)
+		}
+	}
+
+	// Run generic npm command.
+	npmCmd := npm.NewNpmCommand(cmdName, collectBuildInfoIfRequested)
 	npmCmd.SetConfigFilePath(configFilePath).CommonArgs.SetNpmArgs(args)
 	if err = npmCmd.Init(); err != nil {
 		return err
@@ -842,6 +1402,24 @@ func npmGenericCmd(c *cli.Context, cmdName string, collectBuildInfoIfRequested b
 	return commands.Exec(npmCmd)
 }
 
+// Installs the dependencies of a workspaces project, and records the dependencies of each workspace in a build-info module of its own,
+// rather than in a single module of the root project.
+func npmWorkspacesInstallCmd(cmdName, configFilePath string, args []string, workingDir string, buildConfiguration *build.BuildConfiguration) error {
+	npmCmd := npm.NewNpmCommand(cmdName, false)
+	npmCmd.SetConfigFilePath(configFilePath).CommonArgs.SetNpmArgs(args)
+	if err := npmCmd.Init(); err != nil {
+		return err
+	}
+	if err := commands.Exec(npmCmd); err != nil {
+		return err
+	}
+	serverDetails, err := npmCmd.ServerDetails()
+	if err != nil {
+		return err
+	}
+	return workspaces.CollectDependencies(workingDir, serverDetails, buildConfiguration)
+}
+
 func NpmPublishCmd(c *cli.Context) (err error) {
 	if show, err := cliutils.ShowGenericCmdHelpIfNeeded(c, c.Args(), "npmpublishhelp"); show || err != nil {
 		return err
@@ -851,6 +1429,13 @@ func NpmPublishCmd(c *cli.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	publishWorkspaces, selectedWorkspaces, args, err := workspaces.ExtractWorkspacesOptions(args)
+	if err != nil {
+		return err
+	}
+	if publishWorkspaces {
+		return npmPublishWorkspacesCmd(configFilePath, args, selectedWorkspaces)
+	}
 
 	npmCmd := npm.NewNpmPublishCommand()
 	npmCmd.SetConfigFilePath(configFilePath).SetArgs(args)
@@ -871,6 +1456,25 @@ func NpmPublishCmd(c *cli.Context) (err error) {
 	return
 }
 
+func npmPublishWorkspacesCmd(configFilePath string, args, selectedWorkspaces []string) (err error) {
+	npmCmd := workspaces.NewNpmPublishCommand().SetConfigFilePath(configFilePath).SetArgs(args).SetSelectedWorkspaces(selectedWorkspaces)
+	if err = npmCmd.Init(); err != nil {
+		return err
+	}
+	if npmCmd.GetXrayScan() {
+		commandsUtils.ConditionalUploadScanFunc = scan.ConditionalUploadDefaultScanFunc
+	}
+	printDeploymentView, detailedSummary := log.IsStdErrTerminal(), npmCmd.IsDetailedSummary()
+	if !detailedSummary {
+		npmCmd.SetDetailedSummary(printDeploymentView)
+	}
+	err = commands.Exec(npmCmd)
+	result := npmCmd.Result()
+	defer cliutils.CleanupResult(result, &err)
+	err = cliutils.PrintCommandSummary(npmCmd.Result(), detailedSummary, printDeploymentView, false, err)
+	return
+}
+
 func GetNpmConfigAndArgs(c *cli.Context) (configFilePath string, args []string, err error) {
 	configFilePath, exists, err := project.GetProjectConfFilePath(project.Npm)
 	if err != nil {
@@ -884,6 +1488,214 @@ func GetNpmConfigAndArgs(c *cli.Context) (configFilePath string, args []string,
 	return
 }
 
+func PnpmCmd(c *cli.Context) (err error) {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	configFilePath, exists, err := project.GetProjectConfFilePath(project.Pnpm)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errorutils.CheckErrorf("no config file was found! Before running the pnpm command on a project for the first time, the project should be configured using the pnpm-config command")
+	}
+	cmdName, args := getCommandName(c.Args())
+	switch cmdName {
+	// Aliases accepted by pnpm.
+	case "publish", "p":
+		return pnpmPublishCmd(configFilePath, args)
+	case "i":
+		cmdName = "install"
+	}
+	pnpmCmd := pnpm.NewPnpmCommand(cmdName).SetConfigFilePath(configFilePath).SetArgs(args)
+	if err = pnpmCmd.Init(); err != nil {
+		return err
+	}
+	return commands.Exec(pnpmCmd)
+}
+
+func pnpmPublishCmd(configFilePath string, args []string) (err error) {
+	pnpmCmd := pnpm.NewPnpmPublishCommand().SetConfigFilePath(configFilePath).SetArgs(args)
+	if err = pnpmCmd.Init(); err != nil {
+		return err
+	}
+	if pnpmCmd.GetXrayScan() {
+		commandsUtils.ConditionalUploadScanFunc = scan.ConditionalUploadDefaultScanFunc
+	}
+	printDeploymentView, detailedSummary := log.IsStdErrTerminal(), pnpmCmd.IsDetailedSummary()
+	if !detailedSummary {
+		pnpmCmd.SetDetailedSummary(printDeploymentView)
+	}
+	err = commands.Exec(pnpmCmd)
+	result := pnpmCmd.Result()
+	defer cliutils.CleanupResult(result, &err)
+	err = cliutils.PrintCommandSummary(pnpmCmd.Result(), detailedSummary, printDeploymentView, false, err)
+	return
+}
+
+func CargoCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, exists, err := projectconfig.GetProjectConfFilePath(projectconfig.Cargo)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errorutils.CheckErrorf("no config file was found! Before running the cargo command on a project for the first time, the project should be configured using the cargo-config command")
+	}
+	cmdName, args := getCommandName(cliutils.ExtractCommand(c))
+	cargoCmd := cargo.NewCargoCommand(cmdName).SetConfigFilePath(configFilePath).SetArgs(args)
+	if err = cargoCmd.Init(); err != nil {
+		return err
+	}
+	return commands.Exec(cargoCmd)
+}
+
+func HelmCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, exists, err := projectconfig.GetProjectConfFilePath(projectconfig.Helm)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errorutils.CheckErrorf("no config file was found! Before running the helm command on a project for the first time, the project should be configured using the helm-config command")
+	}
+	cmdName, args := getCommandName(cliutils.ExtractCommand(c))
+	helmCmd := helm.NewHelmCommand(cmdName).SetConfigFilePath(configFilePath).SetArgs(args)
+	if err = helmCmd.Init(); err != nil {
+		return err
+	}
+	return commands.Exec(helmCmd)
+}
+
+func ConanCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, exists, err := projectconfig.GetProjectConfFilePath(projectconfig.Conan)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errorutils.CheckErrorf("no config file was found! Before running the conan command on a project for the first time, the project should be configured using the conan-config command")
+	}
+	cmdName, args := getCommandName(cliutils.ExtractCommand(c))
+	conanCmd := conan.NewConanCommand(cmdName).SetConfigFilePath(configFilePath).SetArgs(args)
+	if err = conanCmd.Init(); err != nil {
+		return err
+	}
+	return commands.Exec(conanCmd)
+}
+
+func ComposerCmd(c *cli.Context) error {
+	return packageManagerCmd(c, projectconfig.Composer)
+}
+
+func GemCmd(c *cli.Context) error {
+	return packageManagerCmd(c, projectconfig.Gem)
+}
+
+func BundleCmd(c *cli.Context) error {
+	return packageManagerCmd(c, projectconfig.Bundle)
+}
+
+func UvCmd(c *cli.Context) error {
+	return packageManagerCmd(c, projectconfig.Uv)
+}
+
+func CondaCmd(c *cli.Context) error {
+	return packageManagerCmd(c, projectconfig.Conda)
+}
+
+func BazelCmd(c *cli.Context) error {
+	return packageManagerCmd(c, projectconfig.Bazel)
+}
+
+func SbtCmd(c *cli.Context) error {
+	return packageManagerCmd(c, projectconfig.Sbt)
+}
+
+func packageManagerCmd(c *cli.Context, projectType projectconfig.ProjectType) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	configFilePath, exists, err := projectconfig.GetProjectConfFilePath(projectType)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errorutils.CheckErrorf("no config file was found! Before running the %[1]s command on a project for the first time, the project should be configured using the %[1]s-config command", projectType.String())
+	}
+
+	orgArgs := cliutils.ExtractCommand(c)
+	cmdName, filteredArgs := getCommandName(orgArgs)
+	switch projectType {
+	case projectconfig.Composer:
+		composerCommand := composer.NewComposerCommand(cmdName).SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+		if err = composerCommand.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(composerCommand)
+	case projectconfig.Gem:
+		gemCommand := ruby.NewGemCommand()
+		gemCommand.SetCommandName(cmdName).SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+		if err = gemCommand.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(gemCommand)
+	case projectconfig.Bundle:
+		bundleCommand := ruby.NewBundleCommand()
+		bundleCommand.SetCommandName(cmdName).SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+		if err = bundleCommand.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(bundleCommand)
+	case projectconfig.Uv:
+		uvCommand := uv.NewUvCommand(cmdName).SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+		if err = uvCommand.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(uvCommand)
+	case projectconfig.Conda:
+		condaCommand := conda.NewCondaCommand(cmdName).SetConfigFilePath(configFilePath).SetArgs(filteredArgs)
+		if err = condaCommand.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(condaCommand)
+	case projectconfig.Bazel:
+		// The options before the command are startup options, which Bazel requires before the command.
+		startupOptions, bazelCmdName, bazelArgs := bazel.SplitArgs(orgArgs)
+		bazelCommand := bazel.NewBazelCommand(bazelCmdName).SetConfigFilePath(configFilePath).SetStartupOptions(startupOptions).SetArgs(bazelArgs)
+		if err = bazelCommand.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(bazelCommand)
+	case projectconfig.Sbt:
+		// sbt runs all the commands and tasks which are passed to it, so the arguments are passed as is.
+		sbtCommand := sbt.NewSbtCommand().SetConfigFilePath(configFilePath).SetArgs(orgArgs)
+		if err = sbtCommand.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(sbtCommand)
+	}
+	return errorutils.CheckErrorf("%s is not supported", projectType)
+}
+
 func PipCmd(c *cli.Context) error {
 	return pythonCmd(c, project.Pip)
 }
@@ -949,8 +1761,17 @@ func terraformCmd(c *cli.Context) error {
 	// Aliases accepted by terraform.
 	case "publish", "p":
 		return terraformPublishCmd(configFilePath, filteredArgs, c)
+	case "providers-mirror":
+		return terraformProvidersMirrorCmd(configFilePath, filteredArgs, c)
+	case "":
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	default:
-		return errorutils.CheckErrorf("Terraform command:\"" + cmdName + "\" is not supported. " + cliutils.GetDocumentationMessage())
+		terraformCmd := terraformcmd.NewTerraformCommand()
+		terraformCmd.SetConfigFilePath(configFilePath).SetArgs(orgArgs)
+		if err = terraformCmd.Init(); err != nil {
+			return err
+		}
+		return commands.Exec(terraformCmd)
 	}
 }
 
@@ -977,3 +1798,15 @@ func terraformPublishCmd(configFilePath string, args []string, c *cli.Context) e
 	result := terraformCmd.Result()
 	return cliutils.PrintBriefSummaryReport(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
 }
+
+func terraformProvidersMirrorCmd(configFilePath string, args []string, c *cli.Context) error {
+	targetRepo, platforms, err := terraformcmd.ParseProvidersMirrorArgs(args)
+	if err != nil {
+		return err
+	}
+	mirrorCmd := terraformcmd.NewProvidersMirrorCommand()
+	mirrorCmd.SetConfigFilePath(configFilePath).SetTargetRepo(targetRepo).SetPlatforms(platforms)
+	err = commands.Exec(mirrorCmd)
+	result := mirrorCmd.Result()
+	return cliutils.PrintBriefSummaryReport(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
+}
@@ -18,5 +18,7 @@ func GetArguments() string {
 		It can also collect the list of tracked project issues (for example, issues stored in JIRA or other bug tracking systems) and add them to the build-info. 
 		The issues are collected by reading the git commit messages from the local git log.
 		Each commit message is matched against a pre-configured regular expression, which retrieves the issue ID and issue summary.
-		The information required for collecting the issues is retrieved from a yaml configuration file provided to the command.`
+		The information required for collecting the issues is retrieved from a yaml configuration file provided to the command.
+		In addition, the command records the tags pointing at HEAD, the merge-base with the main branch, and the commits added since the previous published build,
+		including their authors and whether each commit has a verified signature, no signature or a bad signature.`
 }
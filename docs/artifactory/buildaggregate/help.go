@@ -0,0 +1,15 @@
+package buildaggregate
+
+var Usage = []string{"rt bagg <build name> <build number> --children=<build name 1>/<build number 1>,<build name 2>/<build number 2>"}
+
+func GetDescription() string {
+	return "Aggregate published child builds into an umbrella build info."
+}
+
+func GetArguments() string {
+	return `	build name
+		The umbrella build name.
+
+	build number
+		The umbrella build number.`
+}
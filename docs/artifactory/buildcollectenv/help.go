@@ -3,7 +3,7 @@ package buildcollectenv
 var Usage = []string{"rt bce <build name> <build number>"}
 
 func GetDescription() string {
-	return "Collect environment variables. Environment variables can be excluded using the build-publish command."
+	return "Collect environment variables. Environment variables can be excluded using the build-publish command, or using an environment policy."
 }
 
 func GetArguments() string {
@@ -0,0 +1,15 @@
+package buildverify
+
+var Usage = []string{"rt bv [command options] <build name> <build number>"}
+
+func GetDescription() string {
+	return "Verify the signature of a published build info, to confirm that it hasn't been modified since it was published."
+}
+
+func GetArguments() string {
+	return `	build name
+		Build name.
+
+	build number
+		Build number.`
+}
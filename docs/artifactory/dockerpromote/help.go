@@ -1,14 +1,16 @@
 package dockerpromote
 
-var Usage = []string{"rt docker-promote <source docker image> <source repo> <target repo>"}
+var Usage = []string{"rt docker-promote <source docker image> <source repo> <target repo>",
+	"rt docker-promote --build=<build name>/<build number> <source repo> <target repo>"}
 
 func GetDescription() string {
-	return "Promotes a Docker image from one repository to another. Supported by local repositories only."
+	return "Promotes a Docker image from one repository to another. Supported by local repositories only. " +
+		"Use the --tags-regex, --created-after and --build options to promote multiple tags, and --dry-run to list them without promoting."
 }
 
 func GetArguments() string {
 	return `	source docker image
-		The docker image name to promote.
+		The docker image name to promote. Optional when the --build option is used, to promote all the images of the build.
 	source repo
 		Source repository in Artifactory.
 	target repo
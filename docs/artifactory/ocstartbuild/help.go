@@ -3,5 +3,5 @@ package ocstartbuild
 var Usage = []string{"rt oc start-build <build config name | --from-build=<build name>> --repo=<target repository> [command options]"}
 
 func GetDescription() string {
-	return "Run OpenShift CLI (oc) start-build command."
+	return "Run OpenShift CLI (oc) start-build command. To record images built by Tekton pipelines or tracked by image streams, use 'jf container build-info'."
 }
@@ -1,12 +1,22 @@
 package terraformdocs
 
-var Usage = []string{"terraform <terraform arguments> [command options]"}
+var Usage = []string{"terraform <terraform arguments> [command options]", "terraform publish [command options]",
+	"terraform providers-mirror <target repository> [command options]"}
 
 func GetDescription() string {
-	return "Runs terraform "
+	return "Runs terraform. The providers and the modules are resolved from Artifactory, and the publish command publishes the modules of the current directory to Artifactory."
 }
 
 func GetArguments() string {
 	return `	terraform commands
-		Arguments and options for the terraform command.`
+		Arguments and options for the terraform command, such as init, plan or apply.
+
+	publish
+		Publishes the Terraform modules of the current directory to the deployment repository.
+
+	providers-mirror
+		Mirrors the providers of .terraform.lock.hcl to the target repository, in the layout of the Terraform provider network mirror protocol.
+
+	target repository
+		The repository to mirror the providers to, which can then be used as a network mirror of the providers.`
 }
@@ -0,0 +1,14 @@
+package bazel
+
+var Usage = []string{"bazel [startup options] <bazel arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run bazel command."
+}
+
+func GetArguments() string {
+	return `	build, test, run, coverage   Run the bazel command with the external dependencies downloaded through Artifactory, and with an Artifactory repository as the remote cache.
+	                             Collect the outputs of the top-level targets and the downloaded dependencies into the build-info, from the build event protocol output.
+	<any>                        Run the bazel command as is.
+	help, h`
+}
@@ -0,0 +1,7 @@
+package bazelconfig
+
+var Usage = []string{"bazel-config [command options]"}
+
+func GetDescription() string {
+	return "Generate bazel configuration. The resolution repository is used for downloads, and the deployment repository is used as the remote cache."
+}
@@ -0,0 +1,12 @@
+package bundle
+
+var Usage = []string{"bundle <bundle arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run Bundler command."
+}
+
+func GetArguments() string {
+	return `	install, update, <any>    Run the Bundler command with rubygems.org mirrored by Artifactory, and collect the dependencies from Gemfile.lock into the build-info.
+	help, h`
+}
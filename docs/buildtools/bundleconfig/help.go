@@ -0,0 +1,7 @@
+package bundleconfig
+
+var Usage = []string{"bundle-config [command options]"}
+
+func GetDescription() string {
+	return "Generate Bundler configuration."
+}
@@ -0,0 +1,13 @@
+package cargo
+
+var Usage = []string{"cargo <cargo arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run Cargo command."
+}
+
+func GetArguments() string {
+	return `	build, fetch, <any>       Run the Cargo command with the crates resolved from Artifactory, and collect the dependencies from Cargo.lock into the build-info.
+	publish                   Publish the crate to the designated Cargo repository, and record it as a build-info artifact.
+	help, h`
+}
@@ -0,0 +1,7 @@
+package cargoconfig
+
+var Usage = []string{"cargo-config [command options]"}
+
+func GetDescription() string {
+	return "Generate Cargo configuration."
+}
@@ -0,0 +1,13 @@
+package composer
+
+var Usage = []string{"composer <composer arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run Composer command."
+}
+
+func GetArguments() string {
+	return `	install, update, <any>    Run the Composer command with the packages resolved from Artifactory instead of packagist.org, and collect the dependencies from composer.lock into the build-info.
+	publish, p                Archive the package and upload it to the designated Composer repository. The version is taken from composer.json or from the --version option.
+	help, h`
+}
@@ -0,0 +1,14 @@
+package conan
+
+var Usage = []string{"conan <conan arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run Conan command."
+}
+
+func GetArguments() string {
+	return `	install, create, <any>    Run the Conan command with the packages resolved from Artifactory, which is added as the first 'artifactory' remote.
+	                          The install and create commands collect the dependencies from the Conan graph, or from the --lockfile-out lockfile, into the build-info.
+	upload                    Upload the recipes and packages to the designated Conan repository, and record them as build-info artifacts.
+	help, h`
+}
@@ -0,0 +1,14 @@
+package conda
+
+var Usage = []string{"conda <conda arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run conda command."
+}
+
+func GetArguments() string {
+	return `	create, install, update   Run the conda command with the channels resolved from Artifactory, and collect the packages of the environment into the build-info.
+	<any>                     Run the conda command with the channels resolved from Artifactory.
+	publish                   Upload conda package files to the designated Conda repository, under the subdir of each package. The subdir can be set with the --subdir option.
+	help, h`
+}
@@ -0,0 +1,20 @@
+package container
+
+var Usage = []string{"container build-info --from-oci-layout=<directory or tarball> <image tag> [command options]",
+	"container build-info --from-tekton-results=<directory> [<image tag>] [command options]",
+	"container build-info --from-image-stream=[<namespace>/]<image stream>:<tag> [<image tag>] [command options]"}
+
+func GetDescription() string {
+	return `Push images built without a container runtime, such as by kaniko, buildah or podman, to Artifactory, and record them in the build-info. Images built by Tekton pipelines or tracked by OpenShift image streams are recorded as well.`
+}
+
+func GetArguments() string {
+	return `	build-info                  Push the image from a local OCI image layout directory or tarball to the image tag, and record it in the build-info.
+	                            If the layout includes several images, the image annotated with the tag is pushed.
+	                            With --from-tekton-results, the image which a Tekton task, such as kaniko or buildah, pushed to Artifactory is recorded.
+	                            Its URL and digest are read from the IMAGE_URL and IMAGE_DIGEST result files in the directory, such as /tekton/results.
+	                            With --from-image-stream, the image which the OpenShift image stream tag tracks is recorded, as returned by 'oc get istag'.
+	                            The image tag argument, if provided, overrides the image of the Tekton results or the image stream.
+
+	The --server-id, --build-name, --build-number, --module, --project and --skip-login options of 'jf docker' are supported.`
+}
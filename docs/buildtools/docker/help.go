@@ -9,5 +9,6 @@ func GetDescription() string {
 func GetArguments() string {
 	return `	push                        Run docker push.
 	pull                        Run docker pull.
+	buildx build                Run docker buildx build. Images pushed with --push are recorded in the build-info, with a module per platform of multi-platform images.
 	scan                        Scan a local Docker image for security vulnerabilities with JFrog Xray.`
 }
@@ -1,6 +1,6 @@
 package dotnet
 
-var Usage = []string{"dotnet <dotnet sub-command> [command options]"}
+var Usage = []string{"dotnet <dotnet sub-command> [command options]", "dotnet nuget push <package paths> [command options]"}
 
 func GetDescription() string {
 	return "Run .NET Core CLI."
@@ -8,5 +8,13 @@ func GetDescription() string {
 
 func GetArguments() string {
 	return `	dotnet sub-command
-		 Arguments and options for the dotnet command.`
+		 Arguments and options for the dotnet command.
+		 If the projects restore their packages with lock files, the dependencies are collected from the packages.lock.json files.
+
+	nuget push
+		 Pushes the packages to the deployment repository, or to the resolution repository if no deployment repository is configured.
+		 The pushed .nupkg and .snupkg files are recorded as build-info artifacts.
+
+	package paths
+		 The paths of the .nupkg and .snupkg files to push. The paths may include wildcards.`
 }
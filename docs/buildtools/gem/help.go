@@ -0,0 +1,13 @@
+package gem
+
+var Usage = []string{"gem <gem arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run gem command."
+}
+
+func GetArguments() string {
+	return `	install, <any>            Run the gem command with the gems resolved from Artifactory.
+	push                      Push the gem to the designated RubyGems repository, and record it as a build-info artifact.
+	help, h`
+}
@@ -0,0 +1,7 @@
+package gemconfig
+
+var Usage = []string{"gem-config [command options]"}
+
+func GetDescription() string {
+	return "Generate Gem configuration."
+}
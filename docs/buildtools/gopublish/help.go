@@ -1,6 +1,6 @@
 package gopublish
 
-var Usage = []string{"gp [command options] <project version>"}
+var Usage = []string{"gp [command options] <project version>", "gp --all [command options]"}
 
 func GetDescription() string {
 	return "Publish go package and/or its dependencies to Artifactory."
@@ -8,5 +8,5 @@ func GetDescription() string {
 
 func GetArguments() string {
 	return `	project version
-		Package version to be published.`
+		Package version to be published. Not used with --all, since the version of each module is resolved from its git tags.`
 }
@@ -0,0 +1,14 @@
+package helm
+
+var Usage = []string{"helm <helm arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run Helm command."
+}
+
+func GetArguments() string {
+	return `	dependency, package, <any>  Run the Helm command with the charts resolved from Artifactory, as the '@artifactory' Helm repository or from the OCI registry of the server.
+	                            The dependency update/build and package commands collect the dependencies from Chart.lock into the build-info.
+	push                        Push a packaged chart to an OCI repository, or to the Helm repository of the deployer if no remote is given, and record it as a build-info artifact.
+	help, h`
+}
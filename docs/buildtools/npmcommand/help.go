@@ -9,6 +9,7 @@ func GetDescription() string {
 func GetArguments() string {
 	return `	ci                        Run npm ci.
 	publish, p                Packs and deploys the npm package to the designated npm repository.
-	install, i, isntall, add  Run npm install.
+	                          With --workspaces, deploys the workspace packages whose versions aren't deployed yet, each to its own build-info module.
+	install, i, isntall, add  Run npm install. In a workspaces project, the dependencies of each workspace are recorded in its own build-info module.
 	help, h`
 }
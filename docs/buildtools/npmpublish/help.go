@@ -2,8 +2,9 @@ package npmpublish
 
 import "github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 
-var Usage = []string{"npm publish [command options]"}
+var Usage = []string{"npm publish [command options]", "npm publish --workspaces [--workspace <name or path>]... [command options]"}
 
 func GetDescription() string {
-	return `Packs and deploys the npm package to the Artifactory npm repository, configured by the '` + coreutils.GetCliExecutableName() + ` npmc' command.`
+	return `Packs and deploys the npm package to the Artifactory npm repository, configured by the '` + coreutils.GetCliExecutableName() + ` npmc' command.
+With --workspaces, the public packages of the workspaces, whose versions aren't deployed to the repository yet, are deployed. Each package is recorded in the build-info as an artifact of its own module.`
 }
@@ -0,0 +1,20 @@
+package oci
+
+var Usage = []string{"oci push <reference> <file>[:<media type>]... [command options]",
+	"oci pull <reference> [command options]",
+	"oci discover <reference> [command options]"}
+
+func GetDescription() string {
+	return `Push, pull and discover OCI artifacts of any media type, such as SBOMs, Helm charts, WASM modules and ML models, in Artifactory Docker and OCI repositories.`
+}
+
+func GetArguments() string {
+	return `	push                        Push files as the layers of an artifact. The media type of each file can follow its path, separated by a colon.
+	                            Options: --artifact-type=<type>, --config=<file>[:<media type>], --annotation=<key>=<value> (repeatable),
+	                            --subject=<tag or digest> to attach the artifact to a manifest of the same repository as a referrer.
+	pull                        Pull the files of an artifact. Options: --output=<directory>.
+	discover                    List the referrers of an artifact or an image, in JSON format. Options: --artifact-type=<type>.
+
+	The --server-id, --build-name, --build-number, --module, --project and --skip-login options of 'jf docker' are supported.
+	The pushed and pulled artifacts are recorded in the build-info.`
+}
@@ -0,0 +1,13 @@
+package pnpmcommand
+
+var Usage = []string{"pnpm <pnpm arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run pnpm command."
+}
+
+func GetArguments() string {
+	return `	install, i, add           Run pnpm install, and collect the dependencies from pnpm-lock.yaml into the build-info, in a module per workspace project.
+	publish, p                Packs the package with pnpm and deploys it to the designated npm repository.
+	help, h`
+}
@@ -0,0 +1,13 @@
+package sbt
+
+var Usage = []string{"sbt <sbt arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run sbt command."
+}
+
+func GetArguments() string {
+	return `	<commands and tasks>      Run sbt with the dependencies resolved from Artifactory.
+	                          The publish tasks publish to the designated Maven repository, and the published files are recorded as build-info artifacts.
+	help, h`
+}
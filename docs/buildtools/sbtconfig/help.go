@@ -0,0 +1,7 @@
+package sbtconfig
+
+var Usage = []string{"sbt-config [command options]"}
+
+func GetDescription() string {
+	return "Generate sbt configuration."
+}
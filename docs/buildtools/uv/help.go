@@ -0,0 +1,14 @@
+package uv
+
+var Usage = []string{"uv <uv arguments> [command options]"}
+
+func GetDescription() string {
+	return "Run uv command."
+}
+
+func GetArguments() string {
+	return `	sync, add, remove         Run the uv command with the packages resolved from Artifactory, and collect the dependencies from uv.lock into the build-info.
+	<any>                     Run the uv command with the packages resolved from Artifactory.
+	publish                   Publish the distributions to the designated PyPI repository, and record them as build-info artifacts. The files are dist/* by default.
+	help, h`
+}
@@ -0,0 +1,7 @@
+package uvconfig
+
+var Usage = []string{"uv-config [command options]"}
+
+func GetDescription() string {
+	return "Generate uv configuration."
+}
@@ -5,3 +5,9 @@ var Usage = []string{"yarn [yarn command] [command options]"}
 func GetDescription() string {
 	return "Run Yarn commands."
 }
+
+func GetArguments() string {
+	return `	yarn command
+		The yarn command to run. For example, install.
+		In a workspaces project, the dependencies of each workspace are recorded in its own build-info module.`
+}
@@ -111,6 +111,11 @@ var (
 		Environment variables match those patterns will be excluded.
 		This environment variable is used by the "` + coreutils.GetCliExecutableName() + ` rt build-publish" command,
 		in case the --env-exclude command option is not sent.`
+
+	JfrogCliEnvPolicy = `	JFROG_CLI_ENV_POLICY
+		Path to an environment policy file, which determines the environment variables to capture and the values to mask.
+		This environment variable is used by the "` + coreutils.GetCliExecutableName() + ` rt build-collect-env" command,
+		in case the --env-policy command option is not sent.`
 )
 
 func GetGlobalEnvVars() string {
@@ -133,6 +138,7 @@ func GetGlobalEnvVars() string {
 		JfrogCliUploadEmptyArchive,
 		JfrogCliBuildUrl,
 		JfrogCliEnvExclude,
+		JfrogCliEnvPolicy,
 		JfrogCliFailNoOp,
 		JfrogCliEncryptionKey,
 		JfrogCliAvoidNewVersionWarning,
@@ -0,0 +1,9 @@
+package projectinit
+
+var Usage = []string{"project init [command options]"}
+
+func GetDescription() string {
+	return `Scans the project for the build tools which it uses, and creates the configuration of each of them in one step.
+	The repositories are named <prefix>-<package type>-<suffix>, such as teamx-maven-virtual for resolution and teamx-maven-local for deployment.
+	Existing configuration files are kept. A summary of the created files and the JFrog CLI commands for building the project is printed.`
+}
@@ -0,0 +1,45 @@
+package project
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
+	"github.com/jfrog/jfrog-cli/docs/common"
+	projectinitdocs "github.com/jfrog/jfrog-cli/docs/general/projectinit"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/urfave/cli"
+)
+
+func GetCommands() []cli.Command {
+	return cliutils.GetSortedCommands(cli.CommandsByName{
+		{
+			Name:         "init",
+			Flags:        cliutils.GetCommandFlags(cliutils.InitProject),
+			Usage:        projectinitdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("project init", projectinitdocs.GetDescription(), projectinitdocs.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       InitCmd,
+		},
+	})
+}
+
+func InitCmd(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	projectPath := c.String(cliutils.ProjectPath)
+	if projectPath == "" {
+		projectPath = "."
+	}
+	initCmd := NewProjectInitCommand().
+		SetProjectPath(projectPath).
+		SetServerId(c.String("server-id")).
+		SetRepoPrefix(c.String(cliutils.RepoPrefix))
+	if c.IsSet(cliutils.RepoResolveSuffix) {
+		initCmd.SetResolveRepoSuffix(c.String(cliutils.RepoResolveSuffix))
+	}
+	if c.IsSet(cliutils.RepoDeploySuffix) {
+		initCmd.SetDeployRepoSuffix(c.String(cliutils.RepoDeploySuffix))
+	}
+	return commands.Exec(initCmd)
+}
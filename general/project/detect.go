@@ -0,0 +1,227 @@
+package project
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The directories which include dependencies or tool state, rather than project sources.
+var skippedDirs = map[string]bool{
+	".git": true, ".jfrog": true, ".bsp": true, ".idea": true, ".gradle": true, ".terraform": true, ".venv": true, "venv": true, "__pycache__": true,
+	"node_modules": true,
+}
+
+var (
+	requirementsFilePattern  = regexp.MustCompile(`^requirements.*\.txt$`)
+	dotnetProjectPattern     = regexp.MustCompile(`\.(csproj|fsproj|vbproj|sln)$`)
+	dockerfilePattern        = regexp.MustCompile(`^(Dockerfile|Containerfile)(\..+)?$|\.(Dockerfile|dockerfile)$`)
+	terraformFilePattern     = regexp.MustCompile(`\.tf$`)
+	terraformProviderPattern = regexp.MustCompile(`(?m)^\s*(?:provider\s+"([\w-]+)"|resource\s+"([a-z0-9]+)_)`)
+)
+
+// The build outputs and vendored dependencies, by the files of the build tools which create them.
+// A directory with one of these names is skipped only if one of the files is next to it, since it may be a module of the project otherwise.
+var outputDirOwners = map[string]*regexp.Regexp{
+	"target": regexp.MustCompile(`^(pom\.xml|build\.sbt|Cargo\.toml)$`),
+	"build":  regexp.MustCompile(`^((build|settings)\.gradle(\.kts)?|package\.json|setup\.py|pyproject\.toml)$`),
+	"dist":   regexp.MustCompile(`^(package\.json|setup\.py|pyproject\.toml)$`),
+	"bin":    dotnetProjectPattern,
+	"obj":    dotnetProjectPattern,
+	"vendor": regexp.MustCompile(`^(go\.mod|composer\.json|Gemfile)$`),
+}
+
+// The technologies of the project, with the directories in which each of them was detected, relative to the project root.
+type detectedTechnologies map[*technology][]string
+
+// Scans the project for the descriptors of the supported build tools.
+// The files of all the directories are collected first, since some technologies depend on files in parent directories,
+// such as the lockfile of a Yarn or pnpm workspace, which is at the root of the workspace only.
+func detectTechnologies(projectPath string) (detectedTechnologies, error) {
+	filesByDir := make(map[string]map[string]bool)
+	err := filepath.WalkDir(projectPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path == projectPath {
+				return nil
+			}
+			if skippedDirs[entry.Name()] || isOutputDir(path, entry.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		relativeDir, err := filepath.Rel(projectPath, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		if filesByDir[relativeDir] == nil {
+			filesByDir[relativeDir] = make(map[string]bool)
+		}
+		filesByDir[relativeDir][entry.Name()] = true
+		return nil
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+
+	detected := make(detectedTechnologies)
+	dirs := make([]string, 0, len(filesByDir))
+	for dir := range filesByDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		for _, tech := range detectInDir(projectPath, dir, filesByDir) {
+			detected[tech] = append(detected[tech], filepath.ToSlash(dir))
+		}
+	}
+	return detected, nil
+}
+
+// Returns whether the directory is the build output or the vendored dependencies of a build tool, which is configured in the parent directory.
+// The parent directory is read, since its files may not be walked yet.
+func isOutputDir(path, name string) bool {
+	ownerPattern, exists := outputDirOwners[name]
+	if !exists {
+		return false
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && ownerPattern.MatchString(entry.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+func detectInDir(projectPath, dir string, filesByDir map[string]map[string]bool) []*technology {
+	files := filesByDir[dir]
+	var techs []*technology
+	if files["pom.xml"] {
+		techs = append(techs, maven)
+	}
+	if files["build.gradle"] || files["build.gradle.kts"] || files["settings.gradle"] || files["settings.gradle.kts"] {
+		techs = append(techs, gradle)
+	}
+	if files["build.sbt"] {
+		techs = append(techs, sbt)
+	}
+	// The workspace of Bazel is detected by its root files only, since the BUILD files are in every package of the workspace.
+	if files["MODULE.bazel"] || files["WORKSPACE"] || files["WORKSPACE.bazel"] {
+		techs = append(techs, bazel)
+	}
+	if files["package.json"] {
+		techs = append(techs, detectNodePackageManager(dir, filesByDir))
+	}
+	if files["go.mod"] {
+		techs = append(techs, golang)
+	}
+	if pythonTech := detectPythonPackageManager(projectPath, dir, filesByDir); pythonTech != nil {
+		techs = append(techs, pythonTech)
+	}
+	if anyFileMatches(files, dotnetProjectPattern) {
+		techs = append(techs, dotnet)
+	}
+	if anyFileMatches(files, dockerfilePattern) {
+		techs = append(techs, docker)
+	}
+	if anyFileMatches(files, terraformFilePattern) {
+		techs = append(techs, terraform)
+	}
+	return techs
+}
+
+// The package manager is detected by the lockfile in the directory of package.json, or in the closest parent directory which has one.
+func detectNodePackageManager(dir string, filesByDir map[string]map[string]bool) *technology {
+	for ; ; dir = filepath.Dir(dir) {
+		files := filesByDir[dir]
+		switch {
+		case files["pnpm-lock.yaml"]:
+			return pnpm
+		case files["yarn.lock"]:
+			return yarn
+		case files["package-lock.json"] || files["npm-shrinkwrap.json"]:
+			return npm
+		}
+		if dir == "." {
+			return npm
+		}
+	}
+}
+
+// Python projects are detected by the lockfiles of uv, Poetry and Pipenv, or by the descriptors of pip.
+// The members of a uv workspace are detected by the lockfile at the root of the workspace.
+func detectPythonPackageManager(projectPath, dir string, filesByDir map[string]map[string]bool) *technology {
+	files := filesByDir[dir]
+	switch {
+	case files["uv.lock"] || (files["pyproject.toml"] && hasFileInParentDirs(dir, "uv.lock", filesByDir)):
+		return uv
+	case files["poetry.lock"]:
+		return poetry
+	case files["pyproject.toml"] && isPoetryProject(filepath.Join(projectPath, dir, "pyproject.toml")):
+		return poetry
+	case files["Pipfile"] || files["Pipfile.lock"]:
+		return pipenv
+	case files["setup.py"] || files["pyproject.toml"] || anyFileMatches(files, requirementsFilePattern):
+		return pip
+	}
+	return nil
+}
+
+func hasFileInParentDirs(dir, fileName string, filesByDir map[string]map[string]bool) bool {
+	for dir != "." {
+		dir = filepath.Dir(dir)
+		if filesByDir[dir][fileName] {
+			return true
+		}
+	}
+	return false
+}
+
+func isPoetryProject(pyprojectPath string) bool {
+	content, err := os.ReadFile(pyprojectPath)
+	return err == nil && strings.Contains(string(content), "[tool.poetry]")
+}
+
+func anyFileMatches(files map[string]bool, pattern *regexp.Regexp) bool {
+	for file := range files {
+		if pattern.MatchString(file) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the provider of the Terraform modules, by their provider blocks or the types of their resources, such as aws for aws_instance.
+// The module directory name is returned if the provider isn't found.
+func detectTerraformProvider(projectPath string, dirs []string) string {
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(filepath.Join(projectPath, dir))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !terraformFilePattern.MatchString(entry.Name()) {
+				continue
+			}
+			content, err := os.ReadFile(filepath.Join(projectPath, dir, entry.Name()))
+			if err != nil {
+				continue
+			}
+			if match := terraformProviderPattern.FindStringSubmatch(string(content)); match != nil {
+				return match[1] + match[2]
+			}
+		}
+	}
+	return filepath.Base(filepath.Join(projectPath, dirs[0]))
+}
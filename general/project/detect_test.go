@@ -0,0 +1,99 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectTechnologies(t *testing.T) {
+	projectPath := t.TempDir()
+	files := map[string]string{
+		"scala/build.sbt":                    "",
+		"MODULE.bazel":                       "",
+		"pom.xml":                            "<project/>",
+		"web/package.json":                   "{}",
+		"web/yarn.lock":                      "",
+		"web/packages/ui/package.json":       "{}",
+		"web/node_modules/dep/package.json":  "{}",
+		"api/go.mod":                         "module example.com/api",
+		"tools/pyproject.toml":               "[project]\nname = \"tools\"",
+		"tools/uv.lock":                      "",
+		"tools/packages/cli/pyproject.toml":  "[project]\nname = \"cli\"",
+		"scripts/pyproject.toml":             "[tool.poetry]\nname = \"scripts\"",
+		"legacy/requirements-dev.txt":        "",
+		"service/Service.csproj":             "<Project/>",
+		"service/Dockerfile":                 "FROM scratch",
+		"infra/main.tf":                      "",
+		"service/bin/Release/Service.csproj": "",
+		"api/vendor/example.com/dep/go.mod":  "",
+		"target/classes/pom.xml":             "<project/>",
+		"build/plugin/pom.xml":               "<project/>",
+		"dist/go.mod":                        "module example.com/dist",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(projectPath, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+
+	detected, err := detectTechnologies(projectPath)
+	require.NoError(t, err)
+	assert.Equal(t, detectedTechnologies{
+		maven:     {".", "build/plugin"},
+		sbt:       {"scala"},
+		bazel:     {"."},
+		yarn:      {"web", "web/packages/ui"},
+		golang:    {"api", "dist"},
+		uv:        {"tools", "tools/packages/cli"},
+		poetry:    {"scripts"},
+		pip:       {"legacy"},
+		dotnet:    {"service"},
+		docker:    {"service"},
+		terraform: {"infra"},
+	}, detected)
+}
+
+func TestGetRepoName(t *testing.T) {
+	initCmd := NewProjectInitCommand()
+	assert.Equal(t, "maven-virtual", initCmd.getRepoName(maven, initCmd.resolveRepoSuffix))
+	initCmd.SetRepoPrefix("teamx").SetDeployRepoSuffix("")
+	assert.Equal(t, "teamx-pypi-virtual", initCmd.getRepoName(uv, initCmd.resolveRepoSuffix))
+	assert.Equal(t, "teamx-npm", initCmd.getRepoName(npm, initCmd.deployRepoSuffix))
+}
+
+func TestCreateConfigFile(t *testing.T) {
+	initCmd := NewProjectInitCommand()
+	initCmd.serverDetails = &config.ServerDetails{ServerId: "my-server"}
+
+	configFile := initCmd.createConfigFile(configuredTechnology{tech: maven, resolveRepo: "maven-virtual", deployRepo: "maven-local"})
+	assert.Equal(t, "maven", configFile.ConfigType)
+	assert.Equal(t, project.Repository{ServerId: "my-server", ReleaseRepo: "maven-virtual", SnapshotRepo: "maven-virtual"}, configFile.Resolver)
+	assert.Equal(t, "maven-local", configFile.Deployer.ReleaseRepo)
+
+	configFile = initCmd.createConfigFile(configuredTechnology{tech: uv, resolveRepo: "pypi-virtual"})
+	assert.Equal(t, "uv", configFile.ConfigType)
+	assert.Equal(t, project.Repository{ServerId: "my-server", Repo: "pypi-virtual"}, configFile.Resolver)
+	assert.Empty(t, configFile.Deployer.ServerId)
+}
+
+func TestReplacePlaceholders(t *testing.T) {
+	projectPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectPath, "infra"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "infra", "main.tf"), []byte("resource \"aws_instance\" \"web\" {}\n"), 0644))
+	initCmd := NewProjectInitCommand().SetProjectPath(projectPath)
+	initCmd.serverDetails = &config.ServerDetails{Url: "https://acme.jfrog.io/"}
+
+	assert.Equal(t, "jf terraform publish --namespace=my-project --provider=aws --tag=1.0.0 --build-name=my-project --build-number=1",
+		initCmd.replacePlaceholders(terraform.commands[0], "my-project", configuredTechnology{tech: terraform, dirs: []string{"infra"}, deployRepo: "terraform-local"}))
+	assert.Equal(t, "jf go-publish v1.0.0 --build-name=my-project --build-number=1",
+		initCmd.replacePlaceholders(golang.commands[1], "my-project", configuredTechnology{tech: golang, deployRepo: "go-local"}))
+	assert.Equal(t, "jf docker push acme.jfrog.io/docker-local/my-project:1 --build-name=my-project --build-number=1",
+		initCmd.replacePlaceholders(docker.commands[1], "my-project", configuredTechnology{tech: docker, deployRepo: "docker-local"}))
+	assert.Equal(t, "deploy to go-local", initCmd.replacePlaceholders("deploy to <deploy-repo>", "my-project", configuredTechnology{tech: golang, deployRepo: "go-local"}))
+}
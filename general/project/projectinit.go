@@ -0,0 +1,246 @@
+package project
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	DefaultResolveRepoSuffix = "virtual"
+	DefaultDeployRepoSuffix  = "local"
+	buildNumberPlaceholder   = "1"
+	// The version of the Go and Terraform modules in the summary.
+	moduleVersionPlaceholder = "1.0.0"
+)
+
+// Scans the project for the build tools which it uses, and creates the configuration file of each of them in the .jfrog/projects directory of the project.
+// The repositories are named by convention: <prefix>-<package type>-<suffix>, such as teamx-maven-virtual and teamx-maven-local.
+// Existing configuration files are kept.
+type ProjectInitCommand struct {
+	projectPath       string
+	serverId          string
+	repoPrefix        string
+	resolveRepoSuffix string
+	deployRepoSuffix  string
+	serverDetails     *config.ServerDetails
+}
+
+func NewProjectInitCommand() *ProjectInitCommand {
+	return &ProjectInitCommand{resolveRepoSuffix: DefaultResolveRepoSuffix, deployRepoSuffix: DefaultDeployRepoSuffix}
+}
+
+func (pic *ProjectInitCommand) SetProjectPath(projectPath string) *ProjectInitCommand {
+	pic.projectPath = projectPath
+	return pic
+}
+
+func (pic *ProjectInitCommand) SetServerId(serverId string) *ProjectInitCommand {
+	pic.serverId = serverId
+	return pic
+}
+
+func (pic *ProjectInitCommand) SetRepoPrefix(repoPrefix string) *ProjectInitCommand {
+	pic.repoPrefix = repoPrefix
+	return pic
+}
+
+func (pic *ProjectInitCommand) SetResolveRepoSuffix(resolveRepoSuffix string) *ProjectInitCommand {
+	pic.resolveRepoSuffix = resolveRepoSuffix
+	return pic
+}
+
+func (pic *ProjectInitCommand) SetDeployRepoSuffix(deployRepoSuffix string) *ProjectInitCommand {
+	pic.deployRepoSuffix = deployRepoSuffix
+	return pic
+}
+
+func (pic *ProjectInitCommand) ServerDetails() (*config.ServerDetails, error) {
+	return pic.serverDetails, nil
+}
+
+func (pic *ProjectInitCommand) CommandName() string {
+	return "project_init"
+}
+
+// The result of configuring a technology, for the summary.
+type configuredTechnology struct {
+	tech           *technology
+	dirs           []string
+	configFilePath string
+	resolveRepo    string
+	deployRepo     string
+	// Whether the configuration file existed, so it was kept.
+	kept bool
+}
+
+func (pic *ProjectInitCommand) Run() (err error) {
+	if pic.projectPath, err = filepath.Abs(pic.projectPath); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if pic.serverDetails, err = config.GetSpecificConfig(pic.serverId, true, false); err != nil {
+		return err
+	}
+	if pic.serverDetails == nil || pic.serverDetails.ServerId == "" {
+		return errorutils.CheckErrorf("No JFrog servers configured. Use the 'jf c add' command to set the server details.")
+	}
+	log.Info("Scanning " + pic.projectPath + " for build tools...")
+	detected, err := detectTechnologies(pic.projectPath)
+	if err != nil {
+		return err
+	}
+	if len(detected) == 0 {
+		return errorutils.CheckErrorf("no supported build tools were detected in %s", pic.projectPath)
+	}
+	projectsDir := filepath.Join(pic.projectPath, ".jfrog", "projects")
+	var configured []configuredTechnology
+	for _, tech := range technologies {
+		dirs, exists := detected[tech]
+		if !exists {
+			continue
+		}
+		result := configuredTechnology{tech: tech, dirs: dirs}
+		if tech.resolves {
+			result.resolveRepo = pic.getRepoName(tech, pic.resolveRepoSuffix)
+		}
+		if tech.deploys {
+			result.deployRepo = pic.getRepoName(tech, pic.deployRepoSuffix)
+		}
+		if tech.hasConfig {
+			result.configFilePath = filepath.Join(projectsDir, tech.name+".yaml")
+			if result.kept, err = pic.writeConfigFile(result); err != nil {
+				return err
+			}
+		}
+		configured = append(configured, result)
+	}
+	log.Output(pic.createSummary(configured))
+	return nil
+}
+
+// Returns the name of the repository by the naming convention: <prefix>-<package type>-<suffix>. Empty parts are omitted.
+func (pic *ProjectInitCommand) getRepoName(tech *technology, suffix string) string {
+	var parts []string
+	for _, part := range []string{pic.repoPrefix, tech.packageType, suffix} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// Writes the configuration file of the technology, in the format of its config command. Returns true if the file already existed, so it was kept.
+func (pic *ProjectInitCommand) writeConfigFile(result configuredTechnology) (bool, error) {
+	exists, err := fileutils.IsFileExists(result.configFilePath, false)
+	if err != nil || exists {
+		return exists, err
+	}
+	content, err := yaml.Marshal(pic.createConfigFile(result))
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(result.configFilePath)); err != nil {
+		return false, err
+	}
+	return false, errorutils.CheckError(os.WriteFile(result.configFilePath, content, 0644))
+}
+
+func (pic *ProjectInitCommand) createConfigFile(result configuredTechnology) *commands.ConfigFile {
+	var configFile *commands.ConfigFile
+	if projectType, isCoreType := getCoreProjectType(result.tech.name); isCoreType {
+		// The defaults of the core project types, such as the Ivy patterns of Gradle, are set the same way as by their config commands.
+		configFile = commands.NewConfigFileWithOptions(projectType)
+	} else {
+		configFile = &commands.ConfigFile{Version: commands.BuildConfVersion, ConfigType: result.tech.name}
+	}
+	if result.resolveRepo != "" {
+		configFile.Resolver.ServerId = pic.serverDetails.ServerId
+		setRepo(&configFile.Resolver, result.tech, result.resolveRepo)
+	}
+	if result.deployRepo != "" {
+		configFile.Deployer.ServerId = pic.serverDetails.ServerId
+		setRepo(&configFile.Deployer, result.tech, result.deployRepo)
+	}
+	return configFile
+}
+
+// Maven has separate repositories for releases and snapshots. They're set to the same repository, which can serve both.
+func setRepo(repository *project.Repository, tech *technology, repo string) {
+	if tech == maven {
+		repository.ReleaseRepo, repository.SnapshotRepo = repo, repo
+		return
+	}
+	repository.Repo = repo
+}
+
+func getCoreProjectType(name string) (project.ProjectType, bool) {
+	for i, projectType := range project.ProjectTypes {
+		if projectType == name {
+			return project.ProjectType(i), true
+		}
+	}
+	return 0, false
+}
+
+func (pic *ProjectInitCommand) createSummary(configured []configuredTechnology) string {
+	buildName := filepath.Base(pic.projectPath)
+	var summary strings.Builder
+	summary.WriteString("Detected build tools:\n")
+	for _, result := range configured {
+		summary.WriteString(fmt.Sprintf("  %-10s in %s\n", result.tech.name, strings.Join(result.dirs, ", ")))
+		if result.configFilePath != "" {
+			relativePath, _ := filepath.Rel(pic.projectPath, result.configFilePath)
+			status := "created"
+			if result.kept {
+				status = "already exists, kept"
+			}
+			summary.WriteString(fmt.Sprintf("             %s (%s)\n", filepath.ToSlash(relativePath), status))
+		}
+		if result.resolveRepo != "" && !result.kept {
+			summary.WriteString("             Resolution repository: " + result.resolveRepo + "\n")
+		}
+		if result.deployRepo != "" && !result.kept {
+			summary.WriteString("             Deployment repository: " + result.deployRepo + "\n")
+		}
+	}
+	summary.WriteString("\nTo build the project and collect its build-info, run:\n")
+	for _, result := range configured {
+		for _, command := range result.tech.commands {
+			summary.WriteString("  " + pic.replacePlaceholders(command, buildName, result) + "\n")
+		}
+	}
+	summary.WriteString("  jf rt build-publish " + buildName + " " + buildNumberPlaceholder + "\n")
+	return summary.String()
+}
+
+func (pic *ProjectInitCommand) replacePlaceholders(command, buildName string, result configuredTechnology) string {
+	image := "<image>"
+	if registryUrl, err := url.Parse(pic.serverDetails.GetUrl()); err == nil && registryUrl.Host != "" {
+		image = registryUrl.Host + "/" + result.deployRepo + "/" + buildName + ":" + buildNumberPlaceholder
+	}
+	replacements := []string{
+		"<build-name>", buildName,
+		"<build-number>", buildNumberPlaceholder,
+		"<version>", "v" + moduleVersionPlaceholder,
+		"<deploy-repo>", result.deployRepo,
+		"<image>", image,
+	}
+	if result.tech == terraform {
+		// The modules are published to the namespace of the project, under the provider which they use.
+		replacements = append(replacements,
+			"<namespace>", buildName,
+			"<provider>", detectTerraformProvider(pic.projectPath, result.dirs),
+			"<tag>", moduleVersionPlaceholder)
+	}
+	return strings.NewReplacer(replacements...).Replace(command)
+}
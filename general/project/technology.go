@@ -0,0 +1,53 @@
+package project
+
+// A build tool which 'jf project init' detects and configures.
+type technology struct {
+	// The name of the technology, which is also the type of its configuration file, and the prefix of its config command.
+	name string
+	// The package type of the Artifactory repositories of the technology, which is used for naming the repositories.
+	packageType string
+	// Whether the technology resolves dependencies from Artifactory, and deploys artifacts to it.
+	resolves bool
+	deploys  bool
+	// Whether the technology has a configuration file. Docker uses the server configuration only.
+	hasConfig bool
+	// The commands which build the project, with the repositories and build-info configured.
+	// The <build-name>, <build-number>, <version>, <deploy-repo>, <image>, <namespace>, <provider> and <tag> placeholders are replaced in the summary.
+	commands []string
+}
+
+var (
+	maven = &technology{name: "maven", packageType: "maven", resolves: true, deploys: true, hasConfig: true,
+		commands: []string{"jf mvn clean install --build-name=<build-name> --build-number=<build-number>"}}
+	gradle = &technology{name: "gradle", packageType: "gradle", resolves: true, deploys: true, hasConfig: true,
+		commands: []string{"jf gradle clean artifactoryPublish --build-name=<build-name> --build-number=<build-number>"}}
+	npm = &technology{name: "npm", packageType: "npm", resolves: true, deploys: true, hasConfig: true,
+		commands: []string{"jf npm install --build-name=<build-name> --build-number=<build-number>", "jf npm publish --build-name=<build-name> --build-number=<build-number>"}}
+	yarn = &technology{name: "yarn", packageType: "npm", resolves: true, hasConfig: true,
+		commands: []string{"jf yarn install --build-name=<build-name> --build-number=<build-number>"}}
+	pnpm = &technology{name: "pnpm", packageType: "npm", resolves: true, deploys: true, hasConfig: true,
+		commands: []string{"jf pnpm install --build-name=<build-name> --build-number=<build-number>", "jf pnpm publish --build-name=<build-name> --build-number=<build-number>"}}
+	sbt = &technology{name: "sbt", packageType: "maven", resolves: true, deploys: true, hasConfig: true,
+		commands: []string{"jf sbt compile publish --build-name=<build-name> --build-number=<build-number>"}}
+	bazel = &technology{name: "bazel", packageType: "generic", resolves: true, deploys: true, hasConfig: true,
+		commands: []string{"jf bazel build //... --build-name=<build-name> --build-number=<build-number>"}}
+	golang = &technology{name: "go", packageType: "go", resolves: true, deploys: true, hasConfig: true,
+		commands: []string{"jf go build --build-name=<build-name> --build-number=<build-number>", "jf go-publish <version> --build-name=<build-name> --build-number=<build-number>"}}
+	pip = &technology{name: "pip", packageType: "pypi", resolves: true, hasConfig: true,
+		commands: []string{"jf pip install -r requirements.txt --build-name=<build-name> --build-number=<build-number>"}}
+	pipenv = &technology{name: "pipenv", packageType: "pypi", resolves: true, hasConfig: true,
+		commands: []string{"jf pipenv install --build-name=<build-name> --build-number=<build-number>"}}
+	poetry = &technology{name: "poetry", packageType: "pypi", resolves: true, hasConfig: true,
+		commands: []string{"jf poetry install --build-name=<build-name> --build-number=<build-number>"}}
+	uv = &technology{name: "uv", packageType: "pypi", resolves: true, deploys: true, hasConfig: true,
+		commands: []string{"jf uv sync --build-name=<build-name> --build-number=<build-number>", "jf uv publish --build-name=<build-name> --build-number=<build-number>"}}
+	dotnet = &technology{name: "dotnet", packageType: "nuget", resolves: true, hasConfig: true,
+		commands: []string{"jf dotnet restore --build-name=<build-name> --build-number=<build-number>"}}
+	docker = &technology{name: "docker", packageType: "docker", deploys: true,
+		commands: []string{"jf docker build -t <image> .", "jf docker push <image> --build-name=<build-name> --build-number=<build-number>"}}
+	terraform = &technology{name: "terraform", packageType: "terraform", deploys: true, hasConfig: true,
+		commands: []string{"jf terraform publish --namespace=<namespace> --provider=<provider> --tag=<tag> --build-name=<build-name> --build-number=<build-number>"}}
+)
+
+// The technologies in the order in which they're configured and listed in the summary.
+var technologies = []*technology{maven, gradle, sbt, bazel, npm, yarn, pnpm, golang, pip, pipenv, poetry, uv, dotnet, docker, terraform}
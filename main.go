@@ -24,6 +24,7 @@ import (
 	tokenDocs "github.com/jfrog/jfrog-cli/docs/general/token"
 	"github.com/jfrog/jfrog-cli/general/ai"
 	"github.com/jfrog/jfrog-cli/general/login"
+	"github.com/jfrog/jfrog-cli/general/project"
 	"github.com/jfrog/jfrog-cli/general/token"
 	"github.com/jfrog/jfrog-cli/lifecycle"
 	"github.com/jfrog/jfrog-cli/missioncontrol"
@@ -242,6 +243,12 @@ func getCommands() ([]cli.Command, error) {
 			Subcommands: plugins.GetCommands(),
 			Category:    commandNamespacesCategory,
 		},
+		{
+			Name:        cliutils.CmdProject,
+			Usage:       "Project commands.",
+			Subcommands: project.GetCommands(),
+			Category:    commandNamespacesCategory,
+		},
 		{
 			Name:        cliutils.CmdConfig,
 			Aliases:     []string{"c"},
@@ -40,6 +40,7 @@ const (
 	// Env
 	BuildUrl                       = "JFROG_CLI_BUILD_URL"
 	EnvExclude                     = "JFROG_CLI_ENV_EXCLUDE"
+	EnvPolicy                      = "JFROG_CLI_ENV_POLICY"
 	UserAgent                      = "JFROG_CLI_USER_AGENT"
 	JfrogCliAvoidNewVersionWarning = "JFROG_CLI_AVOID_NEW_VERSION_WARNING"
 )
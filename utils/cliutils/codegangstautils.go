@@ -27,6 +27,16 @@ func GetStringsArrFlagValue(c *cli.Context, flagName string) (resultArray []stri
 	return
 }
 
+// Returns the items of a comma-separated list flag, trimmed, without the empty items.
+func GetCommaSeparatedFlagValue(c *cli.Context, flagName string) (resultArray []string) {
+	for _, item := range strings.Split(c.String(flagName), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			resultArray = append(resultArray, item)
+		}
+	}
+	return
+}
+
 func GetThreadsCount(c *cli.Context) (threads int, err error) {
 	return commonCliUtils.GetThreadsCount(c.String("threads"))
 }
@@ -28,8 +28,10 @@ const (
 	Search                 = "search"
 	BuildPublish           = "build-publish"
 	BuildAppend            = "build-append"
+	BuildAggregate         = "build-aggregate"
 	BuildScanLegacy        = "build-scan-legacy"
 	BuildPromote           = "build-promote"
+	BuildVerify            = "build-verify"
 	BuildDiscard           = "build-discard"
 	BuildAddDependencies   = "build-add-dependencies"
 	BuildAddGit            = "build-add-git"
@@ -43,6 +45,8 @@ const (
 	Docker                 = "docker"
 	DockerPush             = "docker-push"
 	DockerPull             = "docker-pull"
+	Oci                    = "oci"
+	Container              = "container"
 	ContainerPull          = "container-pull"
 	ContainerPush          = "container-push"
 	BuildDockerCreate      = "build-docker-create"
@@ -71,6 +75,16 @@ const (
 	PipenvInstall          = "pipenv-install"
 	PoetryConfig           = "poetry-config"
 	Poetry                 = "poetry"
+	CargoConfig            = "cargo-config"
+	HelmConfig             = "helm-config"
+	ConanConfig            = "conan-config"
+	ComposerConfig         = "composer-config"
+	GemConfig              = "gem-config"
+	BundleConfig           = "bundle-config"
+	UvConfig               = "uv-config"
+	CondaConfig            = "conda-config"
+	BazelConfig            = "bazel-config"
+	SbtConfig              = "sbt-config"
 	Ping                   = "ping"
 	RtCurl                 = "rt-curl"
 	TemplateConsumer       = "template-consumer"
@@ -273,6 +287,7 @@ const (
 
 	// Unique go publish flags
 	goPublishExclusions = GoPublish + exclusions
+	goPublishAll        = "all"
 
 	// Unique build-publish flags
 	buildPublishPrefix = "bp-"
@@ -281,8 +296,16 @@ const (
 	envInclude         = "env-include"
 	envExclude         = "env-exclude"
 	buildUrl           = "build-url"
+	signKey            = "sign-key"
 	Project            = "project"
 
+	// Unique build-collect-env flags
+	envPolicy = "env-policy"
+	bceReport = "report"
+
+	// Unique build-verify flags
+	publicKey = "public-key"
+
 	// Unique build-add-dependencies flags
 	badPrefix    = "bad-"
 	badDryRun    = badPrefix + dryRun
@@ -291,7 +314,13 @@ const (
 	badFromRt    = badPrefix + fromRt
 	badModule    = badPrefix + module
 
+	// Unique build-aggregate flags
+	children            = "children"
+	flattenArtifacts    = "flatten-artifacts"
+	flattenDependencies = "flatten-dependencies"
+
 	// Unique build-add-git flags
+	mainBranch = "main-branch"
 	configFlag = "config"
 
 	// Unique build-scan flags
@@ -307,16 +336,23 @@ const (
 	includeDependencies = "include-dependencies"
 	copyFlag            = "copy"
 	failFast            = "fail-fast"
+	bprPublicKey        = buildPromotePrefix + publicKey
 
 	Async = "async"
 
 	// Unique build-discard flags
-	buildDiscardPrefix = "bdi-"
-	bdiAsync           = buildDiscardPrefix + Async
-	maxDays            = "max-days"
-	maxBuilds          = "max-builds"
-	excludeBuilds      = "exclude-builds"
-	deleteArtifacts    = "delete-artifacts"
+	buildDiscardPrefix  = "bdi-"
+	bdiAsync            = buildDiscardPrefix + Async
+	bdiDryRun           = buildDiscardPrefix + dryRun
+	maxDays             = "max-days"
+	maxBuilds           = "max-builds"
+	excludeBuilds       = "exclude-builds"
+	deleteArtifacts     = "delete-artifacts"
+	keepStatuses        = "keep-statuses"
+	keepReleaseBundles  = "keep-release-bundles"
+	keepLatestPerBranch = "keep-latest-per-branch"
+	branchProp          = "branch-prop"
+	keepProps           = "keep-props"
 
 	repo = "repo"
 
@@ -341,6 +377,7 @@ const (
 	repoDeploySnapshots  = "repo-deploy-snapshots"
 	includePatterns      = "include-patterns"
 	excludePatterns      = "exclude-patterns"
+	fromSettings         = "from-settings"
 
 	// Unique gradle-config flags
 	usesPlugin          = "uses-plugin"
@@ -349,10 +386,14 @@ const (
 	deployIvyDesc       = "deploy-ivy-desc"
 	ivyDescPattern      = "ivy-desc-pattern"
 	ivyArtifactsPattern = "ivy-artifacts-pattern"
+	fromInitScript      = "from-init-script"
 
 	// Build tool flags
 	deploymentThreads = "deployment-threads"
 	skipLogin         = "skip-login"
+	dockerSignKey     = "docker-" + signKey
+	verifyKey         = "verify-key"
+	baseImage         = "base-image"
 
 	// Unique docker promote flags
 	dockerPromotePrefix = "docker-promote-"
@@ -360,6 +401,10 @@ const (
 	sourceTag           = "source-tag"
 	targetTag           = "target-tag"
 	dockerPromoteCopy   = dockerPromotePrefix + Copy
+	dockerPromoteBuild  = dockerPromotePrefix + build
+	dockerPromoteDryRun = dockerPromotePrefix + dryRun
+	tagsRegex           = "tags-regex"
+	createdAfter        = "created-after"
 
 	// Unique build docker create
 	imageFile = "image-file"
@@ -373,15 +418,23 @@ const (
 	npmDetailedSummary = npmPrefix + detailedSummary
 
 	// Unique nuget/dotnet config flags
-	nugetV2 = "nuget-v2"
+	nugetV2                = "nuget-v2"
+	dotnetConfigPrefix     = "dotnet-config-"
+	dotnetConfigRepoDeploy = dotnetConfigPrefix + repoDeploy
 
 	// Unique go flags
 	noFallback = "no-fallback"
 
 	// Unique Terraform flags
-	namespace = "namespace"
-	provider  = "provider"
-	tag       = "tag"
+	namespace         = "namespace"
+	provider          = "provider"
+	tag               = "tag"
+	terraformPlatform = "platform"
+
+	// Unique terraform-config flags
+	terraformConfigPrefix      = "terraform-config-"
+	terraformConfigRepoResolve = terraformConfigPrefix + repoResolve
+	terraformConfigRepoDeploy  = terraformConfigPrefix + repoDeploy
 
 	// Template user flags
 	vars = "vars"
@@ -525,7 +578,12 @@ const (
 	configInsecureTls = configPrefix + InsecureTls
 
 	// *** Project Commands' flags ***
-	projectPath = "path"
+	ProjectPath = "path"
+
+	// Unique project init flags
+	RepoPrefix        = "repo-prefix"
+	RepoResolveSuffix = "repo-resolve-suffix"
+	RepoDeploySuffix  = "repo-deploy-suffix"
 
 	// *** Completion Commands' flags ***
 	Completion = "completion"
@@ -957,6 +1015,26 @@ var flagsMap = map[string]cli.Flag{
 		Name:  detailedSummary,
 		Usage: "[Default: false] Set to true to get a command summary with details about the build info artifact.` `",
 	},
+	envPolicy: cli.StringFlag{
+		Name:  envPolicy,
+		Usage: "[Optional] Path to a YAML environment policy file, with include and exclude patterns of variable names, and secret detection settings. Values that look like secrets are masked, even if the variable name looks harmless. Can also be set using the JFROG_CLI_ENV_POLICY environment variable.` `",
+	},
+	bceReport: cli.BoolFlag{
+		Name:  bceReport,
+		Usage: "[Default: false] Set to true to print a JSON report of the captured, excluded and masked environment variables. Requires an environment policy.` `",
+	},
+	signKey: cli.StringFlag{
+		Name:  signKey,
+		Usage: "[Optional] Path to a PEM encoded RSA, ECDSA or Ed25519 private key. If provided, a detached signature over the published build info is attached to it, to allow verifying it using the build-verify command.` `",
+	},
+	publicKey: cli.StringFlag{
+		Name:  publicKey,
+		Usage: "[Mandatory] Path to a PEM encoded public key or certificate, matching the private key used for signing the build info.` `",
+	},
+	bprPublicKey: cli.StringFlag{
+		Name:  publicKey,
+		Usage: "[Optional] Path to a PEM encoded public key or certificate. If provided, the build info signature is verified before promoting the build, and the promotion is aborted if the verification fails.` `",
+	},
 	envInclude: cli.StringFlag{
 		Name:  envInclude,
 		Usage: "[Default: *] List of patterns in the form of \"value1;value2;...\" Only environment variables match those patterns will be included.` `",
@@ -1034,13 +1112,31 @@ var flagsMap = map[string]cli.Flag{
 		Usage: "[Optional] The tag name to promote.` `",
 	},
 	targetTag: cli.StringFlag{
-		Name:  "target-tag",
-		Usage: "[Optional] The target tag to assign the image after promotion.` `",
+		Name: "target-tag",
+		Usage: "[Optional] The target tag to assign the image after promotion. " +
+			"When used with --tags-regex, the target tag can include placeholders in the form of {1}, {2} which are replaced by the corresponding groups of the regular expression.` `",
 	},
 	dockerPromoteCopy: cli.BoolFlag{
 		Name:  "copy",
 		Usage: "[Default: false] If set true, the Docker image is copied to the target repository, otherwise it is moved.` `",
 	},
+	tagsRegex: cli.StringFlag{
+		Name:  tagsRegex,
+		Usage: "[Optional] A regular expression. If specified, all the tags of the image which match it are promoted. For example: '^1\\.2\\.[0-9]+-rc[0-9]+$'.` `",
+	},
+	createdAfter: cli.StringFlag{
+		Name:  createdAfter,
+		Usage: "[Optional] If specified, all the tags of the image which were created after this date are promoted. The date format is YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ.` `",
+	},
+	dockerPromoteBuild: cli.StringFlag{
+		Name: build,
+		Usage: "[Optional] If specified, all the image tags of the specified build are promoted, and the source docker image argument may be omitted. " +
+			"The format is build-name/build-number. If you do not specify the build number, the latest build number is used.` `",
+	},
+	dockerPromoteDryRun: cli.BoolFlag{
+		Name:  dryRun,
+		Usage: "[Default: false] Set to true to list the source manifests and the existing target manifests of the promotion, without promoting the images.` `",
+	},
 	maxDays: cli.StringFlag{
 		Name:  maxDays,
 		Usage: "[Optional] The maximum number of days to keep builds in Artifactory.` `",
@@ -1061,6 +1157,46 @@ var flagsMap = map[string]cli.Flag{
 		Name:  Async,
 		Usage: "[Default: false] If set to true, build discard will run asynchronously and will not wait for response.` `",
 	},
+	bdiDryRun: cli.BoolFlag{
+		Name:  dryRun,
+		Usage: "[Default: false] If true, only the build numbers which would have been discarded are listed. No builds are discarded.` `",
+	},
+	keepStatuses: cli.StringFlag{
+		Name:  keepStatuses,
+		Usage: "[Optional] List of comma-separated(,) promotion statuses in the form of \"status1,status2,...\". Builds promoted to one of these statuses are not discarded.` `",
+	},
+	keepReleaseBundles: cli.StringFlag{
+		Name:  keepReleaseBundles,
+		Usage: "[Optional] List of comma-separated(,) release bundles in the form of \"name1/version1,name2/version2,...\". Builds referenced by one of these release bundles are not discarded.` `",
+	},
+	keepLatestPerBranch: cli.StringFlag{
+		Name:  keepLatestPerBranch,
+		Usage: "[Optional] The number of latest builds of every branch which are not discarded. The branch is read from the build's VCS details, or from the build property set by --branch-prop.` `",
+	},
+	branchProp: cli.StringFlag{
+		Name:  branchProp,
+		Usage: "[Optional] The build property holding the branch name. Used with --keep-latest-per-branch.` `",
+	},
+	keepProps: cli.StringFlag{
+		Name:  keepProps,
+		Usage: "[Optional] List of semicolon-separated(;) build properties in the form of \"key1=value1;key2=value2;...\". Builds tagged with one of these properties are not discarded. Values may include wildcards. A key without a value matches any value.` `",
+	},
+	mainBranch: cli.StringFlag{
+		Name:  mainBranch,
+		Usage: "[Default: main or master] The main branch of the git repository, used for calculating the merge-base of HEAD.` `",
+	},
+	children: cli.StringFlag{
+		Name:  children,
+		Usage: "[Mandatory] List of comma-separated(,) published builds in the form of \"build-name1/build-number1,build-name2/build-number2,...\" to aggregate into the build.` `",
+	},
+	flattenArtifacts: cli.BoolFlag{
+		Name:  flattenArtifacts,
+		Usage: "[Default: false] Set to true to also add the artifacts of the child builds' modules to the build. The flattened modules are named <child build name>/<child build number>/<module ID>.` `",
+	},
+	flattenDependencies: cli.BoolFlag{
+		Name:  flattenDependencies,
+		Usage: "[Default: false] Set to true to also add the dependencies of the child builds' modules to the build. The flattened modules are named <child build name>/<child build number>/<module ID>.` `",
+	},
 	refs: cli.StringFlag{
 		Name:  refs,
 		Usage: "[Default: refs/remotes/*] List of comma-separated(,) Git references in the form of \"ref1,ref2,...\" which should be preserved.` `",
@@ -1121,6 +1257,14 @@ var flagsMap = map[string]cli.Flag{
 		Name:  repoDeploy,
 		Usage: "[Optional] Repository for artifacts deployment.` `",
 	},
+	fromSettings: cli.StringFlag{
+		Name:  fromSettings,
+		Usage: "[Optional] Path to an existing Maven settings.xml file, such as ~/.m2/settings.xml. The resolution and deployment repositories are imported from its mirrors, repositories and deployment properties, instead of being set interactively. The URLs are matched to the configured JFrog servers.` `",
+	},
+	fromInitScript: cli.StringFlag{
+		Name:  fromInitScript,
+		Usage: "[Optional] Path to an existing Gradle init script, such as ~/.gradle/init.gradle. The resolution and deployment repositories are imported from its repositories and publishing blocks, instead of being set interactively. The URLs are matched to the configured JFrog servers.` `",
+	},
 	usesPlugin: cli.BoolFlag{
 		Name:  usesPlugin,
 		Usage: "[Default: false] Set to true if the Gradle Artifactory Plugin is already applied in the build script.` `",
@@ -1150,6 +1294,18 @@ var flagsMap = map[string]cli.Flag{
 		Name:  skipLogin,
 		Usage: "[Default: false] Set to true if you'd like the command to skip performing docker login.` `",
 	},
+	dockerSignKey: cli.StringFlag{
+		Name:  signKey,
+		Usage: "[Optional] Path to a private key, such as cosign.key. If provided, the pushed image is signed in the cosign signature format, and the signature is pushed to the same repository. The password of an encrypted key is read from the COSIGN_PASSWORD environment variable.` `",
+	},
+	verifyKey: cli.StringFlag{
+		Name:  verifyKey,
+		Usage: "[Optional] Path to a public key, such as cosign.pub. If provided, the image isn't pulled unless it has a cosign signature which is verified by the key.` `",
+	},
+	baseImage: cli.StringFlag{
+		Name:  baseImage,
+		Usage: "[Optional] The base image of the pushed image, as pulled through Artifactory. For example: my.jfrog.io/docker-remote/alpine:3.19. If not provided, the image of the FROM instruction of the final stage of the Dockerfile in the current directory is used. The base image is recorded as a dependency of the build-info, and only the layers which the image adds to it are recorded as artifacts.` `",
+	},
 	npmDetailedSummary: cli.BoolFlag{
 		Name:  detailedSummary,
 		Usage: "[Default: false] Set to true to include a list of the affected files in the command summary.` `",
@@ -1158,6 +1314,10 @@ var flagsMap = map[string]cli.Flag{
 		Name:  nugetV2,
 		Usage: "[Default: false] Set to true if you'd like to use the NuGet V2 protocol when restoring packages from Artifactory.` `",
 	},
+	dotnetConfigRepoDeploy: cli.StringFlag{
+		Name:  repoDeploy,
+		Usage: "[Optional] Repository to which the 'dotnet nuget push' command pushes the packages.` `",
+	},
 	noFallback: cli.BoolTFlag{
 		Name:  noFallback,
 		Usage: "[Default: false] Set to true to avoid downloading packages from the VCS, if they are missing in Artifactory.` `",
@@ -1174,6 +1334,18 @@ var flagsMap = map[string]cli.Flag{
 		Name:  tag,
 		Usage: "[Mandatory] Terraform package tag.` `",
 	},
+	terraformPlatform: cli.StringFlag{
+		Name:  terraformPlatform,
+		Usage: "[Default: the current platform] Used by the providers-mirror command. A platform to mirror the providers for, in the format <os>_<arch>, such as linux_amd64. The option may be repeated.` `",
+	},
+	terraformConfigRepoResolve: cli.StringFlag{
+		Name:  repoResolve,
+		Usage: "[Optional] Repository from which the terraform commands install the providers and the modules.` `",
+	},
+	terraformConfigRepoDeploy: cli.StringFlag{
+		Name:  repoDeploy,
+		Usage: "[Optional] Repository to which the modules are published. It's used for the installation too, if no resolution repository is set.` `",
+	},
 	vars: cli.StringFlag{
 		Name:  vars,
 		Usage: "[Optional] List of semicolon-separated(;) variables in the form of \"key1=value1;key2=value2;...\" (wrapped by quotes) to be replaced in the template. In the template, the variables should be used as follows: ${key1}.` `",
@@ -1465,6 +1637,10 @@ var flagsMap = map[string]cli.Flag{
 		Name:  exclusions,
 		Usage: "[Optional] List of semicolon-separated(;) exclusions. Exclusions can include the * and the ? wildcards.` `",
 	},
+	goPublishAll: cli.BoolFlag{
+		Name:  goPublishAll,
+		Usage: "[Default: false] Set to true to publish all the modules of the go.work workspace, or of the go.mod files under the current directory, in dependency order. The version of each module is resolved from the git tags with the path of the module as prefix, such as sub/module/v1.2.3.` `",
+	},
 	rescan: cli.BoolFlag{
 		Name:  rescan,
 		Usage: "[Default: false] Set to true when scanning an already successfully scanned build, for example after adding an ignore rule.` `",
@@ -1543,10 +1719,22 @@ var flagsMap = map[string]cli.Flag{
 		Name:  InsecureTls,
 		Usage: "[Default: false] Set to true to skip TLS certificates verification, while encrypting the Artifactory password during the config process.` `",
 	},
-	projectPath: cli.StringFlag{
-		Name:  projectPath,
+	ProjectPath: cli.StringFlag{
+		Name:  ProjectPath,
 		Usage: "[Default: ./] Full path to the code project.` `",
 	},
+	RepoPrefix: cli.StringFlag{
+		Name:  RepoPrefix,
+		Usage: "[Optional] Prefix of the repository names, which are named <prefix>-<package type>-<suffix>. For example, with 'teamx' the Maven repositories are teamx-maven-virtual and teamx-maven-local.` `",
+	},
+	RepoResolveSuffix: cli.StringFlag{
+		Name:  RepoResolveSuffix,
+		Usage: "[Default: virtual] Suffix of the names of the repositories for dependencies resolution.` `",
+	},
+	RepoDeploySuffix: cli.StringFlag{
+		Name:  RepoDeploySuffix,
+		Usage: "[Default: local] Suffix of the names of the repositories for artifacts deployment.` `",
+	},
 	Install: cli.BoolFlag{
 		Name:  Install,
 		Usage: "[Default: false] Set to true to install the completion script instead of printing it to the standard output.` `",
@@ -1776,20 +1964,27 @@ var commandFlags = map[string][]string{
 	},
 	BuildPublish: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
-		envInclude, envExclude, InsecureTls, Project, bpDetailedSummary,
+		envInclude, envExclude, InsecureTls, Project, bpDetailedSummary, signKey,
+	},
+	BuildVerify: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, publicKey, InsecureTls, Project,
 	},
 	BuildAppend: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
 		envInclude, envExclude, InsecureTls, Project,
 	},
+	BuildAggregate: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, children, flattenArtifacts,
+		flattenDependencies, InsecureTls, Project,
+	},
 	BuildAddDependencies: {
 		specFlag, specVars, uploadExclusions, badRecursive, badRegexp, badDryRun, Project, badFromRt, serverId, badModule,
 	},
 	BuildAddGit: {
-		configFlag, serverId, Project,
+		configFlag, serverId, mainBranch, Project,
 	},
 	BuildCollectEnv: {
-		Project,
+		Project, envPolicy, bceReport,
 	},
 	BuildDockerCreate: {
 		buildName, buildNumber, module, url, user, password, accessToken, sshPassphrase, sshKeyPath,
@@ -1804,22 +1999,23 @@ var commandFlags = map[string][]string{
 	},
 	BuildPromote: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, Status, comment,
-		sourceRepo, includeDependencies, copyFlag, failFast, bprDryRun, bprProps, InsecureTls, Project,
+		sourceRepo, includeDependencies, copyFlag, failFast, bprDryRun, bprProps, InsecureTls, Project, bprPublicKey,
 	},
 	BuildDiscard: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, maxDays, maxBuilds,
-		excludeBuilds, deleteArtifacts, bdiAsync, InsecureTls, Project,
+		excludeBuilds, deleteArtifacts, bdiAsync, InsecureTls, Project, bdiDryRun, keepStatuses,
+		keepReleaseBundles, keepLatestPerBranch, branchProp, keepProps,
 	},
 	GitLfsClean: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, refs, glcRepo, glcDryRun,
 		glcQuiet, InsecureTls, retries, retryWaitTime,
 	},
 	MvnConfig: {
-		global, serverIdResolve, serverIdDeploy, repoResolveReleases, repoResolveSnapshots, repoDeployReleases, repoDeploySnapshots, includePatterns, excludePatterns, UseWrapper,
+		global, serverIdResolve, serverIdDeploy, repoResolveReleases, repoResolveSnapshots, repoDeployReleases, repoDeploySnapshots, includePatterns, excludePatterns, UseWrapper, fromSettings,
 	},
 	GradleConfig: {
 		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy, usesPlugin, UseWrapper, deployMavenDesc,
-		deployIvyDesc, ivyDescPattern, ivyArtifactsPattern,
+		deployIvyDesc, ivyDescPattern, ivyArtifactsPattern, fromInitScript,
 	},
 	Mvn: {
 		buildName, buildNumber, deploymentThreads, InsecureTls, Project, detailedSummary, xrayScan, xrOutput,
@@ -1829,19 +2025,27 @@ var commandFlags = map[string][]string{
 	},
 	Docker: {
 		buildName, buildNumber, module, Project,
-		serverId, skipLogin, threads, detailedSummary, watches, repoPath, licenses, xrOutput, fail, ExtendedTable, BypassArchiveLimits, MinSeverity, FixableOnly,
+		serverId, skipLogin, dockerSignKey, verifyKey, baseImage, threads, detailedSummary, watches, repoPath, licenses, xrOutput, fail, ExtendedTable, BypassArchiveLimits, MinSeverity, FixableOnly,
 	},
 	DockerPush: {
 		buildName, buildNumber, module, Project,
-		serverId, skipLogin, threads, detailedSummary,
+		serverId, skipLogin, threads, detailedSummary, dockerSignKey, baseImage,
 	},
-	DockerPull: {
+	Oci: {
+		buildName, buildNumber, module, Project,
+		serverId, skipLogin,
+	},
+	Container: {
 		buildName, buildNumber, module, Project,
 		serverId, skipLogin,
 	},
+	DockerPull: {
+		buildName, buildNumber, module, Project,
+		serverId, skipLogin, verifyKey,
+	},
 	DockerPromote: {
-		targetDockerImage, sourceTag, targetTag, dockerPromoteCopy, url, user, password, accessToken, sshPassphrase, sshKeyPath,
-		serverId,
+		targetDockerImage, sourceTag, targetTag, dockerPromoteCopy, tagsRegex, createdAfter, dockerPromoteBuild, Project, dockerPromoteDryRun,
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId,
 	},
 	ContainerPush: {
 		buildName, buildNumber, module, url, user, password, accessToken, sshPassphrase, sshKeyPath,
@@ -1861,11 +2065,41 @@ var commandFlags = map[string][]string{
 		buildName, buildNumber, module, Project, npmDetailedSummary, xrayScan, xrOutput,
 	},
 	PnpmConfig: {
-		global, serverIdResolve, repoResolve,
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
 	},
 	YarnConfig: {
 		global, serverIdResolve, repoResolve,
 	},
+	CargoConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	HelmConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	ConanConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	ComposerConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	GemConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	BundleConfig: {
+		global, serverIdResolve, repoResolve,
+	},
+	UvConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	CondaConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	BazelConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	SbtConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
 	Yarn: {
 		buildName, buildNumber, module, Project,
 	},
@@ -1876,7 +2110,7 @@ var commandFlags = map[string][]string{
 		buildName, buildNumber, module, Project,
 	},
 	DotnetConfig: {
-		global, serverIdResolve, repoResolve, nugetV2,
+		global, serverIdResolve, serverIdDeploy, repoResolve, dotnetConfigRepoDeploy, nugetV2,
 	},
 	Dotnet: {
 		buildName, buildNumber, module, Project,
@@ -1885,16 +2119,16 @@ var commandFlags = map[string][]string{
 		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
 	},
 	GoPublish: {
-		url, user, password, accessToken, buildName, buildNumber, module, Project, detailedSummary, goPublishExclusions,
+		url, user, password, accessToken, buildName, buildNumber, module, Project, detailedSummary, goPublishExclusions, goPublishAll,
 	},
 	Go: {
 		buildName, buildNumber, module, Project, noFallback,
 	},
 	TerraformConfig: {
-		global, serverIdDeploy, repoDeploy,
+		global, serverIdResolve, serverIdDeploy, terraformConfigRepoResolve, terraformConfigRepoDeploy,
 	},
 	Terraform: {
-		namespace, provider, tag, exclusions,
+		namespace, provider, tag, exclusions, terraformPlatform,
 		buildName, buildNumber, module, Project,
 	},
 	TransferConfig: {
@@ -2047,7 +2281,7 @@ var commandFlags = map[string][]string{
 	},
 	// Project commands
 	InitProject: {
-		projectPath, serverId,
+		ProjectPath, serverId, RepoPrefix, RepoResolveSuffix, RepoDeploySuffix,
 	},
 	// Completion commands
 	Completion: {
@@ -29,6 +29,7 @@ import (
 	speccore "github.com/jfrog/jfrog-cli-core/v2/common/spec"
 	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
 	"github.com/jfrog/jfrog-cli/utils/summary"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -361,6 +362,10 @@ func GetEnvExclude(envExclude string) string {
 	return getOrDefaultEnv(envExclude, EnvExclude)
 }
 
+func GetEnvPolicy(envPolicy string) string {
+	return getOrDefaultEnv(envPolicy, EnvPolicy)
+}
+
 // Return argument if not empty or retrieve from environment variable
 func getOrDefaultEnv(arg, envKey string) string {
 	if arg != "" {
@@ -551,9 +556,24 @@ func CreateConfigCmd(c *cli.Context, confType project.ProjectType) error {
 	if c.NArg() != 0 {
 		return WrongNumberOfArgumentsHandler(c)
 	}
+	// The Maven and Gradle configurations can be imported from existing settings, instead of being set by flags or interactively.
+	if confType == project.Maven && c.IsSet(fromSettings) {
+		return projectconfig.ImportMavenConfig(c, c.String(fromSettings))
+	}
+	if confType == project.Gradle && c.IsSet(fromInitScript) {
+		return projectconfig.ImportGradleConfig(c, c.String(fromInitScript))
+	}
 	return commonCommands.CreateBuildConfig(c, confType)
 }
 
+// Creates the configuration of a project type which isn't supported by jfrog-cli-core.
+func CreateProjectConfigCmd(c *cli.Context, confType projectconfig.ProjectType) error {
+	if c.NArg() != 0 {
+		return WrongNumberOfArgumentsHandler(c)
+	}
+	return projectconfig.CreateBuildConfig(c, confType)
+}
+
 func RunNativeCmdWithDeprecationWarning(cmdName string, projectType project.ProjectType, c *cli.Context, cmd func(c *cli.Context) error) error {
 	if cliutils.ShouldLogWarning() {
 		LogNativeCommandDeprecation(cmdName, projectType.String())
@@ -0,0 +1,93 @@
+package projectconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/checksums"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Runs a command of a package manager, which resolves the dependencies from Artifactory.
+// If the command installs the dependencies and the build-info is collected, the dependencies are collected after the command finishes.
+func RunWithResolution(run func() error, collectBuildInfo, installsDependencies bool, collectDependencies func() error) error {
+	if err := run(); err != nil {
+		return err
+	}
+	if !collectBuildInfo || !installsDependencies {
+		return nil
+	}
+	return collectDependencies()
+}
+
+// Returns whether the lockfile, which the dependencies are collected from, exists. A missing lockfile is reported, since no dependencies are collected.
+func LockfileExists(lockfilePath string) bool {
+	lockfileName := filepath.Base(lockfilePath)
+	if _, err := os.Stat(lockfilePath); err != nil {
+		log.Warn("The " + lockfileName + " file could not be found, so the dependencies are not collected into the build-info.")
+		return false
+	}
+	log.Info("Collecting dependencies from " + lockfileName + "...")
+	return true
+}
+
+// Sets the checksums of the dependencies, by searching Artifactory for the checksum of each dependency, as listed in the lockfile.
+// field is the checksum field of the lockfile, either checksums.Sha256Field or checksums.Sha1Field. Dependencies which aren't found in Artifactory are reported.
+func SetChecksums(serverDetails *config.ServerDetails, field string, dependencies []buildinfo.Dependency) error {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	lockfileChecksum := func(dependency buildinfo.Dependency) string {
+		if field == checksums.Sha256Field {
+			return dependency.Sha256
+		}
+		return dependency.Sha1
+	}
+	values := make([]string, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		values = append(values, lockfileChecksum(dependency))
+	}
+	found, err := checksums.Search(servicesManager, field, values)
+	if err != nil {
+		return err
+	}
+	var missingDependencies []string
+	for i, dependency := range dependencies {
+		if checksum, exists := found[strings.ToLower(lockfileChecksum(dependency))]; exists {
+			dependencies[i].Checksum = checksum
+		} else {
+			dependencies[i].Checksum = buildinfo.Checksum{}
+			missingDependencies = append(missingDependencies, dependency.Id)
+		}
+	}
+	commandUtils.PrintMissingDependencies(missingDependencies)
+	return nil
+}
+
+// Saves a module of the package manager in the build-info, with the artifacts or dependencies which populatePartial sets.
+func SaveModule(buildConfiguration *build.BuildConfiguration, moduleType buildinfo.ModuleType, moduleId string, populatePartial func(partial *buildinfo.Partial)) error {
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	return build.SavePartialBuildInfo(buildName, buildNumber, projectKey, func(partial *buildinfo.Partial) {
+		partial.ModuleType = moduleType
+		partial.ModuleId = moduleId
+		populatePartial(partial)
+	})
+}
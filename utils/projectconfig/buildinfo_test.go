@@ -0,0 +1,35 @@
+package projectconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunWithResolution(t *testing.T) {
+	collected := 0
+	collectDependencies := func() error {
+		collected++
+		return nil
+	}
+	run := func() error { return nil }
+	require.NoError(t, RunWithResolution(run, true, true, collectDependencies))
+	require.NoError(t, RunWithResolution(run, false, true, collectDependencies))
+	require.NoError(t, RunWithResolution(run, true, false, collectDependencies))
+	assert.Equal(t, 1, collected)
+
+	// The dependencies aren't collected if the command fails.
+	assert.EqualError(t, RunWithResolution(func() error { return errors.New("failed") }, true, true, collectDependencies), "failed")
+	assert.Equal(t, 1, collected)
+}
+
+func TestLockfileExists(t *testing.T) {
+	lockfilePath := filepath.Join(t.TempDir(), "Cargo.lock")
+	assert.False(t, LockfileExists(lockfilePath))
+	require.NoError(t, os.WriteFile(lockfilePath, []byte("version = 3\n"), 0600))
+	assert.True(t, LockfileExists(lockfilePath))
+}
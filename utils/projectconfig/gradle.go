@@ -0,0 +1,120 @@
+package projectconfig
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/urfave/cli"
+)
+
+// The tokens of Groovy and Kotlin init scripts which are relevant for finding the repositories:
+// comments and other strings are matched only so that their content is skipped.
+var gradleTokenPattern = regexp.MustCompile(`//[^\n]*` +
+	`|/\*[\s\S]*?\*/` +
+	`|\b(url|setUrl|maven|contextUrl)\s*(?:=\s*)?\(?\s*(?:uri\s*\(\s*)?["']([^"'$]*)["']` +
+	`|\brepoKey\s*=\s*["']([^"'$]*)["']` +
+	`|"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'` +
+	`|([A-Za-z_][\w.]*)\s*(?:\([^(){}]*\)\s*)?\{` +
+	`|[{}]`)
+
+// The blocks in which the repositories are used for deployment: the publishing extension, the legacy uploadArchives task,
+// and the publish block of the Gradle Artifactory Plugin.
+var gradleDeploymentBlocks = map[string]bool{"publishing": true, "uploadArchives": true, "mavenDeployer": true, "publish": true}
+
+// The repositories which were imported from a Gradle init script.
+type gradleImport struct {
+	Resolver *importedRepository
+	Deployer *importedRepository
+}
+
+// Creates the Gradle configuration from the repositories of an existing init script.
+// The repositories in the publishing block are used for deployment, and the other repositories are used for resolution.
+// The repositories of the Gradle Artifactory Plugin, which are set by contextUrl and repoKey, are imported too.
+// The URLs are matched to the configured JFrog servers. Flags which are set explicitly override the imported values.
+func ImportGradleConfig(c *cli.Context, initScriptPath string) error {
+	initScriptPath, err := expandHomeDir(initScriptPath)
+	if err != nil {
+		return err
+	}
+	matcher, err := newServerMatcher()
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(initScriptPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	imported := parseGradleInitScript(string(content), matcher)
+	matcher.logUnmatchedUrls(initScriptPath)
+	logImportedRepository("Resolution", imported.Resolver)
+	logImportedRepository("Deployment", imported.Deployer)
+	if imported.Resolver == nil && imported.Deployer == nil {
+		return errorutils.CheckErrorf("no repositories of the configured JFrog servers were found in %s", initScriptPath)
+	}
+	return writeImportedConfig(c, project.Gradle, imported.options())
+}
+
+func parseGradleInitScript(content string, matcher *serverMatcher) *gradleImport {
+	imported := new(gradleImport)
+	var blocks []string
+	contextUrl := ""
+	for _, match := range gradleTokenPattern.FindAllStringSubmatch(content, -1) {
+		token := match[0]
+		switch {
+		case strings.HasPrefix(token, "//") || strings.HasPrefix(token, "/*"):
+		case match[1] == "contextUrl":
+			contextUrl = match[2]
+		case match[1] != "":
+			imported.add(matcher.match(match[2]), isInGradleDeploymentBlock(blocks))
+		case strings.HasPrefix(token, "repoKey"):
+			if contextUrl != "" {
+				imported.add(matcher.match(strings.TrimSuffix(contextUrl, "/")+"/"+match[3]), isInGradleDeploymentBlock(blocks))
+			}
+		case match[4] != "":
+			blocks = append(blocks, match[4])
+		case token == "{":
+			blocks = append(blocks, "")
+		case token == "}":
+			if len(blocks) > 0 {
+				blocks = blocks[:len(blocks)-1]
+			}
+		}
+	}
+	return imported
+}
+
+func isInGradleDeploymentBlock(blocks []string) bool {
+	for _, block := range blocks {
+		if gradleDeploymentBlocks[block] {
+			return true
+		}
+	}
+	return false
+}
+
+// The first repository of each kind is imported.
+func (gi *gradleImport) add(repository *importedRepository, isDeployment bool) {
+	if repository == nil {
+		return
+	}
+	if isDeployment && gi.Deployer == nil {
+		gi.Deployer = repository
+	} else if !isDeployment && gi.Resolver == nil {
+		gi.Resolver = repository
+	}
+}
+
+func (gi *gradleImport) options() []commands.ConfigOption {
+	var options []commands.ConfigOption
+	if gi.Resolver != nil {
+		options = append(options, commands.WithResolverServerId(gi.Resolver.ServerId), commands.WithResolverRepo(gi.Resolver.Repo))
+	}
+	if gi.Deployer != nil {
+		options = append(options, commands.WithDeployerServerId(gi.Deployer.ServerId), commands.WithDeployerRepo(gi.Deployer.Repo))
+	}
+	return options
+}
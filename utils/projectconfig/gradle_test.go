@@ -0,0 +1,80 @@
+package projectconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGradleInitScript(t *testing.T) {
+	initScript := `// url "https://acme.jfrog.io/artifactory/commented-out"
+allprojects {
+    repositories {
+        maven {
+            name = "Artifactory {internal}"
+            url "https://acme.jfrog.io/artifactory/gradle-virtual"
+            credentials {
+                username = System.getenv("ARTIFACTORY_USER")
+            }
+        }
+        mavenCentral()
+    }
+    plugins.withType(MavenPublishPlugin) {
+        publishing {
+            repositories {
+                maven { url = uri("https://deploy.acme.io/artifactory/gradle-local/") }
+            }
+        }
+    }
+}`
+	imported := parseGradleInitScript(initScript, newTestServerMatcher())
+	assert.Equal(t, &gradleImport{
+		Resolver: &importedRepository{ServerId: "acme", Repo: "gradle-virtual", Url: "https://acme.jfrog.io/artifactory/gradle-virtual"},
+		Deployer: &importedRepository{ServerId: "deploy", Repo: "gradle-local", Url: "https://deploy.acme.io/artifactory/gradle-local/"},
+	}, imported)
+}
+
+func TestParseGradleInitScriptKotlin(t *testing.T) {
+	initScript := `settingsEvaluated {
+    pluginManagement {
+        repositories {
+            maven("https://acme.jfrog.io/artifactory/plugins-virtual")
+        }
+    }
+}
+allprojects {
+    repositories {
+        maven { url = uri("$artifactoryUrl/gradle-virtual") }
+    }
+}`
+	matcher := newTestServerMatcher()
+	imported := parseGradleInitScript(initScript, matcher)
+	assert.Equal(t, &gradleImport{
+		Resolver: &importedRepository{ServerId: "acme", Repo: "plugins-virtual", Url: "https://acme.jfrog.io/artifactory/plugins-virtual"},
+	}, imported)
+	assert.Empty(t, matcher.unmatchedUrls)
+}
+
+func TestParseGradleInitScriptArtifactoryPlugin(t *testing.T) {
+	initScript := `allprojects {
+    apply plugin: com.jfrog.gradle.plugin.artifactory.ArtifactoryPlugin
+    artifactory {
+        contextUrl = 'https://acme.jfrog.io/artifactory'
+        publish {
+            repository {
+                repoKey = 'libs-release-local'
+            }
+        }
+        resolve {
+            repository {
+                repoKey = 'libs-release'
+            }
+        }
+    }
+}`
+	imported := parseGradleInitScript(initScript, newTestServerMatcher())
+	assert.Equal(t, &gradleImport{
+		Resolver: &importedRepository{ServerId: "acme", Repo: "libs-release", Url: "https://acme.jfrog.io/artifactory/libs-release"},
+		Deployer: &importedRepository{ServerId: "acme", Repo: "libs-release-local", Url: "https://acme.jfrog.io/artifactory/libs-release-local"},
+	}, imported)
+}
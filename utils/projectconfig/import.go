@@ -0,0 +1,177 @@
+package projectconfig
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+const (
+	// Maven and Gradle config flags, which override the imported configuration.
+	resolutionReleasesRepo  = "repo-resolve-releases"
+	resolutionSnapshotsRepo = "repo-resolve-snapshots"
+	deploymentReleasesRepo  = "repo-deploy-releases"
+	deploymentSnapshotsRepo = "repo-deploy-snapshots"
+	includePatterns         = "include-patterns"
+	excludePatterns         = "exclude-patterns"
+	usesPlugin              = "uses-plugin"
+	useWrapper              = "use-wrapper"
+	deployMavenDesc         = "deploy-maven-desc"
+	deployIvyDesc           = "deploy-ivy-desc"
+	ivyDescPattern          = "ivy-desc-pattern"
+	ivyArtifactsPattern     = "ivy-artifacts-pattern"
+)
+
+// An Artifactory repository which is referenced by a URL in an imported configuration file.
+type importedRepository struct {
+	ServerId string
+	Repo     string
+	Url      string
+}
+
+// Matches repository URLs to the configured JFrog servers, by the Artifactory URL of the servers.
+type serverMatcher struct {
+	servers []*config.ServerDetails
+	// The URLs which don't belong to any of the configured servers.
+	unmatchedUrls []string
+}
+
+func newServerMatcher() (*serverMatcher, error) {
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		return nil, err
+	}
+	if len(servers) == 0 {
+		return nil, errorutils.CheckErrorf("No Artifactory servers configured. Use the 'jfrog c add' command to set the Artifactory server details.")
+	}
+	return &serverMatcher{servers: servers}, nil
+}
+
+// Returns the server and the repository of the URL, which is either <artifactory-url>/<repo> or <artifactory-url>/api/<type>/<repo>.
+// If the URL doesn't belong to any of the configured servers, it's recorded as unmatched, and nil is returned.
+func (sm *serverMatcher) match(repositoryUrl string) *importedRepository {
+	repositoryUrl = strings.TrimSpace(repositoryUrl)
+	if repositoryUrl == "" {
+		return nil
+	}
+	for _, server := range sm.servers {
+		relativePath, found := cutUrlPrefix(repositoryUrl, server.GetArtifactoryUrl())
+		if !found {
+			continue
+		}
+		segments := strings.Split(strings.Trim(relativePath, "/"), "/")
+		repo := segments[0]
+		if repo == "api" && len(segments) > 2 {
+			repo = segments[2]
+		}
+		if repo == "" || repo == "api" {
+			continue
+		}
+		return &importedRepository{ServerId: server.ServerId, Repo: repo, Url: repositoryUrl}
+	}
+	for _, unmatchedUrl := range sm.unmatchedUrls {
+		if unmatchedUrl == repositoryUrl {
+			return nil
+		}
+	}
+	sm.unmatchedUrls = append(sm.unmatchedUrls, repositoryUrl)
+	return nil
+}
+
+// Returns the path of the URL relative to the base URL. The scheme and host are compared case-insensitively.
+func cutUrlPrefix(rawUrl, rawBaseUrl string) (string, bool) {
+	if rawBaseUrl == "" {
+		return "", false
+	}
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", false
+	}
+	baseUrl, err := url.Parse(rawBaseUrl)
+	if err != nil {
+		return "", false
+	}
+	if !strings.EqualFold(parsedUrl.Scheme, baseUrl.Scheme) || !strings.EqualFold(parsedUrl.Host, baseUrl.Host) {
+		return "", false
+	}
+	basePath := strings.TrimSuffix(baseUrl.Path, "/") + "/"
+	return strings.CutPrefix(strings.TrimSuffix(parsedUrl.Path, "/")+"/", basePath)
+}
+
+func (sm *serverMatcher) logUnmatchedUrls(sourceFile string) {
+	for _, unmatchedUrl := range sm.unmatchedUrls {
+		log.Warn("The URL " + unmatchedUrl + " in " + sourceFile + " doesn't belong to any of the configured JFrog servers, so it was not imported.")
+	}
+}
+
+func logImportedRepository(title string, repository *importedRepository) {
+	if repository != nil {
+		log.Info(title + ": repository '" + repository.Repo + "' on server '" + repository.ServerId + "' (" + repository.Url + ")")
+	}
+}
+
+// Returns the configuration options of the Maven and Gradle config flags which were set explicitly, so that they override the imported configuration.
+func getFlagOptions(c *cli.Context, projectType project.ProjectType) []commands.ConfigOption {
+	var options []commands.ConfigOption
+	addStringOption := func(flagName string, option func(string) commands.ConfigOption) {
+		if c.IsSet(flagName) {
+			options = append(options, option(c.String(flagName)))
+		}
+	}
+	addBoolOption := func(flagName string, option func(bool) commands.ConfigOption) {
+		if c.IsSet(flagName) {
+			options = append(options, option(c.Bool(flagName)))
+		}
+	}
+	addStringOption(resolutionServerId, commands.WithResolverServerId)
+	addStringOption(deploymentServerId, commands.WithDeployerServerId)
+	addBoolOption(useWrapper, commands.UseWrapper)
+	switch projectType {
+	case project.Maven:
+		addStringOption(resolutionReleasesRepo, commands.WithResolverReleaseRepo)
+		addStringOption(resolutionSnapshotsRepo, commands.WithResolverSnapshotRepo)
+		addStringOption(deploymentReleasesRepo, commands.WithDeployerReleaseRepo)
+		addStringOption(deploymentSnapshotsRepo, commands.WithDeployerSnapshotRepo)
+		addStringOption(includePatterns, commands.WithDeployerIncludePatterns)
+		addStringOption(excludePatterns, commands.WithDeployerExcludePatterns)
+	case project.Gradle:
+		addStringOption(resolutionRepo, commands.WithResolverRepo)
+		addStringOption(deploymentRepo, commands.WithDeployerRepo)
+		addBoolOption(usesPlugin, commands.UsePlugin)
+		addBoolOption(deployMavenDesc, commands.WithMavenDescDeployment)
+		addBoolOption(deployIvyDesc, commands.WithIvyDescDeployment)
+		addStringOption(ivyDescPattern, commands.WithIvyDeploymentPattern)
+		addStringOption(ivyArtifactsPattern, commands.WithArtifactsDeploymentPattern)
+	}
+	return options
+}
+
+// Writes the configuration file of the project type, without prompting, since the repositories were imported.
+func writeImportedConfig(c *cli.Context, projectType project.ProjectType, options []commands.ConfigOption) error {
+	options = append(options, getFlagOptions(c, projectType)...)
+	options = append(options, func(configFile *commands.ConfigFile) {
+		configFile.Interactive = false
+	})
+	return commands.CreateBuildConfigWithOptions(c.Bool(global), projectType, options...)
+}
+
+// Expands the home directory in paths such as ~/.m2/settings.xml, which the shell doesn't expand in --flag=value arguments.
+func expandHomeDir(path string) (string, error) {
+	relativePath, found := strings.CutPrefix(path, "~/")
+	if !found {
+		return path, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.Join(homeDir, relativePath), nil
+}
@@ -0,0 +1,236 @@
+package projectconfig
+
+import (
+	"encoding/xml"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+// The properties of the Maven Deploy Plugin, which set the deployment repositories from settings.xml, as <id>::<url> or <id>::<layout>::<url>.
+const (
+	altDeploymentRepositoryProperty         = "altDeploymentRepository"
+	altReleaseDeploymentRepositoryProperty  = "altReleaseDeploymentRepository"
+	altSnapshotDeploymentRepositoryProperty = "altSnapshotDeploymentRepository"
+)
+
+type mavenSettings struct {
+	Mirrors        []mavenMirror  `xml:"mirrors>mirror"`
+	Profiles       []mavenProfile `xml:"profiles>profile"`
+	ActiveProfiles []string       `xml:"activeProfiles>activeProfile"`
+}
+
+type mavenMirror struct {
+	Id       string `xml:"id"`
+	MirrorOf string `xml:"mirrorOf"`
+	Url      string `xml:"url"`
+}
+
+type mavenProfile struct {
+	Id                 string            `xml:"id"`
+	ActiveByDefault    bool              `xml:"activation>activeByDefault"`
+	Repositories       []mavenRepository `xml:"repositories>repository"`
+	PluginRepositories []mavenRepository `xml:"pluginRepositories>pluginRepository"`
+	Properties         mavenProperties   `xml:"properties"`
+}
+
+type mavenRepository struct {
+	Id        string           `xml:"id"`
+	Url       string           `xml:"url"`
+	Releases  *mavenRepoPolicy `xml:"releases"`
+	Snapshots *mavenRepoPolicy `xml:"snapshots"`
+}
+
+// Releases and snapshots are enabled by default.
+type mavenRepoPolicy struct {
+	Enabled string `xml:"enabled"`
+}
+
+func (mrp *mavenRepoPolicy) isEnabled() bool {
+	return mrp == nil || strings.TrimSpace(mrp.Enabled) != "false"
+}
+
+// The properties element has arbitrary child elements.
+type mavenProperties map[string]string
+
+func (mp *mavenProperties) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	*mp = make(mavenProperties)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err = decoder.DecodeElement(&value, &element); err != nil {
+				return err
+			}
+			(*mp)[element.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// The repositories which were imported from settings.xml.
+type mavenImport struct {
+	ResolverReleases  *importedRepository
+	ResolverSnapshots *importedRepository
+	DeployerReleases  *importedRepository
+	DeployerSnapshots *importedRepository
+}
+
+// Creates the Maven configuration from the mirrors, repositories and deployment properties of an existing settings.xml file.
+// The URLs are matched to the configured JFrog servers. Flags which are set explicitly override the imported values.
+func ImportMavenConfig(c *cli.Context, settingsPath string) error {
+	settingsPath, err := expandHomeDir(settingsPath)
+	if err != nil {
+		return err
+	}
+	matcher, err := newServerMatcher()
+	if err != nil {
+		return err
+	}
+	imported, err := parseMavenSettings(settingsPath, matcher)
+	if err != nil {
+		return err
+	}
+	matcher.logUnmatchedUrls(settingsPath)
+	logImportedRepository("Resolution of releases", imported.ResolverReleases)
+	logImportedRepository("Resolution of snapshots", imported.ResolverSnapshots)
+	logImportedRepository("Deployment of releases", imported.DeployerReleases)
+	logImportedRepository("Deployment of snapshots", imported.DeployerSnapshots)
+	if imported.ResolverReleases == nil && imported.DeployerReleases == nil {
+		return errorutils.CheckErrorf("no repositories of the configured JFrog servers were found in %s", settingsPath)
+	}
+	return writeImportedConfig(c, project.Maven, imported.options())
+}
+
+func parseMavenSettings(settingsPath string, matcher *serverMatcher) (*mavenImport, error) {
+	content, err := os.ReadFile(settingsPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	settings := new(mavenSettings)
+	if err = xml.Unmarshal(content, settings); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing %s: %s", settingsPath, err.Error())
+	}
+	imported := new(mavenImport)
+	// A mirror of all the repositories, or of Maven Central, replaces the repositories of the profiles.
+	for _, mirror := range settings.Mirrors {
+		if !isCentralMirror(mirror.MirrorOf) {
+			continue
+		}
+		if repository := matcher.match(mirror.Url); repository != nil {
+			imported.ResolverReleases, imported.ResolverSnapshots = repository, repository
+			break
+		}
+	}
+	for _, profile := range settings.getActiveProfiles() {
+		if imported.ResolverReleases == nil || imported.ResolverSnapshots == nil {
+			for _, repository := range append(append([]mavenRepository(nil), profile.Repositories...), profile.PluginRepositories...) {
+				matched := matcher.match(repository.Url)
+				if matched == nil {
+					continue
+				}
+				if imported.ResolverReleases == nil && repository.Releases.isEnabled() {
+					imported.ResolverReleases = matched
+				}
+				if imported.ResolverSnapshots == nil && repository.Snapshots.isEnabled() {
+					imported.ResolverSnapshots = matched
+				}
+			}
+		}
+		if imported.DeployerReleases == nil {
+			imported.DeployerReleases = matchDeploymentProperty(profile.Properties[altReleaseDeploymentRepositoryProperty], matcher)
+		}
+		if imported.DeployerSnapshots == nil {
+			imported.DeployerSnapshots = matchDeploymentProperty(profile.Properties[altSnapshotDeploymentRepositoryProperty], matcher)
+		}
+		if repository := matchDeploymentProperty(profile.Properties[altDeploymentRepositoryProperty], matcher); repository != nil {
+			if imported.DeployerReleases == nil {
+				imported.DeployerReleases = repository
+			}
+			if imported.DeployerSnapshots == nil {
+				imported.DeployerSnapshots = repository
+			}
+		}
+	}
+	// The configuration requires both the releases and the snapshots repositories. Virtual repositories usually serve both.
+	imported.ResolverReleases, imported.ResolverSnapshots = completePair(imported.ResolverReleases, imported.ResolverSnapshots)
+	imported.DeployerReleases, imported.DeployerSnapshots = completePair(imported.DeployerReleases, imported.DeployerSnapshots)
+	return imported, nil
+}
+
+// Profiles are active if they're listed in activeProfiles, or if they're active by default.
+func (ms *mavenSettings) getActiveProfiles() []mavenProfile {
+	var activeProfiles []mavenProfile
+	for _, profile := range ms.Profiles {
+		isActive := profile.ActiveByDefault
+		for _, activeProfile := range ms.ActiveProfiles {
+			if strings.TrimSpace(activeProfile) == profile.Id {
+				isActive = true
+			}
+		}
+		if isActive {
+			activeProfiles = append(activeProfiles, profile)
+		}
+	}
+	return activeProfiles
+}
+
+// Whether the mirrorOf value of a mirror includes Maven Central, such as *, central or external:*. Excluded repositories are ignored.
+func isCentralMirror(mirrorOf string) bool {
+	for _, pattern := range strings.Split(mirrorOf, ",") {
+		switch strings.TrimSpace(pattern) {
+		case "*", "central", "external:*", "external:http:*":
+			return true
+		}
+	}
+	return false
+}
+
+// The value of the deployment properties is <id>::<url>, or <id>::<layout>::<url> in older versions of the Maven Deploy Plugin.
+func matchDeploymentProperty(value string, matcher *serverMatcher) *importedRepository {
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, "::")
+	return matcher.match(parts[len(parts)-1])
+}
+
+func completePair(releases, snapshots *importedRepository) (*importedRepository, *importedRepository) {
+	if releases == nil {
+		releases = snapshots
+	}
+	if snapshots == nil {
+		snapshots = releases
+	}
+	if releases != nil && snapshots.ServerId != releases.ServerId {
+		log.Warn("The releases and snapshots repositories belong to different servers. The server of the releases repository is used for both.")
+	}
+	return releases, snapshots
+}
+
+func (mi *mavenImport) options() []commands.ConfigOption {
+	var options []commands.ConfigOption
+	if mi.ResolverReleases != nil {
+		options = append(options,
+			commands.WithResolverServerId(mi.ResolverReleases.ServerId),
+			commands.WithResolverReleaseRepo(mi.ResolverReleases.Repo),
+			commands.WithResolverSnapshotRepo(mi.ResolverSnapshots.Repo))
+	}
+	if mi.DeployerReleases != nil {
+		options = append(options,
+			commands.WithDeployerServerId(mi.DeployerReleases.ServerId),
+			commands.WithDeployerReleaseRepo(mi.DeployerReleases.Repo),
+			commands.WithDeployerSnapshotRepo(mi.DeployerSnapshots.Repo))
+	}
+	return options
+}
@@ -0,0 +1,108 @@
+package projectconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const settingsXmlContent = `<?xml version="1.0" encoding="UTF-8"?>
+<settings xmlns="http://maven.apache.org/SETTINGS/1.2.0">
+  <servers>
+    <server>
+      <id>artifactory</id>
+      <username>admin</username>
+      <password>${env.ARTIFACTORY_PASSWORD}</password>
+    </server>
+  </servers>
+  <mirrors>
+    <mirror>
+      <id>internal</id>
+      <mirrorOf>internal-only</mirrorOf>
+      <url>https://acme.jfrog.io/artifactory/internal</url>
+    </mirror>
+  </mirrors>
+  <profiles>
+    <profile>
+      <id>inactive</id>
+      <repositories>
+        <repository>
+          <id>inactive</id>
+          <url>https://acme.jfrog.io/artifactory/inactive</url>
+        </repository>
+      </repositories>
+    </profile>
+    <profile>
+      <id>artifactory</id>
+      <repositories>
+        <repository>
+          <id>github</id>
+          <url>https://maven.pkg.github.com/acme/packages</url>
+        </repository>
+        <repository>
+          <id>releases</id>
+          <url>https://ACME.jfrog.io/artifactory/libs-release/</url>
+          <snapshots><enabled>false</enabled></snapshots>
+        </repository>
+        <repository>
+          <id>snapshots</id>
+          <url>https://acme.jfrog.io/artifactory/api/maven/libs-snapshot</url>
+          <releases><enabled>false</enabled></releases>
+        </repository>
+      </repositories>
+      <properties>
+        <altReleaseDeploymentRepository>artifactory::https://deploy.acme.io/artifactory/libs-release-local</altReleaseDeploymentRepository>
+        <altDeploymentRepository>artifactory::default::https://deploy.acme.io/artifactory/libs-snapshot-local</altDeploymentRepository>
+      </properties>
+    </profile>
+  </profiles>
+  <activeProfiles>
+    <activeProfile>artifactory</activeProfile>
+  </activeProfiles>
+</settings>`
+
+func newTestServerMatcher() *serverMatcher {
+	return &serverMatcher{servers: []*config.ServerDetails{
+		{ServerId: "acme", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"},
+		{ServerId: "deploy", ArtifactoryUrl: "https://deploy.acme.io/artifactory"},
+	}}
+}
+
+func TestParseMavenSettings(t *testing.T) {
+	settingsPath := filepath.Join(t.TempDir(), "settings.xml")
+	require.NoError(t, os.WriteFile(settingsPath, []byte(settingsXmlContent), 0644))
+	matcher := newTestServerMatcher()
+
+	imported, err := parseMavenSettings(settingsPath, matcher)
+	require.NoError(t, err)
+	assert.Equal(t, &mavenImport{
+		ResolverReleases:  &importedRepository{ServerId: "acme", Repo: "libs-release", Url: "https://ACME.jfrog.io/artifactory/libs-release/"},
+		ResolverSnapshots: &importedRepository{ServerId: "acme", Repo: "libs-snapshot", Url: "https://acme.jfrog.io/artifactory/api/maven/libs-snapshot"},
+		DeployerReleases:  &importedRepository{ServerId: "deploy", Repo: "libs-release-local", Url: "https://deploy.acme.io/artifactory/libs-release-local"},
+		DeployerSnapshots: &importedRepository{ServerId: "deploy", Repo: "libs-snapshot-local", Url: "https://deploy.acme.io/artifactory/libs-snapshot-local"},
+	}, imported)
+	assert.Equal(t, []string{"https://maven.pkg.github.com/acme/packages"}, matcher.unmatchedUrls)
+}
+
+func TestParseMavenSettingsWithMirror(t *testing.T) {
+	settingsPath := filepath.Join(t.TempDir(), "settings.xml")
+	content := `<settings>
+  <mirrors>
+    <mirror>
+      <id>artifactory</id>
+      <mirrorOf>*,!local</mirrorOf>
+      <url>https://acme.jfrog.io/artifactory/maven-virtual</url>
+    </mirror>
+  </mirrors>
+</settings>`
+	require.NoError(t, os.WriteFile(settingsPath, []byte(content), 0644))
+
+	imported, err := parseMavenSettings(settingsPath, newTestServerMatcher())
+	require.NoError(t, err)
+	expected := &importedRepository{ServerId: "acme", Repo: "maven-virtual", Url: "https://acme.jfrog.io/artifactory/maven-virtual"}
+	assert.Equal(t, &mavenImport{ResolverReleases: expected, ResolverSnapshots: expected}, imported)
+}
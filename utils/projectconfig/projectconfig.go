@@ -0,0 +1,239 @@
+package projectconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+// Project types which are not supported by the project package of jfrog-cli-core.
+// Their configuration files have the same structure and location as the ones created by commands.CreateBuildConfig,
+// so they can be read using project.ReadConfigFile and project.GetRepoConfigByPrefix.
+type ProjectType int
+
+const (
+	Cargo ProjectType = iota
+	Helm
+	Conan
+	Composer
+	Gem
+	Bundle
+	Uv
+	Conda
+	Bazel
+	Sbt
+)
+
+var projectTypes = []string{
+	"cargo",
+	"helm",
+	"conan",
+	"composer",
+	"gem",
+	"bundle",
+	"uv",
+	"conda",
+	"bazel",
+	"sbt",
+}
+
+func (projectType ProjectType) String() string {
+	return projectTypes[projectType]
+}
+
+// Whether the project type deploys artifacts, in addition to resolving dependencies.
+var deployingProjectTypes = map[ProjectType]bool{
+	Cargo:    true,
+	Helm:     true,
+	Conan:    true,
+	Composer: true,
+	Gem:      true,
+	Uv:       true,
+	Conda:    true,
+	Bazel:    true,
+	Sbt:      true,
+}
+
+const (
+	global             = "global"
+	resolutionServerId = "server-id-resolve"
+	deploymentServerId = "server-id-deploy"
+	resolutionRepo     = "repo-resolve"
+	deploymentRepo     = "repo-deploy"
+)
+
+// Creates the configuration file of the project type, from the command flags or interactively.
+func CreateBuildConfig(c *cli.Context, projectType ProjectType) error {
+	configFile := &commands.ConfigFile{
+		Version:    commands.BuildConfVersion,
+		ConfigType: projectType.String(),
+		Resolver:   project.Repository{ServerId: c.String(resolutionServerId), Repo: c.String(resolutionRepo)},
+		Deployer:   project.Repository{ServerId: c.String(deploymentServerId), Repo: c.String(deploymentRepo)},
+	}
+	configFile.Interactive = !isCI() && !isAnyFlagSet(c, resolutionServerId, resolutionRepo, deploymentServerId, deploymentRepo)
+
+	projectDir, err := utils.GetProjectDir(c.Bool(global))
+	if err != nil {
+		return err
+	}
+	if err = fileutils.CreateDirIfNotExist(projectDir); err != nil {
+		return err
+	}
+	configFilePath := filepath.Join(projectDir, projectType.String()+".yaml")
+	if err = configFile.VerifyConfigFile(configFilePath); err != nil {
+		return err
+	}
+	if configFile.Interactive {
+		if err = setRepository(&configFile.Resolver, "Resolve dependencies from Artifactory?", "Set repository for dependencies resolution", utils.Virtual, utils.Remote); err != nil {
+			return err
+		}
+		if deployingProjectTypes[projectType] {
+			if err = setRepository(&configFile.Deployer, "Deploy project artifacts to Artifactory?", "Set repository for artifacts deployment", utils.Virtual, utils.Local); err != nil {
+				return err
+			}
+		}
+	}
+	if err = validateRepository(&configFile.Resolver, "[Resolution]: "); err != nil {
+		return err
+	}
+	if err = validateRepository(&configFile.Deployer, "[Deployment]: "); err != nil {
+		return err
+	}
+	content, err := yaml.Marshal(configFile)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.WriteFile(configFilePath, content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info(configFile.ConfigType + " build config successfully created.")
+	return nil
+}
+
+// Returns the path of the project type configuration file, in the project's .jfrog directory or in the JFrog home directory.
+func GetProjectConfFilePath(projectType ProjectType) (confFilePath string, exists bool, err error) {
+	confFileName := filepath.Join("projects", projectType.String()+".yaml")
+	projectDir, exists, err := fileutils.FindUpstream(".jfrog", fileutils.Dir)
+	if err != nil {
+		return
+	}
+	if exists {
+		filePath := filepath.Join(projectDir, ".jfrog", confFileName)
+		if exists, err = fileutils.IsFileExists(filePath, false); err != nil || exists {
+			return filePath, exists, err
+		}
+	}
+	jfrogHomeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return
+	}
+	filePath := filepath.Join(jfrogHomeDir, confFileName)
+	if exists, err = fileutils.IsFileExists(filePath, false); err != nil || !exists {
+		return "", false, err
+	}
+	return filePath, true, nil
+}
+
+// Asks whether to use Artifactory, and if so, for the server ID and the repository.
+func setRepository(repository *project.Repository, useArtifactoryQuestion, repoPrompt string, repoTypes ...utils.RepoType) error {
+	serverConfigs, err := config.GetAllServersConfigs()
+	if err != nil {
+		return err
+	}
+	if len(serverConfigs) == 0 {
+		return errorutils.CheckErrorf("No Artifactory servers configured. Use the 'jfrog c add' command to set the Artifactory server details.")
+	}
+	if !coreutils.AskYesNo(useArtifactoryQuestion, true) {
+		return nil
+	}
+	var serverIds []string
+	var defaultServerId string
+	for _, serverConfig := range serverConfigs {
+		serverIds = append(serverIds, serverConfig.ServerId)
+		if serverConfig.IsDefault {
+			defaultServerId = serverConfig.ServerId
+		}
+	}
+	repository.ServerId = ioutils.AskFromList("", "Set Artifactory server ID", false, ioutils.ConvertToSuggests(serverIds), defaultServerId)
+
+	var repos []string
+	serverDetails, err := config.GetSpecificConfig(repository.ServerId, false, true)
+	if err == nil {
+		repos, err = utils.GetRepositories(serverDetails, repoTypes...)
+	}
+	if err != nil {
+		log.Error("failed getting repositories list: " + err.Error())
+	}
+	if len(repos) > 0 {
+		repository.Repo = ioutils.AskFromListWithMismatchConfirmation(repoPrompt, "Repository not found.", ioutils.ConvertToSuggests(repos))
+	} else {
+		repository.Repo = ioutils.AskString("", repoPrompt, false, false)
+	}
+	return nil
+}
+
+// Validates the repository configuration the same way commands.CreateBuildConfig does.
+// If the repository is set without a server ID, the default server is used.
+func validateRepository(repository *project.Repository, errorPrefix string) error {
+	if repository.ServerId != "" && repository.Repo == "" {
+		return errorutils.CheckErrorf(errorPrefix + "repository/ies must be set. ")
+	}
+	if repository.ServerId != "" || repository.Repo == "" {
+		return nil
+	}
+	serverId := os.Getenv(coreutils.ServerID)
+	if serverId == "" {
+		defaultServerDetails, err := config.GetDefaultServerConf()
+		if err != nil {
+			return err
+		}
+		if defaultServerDetails != nil {
+			serverId = defaultServerDetails.ServerId
+		}
+	}
+	if serverId == "" {
+		return errorutils.CheckErrorf(errorPrefix + "server ID must be set. Use the --server-id-resolve/deploy flag or configure a default server using 'jfrog c add' and 'jfrog c use' commands. ")
+	}
+	repository.ServerId = serverId
+	return nil
+}
+
+func isCI() bool {
+	return strings.ToLower(os.Getenv(coreutils.CI)) == "true"
+}
+
+func isAnyFlagSet(c *cli.Context, flagNames ...string) bool {
+	for _, flagName := range flagNames {
+		if c.IsSet(flagName) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the credentials which the package managers use for basic authentication against the server, in the same way 'docker login' is performed:
+// the access token is preferred over the password, and if no username is configured, it's extracted from the token.
+// An empty password is returned if the server has no credentials.
+func GetBasicAuthCredentials(serverDetails *config.ServerDetails) (username, password string) {
+	username, password = serverDetails.GetUser(), serverDetails.GetPassword()
+	if serverDetails.GetAccessToken() != "" {
+		if username == "" {
+			username = auth.ExtractUsernameFromAccessToken(serverDetails.GetAccessToken())
+		}
+		password = serverDetails.GetAccessToken()
+	}
+	return
+}
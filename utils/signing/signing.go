@@ -0,0 +1,128 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"slices"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/secure-systems-lab/go-securesystemslib/encrypted"
+)
+
+// The password of an encrypted signing key, as read by cosign.
+const SigningKeyPasswordEnv = "COSIGN_PASSWORD"
+
+// The PEM types of the private keys generated by 'cosign generate-key-pair', which are encrypted by a password.
+var EncryptedKeyPemTypes = []string{"ENCRYPTED SIGSTORE PRIVATE KEY", "ENCRYPTED COSIGN PRIVATE KEY"}
+
+// Reads a PEM encoded private key. RSA, ECDSA and Ed25519 keys are supported, in PKCS #8, PKCS #1 or SEC 1 forms,
+// as well as the encrypted keys generated by 'cosign generate-key-pair'.
+// The password of an encrypted key is read from COSIGN_PASSWORD, or from the console if the variable isn't set.
+func LoadSigningKey(keyPath string) (crypto.Signer, error) {
+	block, err := readPemFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	keyBytes := block.Bytes
+	if slices.Contains(EncryptedKeyPemTypes, block.Type) {
+		password, err := readSigningKeyPassword(keyPath)
+		if err != nil {
+			return nil, err
+		}
+		if keyBytes, err = encrypted.Decrypt(keyBytes, password); err != nil {
+			return nil, errorutils.CheckErrorf("failed decrypting the signing key %s. Make sure the password is correct: %s", keyPath, err.Error())
+		}
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(keyBytes); err == nil {
+		if signer, ok := key.(crypto.Signer); ok {
+			return signer, nil
+		}
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(keyBytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(keyBytes); err == nil {
+		return key, nil
+	}
+	return nil, errorutils.CheckErrorf("unsupported private key in %s. Expected an RSA, ECDSA or Ed25519 private key", keyPath)
+}
+
+func readSigningKeyPassword(keyPath string) ([]byte, error) {
+	if password, exists := os.LookupEnv(SigningKeyPasswordEnv); exists {
+		return []byte(password), nil
+	}
+	if !log.IsStdErrTerminal() {
+		return nil, errorutils.CheckErrorf("the signing key %s is encrypted. Provide its password in the %s environment variable", keyPath, SigningKeyPasswordEnv)
+	}
+	password, err := ioutils.ScanPasswordFromConsole("Enter the password of the signing key " + keyPath + ": ")
+	return []byte(password), err
+}
+
+// Reads a PEM encoded public key or certificate, such as cosign.pub which is generated by 'cosign generate-key-pair'.
+func LoadVerificationKey(keyPath string) (crypto.PublicKey, error) {
+	block, err := readPemFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if certificate, err := x509.ParseCertificate(block.Bytes); err == nil {
+		return certificate.PublicKey, nil
+	}
+	return nil, errorutils.CheckErrorf("unsupported public key in %s. Expected an RSA, ECDSA or Ed25519 public key or certificate", keyPath)
+}
+
+func readPemFile(filePath string) (*pem.Block, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errorutils.CheckErrorf("no PEM data was found in %s", filePath)
+	}
+	return block, nil
+}
+
+// Signs the SHA-256 digest of the payload with an RSA (PKCS #1 v1.5) or ECDSA key, or the payload itself with an Ed25519 key, as cosign does.
+func Sign(signer crypto.Signer, payload []byte) ([]byte, error) {
+	switch signer.Public().(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		return signature, errorutils.CheckError(err)
+	case ed25519.PublicKey:
+		signature, err := signer.Sign(rand.Reader, payload, crypto.Hash(0))
+		return signature, errorutils.CheckError(err)
+	default:
+		return nil, errorutils.CheckErrorf("unsupported signing key type %T", signer.Public())
+	}
+}
+
+// Returns whether the signature of the payload, as created by Sign, is verified by the public key.
+func Verify(publicKey crypto.PublicKey, payload, signature []byte) bool {
+	digest := sha256.Sum256(payload)
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], signature)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, payload, signature)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,76 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/secure-systems-lab/go-securesystemslib/encrypted"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	payload := []byte(`{"critical":{}}`)
+	for name, key := range map[string]crypto.Signer{"rsa": rsaKey, "ecdsa": ecdsaKey, "ed25519": ed25519Key} {
+		t.Run(name, func(t *testing.T) {
+			privateKeyPath, publicKeyPath := WriteTestKeys(t, key)
+			signer, err := LoadSigningKey(privateKeyPath)
+			require.NoError(t, err)
+			publicKey, err := LoadVerificationKey(publicKeyPath)
+			require.NoError(t, err)
+
+			signature, err := Sign(signer, payload)
+			require.NoError(t, err)
+			assert.True(t, Verify(publicKey, payload, signature))
+			assert.False(t, Verify(publicKey, []byte(`{"critical":{"tampered":true}}`), signature))
+			assert.False(t, Verify(otherKey.Public(), payload, signature))
+		})
+	}
+}
+
+func TestLoadEncryptedSigningKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	encryptedBytes, err := encrypted.Encrypt(keyBytes, []byte("secret"))
+	require.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "cosign.key")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: EncryptedKeyPemTypes[0], Bytes: encryptedBytes}), 0600))
+
+	t.Setenv(SigningKeyPasswordEnv, "secret")
+	signer, err := LoadSigningKey(keyPath)
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(signer.Public()))
+
+	t.Setenv(SigningKeyPasswordEnv, "wrong")
+	_, err = LoadSigningKey(keyPath)
+	assert.ErrorContains(t, err, "failed decrypting the signing key")
+}
+
+func TestLoadKeysInvalidFile(t *testing.T) {
+	invalidPath := filepath.Join(t.TempDir(), "invalid.pem")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("not a key"), 0600))
+	_, err := LoadSigningKey(invalidPath)
+	assert.Error(t, err)
+	_, err = LoadVerificationKey(invalidPath)
+	assert.Error(t, err)
+}
@@ -0,0 +1,25 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Writes the private key in PKCS #8 form and its public key in PKIX form to a temporary directory of the test, and returns the paths of the files.
+func WriteTestKeys(t *testing.T, key crypto.Signer) (privateKeyPath, publicKeyPath string) {
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	dir := t.TempDir()
+	privateKeyPath, publicKeyPath = filepath.Join(dir, "key.pem"), filepath.Join(dir, "key.pub")
+	require.NoError(t, os.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyBytes}), 0600))
+	require.NoError(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}), 0600))
+	return
+}